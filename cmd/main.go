@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"os"
@@ -12,11 +13,20 @@ import (
 
 	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/config/databases"
+	db_admin "github.com/bsv-blockchain/spv-wallet-web-backend/data/admin"
+	db_audit "github.com/bsv-blockchain/spv-wallet-web-backend/data/audit"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/data/avatars"
+	db_passkeys "github.com/bsv-blockchain/spv-wallet-web-backend/data/passkeys"
+	db_teams "github.com/bsv-blockchain/spv-wallet-web-backend/data/teams"
+	db_transactions "github.com/bsv-blockchain/spv-wallet-web-backend/data/transactions"
 	db_users "github.com/bsv-blockchain/spv-wallet-web-backend/data/users"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/logging"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/auth"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/api/webhooks"
 	httpserver "github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/server"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/spvwallet"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/websocket"
 )
 
@@ -36,12 +46,29 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Keys derived from spending PINs can be guessed offline from a few digits without the pepper.
+	if viper.GetString(config.EnvSpendingPINPepper) == "" {
+		log.Error().Msgf("spending PIN pepper %s is not configured", config.EnvSpendingPINPepper)
+		os.Exit(1)
+	}
+
 	db := databases.SetUpDatabase(log)
 	defer db.Close() //nolint:errcheck // best effort cleanup on exit
 
 	repo := db_users.NewUsersRepository(db)
+	trackingRepo := db_transactions.NewTrackingRepository(db)
+	actionsRepo := db_admin.NewActionsRepository(db)
+	auditRepo := db_audit.NewEventsRepository(db)
+	walletsRepo := db_teams.NewWalletsRepository(db)
+	passkeysRepo := db_passkeys.NewPasskeysRepository(db)
 
-	s, err := domain.NewServices(repo, log)
+	avatarStorage, err := avatars.NewFileStorage(viper.GetString(config.EnvAvatarsDirectory))
+	if err != nil {
+		log.Error().Msgf("cannot create avatars storage because of an error: %v", err)
+		os.Exit(1)
+	}
+
+	s, err := domain.NewServices(repo, trackingRepo, actionsRepo, auditRepo, walletsRepo, passkeysRepo, avatarStorage, log)
 	if err != nil {
 		log.Error().Msgf("cannot create services because of an error: %v", err)
 		os.Exit(1)
@@ -58,8 +85,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	go s.TransactionTracker.Run(workersCtx)
+	go s.RatesService.Run(workersCtx)
+	go s.UsersService.RunRegistrationCleanup(workersCtx)
+	go s.TeamsService.RunPaymentsCleanup(workersCtx)
+	go auth.NewSessionCleaner(db, log).Run(workersCtx)
+	if viper.GetString(config.EnvWebsocketBroker) == "postgres" {
+		go websocket.NewHistoryCleaner(db, log).Run(workersCtx)
+	}
+
+	webhook := webhooks.NewWebhook(s, log)
+	if webhook != nil {
+		go spvwallet.NewWebhookSubscription(webhook, log).Run(workersCtx)
+	}
+
 	server := httpserver.NewHTTPServer(viper.GetInt(config.EnvHTTPServerPort), log)
-	server.ApplyConfiguration(endpoints.SetupWalletRoutes(s, db, log, ws))
+	server.ApplyConfiguration(endpoints.SetupWalletRoutes(s, db, log, ws, webhook))
 	server.ApplyConfiguration(ws.SetupEntrypoint)
 
 	go startServer(server)
@@ -30,10 +30,24 @@ const (
 	EnvHTTPServerCookieDomain = "http.server.cookie.domain"
 	// EnvHTTPServerCookieSecure http server cookie secure parameter.
 	EnvHTTPServerCookieSecure = "http.server.cookie.secure"
+	// EnvHTTPServerCookieSameSite http server cookie same site parameter - strict/lax/none.
+	EnvHTTPServerCookieSameSite = "http.server.cookie.sameSite"
 	// EnvHTTPServerCorsAllowedDomains http server cors origin allowed domains.
 	EnvHTTPServerCorsAllowedDomains = "http.server.cors.allowedDomains"
 	// EnvHTTPServerSessionSecret gin session store secret to encrypt session data in database.
 	EnvHTTPServerSessionSecret = "http.server.session.secret" //nolint:gosec // not a hardcoded credential, just a config key name
+	// EnvHTTPServerSessionIdleTimeout define after how long inactivity session expires.
+	EnvHTTPServerSessionIdleTimeout = "http.server.session.idleTimeout"
+	// EnvHTTPServerSessionAbsoluteTimeout define after how long since sign in session expires regardless of activity.
+	EnvHTTPServerSessionAbsoluteTimeout = "http.server.session.absoluteTimeout"
+	// EnvHTTPServerSessionRememberMeIdleTimeout define after how long inactivity session of user who chose to be remembered expires.
+	EnvHTTPServerSessionRememberMeIdleTimeout = "http.server.session.rememberMe.idleTimeout"
+	// EnvHTTPServerSessionRememberMeAbsoluteTimeout define after how long since sign in session of user who chose to be remembered expires.
+	EnvHTTPServerSessionRememberMeAbsoluteTimeout = "http.server.session.rememberMe.absoluteTimeout"
+	// EnvHTTPServerSessionCleanupInterval define how often expired sessions are deleted from the database.
+	EnvHTTPServerSessionCleanupInterval = "http.server.session.cleanupInterval"
+	// EnvHTTPServerAdminToken token authorizing admin endpoints, admin endpoints are disabled when empty.
+	EnvHTTPServerAdminToken = "http.server.admin.token" //nolint:gosec // not a hardcoded credential, just a config key name
 )
 
 // Define basic spv-wallet config keys.
@@ -46,6 +60,19 @@ const (
 	EnvPaymailDomain = "spvwallet.paymail.domain"
 	// EnvPaymailAvatar define the paymail avatar url.
 	EnvPaymailAvatar = "spvwallet.paymail.avatar"
+	// EnvPaymailDomainSettings define per paymail domain settings as JSON object keyed by domain,
+	// e.g. {"example.com": {"avatar": "https://example.com/avatar.jpg", "registrationClosed": true}}.
+	EnvPaymailDomainSettings = "spvwallet.paymail.domainSettings"
+	// EnvWebhookURL define the public url of the webhook endpoint registered in spv-wallet, webhook is disabled when empty.
+	EnvWebhookURL = "spvwallet.webhook.url"
+	// EnvWebhookTokenHeader define the header in which spv-wallet sends the webhook token.
+	EnvWebhookTokenHeader = "spvwallet.webhook.token.header" //nolint:gosec // not a hardcoded credential, just a config key name
+	// EnvWebhookTokenValue define the token shared with spv-wallet used to verify webhook calls.
+	EnvWebhookTokenValue = "spvwallet.webhook.token.value" //nolint:gosec // not a hardcoded credential, just a config key name
+	// EnvWebhookSubscribeTimeout define the timeout of a single attempt to subscribe the webhook in spv-wallet.
+	EnvWebhookSubscribeTimeout = "spvwallet.webhook.subscribe.timeout"
+	// EnvWebhookSubscribeRetryInterval define how long to wait before subscribing the webhook again after a failed attempt.
+	EnvWebhookSubscribeRetryInterval = "spvwallet.webhook.subscribe.retryInterval"
 )
 
 const (
@@ -55,6 +82,12 @@ const (
 	// EnvWebsocketHistoryTTL max minutes for which published events should be hold
 	// and send to client in case of restored lost connection.
 	EnvWebsocketHistoryTTL = "websocket.history.ttl"
+	// EnvWebsocketBroker define the broker used to deliver events between application instances - memory/postgres.
+	EnvWebsocketBroker = "websocket.broker"
+	// EnvWebsocketSessionCheckInterval define how often session of websocket connection is validated.
+	EnvWebsocketSessionCheckInterval = "websocket.session.checkInterval"
+	// EnvWebsocketHistoryCleanupInterval define how often expired history of postgres broker is deleted from the database.
+	EnvWebsocketHistoryCleanupInterval = "websocket.history.cleanupInterval"
 )
 
 // EnvHashSalt define the hash salt.
@@ -74,6 +107,8 @@ const (
 const (
 	// EnvEndpointsExchangeRate define the exchange rate endpoint.
 	EnvEndpointsExchangeRate = "endpoints.exchangeRate"
+	// EnvEndpointsChainInfo define the chain info endpoint used to get the current block height.
+	EnvEndpointsChainInfo = "endpoints.chainInfo"
 )
 
 const (
@@ -81,11 +116,106 @@ const (
 	EnvContactsPasscodePeriod = "contacts.passcode.period"
 	// EnvContactsPasscodeDigits define the contacts passcode digits number.
 	EnvContactsPasscodeDigits = "contacts.passcode.digits"
+	// EnvContactsNotificationsInterval define how often contacts of connected users are checked for changes.
+	EnvContactsNotificationsInterval = "contacts.notifications.interval"
+)
+
+const (
+	// EnvTransactionsNotificationsInterval define how often transactions of connected users are checked for incoming payments.
+	EnvTransactionsNotificationsInterval = "transactions.notifications.interval"
+	// EnvTransactionsTrackingInterval define how often tracked transactions are checked for status changes.
+	EnvTransactionsTrackingInterval = "transactions.tracking.interval"
+	// EnvTransactionsTrackingConfirmations define the number of confirmations after which a transaction is no longer tracked.
+	EnvTransactionsTrackingConfirmations = "transactions.tracking.confirmations"
 )
 
 const (
 	// EnvCacheSettingsTTL define the cache settings ttl used for exchange rates storage.
 	EnvCacheSettingsTTL = "cache.settings.ttl"
+	// EnvCachePaymailTTL define the cache ttl used for resolved paymail addresses.
+	EnvCachePaymailTTL = "cache.paymail.ttl"
+	// EnvCachePaymailMaxEntries define the max number of resolved paymail addresses kept in cache.
+	EnvCachePaymailMaxEntries = "cache.paymail.maxEntries"
+)
+
+const (
+	// EnvAvatarsDirectory define the directory in which uploaded user avatars are stored.
+	EnvAvatarsDirectory = "avatars.directory"
+	// EnvAvatarsURL define the public url under which stored avatars are served by the backend.
+	EnvAvatarsURL = "avatars.url"
+	// EnvAvatarsMaxSize define the max size of uploaded avatar in bytes.
+	EnvAvatarsMaxSize = "avatars.maxSize"
+)
+
+const (
+	// EnvRegistrationMaxAttempts define the number of failed attempts after which unfinished registration is rolled back.
+	EnvRegistrationMaxAttempts = "registration.maxAttempts"
+	// EnvRegistrationPendingTTL define how long unfinished registration can be resumed before it is rolled back.
+	EnvRegistrationPendingTTL = "registration.pendingTTL"
+	// EnvRegistrationCleanupInterval define how often expired unfinished registrations are rolled back.
+	EnvRegistrationCleanupInterval = "registration.cleanupInterval"
+)
+
+const (
+	// EnvViewersInvitationTTL define how long invitation of a viewer can be accepted.
+	EnvViewersInvitationTTL = "viewers.invitationTTL"
+)
+
+const (
+	// EnvTeamsInvitationTTL define how long invitation of a member to a team wallet can be accepted.
+	EnvTeamsInvitationTTL = "teams.invitationTTL"
+	// EnvTeamsApprovalThreshold define the amount in satoshis above which payment from a team wallet has to be approved, 0 disables approvals.
+	EnvTeamsApprovalThreshold = "teams.approvalThreshold"
+	// EnvTeamsRequiredApprovals define the number of members, including the initiator, who have to approve the payment.
+	EnvTeamsRequiredApprovals = "teams.requiredApprovals"
+	// EnvTeamsApprovalTTL define how long payment can wait for approvals before it is discarded.
+	EnvTeamsApprovalTTL = "teams.approvalTTL"
+	// EnvTeamsApprovalCleanupInterval define how often expired payments waiting for approvals are discarded.
+	EnvTeamsApprovalCleanupInterval = "teams.approvalCleanupInterval"
+)
+
+const (
+	// EnvAPITokensDefaultTTL define how long API token is valid when it is created without expiry.
+	EnvAPITokensDefaultTTL = "apiTokens.defaultTTL"
+	// EnvAPITokensMaxTTL define the longest lifetime of API token.
+	EnvAPITokensMaxTTL = "apiTokens.maxTTL"
+)
+
+const (
+	// EnvSSOEnabled define whether users can sign in with the OpenID Connect identity provider.
+	EnvSSOEnabled = "sso.enabled"
+	// EnvSSOIssuer define the issuer url of the identity provider, its configuration is discovered from it.
+	EnvSSOIssuer = "sso.issuer"
+	// EnvSSOClientID define the client id registered in the identity provider.
+	EnvSSOClientID = "sso.clientId"
+	// EnvSSOClientSecret define the client secret, it can be empty for public clients relying on PKCE.
+	EnvSSOClientSecret = "sso.clientSecret" //nolint:gosec // not a hardcoded credential, just a config key name
+	// EnvSSOCallbackURL define the public url of the callback endpoint registered in the identity provider.
+	EnvSSOCallbackURL = "sso.callbackURL"
+	// EnvSSOFrontendURL define the url of the frontend page to which the user is redirected after the callback.
+	EnvSSOFrontendURL = "sso.frontendURL"
+	// EnvSSOAccessKeySecret define the secret encrypting access keys used to open sessions of users signing in with the identity provider.
+	EnvSSOAccessKeySecret = "sso.accessKeySecret" //nolint:gosec // not a hardcoded credential, just a config key name
+	// EnvSSOFlowTTL define how long the sign in with the identity provider and registration after it can take.
+	EnvSSOFlowTTL = "sso.flowTTL"
+)
+
+const (
+	// EnvSpendingPINPepper define the server secret mixed into keys derived from spending PINs, it is not stored with the keys.
+	EnvSpendingPINPepper = "spendingPin.pepper"
+	// EnvSpendingPINMaxAttempts define the number of invalid spending PIN attempts after which the PIN is locked.
+	EnvSpendingPINMaxAttempts = "spendingPin.maxAttempts"
+	// EnvSpendingPINLockout define how long the spending PIN is locked after too many invalid attempts.
+	EnvSpendingPINLockout = "spendingPin.lockout"
+)
+
+const (
+	// EnvPasskeysRPID define the relying party id of passkeys, the domain of the frontend, e.g. "example.com".
+	EnvPasskeysRPID = "passkeys.rpId"
+	// EnvPasskeysRPDisplayName define the relying party name shown to the user when passkey is used.
+	EnvPasskeysRPDisplayName = "passkeys.rpDisplayName"
+	// EnvPasskeysRPOrigins define the origins of the frontend allowed to use passkeys, e.g. "https://wallet.example.com".
+	EnvPasskeysRPOrigins = "passkeys.rpOrigins"
 )
 
 // Config returns strongly typed config values.
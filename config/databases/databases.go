@@ -20,18 +20,7 @@ import (
 func SetUpDatabase(l *zerolog.Logger) *sql.DB {
 	log := l.With().Str("service", "database").Logger()
 
-	// Load config.
-	host := viper.GetString(config.EnvDbHost)
-	port := viper.GetInt(config.EnvDbPort)
-	user := viper.GetString(config.EnvDbUser)
-	password := viper.GetString(config.EnvDbPassword)
-	dbname := viper.GetString(config.EnvDbName)
-	sslMode := viper.GetString(config.EnvDbSslMode)
-
-	// Build connection string.
-	psqlInfo := fmt.Sprintf("host=%s port=%d user=%s "+
-		"password=%s dbname=%s sslmode=%s",
-		host, port, user, password, dbname, sslMode)
+	psqlInfo := ConnectionString()
 
 	log.Debug().Msg(psqlInfo)
 
@@ -56,6 +45,22 @@ func SetUpDatabase(l *zerolog.Logger) *sql.DB {
 	return db
 }
 
+// ConnectionString returns postgres connection string built from config.
+func ConnectionString() string {
+	// Load config.
+	host := viper.GetString(config.EnvDbHost)
+	port := viper.GetInt(config.EnvDbPort)
+	user := viper.GetString(config.EnvDbUser)
+	password := viper.GetString(config.EnvDbPassword)
+	dbname := viper.GetString(config.EnvDbName)
+	sslMode := viper.GetString(config.EnvDbSslMode)
+
+	// Build connection string.
+	return fmt.Sprintf("host=%s port=%d user=%s "+
+		"password=%s dbname=%s sslmode=%s",
+		host, port, user, password, dbname, sslMode)
+}
+
 // runMigration is used to run database migrations.
 func runMigration(db *sql.DB) {
 	driver, err := postgres.WithInstance(db, &postgres.Config{})
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// PaymailDomainSettings represents settings of a single paymail domain.
+type PaymailDomainSettings struct {
+	Avatar             string `json:"avatar"`
+	RegistrationClosed bool   `json:"registrationClosed"`
+}
+
+// GetPaymailDomainSettings returns settings of the paymail domain. Domains without own settings
+// are open for registration and use the global paymail avatar.
+// Defaults are returned together with an error when the settings cannot be parsed.
+func GetPaymailDomainSettings(domain string) (PaymailDomainSettings, error) {
+	defaults := PaymailDomainSettings{
+		Avatar: viper.GetString(EnvPaymailAvatar),
+	}
+
+	raw := strings.TrimSpace(viper.GetString(EnvPaymailDomainSettings))
+	if raw == "" {
+		return defaults, nil
+	}
+
+	var settings map[string]PaymailDomainSettings
+	if err := json.Unmarshal([]byte(raw), &settings); err != nil {
+		return defaults, errors.Wrap(err, "invalid paymail domain settings")
+	}
+
+	domainSettings, ok := settings[strings.ToLower(domain)]
+	if !ok {
+		return defaults, nil
+	}
+	if domainSettings.Avatar == "" {
+		domainSettings.Avatar = defaults.Avatar
+	}
+	return domainSettings, nil
+}
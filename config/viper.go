@@ -18,7 +18,16 @@ func NewViperConfig() *Config {
 	setEndpointsDefaults()
 	setWebsocketDefaults()
 	setContactsDefaults()
+	setTransactionsDefaults()
 	setCacheDefaults()
+	setAvatarsDefaults()
+	setRegistrationDefaults()
+	setViewersDefaults()
+	setTeamsDefaults()
+	setAPITokensDefaults()
+	setSSODefaults()
+	setSpendingPINDefaults()
+	setPasskeysDefaults()
 	return &Config{}
 }
 
@@ -51,8 +60,15 @@ func setHTTPServerDefaults() {
 	viper.SetDefault(EnvHTTPServerPort, 8180)
 	viper.SetDefault(EnvHTTPServerCookieDomain, "localhost")
 	viper.SetDefault(EnvHTTPServerCookieSecure, false)
+	viper.SetDefault(EnvHTTPServerCookieSameSite, "strict")
 	viper.SetDefault(EnvHTTPServerCorsAllowedDomains, []string{})
 	viper.SetDefault(EnvHTTPServerSessionSecret, "secret")
+	viper.SetDefault(EnvHTTPServerSessionIdleTimeout, 30*time.Minute)
+	viper.SetDefault(EnvHTTPServerSessionAbsoluteTimeout, 12*time.Hour)
+	viper.SetDefault(EnvHTTPServerSessionRememberMeIdleTimeout, 7*24*time.Hour)
+	viper.SetDefault(EnvHTTPServerSessionRememberMeAbsoluteTimeout, 30*24*time.Hour)
+	viper.SetDefault(EnvHTTPServerSessionCleanupInterval, 10*time.Minute)
+	viper.SetDefault(EnvHTTPServerAdminToken, "")
 }
 
 // setSpvWalletDefaults sets default values for spv-wallet connection.
@@ -61,6 +77,12 @@ func setSpvWalletDefaults() {
 	viper.SetDefault(EnvServerURL, "http://localhost:3003")
 	viper.SetDefault(EnvPaymailDomain, "example.com")
 	viper.SetDefault(EnvPaymailAvatar, "http://localhost:3003/static/paymail/avatar.jpg")
+	viper.SetDefault(EnvPaymailDomainSettings, "")
+	viper.SetDefault(EnvWebhookURL, "")
+	viper.SetDefault(EnvWebhookTokenHeader, "X-Webhook-Token")
+	viper.SetDefault(EnvWebhookTokenValue, "")
+	viper.SetDefault(EnvWebhookSubscribeTimeout, 10*time.Second)
+	viper.SetDefault(EnvWebhookSubscribeRetryInterval, 30*time.Second)
 }
 
 // setHashDefaults sets default values for hash.
@@ -78,20 +100,94 @@ func setLoggingDefaults() {
 // setEndpointsDefaults sets default values for endpoints used in app.
 func setEndpointsDefaults() {
 	viper.SetDefault(EnvEndpointsExchangeRate, "https://api.whatsonchain.com/v1/bsv/main/exchangerate")
+	viper.SetDefault(EnvEndpointsChainInfo, "https://api.whatsonchain.com/v1/bsv/main/chain/info")
 }
 
 // setWebhookDefaults sets default values for websocket.
 func setWebsocketDefaults() {
 	viper.SetDefault(EnvWebsocketHistoryMax, 300)
 	viper.SetDefault(EnvWebsocketHistoryTTL, 10)
+	viper.SetDefault(EnvWebsocketBroker, "memory")
+	viper.SetDefault(EnvWebsocketSessionCheckInterval, time.Minute)
+	viper.SetDefault(EnvWebsocketHistoryCleanupInterval, 10*time.Minute)
 }
 
 func setContactsDefaults() {
 	viper.SetDefault(EnvContactsPasscodePeriod, uint(3600)) // 1h
 	viper.SetDefault(EnvContactsPasscodeDigits, uint(2))
+	viper.SetDefault(EnvContactsNotificationsInterval, 30*time.Second)
+}
+
+// setTransactionsDefaults sets default values for transactions.
+func setTransactionsDefaults() {
+	viper.SetDefault(EnvTransactionsNotificationsInterval, 30*time.Second)
+	viper.SetDefault(EnvTransactionsTrackingInterval, time.Minute)
+	viper.SetDefault(EnvTransactionsTrackingConfirmations, uint64(6))
 }
 
 // setCacheDefaults sets default values for cache.
 func setCacheDefaults() {
 	viper.SetDefault(EnvCacheSettingsTTL, 60*time.Second)
+	viper.SetDefault(EnvCachePaymailTTL, 10*time.Minute)
+	viper.SetDefault(EnvCachePaymailMaxEntries, 10000)
+}
+
+// setAvatarsDefaults sets default values for user avatars.
+func setAvatarsDefaults() {
+	viper.SetDefault(EnvAvatarsDirectory, "storage/avatars")
+	viper.SetDefault(EnvAvatarsURL, "http://localhost:8180/api/v1/avatars")
+	viper.SetDefault(EnvAvatarsMaxSize, 1<<20) // 1MB
+}
+
+// setRegistrationDefaults sets default values for user registration.
+func setRegistrationDefaults() {
+	viper.SetDefault(EnvRegistrationMaxAttempts, 3)
+	viper.SetDefault(EnvRegistrationPendingTTL, 24*time.Hour)
+	viper.SetDefault(EnvRegistrationCleanupInterval, time.Hour)
+}
+
+// setViewersDefaults sets default values for viewers invited by wallet owners.
+func setViewersDefaults() {
+	viper.SetDefault(EnvViewersInvitationTTL, 72*time.Hour)
+}
+
+// setTeamsDefaults sets default values for team wallets.
+func setTeamsDefaults() {
+	viper.SetDefault(EnvTeamsInvitationTTL, 72*time.Hour)
+	viper.SetDefault(EnvTeamsApprovalThreshold, 0)
+	viper.SetDefault(EnvTeamsRequiredApprovals, 2)
+	viper.SetDefault(EnvTeamsApprovalTTL, 24*time.Hour)
+	viper.SetDefault(EnvTeamsApprovalCleanupInterval, 5*time.Minute)
+}
+
+// setAPITokensDefaults sets default values for personal API tokens.
+func setAPITokensDefaults() {
+	viper.SetDefault(EnvAPITokensDefaultTTL, 30*24*time.Hour)
+	viper.SetDefault(EnvAPITokensMaxTTL, 365*24*time.Hour)
+}
+
+// setSSODefaults sets default values for single sign-on with the OpenID Connect identity provider.
+func setSSODefaults() {
+	viper.SetDefault(EnvSSOEnabled, false)
+	viper.SetDefault(EnvSSOIssuer, "")
+	viper.SetDefault(EnvSSOClientID, "")
+	viper.SetDefault(EnvSSOClientSecret, "")
+	viper.SetDefault(EnvSSOCallbackURL, "http://localhost:8180/api/v1/sso/callback")
+	viper.SetDefault(EnvSSOFrontendURL, "http://localhost:3002/sso")
+	viper.SetDefault(EnvSSOAccessKeySecret, "")
+	viper.SetDefault(EnvSSOFlowTTL, 10*time.Minute)
+}
+
+// setSpendingPINDefaults sets default values for keys derived from spending PINs and their attempt limiter.
+func setSpendingPINDefaults() {
+	viper.SetDefault(EnvSpendingPINPepper, "")
+	viper.SetDefault(EnvSpendingPINMaxAttempts, 5)
+	viper.SetDefault(EnvSpendingPINLockout, 15*time.Minute)
+}
+
+// setPasskeysDefaults sets default values for sign in and payment confirmation with passkeys.
+func setPasskeysDefaults() {
+	viper.SetDefault(EnvPasskeysRPID, "localhost")
+	viper.SetDefault(EnvPasskeysRPDisplayName, "SPV Wallet")
+	viper.SetDefault(EnvPasskeysRPOrigins, []string{"http://localhost:3002"})
 }
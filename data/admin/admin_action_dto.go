@@ -0,0 +1,35 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/admin"
+)
+
+// ActionDto is a struct that represent admin action database record.
+type ActionDto struct {
+	ID           int       `db:"id"`
+	Actor        string    `db:"actor"`
+	Action       string    `db:"action"`
+	TargetUserID *int      `db:"target_user_id"`
+	Details      string    `db:"details"`
+	IP           string    `db:"ip"`
+	UserAgent    string    `db:"user_agent"`
+	Result       string    `db:"result"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// toAction converts ActionDto to Action.
+func (a *ActionDto) toAction() *admin.Action {
+	return &admin.Action{
+		ID:           a.ID,
+		Actor:        a.Actor,
+		Action:       a.Action,
+		TargetUserID: a.TargetUserID,
+		Details:      a.Details,
+		IP:           a.IP,
+		UserAgent:    a.UserAgent,
+		Result:       a.Result,
+		CreatedAt:    a.CreatedAt,
+	}
+}
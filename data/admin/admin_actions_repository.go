@@ -0,0 +1,84 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/admin"
+)
+
+const (
+	postgresInsertAction = `
+	INSERT INTO admin_actions(actor, action, target_user_id, details, ip, user_agent, result, created_at)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING id
+	`
+
+	postgresActionsCondition = `
+	WHERE ($1 = '' OR actor = $1) AND ($2 = '' OR action = $2) AND ($3::INTEGER IS NULL OR target_user_id = $3)
+	`
+
+	postgresGetActions = `
+	SELECT id, actor, action, target_user_id, details, ip, user_agent, result, created_at
+	FROM admin_actions
+	` + postgresActionsCondition + `
+	ORDER BY created_at DESC, id DESC
+	LIMIT $4 OFFSET $5
+	`
+
+	postgresCountActions = `
+	SELECT COUNT(*)
+	FROM admin_actions
+	` + postgresActionsCondition
+)
+
+// ActionsRepository is an append-only repository for the audit trail of admin actions.
+type ActionsRepository struct {
+	db *sql.DB
+}
+
+// NewActionsRepository creates a new admin actions repository.
+func NewActionsRepository(db *sql.DB) *ActionsRepository {
+	return &ActionsRepository{
+		db: db,
+	}
+}
+
+// InsertAction inserts an admin action to db.
+func (r *ActionsRepository) InsertAction(ctx context.Context, action *admin.Action) error {
+	row := r.db.QueryRowContext(ctx, postgresInsertAction,
+		action.Actor, action.Action, action.TargetUserID, action.Details, action.IP, action.UserAgent, action.Result, action.CreatedAt)
+	return errors.Wrap(row.Scan(&action.ID), "internal error")
+}
+
+// GetActions returns page of admin actions matching the filter, the newest first, and the number of all such actions.
+func (r *ActionsRepository) GetActions(ctx context.Context, filter *admin.ActionsFilter, page, pageSize int) ([]*admin.Action, int64, error) {
+	if filter == nil {
+		filter = &admin.ActionsFilter{}
+	}
+
+	var count int64
+	row := r.db.QueryRowContext(ctx, postgresCountActions, filter.Actor, filter.Action, filter.TargetUserID)
+	if err := row.Scan(&count); err != nil {
+		return nil, 0, errors.Wrap(err, "internal error")
+	}
+
+	rows, err := r.db.QueryContext(ctx, postgresGetActions, filter.Actor, filter.Action, filter.TargetUserID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:errcheck // best effort cleanup
+
+	actions := make([]*admin.Action, 0)
+	for rows.Next() {
+		var action ActionDto
+		if err = rows.Scan(&action.ID, &action.Actor, &action.Action, &action.TargetUserID, &action.Details,
+			&action.IP, &action.UserAgent, &action.Result, &action.CreatedAt); err != nil {
+			return nil, 0, errors.Wrap(err, "internal error")
+		}
+		actions = append(actions, action.toAction())
+	}
+	return actions, count, errors.Wrap(rows.Err(), "internal error")
+}
@@ -0,0 +1,37 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/audit"
+)
+
+// EventDto is a struct that represent audit event database record.
+type EventDto struct {
+	ID        int64     `db:"id"`
+	UserID    *int      `db:"user_id"`
+	Actor     string    `db:"actor"`
+	Action    string    `db:"action"`
+	Target    string    `db:"target"`
+	Details   string    `db:"details"`
+	IP        string    `db:"ip"`
+	UserAgent string    `db:"user_agent"`
+	Result    string    `db:"result"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// toEvent converts EventDto to Event.
+func (e *EventDto) toEvent() *audit.Event {
+	return &audit.Event{
+		ID:        e.ID,
+		UserID:    e.UserID,
+		Actor:     e.Actor,
+		Action:    e.Action,
+		Target:    e.Target,
+		Details:   e.Details,
+		IP:        e.IP,
+		UserAgent: e.UserAgent,
+		Result:    e.Result,
+		CreatedAt: e.CreatedAt,
+	}
+}
@@ -0,0 +1,88 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/audit"
+)
+
+const (
+	postgresInsertEvent = `
+	INSERT INTO audit_events(user_id, actor, action, target, details, ip, user_agent, result, created_at)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	RETURNING id
+	`
+
+	postgresEventsCondition = `
+	WHERE ($1::INTEGER IS NULL OR user_id = $1)
+	AND ($2 = '' OR action = $2)
+	AND ($3 = '' OR result = $3)
+	AND ($4::TIMESTAMP IS NULL OR created_at >= $4)
+	AND ($5::TIMESTAMP IS NULL OR created_at < $5)
+	`
+
+	postgresGetEvents = `
+	SELECT id, user_id, actor, action, target, details, ip, user_agent, result, created_at
+	FROM audit_events
+	` + postgresEventsCondition + `
+	ORDER BY created_at DESC, id DESC
+	LIMIT $6 OFFSET $7
+	`
+
+	postgresCountEvents = `
+	SELECT COUNT(*)
+	FROM audit_events
+	` + postgresEventsCondition
+)
+
+// EventsRepository is an append-only repository for audit events.
+type EventsRepository struct {
+	db *sql.DB
+}
+
+// NewEventsRepository creates a new audit events repository.
+func NewEventsRepository(db *sql.DB) *EventsRepository {
+	return &EventsRepository{
+		db: db,
+	}
+}
+
+// InsertEvent inserts an audit event to db.
+func (r *EventsRepository) InsertEvent(ctx context.Context, event *audit.Event) error {
+	row := r.db.QueryRowContext(ctx, postgresInsertEvent,
+		event.UserID, event.Actor, event.Action, event.Target, event.Details, event.IP, event.UserAgent, event.Result, event.CreatedAt)
+	return errors.Wrap(row.Scan(&event.ID), "internal error")
+}
+
+// GetEvents returns page of audit events matching the filter, the newest first, and the number of all such events.
+func (r *EventsRepository) GetEvents(ctx context.Context, filter *audit.EventsFilter, page, pageSize int) ([]*audit.Event, int64, error) {
+	if filter == nil {
+		filter = &audit.EventsFilter{}
+	}
+	args := []any{filter.UserID, filter.Action, filter.Result, filter.From, filter.To}
+
+	var count int64
+	if err := r.db.QueryRowContext(ctx, postgresCountEvents, args...).Scan(&count); err != nil {
+		return nil, 0, errors.Wrap(err, "internal error")
+	}
+
+	rows, err := r.db.QueryContext(ctx, postgresGetEvents, append(args, pageSize, (page-1)*pageSize)...)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:errcheck // best effort cleanup
+
+	events := make([]*audit.Event, 0)
+	for rows.Next() {
+		var event EventDto
+		if err = rows.Scan(&event.ID, &event.UserID, &event.Actor, &event.Action, &event.Target, &event.Details,
+			&event.IP, &event.UserAgent, &event.Result, &event.CreatedAt); err != nil {
+			return nil, 0, errors.Wrap(err, "internal error")
+		}
+		events = append(events, event.toEvent())
+	}
+	return events, count, errors.Wrap(rows.Err(), "internal error")
+}
@@ -0,0 +1,58 @@
+package avatars
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// FileStorage stores user avatars as files in a directory.
+type FileStorage struct {
+	dir string
+}
+
+// NewFileStorage creates a new avatars storage, the directory is created if it does not exist.
+func NewFileStorage(dir string) (*FileStorage, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, errors.Wrap(err, "cannot create avatars directory")
+	}
+
+	return &FileStorage{
+		dir: dir,
+	}, nil
+}
+
+// Save stores avatar with given name.
+func (s *FileStorage) Save(name string, data []byte) error {
+	path, err := s.Path(name)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(path, data, 0o600)
+	return errors.Wrap(err, "internal error")
+}
+
+// Delete removes avatar with given name, missing avatar is not an error.
+func (s *FileStorage) Delete(name string) error {
+	path, err := s.Path(name)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// Path returns location of the avatar file, names pointing outside of the storage directory are rejected.
+func (s *FileStorage) Path(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return "", errors.Errorf("invalid avatar name %q", name)
+	}
+
+	return filepath.Join(s.dir, name), nil
+}
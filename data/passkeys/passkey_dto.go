@@ -0,0 +1,41 @@
+package passkeys
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/passkeys"
+)
+
+// PasskeyDto is a struct that represent passkey database record.
+type PasskeyDto struct {
+	ID           int        `db:"id"`
+	UserID       int        `db:"user_id"`
+	Name         string     `db:"name"`
+	CredentialID []byte     `db:"credential_id"`
+	Credential   []byte     `db:"credential"`
+	Xpriv        string     `db:"xpriv"`
+	LastUsedAt   *time.Time `db:"last_used_at"`
+	CreatedAt    time.Time  `db:"created_at"`
+}
+
+// toPasskey converts PasskeyDto to Passkey.
+func (p *PasskeyDto) toPasskey() (*passkeys.Passkey, error) {
+	var credential webauthn.Credential
+	if err := json.Unmarshal(p.Credential, &credential); err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped by callers
+	}
+
+	return &passkeys.Passkey{
+		ID:           p.ID,
+		UserID:       p.UserID,
+		Name:         p.Name,
+		CredentialID: p.CredentialID,
+		Credential:   &credential,
+		Xpriv:        p.Xpriv,
+		LastUsedAt:   p.LastUsedAt,
+		CreatedAt:    p.CreatedAt,
+	}, nil
+}
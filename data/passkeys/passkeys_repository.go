@@ -0,0 +1,137 @@
+package passkeys
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/pkg/errors"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/passkeys"
+)
+
+const (
+	postgresInsertPasskey = `
+	INSERT INTO passkeys(user_id, name, credential_id, credential, xpriv, created_at)
+	VALUES($1, $2, $3, $4, $5, $6)
+	RETURNING id
+	`
+
+	postgresSelectPasskey = `
+	SELECT id, user_id, name, credential_id, credential, xpriv, last_used_at, created_at
+	FROM passkeys
+	`
+
+	postgresGetPasskeys = postgresSelectPasskey + `
+	WHERE user_id = $1
+	ORDER BY created_at
+	`
+
+	postgresGetPasskeyByCredentialID = postgresSelectPasskey + `
+	WHERE credential_id = $1
+	`
+
+	postgresUpdatePasskeyCredential = `
+	UPDATE passkeys
+	SET credential = $2, last_used_at = $3
+	WHERE id = $1
+	`
+
+	postgresDeletePasskey = `
+	DELETE FROM passkeys
+	WHERE id = $1 AND user_id = $2
+	`
+)
+
+// Repository is a repository for passkeys.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewPasskeysRepository creates a new passkeys repository.
+func NewPasskeysRepository(db *sql.DB) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
+
+// InsertPasskey inserts passkey of the user to db.
+func (r *Repository) InsertPasskey(ctx context.Context, passkey *passkeys.Passkey) error {
+	credential, err := json.Marshal(passkey.Credential)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+
+	row := r.db.QueryRowContext(ctx, postgresInsertPasskey,
+		passkey.UserID, passkey.Name, passkey.CredentialID, credential, passkey.Xpriv, passkey.CreatedAt)
+	return errors.Wrap(row.Scan(&passkey.ID), "internal error")
+}
+
+// GetPasskeys returns passkeys of the user.
+func (r *Repository) GetPasskeys(ctx context.Context, userID int) ([]*passkeys.Passkey, error) {
+	rows, err := r.db.QueryContext(ctx, postgresGetPasskeys, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:errcheck // best effort cleanup
+
+	result := make([]*passkeys.Passkey, 0)
+	for rows.Next() {
+		passkey, err := scanPasskey(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		result = append(result, passkey)
+	}
+	return result, errors.Wrap(rows.Err(), "internal error")
+}
+
+// GetPasskeyByCredentialID returns passkey by id of its credential. Can return nil passkey without an error - if no rows found.
+func (r *Repository) GetPasskeyByCredentialID(ctx context.Context, credentialID []byte) (*passkeys.Passkey, error) {
+	passkey, err := scanPasskey(r.db.QueryRowContext(ctx, postgresGetPasskeyByCredentialID, credentialID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return passkey, nil
+}
+
+// UpdatePasskeyCredential stores the credential of the passkey updated by its use, e.g. with new sign counter.
+func (r *Repository) UpdatePasskeyCredential(ctx context.Context, id int, credential *webauthn.Credential, usedAt time.Time) error {
+	data, err := json.Marshal(credential)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+
+	_, err = r.db.ExecContext(ctx, postgresUpdatePasskeyCredential, id, data, usedAt)
+	return errors.Wrap(err, "internal error")
+}
+
+// DeletePasskey deletes passkey of the user from db. Returns false if the user has no such passkey.
+func (r *Repository) DeletePasskey(ctx context.Context, userID, id int) (bool, error) {
+	result, err := r.db.ExecContext(ctx, postgresDeletePasskey, id, userID)
+	if err != nil {
+		return false, errors.Wrap(err, "internal error")
+	}
+	deleted, err := result.RowsAffected()
+	return deleted > 0, errors.Wrap(err, "internal error")
+}
+
+// rowScanner is implemented by both sql.Row and sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPasskey(row rowScanner) (*passkeys.Passkey, error) {
+	var passkey PasskeyDto
+	err := row.Scan(&passkey.ID, &passkey.UserID, &passkey.Name, &passkey.CredentialID, &passkey.Credential,
+		&passkey.Xpriv, &passkey.LastUsedAt, &passkey.CreatedAt)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped by callers
+	}
+	return passkey.toPasskey()
+}
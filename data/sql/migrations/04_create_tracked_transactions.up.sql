@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS tracked_transactions (
+    transaction_id VARCHAR(64) NOT NULL,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL,
+    block_hash VARCHAR(64) NOT NULL DEFAULT '',
+    block_height BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (transaction_id, user_id)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE users ADD COLUMN xpub_id VARCHAR(64);
+CREATE INDEX IF NOT EXISTS users_xpub_id_idx ON users(xpub_id);
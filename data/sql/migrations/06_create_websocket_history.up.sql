@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS websocket_channels (
+    channel VARCHAR(255) PRIMARY KEY,
+    epoch VARCHAR(32) NOT NULL,
+    top_offset BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS websocket_publications (
+    channel VARCHAR(255) NOT NULL,
+    publication_offset BIGINT NOT NULL,
+    data BYTEA NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (channel, publication_offset)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS user_paymails (
+    id serial PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    paymail VARCHAR(255) UNIQUE NOT NULL,
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS user_paymails_primary_idx ON user_paymails(user_id) WHERE is_primary;
+
+INSERT INTO user_paymails(user_id, paymail, is_primary, created_at)
+SELECT id, paymail, TRUE, created_at FROM users WHERE paymail IS NOT NULL AND paymail <> ''
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE users DROP COLUMN paymail;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS pending_registrations (
+    id serial PRIMARY KEY,
+    email VARCHAR(255) UNIQUE NOT NULL,
+    xpriv TEXT NOT NULL,
+    mnemonic TEXT NOT NULL,
+    alias VARCHAR(64) NOT NULL,
+    domain VARCHAR(255) NOT NULL,
+    xpub_id VARCHAR(64) NOT NULL,
+    paymail VARCHAR(255) NOT NULL DEFAULT '',
+    step VARCHAR(20) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
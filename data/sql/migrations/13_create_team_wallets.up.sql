@@ -0,0 +1,47 @@
+-- Team wallets are shared by members signing in with their own credentials.
+CREATE TABLE IF NOT EXISTS team_wallets (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    paymail VARCHAR(255) UNIQUE NOT NULL,
+    xpub_id VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+-- Wallet xpriv is encrypted with the password of every member separately.
+CREATE TABLE IF NOT EXISTS team_wallet_members (
+    wallet_id INTEGER NOT NULL REFERENCES team_wallets(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(16) NOT NULL,
+    xpriv TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (wallet_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS team_wallet_members_user_id_idx ON team_wallet_members(user_id);
+
+-- Wallet xpriv of the invitation is encrypted with the invitation token, only hash of the token is stored.
+CREATE TABLE IF NOT EXISTS team_wallet_invitations (
+    id SERIAL PRIMARY KEY,
+    wallet_id INTEGER NOT NULL REFERENCES team_wallets(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(16) NOT NULL,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    xpriv TEXT NOT NULL,
+    invited_by INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+-- Initiator is not a foreign key, so the history is kept after the member leaves.
+CREATE TABLE IF NOT EXISTS team_wallet_transactions (
+    id SERIAL PRIMARY KEY,
+    wallet_id INTEGER NOT NULL REFERENCES team_wallets(id) ON DELETE CASCADE,
+    transaction_id VARCHAR(64) NOT NULL,
+    initiator_id INTEGER NOT NULL,
+    initiator_email VARCHAR(255) NOT NULL,
+    recipient VARCHAR(255) NOT NULL,
+    satoshis BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS team_wallet_transactions_wallet_id_idx ON team_wallet_transactions(wallet_id, created_at);
@@ -0,0 +1,23 @@
+-- Payments above the approval threshold are held until enough members approve them, the last approval drafts them.
+CREATE TABLE IF NOT EXISTS team_wallet_payments (
+    id SERIAL PRIMARY KEY,
+    wallet_id INTEGER NOT NULL REFERENCES team_wallets(id) ON DELETE CASCADE,
+    initiator_id INTEGER NOT NULL,
+    initiator_email VARCHAR(255) NOT NULL,
+    recipient VARCHAR(255) NOT NULL,
+    satoshis BIGINT NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    required_approvals INTEGER NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS team_wallet_payments_wallet_id_idx ON team_wallet_payments(wallet_id, status);
+CREATE INDEX IF NOT EXISTS team_wallet_payments_expires_at_idx ON team_wallet_payments(expires_at) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS team_wallet_payment_approvals (
+    payment_id INTEGER NOT NULL REFERENCES team_wallet_payments(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (payment_id, user_id)
+);
@@ -0,0 +1,19 @@
+-- Access key and xpriv of the token are encrypted with the token, only hash of the token is stored.
+-- Xpriv is stored only for tokens with scopes which need to sign, send limit is the total amount the token can spend.
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(64) NOT NULL,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    scopes TEXT[] NOT NULL,
+    send_limit BIGINT NOT NULL DEFAULT 0,
+    spent BIGINT NOT NULL DEFAULT 0,
+    access_key_id VARCHAR(255) NOT NULL,
+    access_key VARCHAR(255) NOT NULL,
+    xpriv TEXT NOT NULL DEFAULT '',
+    expires_at TIMESTAMP NOT NULL,
+    last_used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS api_tokens_user_id_idx ON api_tokens(user_id);
@@ -0,0 +1,6 @@
+-- Users signing in with the identity provider are mapped by issuer and subject of their identity.
+-- Their xpriv is encrypted with spending PIN, sessions are opened with the access key encrypted with the server secret.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS sso_issuer VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS sso_subject VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS users_sso_identity_idx ON users(sso_issuer, sso_subject) WHERE sso_subject <> '';
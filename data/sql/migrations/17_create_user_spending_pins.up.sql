@@ -0,0 +1,7 @@
+-- Invalid attempts of the spending PIN are counted per user, the PIN is locked until locked_until after too many of them.
+CREATE TABLE IF NOT EXISTS user_spending_pins (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL
+);
@@ -0,0 +1,14 @@
+-- Credential is the WebAuthn credential record with the public key and sign counter of the passkey.
+-- Xpriv of the user is encrypted with the PRF output of the passkey, which is never stored.
+CREATE TABLE IF NOT EXISTS passkeys (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(64) NOT NULL,
+    credential_id BYTEA UNIQUE NOT NULL,
+    credential JSONB NOT NULL,
+    xpriv TEXT NOT NULL,
+    last_used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS passkeys_user_id_idx ON passkeys(user_id);
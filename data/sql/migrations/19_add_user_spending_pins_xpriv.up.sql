@@ -0,0 +1,3 @@
+-- Xpriv is a copy of the user xpriv encrypted with the spending PIN, empty for users signing in with the identity provider,
+-- whose xpriv in users table is encrypted with the PIN already.
+ALTER TABLE user_spending_pins ADD COLUMN IF NOT EXISTS xpriv TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,6 @@
+-- Wallet of the user is watched by a single application instance, which holds the lease until expires_at.
+CREATE TABLE IF NOT EXISTS websocket_watchers (
+    user_id VARCHAR(255) PRIMARY KEY,
+    instance_id VARCHAR(32) NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
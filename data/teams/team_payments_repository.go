@@ -0,0 +1,198 @@
+package teams
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/teams"
+)
+
+const (
+	postgresInsertPendingPayment = `
+	INSERT INTO team_wallet_payments(wallet_id, initiator_id, initiator_email, recipient, satoshis, status,
+		required_approvals, expires_at, created_at)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	RETURNING id
+	`
+
+	postgresSelectPendingPayment = `
+	SELECT p.id, p.wallet_id, p.initiator_id, p.initiator_email, p.recipient, p.satoshis, p.status,
+		p.required_approvals, ARRAY(SELECT a.user_id FROM team_wallet_payment_approvals a WHERE a.payment_id = p.id ORDER BY a.created_at),
+		p.expires_at, p.created_at
+	FROM team_wallet_payments p
+	`
+
+	postgresGetPendingPayment = postgresSelectPendingPayment + `
+	WHERE p.id = $1
+	`
+
+	postgresGetPendingPayments = postgresSelectPendingPayment + `
+	WHERE p.wallet_id = $1 AND p.status = $2
+	ORDER BY p.created_at
+	`
+
+	postgresInsertPaymentApproval = `
+	INSERT INTO team_wallet_payment_approvals(payment_id, user_id, created_at)
+	VALUES($1, $2, $3)
+	`
+
+	// Payment is locked, so concurrent approvals are counted one after another and each sees the previous ones.
+	postgresLockPayment = `
+	SELECT id
+	FROM team_wallet_payments
+	WHERE id = $1
+	FOR UPDATE
+	`
+
+	postgresGetPaymentApprovers = `
+	SELECT user_id
+	FROM team_wallet_payment_approvals
+	WHERE payment_id = $1
+	ORDER BY created_at
+	`
+
+	postgresUpdatePaymentStatus = `
+	UPDATE team_wallet_payments
+	SET status = $3
+	WHERE id = $1 AND status = $2
+	`
+
+	postgresExpirePendingPayments = `
+	UPDATE team_wallet_payments
+	SET status = $3
+	WHERE status = $2 AND expires_at < $1
+	`
+)
+
+// InsertPendingPayment inserts the payment waiting for approvals with approvals it already has in one transaction.
+func (r *WalletsRepository) InsertPendingPayment(ctx context.Context, payment *teams.PendingPayment) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	err = tx.QueryRowContext(ctx, postgresInsertPendingPayment,
+		payment.WalletID, payment.InitiatorID, payment.InitiatorEmail, payment.Recipient, payment.Satoshis,
+		payment.Status, payment.RequiredApprovals, payment.ExpiresAt, payment.CreatedAt).Scan(&payment.ID)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	for _, userID := range payment.ApprovedBy {
+		if _, err = tx.ExecContext(ctx, postgresInsertPaymentApproval, payment.ID, userID, payment.CreatedAt); err != nil {
+			return errors.Wrap(err, "internal error")
+		}
+	}
+	err = tx.Commit()
+	return errors.Wrap(err, "internal error")
+}
+
+// GetPendingPayment returns payment with its approvals by id. Can return nil payment without an error - if no rows found.
+func (r *WalletsRepository) GetPendingPayment(ctx context.Context, id int) (*teams.PendingPayment, error) {
+	payment, err := scanPendingPayment(r.db.QueryRowContext(ctx, postgresGetPendingPayment, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return payment.toPendingPayment(), nil
+}
+
+// GetPendingPayments returns payments of the team wallet waiting for approvals, the first initiated first.
+func (r *WalletsRepository) GetPendingPayments(ctx context.Context, walletID int) ([]*teams.PendingPayment, error) {
+	rows, err := r.db.QueryContext(ctx, postgresGetPendingPayments, walletID, teams.PaymentStatusPending)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:errcheck // best effort cleanup
+
+	payments := make([]*teams.PendingPayment, 0)
+	for rows.Next() {
+		payment, err := scanPendingPayment(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		payments = append(payments, payment.toPendingPayment())
+	}
+	return payments, errors.Wrap(rows.Err(), "internal error")
+}
+
+// InsertPaymentApproval inserts approval of the payment by the member and returns members who approved the payment,
+// including approvals inserted concurrently before it.
+func (r *WalletsRepository) InsertPaymentApproval(ctx context.Context, paymentID, userID int, approvedAt time.Time) ([]int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	if _, err = tx.ExecContext(ctx, postgresLockPayment, paymentID); err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	if _, err = tx.ExecContext(ctx, postgresInsertPaymentApproval, paymentID, userID, approvedAt); err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+
+	rows, err := tx.QueryContext(ctx, postgresGetPaymentApprovers, paymentID)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:errcheck // best effort cleanup
+
+	approvers := make([]int, 0)
+	for rows.Next() {
+		var approver int
+		if err = rows.Scan(&approver); err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		approvers = append(approvers, approver)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+
+	err = tx.Commit()
+	return approvers, errors.Wrap(err, "internal error")
+}
+
+// UpdatePaymentStatus changes status of the payment only if it has the expected one, returns whether it was changed.
+func (r *WalletsRepository) UpdatePaymentStatus(ctx context.Context, id int, from, to string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, postgresUpdatePaymentStatus, id, from, to)
+	if err != nil {
+		return false, errors.Wrap(err, "internal error")
+	}
+	updated, err := result.RowsAffected()
+	return updated > 0, errors.Wrap(err, "internal error")
+}
+
+// ExpirePendingPayments marks payments waiting for approvals which expired before given time, returns the number of them.
+func (r *WalletsRepository) ExpirePendingPayments(ctx context.Context, expiredBefore time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, postgresExpirePendingPayments, expiredBefore, teams.PaymentStatusPending, teams.PaymentStatusExpired)
+	if err != nil {
+		return 0, errors.Wrap(err, "internal error")
+	}
+	expired, err := result.RowsAffected()
+	return expired, errors.Wrap(err, "internal error")
+}
+
+// rowScanner is implemented by both sql.Row and sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPendingPayment(row rowScanner) (*PendingPaymentDto, error) {
+	var payment PendingPaymentDto
+	err := row.Scan(&payment.ID, &payment.WalletID, &payment.InitiatorID, &payment.InitiatorEmail, &payment.Recipient, &payment.Satoshis,
+		&payment.Status, &payment.RequiredApprovals, &payment.ApprovedBy, &payment.ExpiresAt,
+		&payment.CreatedAt)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped by the caller
+	}
+	return &payment, nil
+}
@@ -0,0 +1,141 @@
+package teams
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/teams"
+)
+
+// WalletDto is a struct that represent team wallet database record.
+type WalletDto struct {
+	ID        int       `db:"id"`
+	Name      string    `db:"name"`
+	Paymail   string    `db:"paymail"`
+	XpubID    string    `db:"xpub_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// toWallet converts WalletDto to Wallet.
+func (w *WalletDto) toWallet() *teams.Wallet {
+	return &teams.Wallet{
+		ID:        w.ID,
+		Name:      w.Name,
+		Paymail:   w.Paymail,
+		XpubID:    w.XpubID,
+		CreatedAt: w.CreatedAt,
+	}
+}
+
+// MemberDto is a struct that represent team wallet member database record joined with email of the user.
+type MemberDto struct {
+	WalletID  int       `db:"wallet_id"`
+	UserID    int       `db:"user_id"`
+	Email     string    `db:"email"`
+	Role      string    `db:"role"`
+	Xpriv     string    `db:"xpriv"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// toMember converts MemberDto to Member.
+func (m *MemberDto) toMember() *teams.Member {
+	return &teams.Member{
+		WalletID:  m.WalletID,
+		UserID:    m.UserID,
+		Email:     m.Email,
+		Role:      m.Role,
+		Xpriv:     m.Xpriv,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// InvitationDto is a struct that represent invitation to a team wallet database record.
+type InvitationDto struct {
+	ID        int       `db:"id"`
+	WalletID  int       `db:"wallet_id"`
+	Email     string    `db:"email"`
+	Role      string    `db:"role"`
+	TokenHash string    `db:"token_hash"`
+	Xpriv     string    `db:"xpriv"`
+	InvitedBy int       `db:"invited_by"`
+	ExpiresAt time.Time `db:"expires_at"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// toInvitation converts InvitationDto to Invitation.
+func (i *InvitationDto) toInvitation() *teams.Invitation {
+	return &teams.Invitation{
+		ID:        i.ID,
+		WalletID:  i.WalletID,
+		Email:     i.Email,
+		Role:      i.Role,
+		TokenHash: i.TokenHash,
+		Xpriv:     i.Xpriv,
+		InvitedBy: i.InvitedBy,
+		ExpiresAt: i.ExpiresAt,
+		CreatedAt: i.CreatedAt,
+	}
+}
+
+// TransactionDto is a struct that represent team wallet transaction database record.
+type TransactionDto struct {
+	ID             int       `db:"id"`
+	WalletID       int       `db:"wallet_id"`
+	TransactionID  string    `db:"transaction_id"`
+	InitiatorID    int       `db:"initiator_id"`
+	InitiatorEmail string    `db:"initiator_email"`
+	Recipient      string    `db:"recipient"`
+	Satoshis       uint64    `db:"satoshis"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+// toTransaction converts TransactionDto to Transaction.
+func (t *TransactionDto) toTransaction() *teams.Transaction {
+	return &teams.Transaction{
+		ID:             t.ID,
+		WalletID:       t.WalletID,
+		TransactionID:  t.TransactionID,
+		InitiatorID:    t.InitiatorID,
+		InitiatorEmail: t.InitiatorEmail,
+		Recipient:      t.Recipient,
+		Satoshis:       t.Satoshis,
+		CreatedAt:      t.CreatedAt,
+	}
+}
+
+// PendingPaymentDto is a struct that represent team wallet payment waiting for approvals database record.
+type PendingPaymentDto struct {
+	ID                int           `db:"id"`
+	WalletID          int           `db:"wallet_id"`
+	InitiatorID       int           `db:"initiator_id"`
+	InitiatorEmail    string        `db:"initiator_email"`
+	Recipient         string        `db:"recipient"`
+	Satoshis          uint64        `db:"satoshis"`
+	Status            string        `db:"status"`
+	RequiredApprovals int           `db:"required_approvals"`
+	ApprovedBy        pq.Int64Array `db:"approved_by"`
+	ExpiresAt         time.Time     `db:"expires_at"`
+	CreatedAt         time.Time     `db:"created_at"`
+}
+
+// toPendingPayment converts PendingPaymentDto to PendingPayment.
+func (p *PendingPaymentDto) toPendingPayment() *teams.PendingPayment {
+	approvedBy := make([]int, 0, len(p.ApprovedBy))
+	for _, userID := range p.ApprovedBy {
+		approvedBy = append(approvedBy, int(userID))
+	}
+	return &teams.PendingPayment{
+		ID:                p.ID,
+		WalletID:          p.WalletID,
+		InitiatorID:       p.InitiatorID,
+		InitiatorEmail:    p.InitiatorEmail,
+		Recipient:         p.Recipient,
+		Satoshis:          p.Satoshis,
+		Status:            p.Status,
+		RequiredApprovals: p.RequiredApprovals,
+		ApprovedBy:        approvedBy,
+		ExpiresAt:         p.ExpiresAt,
+		CreatedAt:         p.CreatedAt,
+	}
+}
@@ -0,0 +1,273 @@
+package teams
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/teams"
+)
+
+const (
+	postgresInsertWallet = `
+	INSERT INTO team_wallets(name, paymail, xpub_id, created_at)
+	VALUES($1, $2, $3, $4)
+	RETURNING id
+	`
+
+	postgresGetWallet = `
+	SELECT id, name, paymail, xpub_id, created_at
+	FROM team_wallets
+	WHERE id = $1
+	`
+
+	postgresGetMemberWallets = `
+	SELECT w.id, w.name, w.paymail, w.xpub_id, w.created_at, m.role
+	FROM team_wallets w
+	JOIN team_wallet_members m ON m.wallet_id = w.id
+	WHERE m.user_id = $1
+	ORDER BY w.created_at
+	`
+
+	postgresInsertMember = `
+	INSERT INTO team_wallet_members(wallet_id, user_id, role, xpriv, created_at)
+	VALUES($1, $2, $3, $4, $5)
+	`
+
+	postgresSelectMember = `
+	SELECT m.wallet_id, m.user_id, u.email, m.role, m.xpriv, m.created_at
+	FROM team_wallet_members m
+	JOIN users u ON u.id = m.user_id
+	`
+
+	postgresGetMembers = postgresSelectMember + `
+	WHERE m.wallet_id = $1
+	ORDER BY m.created_at
+	`
+
+	postgresGetMember = postgresSelectMember + `
+	WHERE m.wallet_id = $1 AND m.user_id = $2
+	`
+
+	postgresDeleteMember = `
+	DELETE FROM team_wallet_members
+	WHERE wallet_id = $1 AND user_id = $2
+	`
+
+	postgresInsertInvitation = `
+	INSERT INTO team_wallet_invitations(wallet_id, email, role, token_hash, xpriv, invited_by, expires_at, created_at)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING id
+	`
+
+	postgresGetInvitation = `
+	SELECT id, wallet_id, email, role, token_hash, xpriv, invited_by, expires_at, created_at
+	FROM team_wallet_invitations
+	WHERE token_hash = $1
+	`
+
+	postgresDeleteInvitation = `
+	DELETE FROM team_wallet_invitations
+	WHERE id = $1
+	`
+
+	postgresInsertTransaction = `
+	INSERT INTO team_wallet_transactions(wallet_id, transaction_id, initiator_id, initiator_email, recipient, satoshis, created_at)
+	VALUES($1, $2, $3, $4, $5, $6, $7)
+	RETURNING id
+	`
+
+	postgresGetTransactions = `
+	SELECT id, wallet_id, transaction_id, initiator_id, initiator_email, recipient, satoshis, created_at
+	FROM team_wallet_transactions
+	WHERE wallet_id = $1
+	ORDER BY created_at DESC, id DESC
+	LIMIT $2 OFFSET $3
+	`
+
+	postgresCountTransactions = `
+	SELECT COUNT(*)
+	FROM team_wallet_transactions
+	WHERE wallet_id = $1
+	`
+)
+
+// WalletsRepository is a repository for team wallets, their members and transactions.
+type WalletsRepository struct {
+	db *sql.DB
+}
+
+// NewWalletsRepository creates a new team wallets repository.
+func NewWalletsRepository(db *sql.DB) *WalletsRepository {
+	return &WalletsRepository{
+		db: db,
+	}
+}
+
+// InsertWallet inserts the team wallet with its first owner in one transaction.
+func (r *WalletsRepository) InsertWallet(ctx context.Context, wallet *teams.Wallet, owner *teams.Member) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	err = tx.QueryRowContext(ctx, postgresInsertWallet, wallet.Name, wallet.Paymail, wallet.XpubID, wallet.CreatedAt).Scan(&wallet.ID)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	owner.WalletID = wallet.ID
+	if _, err = tx.ExecContext(ctx, postgresInsertMember, owner.WalletID, owner.UserID, owner.Role, owner.Xpriv, owner.CreatedAt); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	err = tx.Commit()
+	return errors.Wrap(err, "internal error")
+}
+
+// GetWallet returns team wallet by id. Can return nil wallet without an error - if no rows found.
+func (r *WalletsRepository) GetWallet(ctx context.Context, id int) (*teams.Wallet, error) {
+	var wallet WalletDto
+	row := r.db.QueryRowContext(ctx, postgresGetWallet, id)
+	if err := row.Scan(&wallet.ID, &wallet.Name, &wallet.Paymail, &wallet.XpubID, &wallet.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return wallet.toWallet(), nil
+}
+
+// GetMemberWallets returns team wallets of the user with role of the user in them.
+func (r *WalletsRepository) GetMemberWallets(ctx context.Context, userID int) ([]*teams.MemberWallet, error) {
+	rows, err := r.db.QueryContext(ctx, postgresGetMemberWallets, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:errcheck // best effort cleanup
+
+	wallets := make([]*teams.MemberWallet, 0)
+	for rows.Next() {
+		var wallet WalletDto
+		var role string
+		if err = rows.Scan(&wallet.ID, &wallet.Name, &wallet.Paymail, &wallet.XpubID, &wallet.CreatedAt, &role); err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		wallets = append(wallets, &teams.MemberWallet{Wallet: wallet.toWallet(), Role: role})
+	}
+	return wallets, errors.Wrap(rows.Err(), "internal error")
+}
+
+// GetMembers returns members of the team wallet, the first joined first.
+func (r *WalletsRepository) GetMembers(ctx context.Context, walletID int) ([]*teams.Member, error) {
+	rows, err := r.db.QueryContext(ctx, postgresGetMembers, walletID)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:errcheck // best effort cleanup
+
+	members := make([]*teams.Member, 0)
+	for rows.Next() {
+		var member MemberDto
+		if err = rows.Scan(&member.WalletID, &member.UserID, &member.Email, &member.Role, &member.Xpriv, &member.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		members = append(members, member.toMember())
+	}
+	return members, errors.Wrap(rows.Err(), "internal error")
+}
+
+// GetMember returns member of the team wallet. Can return nil member without an error - if no rows found.
+func (r *WalletsRepository) GetMember(ctx context.Context, walletID, userID int) (*teams.Member, error) {
+	var member MemberDto
+	row := r.db.QueryRowContext(ctx, postgresGetMember, walletID, userID)
+	if err := row.Scan(&member.WalletID, &member.UserID, &member.Email, &member.Role, &member.Xpriv, &member.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return member.toMember(), nil
+}
+
+// DeleteMember deletes member of the team wallet.
+func (r *WalletsRepository) DeleteMember(ctx context.Context, walletID, userID int) error {
+	_, err := r.db.ExecContext(ctx, postgresDeleteMember, walletID, userID)
+	return errors.Wrap(err, "internal error")
+}
+
+// InsertInvitation inserts invitation to the team wallet to db.
+func (r *WalletsRepository) InsertInvitation(ctx context.Context, invitation *teams.Invitation) error {
+	row := r.db.QueryRowContext(ctx, postgresInsertInvitation,
+		invitation.WalletID, invitation.Email, invitation.Role, invitation.TokenHash, invitation.Xpriv, invitation.InvitedBy,
+		invitation.ExpiresAt, invitation.CreatedAt)
+	return errors.Wrap(row.Scan(&invitation.ID), "internal error")
+}
+
+// GetInvitation returns invitation by hash of its token. Can return nil invitation without an error - if no rows found.
+func (r *WalletsRepository) GetInvitation(ctx context.Context, tokenHash string) (*teams.Invitation, error) {
+	var invitation InvitationDto
+	row := r.db.QueryRowContext(ctx, postgresGetInvitation, tokenHash)
+	err := row.Scan(&invitation.ID, &invitation.WalletID, &invitation.Email, &invitation.Role, &invitation.TokenHash,
+		&invitation.Xpriv, &invitation.InvitedBy, &invitation.ExpiresAt, &invitation.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return invitation.toInvitation(), nil
+}
+
+// AcceptInvitation inserts the member and deletes the invitation in one transaction.
+func (r *WalletsRepository) AcceptInvitation(ctx context.Context, member *teams.Member, invitationID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	if _, err = tx.ExecContext(ctx, postgresInsertMember, member.WalletID, member.UserID, member.Role, member.Xpriv, member.CreatedAt); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	if _, err = tx.ExecContext(ctx, postgresDeleteInvitation, invitationID); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	err = tx.Commit()
+	return errors.Wrap(err, "internal error")
+}
+
+// InsertTransaction inserts transaction of the team wallet to db.
+func (r *WalletsRepository) InsertTransaction(ctx context.Context, transaction *teams.Transaction) error {
+	row := r.db.QueryRowContext(ctx, postgresInsertTransaction,
+		transaction.WalletID, transaction.TransactionID, transaction.InitiatorID, transaction.InitiatorEmail,
+		transaction.Recipient, transaction.Satoshis, transaction.CreatedAt)
+	return errors.Wrap(row.Scan(&transaction.ID), "internal error")
+}
+
+// GetTransactions returns page of transactions of the team wallet, the newest first, and the number of all of them.
+func (r *WalletsRepository) GetTransactions(ctx context.Context, walletID, page, pageSize int) ([]*teams.Transaction, int64, error) {
+	var count int64
+	if err := r.db.QueryRowContext(ctx, postgresCountTransactions, walletID).Scan(&count); err != nil {
+		return nil, 0, errors.Wrap(err, "internal error")
+	}
+
+	rows, err := r.db.QueryContext(ctx, postgresGetTransactions, walletID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:errcheck // best effort cleanup
+
+	txs := make([]*teams.Transaction, 0)
+	for rows.Next() {
+		var transaction TransactionDto
+		if err = rows.Scan(&transaction.ID, &transaction.WalletID, &transaction.TransactionID, &transaction.InitiatorID,
+			&transaction.InitiatorEmail, &transaction.Recipient, &transaction.Satoshis, &transaction.CreatedAt); err != nil {
+			return nil, 0, errors.Wrap(err, "internal error")
+		}
+		txs = append(txs, transaction.toTransaction())
+	}
+	return txs, count, errors.Wrap(rows.Err(), "internal error")
+}
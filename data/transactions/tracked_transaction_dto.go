@@ -0,0 +1,31 @@
+package transactions
+
+import (
+	"time"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/transactions"
+)
+
+// TrackedTransactionDto is a struct that represent tracked transaction database record.
+type TrackedTransactionDto struct {
+	TransactionID string    `db:"transaction_id"`
+	UserID        int       `db:"user_id"`
+	Status        string    `db:"status"`
+	BlockHash     string    `db:"block_hash"`
+	BlockHeight   uint64    `db:"block_height"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
+
+// toTrackedTransaction converts TrackedTransactionDto to TrackedTransaction.
+func (tx *TrackedTransactionDto) toTrackedTransaction() *transactions.TrackedTransaction {
+	return &transactions.TrackedTransaction{
+		TransactionID: tx.TransactionID,
+		UserID:        tx.UserID,
+		Status:        tx.Status,
+		BlockHash:     tx.BlockHash,
+		BlockHeight:   tx.BlockHeight,
+		CreatedAt:     tx.CreatedAt,
+		UpdatedAt:     tx.UpdatedAt,
+	}
+}
@@ -0,0 +1,86 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/transactions"
+)
+
+const (
+	postgresInsertTrackedTransaction = `
+	INSERT INTO tracked_transactions(transaction_id, user_id, status, block_hash, block_height, created_at, updated_at)
+	VALUES($1, $2, $3, $4, $5, $6, $7)
+	ON CONFLICT (transaction_id, user_id) DO NOTHING
+	`
+
+	postgresGetTrackedTransactions = `
+	SELECT transaction_id, user_id, status, block_hash, block_height, created_at, updated_at
+	FROM tracked_transactions
+	ORDER BY created_at
+	`
+
+	postgresUpdateTrackedTransaction = `
+	UPDATE tracked_transactions
+	SET status = $3, block_hash = $4, block_height = $5, updated_at = $6
+	WHERE transaction_id = $1 AND user_id = $2
+	`
+
+	postgresDeleteTrackedTransaction = `
+	DELETE FROM tracked_transactions
+	WHERE transaction_id = $1 AND user_id = $2
+	`
+)
+
+// TrackingRepository is a repository for tracked transactions.
+type TrackingRepository struct {
+	db *sql.DB
+}
+
+// NewTrackingRepository creates a new tracked transactions repository.
+func NewTrackingRepository(db *sql.DB) *TrackingRepository {
+	return &TrackingRepository{
+		db: db,
+	}
+}
+
+// InsertTrackedTransaction inserts a tracked transaction to db. Already tracked transactions are left unchanged.
+func (r *TrackingRepository) InsertTrackedTransaction(ctx context.Context, tx *transactions.TrackedTransaction) error {
+	_, err := r.db.ExecContext(ctx, postgresInsertTrackedTransaction,
+		tx.TransactionID, tx.UserID, tx.Status, tx.BlockHash, tx.BlockHeight, tx.CreatedAt, tx.UpdatedAt)
+	return errors.Wrap(err, "internal error")
+}
+
+// GetTrackedTransactions returns all tracked transactions.
+func (r *TrackingRepository) GetTrackedTransactions(ctx context.Context) ([]*transactions.TrackedTransaction, error) {
+	rows, err := r.db.QueryContext(ctx, postgresGetTrackedTransactions)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:errcheck // best effort cleanup
+
+	tracked := make([]*transactions.TrackedTransaction, 0)
+	for rows.Next() {
+		var tx TrackedTransactionDto
+		if err = rows.Scan(&tx.TransactionID, &tx.UserID, &tx.Status, &tx.BlockHash, &tx.BlockHeight, &tx.CreatedAt, &tx.UpdatedAt); err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		tracked = append(tracked, tx.toTrackedTransaction())
+	}
+	return tracked, errors.Wrap(rows.Err(), "internal error")
+}
+
+// UpdateTrackedTransaction updates status and block of a tracked transaction.
+func (r *TrackingRepository) UpdateTrackedTransaction(ctx context.Context, tx *transactions.TrackedTransaction) error {
+	_, err := r.db.ExecContext(ctx, postgresUpdateTrackedTransaction,
+		tx.TransactionID, tx.UserID, tx.Status, tx.BlockHash, tx.BlockHeight, tx.UpdatedAt)
+	return errors.Wrap(err, "internal error")
+}
+
+// DeleteTrackedTransaction removes a transaction from tracked transactions.
+func (r *TrackingRepository) DeleteTrackedTransaction(ctx context.Context, transactionID string, userID int) error {
+	_, err := r.db.ExecContext(ctx, postgresDeleteTrackedTransaction, transactionID, userID)
+	return errors.Wrap(err, "internal error")
+}
@@ -0,0 +1,149 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+)
+
+const (
+	postgresInsertAPIToken = `
+	INSERT INTO api_tokens(user_id, name, token_hash, scopes, send_limit, access_key_id, access_key, xpriv, expires_at, created_at)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	RETURNING id
+	`
+
+	postgresSelectAPIToken = `
+	SELECT id, user_id, name, token_hash, scopes, send_limit, spent, access_key_id, access_key, xpriv, expires_at, last_used_at, created_at
+	FROM api_tokens
+	`
+
+	postgresGetAPITokens = postgresSelectAPIToken + `
+	WHERE user_id = $1
+	ORDER BY created_at
+	`
+
+	postgresGetAPIToken = postgresSelectAPIToken + `
+	WHERE token_hash = $1
+	`
+
+	postgresGetAPITokenByID = postgresSelectAPIToken + `
+	WHERE id = $1
+	`
+
+	postgresDeleteAPIToken = `
+	DELETE FROM api_tokens
+	WHERE id = $1
+	`
+
+	postgresUpdateAPITokenLastUsed = `
+	UPDATE api_tokens
+	SET last_used_at = $2
+	WHERE id = $1
+	`
+
+	// Limit is checked in the update, so concurrent payments cannot spend more than the limit together.
+	postgresSpendAPIToken = `
+	UPDATE api_tokens
+	SET spent = spent + $2
+	WHERE id = $1 AND spent + $2 <= send_limit
+	`
+
+	postgresRefundAPIToken = `
+	UPDATE api_tokens
+	SET spent = GREATEST(spent - $2, 0)
+	WHERE id = $1
+	`
+)
+
+// InsertAPIToken inserts API token of the user to db.
+func (r *Repository) InsertAPIToken(ctx context.Context, token *users.APIToken) error {
+	row := r.db.QueryRowContext(ctx, postgresInsertAPIToken,
+		token.UserID, token.Name, token.TokenHash, pq.StringArray(token.Scopes), token.SendLimit,
+		token.AccessKeyID, token.AccessKey, token.Xpriv, token.ExpiresAt, token.CreatedAt)
+	return errors.Wrap(row.Scan(&token.ID), "internal error")
+}
+
+// GetAPITokens returns API tokens of the user.
+func (r *Repository) GetAPITokens(ctx context.Context, userID int) ([]*users.APIToken, error) {
+	rows, err := r.db.QueryContext(ctx, postgresGetAPITokens, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:errcheck // best effort cleanup
+
+	tokens := make([]*users.APIToken, 0)
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		tokens = append(tokens, token.toAPIToken())
+	}
+	return tokens, errors.Wrap(rows.Err(), "internal error")
+}
+
+// GetAPIToken returns API token by hash of the token. Can return nil token without an error - if no rows found.
+func (r *Repository) GetAPIToken(ctx context.Context, tokenHash string) (*users.APIToken, error) {
+	return r.getAPIToken(ctx, postgresGetAPIToken, tokenHash)
+}
+
+// GetAPITokenByID returns API token by id. Can return nil token without an error - if no rows found.
+func (r *Repository) GetAPITokenByID(ctx context.Context, id int) (*users.APIToken, error) {
+	return r.getAPIToken(ctx, postgresGetAPITokenByID, id)
+}
+
+// DeleteAPIToken deletes API token from db.
+func (r *Repository) DeleteAPIToken(ctx context.Context, id int) error {
+	_, err := r.db.ExecContext(ctx, postgresDeleteAPIToken, id)
+	return errors.Wrap(err, "internal error")
+}
+
+// UpdateAPITokenLastUsed sets time when API token was used last time.
+func (r *Repository) UpdateAPITokenLastUsed(ctx context.Context, id int, usedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, postgresUpdateAPITokenLastUsed, id, usedAt)
+	return errors.Wrap(err, "internal error")
+}
+
+// SpendAPIToken adds the amount to the amount spent with API token, if it does not exceed the send limit.
+// Returns false if the amount exceeds the limit.
+func (r *Repository) SpendAPIToken(ctx context.Context, id int, satoshis uint64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, postgresSpendAPIToken, id, satoshis)
+	if err != nil {
+		return false, errors.Wrap(err, "internal error")
+	}
+	spent, err := result.RowsAffected()
+	return spent > 0, errors.Wrap(err, "internal error")
+}
+
+// RefundAPIToken subtracts the amount from the amount spent with API token.
+func (r *Repository) RefundAPIToken(ctx context.Context, id int, satoshis uint64) error {
+	_, err := r.db.ExecContext(ctx, postgresRefundAPIToken, id, satoshis)
+	return errors.Wrap(err, "internal error")
+}
+
+func (r *Repository) getAPIToken(ctx context.Context, query string, arg any) (*users.APIToken, error) {
+	token, err := scanAPIToken(r.db.QueryRowContext(ctx, query, arg))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return token.toAPIToken(), nil
+}
+
+func scanAPIToken(row rowScanner) (*APITokenDto, error) {
+	var token APITokenDto
+	err := row.Scan(&token.ID, &token.UserID, &token.Name, &token.TokenHash, &token.Scopes, &token.SendLimit, &token.Spent,
+		&token.AccessKeyID, &token.AccessKey, &token.Xpriv, &token.ExpiresAt, &token.LastUsedAt, &token.CreatedAt)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped by callers
+	}
+	return &token, nil
+}
@@ -0,0 +1,133 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+)
+
+const (
+	postgresInsertPendingRegistration = `
+	INSERT INTO pending_registrations(email, xpriv, mnemonic, alias, domain, xpub_id, paymail, step, attempts, last_error, created_at, updated_at)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	RETURNING id
+	`
+
+	postgresSelectPendingRegistration = `
+	SELECT id, email, xpriv, mnemonic, alias, domain, xpub_id, paymail, step, attempts, last_error, created_at, updated_at
+	FROM pending_registrations
+	`
+
+	postgresGetPendingRegistration = postgresSelectPendingRegistration + `
+	WHERE email = $1
+	`
+
+	postgresGetPendingRegistrationsBefore = postgresSelectPendingRegistration + `
+	WHERE created_at < $1
+	ORDER BY created_at
+	`
+
+	postgresUpdatePendingRegistration = `
+	UPDATE pending_registrations
+	SET paymail = $2, step = $3, attempts = $4, last_error = $5, updated_at = $6
+	WHERE id = $1
+	`
+
+	postgresDeletePendingRegistration = `
+	DELETE FROM pending_registrations
+	WHERE id = $1
+	`
+)
+
+// InsertPendingRegistration inserts registration which is not finished yet to db.
+func (r *Repository) InsertPendingRegistration(ctx context.Context, registration *users.PendingRegistration) error {
+	dto := toPendingRegistrationDto(registration)
+	row := r.db.QueryRowContext(ctx, postgresInsertPendingRegistration,
+		dto.Email, dto.Xpriv, dto.Mnemonic, dto.Alias, dto.Domain, dto.XpubID, dto.Paymail,
+		dto.Step, dto.Attempts, dto.LastError, dto.CreatedAt, dto.UpdatedAt)
+	return errors.Wrap(row.Scan(&registration.ID), "internal error")
+}
+
+// GetPendingRegistration returns pending registration by email. Can return nil registration without an error - if no rows found.
+func (r *Repository) GetPendingRegistration(ctx context.Context, email string) (*users.PendingRegistration, error) {
+	row := r.db.QueryRowContext(ctx, postgresGetPendingRegistration, email)
+	registration, err := scanPendingRegistration(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return registration.toPendingRegistration(), nil
+}
+
+// GetPendingRegistrationsBefore returns pending registrations started before given time.
+func (r *Repository) GetPendingRegistrationsBefore(ctx context.Context, createdBefore time.Time) ([]*users.PendingRegistration, error) {
+	rows, err := r.db.QueryContext(ctx, postgresGetPendingRegistrationsBefore, createdBefore)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:errcheck // best effort cleanup
+
+	registrations := make([]*users.PendingRegistration, 0)
+	for rows.Next() {
+		registration, err := scanPendingRegistration(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		registrations = append(registrations, registration.toPendingRegistration())
+	}
+	return registrations, errors.Wrap(rows.Err(), "internal error")
+}
+
+// UpdatePendingRegistration updates progress of the pending registration.
+func (r *Repository) UpdatePendingRegistration(ctx context.Context, registration *users.PendingRegistration) error {
+	_, err := r.db.ExecContext(ctx, postgresUpdatePendingRegistration,
+		registration.ID, registration.Paymail, registration.Step, registration.Attempts, registration.LastError, registration.UpdatedAt)
+	return errors.Wrap(err, "internal error")
+}
+
+// DeletePendingRegistration deletes pending registration.
+func (r *Repository) DeletePendingRegistration(ctx context.Context, id int) error {
+	_, err := r.db.ExecContext(ctx, postgresDeletePendingRegistration, id)
+	return errors.Wrap(err, "internal error")
+}
+
+// CompleteRegistration inserts the user with its primary paymail and deletes the pending registration in one transaction.
+func (r *Repository) CompleteRegistration(ctx context.Context, user *users.User, registrationID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	if err = insertUser(ctx, tx, user); err != nil {
+		return err
+	}
+	if _, err = tx.ExecContext(ctx, postgresDeletePendingRegistration, registrationID); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	err = tx.Commit()
+	return errors.Wrap(err, "internal error")
+}
+
+// rowScanner is implemented by both sql.Row and sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPendingRegistration(row rowScanner) (*PendingRegistrationDto, error) {
+	var registration PendingRegistrationDto
+	err := row.Scan(&registration.ID, &registration.Email, &registration.Xpriv, &registration.Mnemonic,
+		&registration.Alias, &registration.Domain, &registration.XpubID, &registration.Paymail,
+		&registration.Step, &registration.Attempts, &registration.LastError, &registration.CreatedAt, &registration.UpdatedAt)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped by callers
+	}
+	return &registration, nil
+}
@@ -0,0 +1,107 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+)
+
+const (
+	postgresGetSpendingPIN = `
+	SELECT user_id, xpriv, failed_attempts, locked_until, updated_at
+	FROM user_spending_pins
+	WHERE user_id = $1
+	`
+
+	// Setting the PIN starts its attempt limiter over.
+	postgresUpsertSpendingPIN = `
+	INSERT INTO user_spending_pins(user_id, xpriv, failed_attempts, locked_until, updated_at)
+	VALUES($1, $2, 0, NULL, $3)
+	ON CONFLICT (user_id) DO UPDATE
+	SET xpriv = EXCLUDED.xpriv, failed_attempts = 0, locked_until = NULL, updated_at = EXCLUDED.updated_at
+	`
+
+	postgresDeleteSpendingPIN = `
+	DELETE FROM user_spending_pins
+	WHERE user_id = $1
+	`
+
+	// Attempt is counted before the PIN is tried and only when the PIN is not locked, so concurrent attempts cannot
+	// exceed the limit together. Lock which expired is cleared with the attempt.
+	// The row is created for users signing in with the identity provider, who have no PIN copy of the xpriv.
+	postgresReserveSpendingPINAttempt = `
+	INSERT INTO user_spending_pins(user_id, failed_attempts, updated_at)
+	VALUES($1, 1, $2)
+	ON CONFLICT (user_id) DO UPDATE
+	SET failed_attempts = user_spending_pins.failed_attempts + 1, locked_until = NULL
+	WHERE user_spending_pins.locked_until IS NULL OR user_spending_pins.locked_until < $2
+	RETURNING failed_attempts
+	`
+
+	postgresLockSpendingPIN = `
+	UPDATE user_spending_pins
+	SET failed_attempts = 0, locked_until = $2
+	WHERE user_id = $1
+	`
+
+	postgresResetSpendingPINAttempts = `
+	UPDATE user_spending_pins
+	SET failed_attempts = 0, locked_until = NULL
+	WHERE user_id = $1 AND (failed_attempts > 0 OR locked_until IS NOT NULL)
+	`
+)
+
+// GetSpendingPIN returns spending PIN of the user. Can return nil PIN without an error - if no rows found.
+func (r *Repository) GetSpendingPIN(ctx context.Context, userID int) (*users.SpendingPIN, error) {
+	var pin SpendingPINDto
+	row := r.db.QueryRowContext(ctx, postgresGetSpendingPIN, userID)
+	if err := row.Scan(&pin.UserID, &pin.Xpriv, &pin.FailedAttempts, &pin.LockedUntil, &pin.UpdatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return pin.toSpendingPIN(), nil
+}
+
+// UpsertSpendingPIN inserts or updates spending PIN of the user and resets its attempts.
+func (r *Repository) UpsertSpendingPIN(ctx context.Context, pin *users.SpendingPIN) error {
+	_, err := r.db.ExecContext(ctx, postgresUpsertSpendingPIN, pin.UserID, pin.Xpriv, pin.UpdatedAt)
+	return errors.Wrap(err, "internal error")
+}
+
+// DeleteSpendingPIN deletes spending PIN of the user.
+func (r *Repository) DeleteSpendingPIN(ctx context.Context, userID int) error {
+	_, err := r.db.ExecContext(ctx, postgresDeleteSpendingPIN, userID)
+	return errors.Wrap(err, "internal error")
+}
+
+// ReserveSpendingPINAttempt counts attempt of the spending PIN before it is tried and returns the number of attempts
+// since the last valid one. Returns false if the PIN is locked and the attempt is not counted.
+func (r *Repository) ReserveSpendingPINAttempt(ctx context.Context, userID int, attemptedAt time.Time) (int, bool, error) {
+	var attempts int
+	row := r.db.QueryRowContext(ctx, postgresReserveSpendingPINAttempt, userID, attemptedAt)
+	if err := row.Scan(&attempts); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, errors.Wrap(err, "internal error")
+	}
+	return attempts, true, nil
+}
+
+// LockSpendingPIN locks spending PIN of the user until the given time.
+func (r *Repository) LockSpendingPIN(ctx context.Context, userID int, lockedUntil time.Time) error {
+	_, err := r.db.ExecContext(ctx, postgresLockSpendingPIN, userID, lockedUntil)
+	return errors.Wrap(err, "internal error")
+}
+
+// ResetSpendingPINAttempts resets failed attempts of the spending PIN of the user.
+func (r *Repository) ResetSpendingPINAttempts(ctx context.Context, userID int) error {
+	_, err := r.db.ExecContext(ctx, postgresResetSpendingPINAttempts, userID)
+	return errors.Wrap(err, "internal error")
+}
@@ -3,6 +3,8 @@ package users
 import (
 	"time"
 
+	"github.com/lib/pq"
+
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
 )
 
@@ -12,7 +14,19 @@ type UserDto struct {
 	Email     string    `db:"email"`
 	Xpriv     string    `db:"xpriv"`
 	Paymail   string    `db:"paymail"`
+	XpubID    string    `db:"xpub_id"`
 	CreatedAt time.Time `db:"created_at"`
+
+	DisabledAt        *time.Time `db:"disabled_at"`
+	SessionsRevokedAt *time.Time `db:"sessions_revoked_at"`
+
+	Role        string `db:"role"`
+	OwnerID     *int   `db:"owner_id"`
+	AccessKeyID string `db:"access_key_id"`
+	AccessKey   string `db:"access_key"`
+
+	SSOIssuer  string `db:"sso_issuer"`
+	SSOSubject string `db:"sso_subject"`
 }
 
 // toUser converts UserDto to User.
@@ -22,6 +36,193 @@ func (user *UserDto) toUser() *users.User {
 		Email:     user.Email,
 		Xpriv:     user.Xpriv,
 		Paymail:   user.Paymail,
+		XpubID:    user.XpubID,
 		CreatedAt: user.CreatedAt,
+
+		DisabledAt:        user.DisabledAt,
+		SessionsRevokedAt: user.SessionsRevokedAt,
+
+		Role:        user.Role,
+		OwnerID:     user.OwnerID,
+		AccessKeyID: user.AccessKeyID,
+		AccessKey:   user.AccessKey,
+
+		SSOIssuer:  user.SSOIssuer,
+		SSOSubject: user.SSOSubject,
+	}
+}
+
+// UserPaymailDto is a struct that represent user paymail database record.
+type UserPaymailDto struct {
+	ID        int       `db:"id"`
+	UserID    int       `db:"user_id"`
+	Paymail   string    `db:"paymail"`
+	Primary   bool      `db:"is_primary"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// toUserPaymail converts UserPaymailDto to UserPaymail.
+func (paymail *UserPaymailDto) toUserPaymail() *users.UserPaymail {
+	return &users.UserPaymail{
+		ID:        paymail.ID,
+		UserID:    paymail.UserID,
+		Paymail:   paymail.Paymail,
+		Primary:   paymail.Primary,
+		CreatedAt: paymail.CreatedAt,
+	}
+}
+
+// UserProfileDto is a struct that represent user profile database record.
+type UserProfileDto struct {
+	UserID      int       `db:"user_id"`
+	DisplayName string    `db:"display_name"`
+	Avatar      string    `db:"avatar"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+// toProfile converts UserProfileDto to Profile.
+func (profile *UserProfileDto) toProfile() *users.Profile {
+	return &users.Profile{
+		UserID:      profile.UserID,
+		DisplayName: profile.DisplayName,
+		Avatar:      profile.Avatar,
+		UpdatedAt:   profile.UpdatedAt,
+	}
+}
+
+// PendingRegistrationDto is a struct that represent pending registration database record.
+type PendingRegistrationDto struct {
+	ID        int       `db:"id"`
+	Email     string    `db:"email"`
+	Xpriv     string    `db:"xpriv"`
+	Mnemonic  string    `db:"mnemonic"`
+	Alias     string    `db:"alias"`
+	Domain    string    `db:"domain"`
+	XpubID    string    `db:"xpub_id"`
+	Paymail   string    `db:"paymail"`
+	Step      string    `db:"step"`
+	Attempts  int       `db:"attempts"`
+	LastError string    `db:"last_error"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// toPendingRegistration converts PendingRegistrationDto to PendingRegistration.
+func (registration *PendingRegistrationDto) toPendingRegistration() *users.PendingRegistration {
+	return &users.PendingRegistration{
+		ID:        registration.ID,
+		Email:     registration.Email,
+		Xpriv:     registration.Xpriv,
+		Mnemonic:  registration.Mnemonic,
+		Alias:     registration.Alias,
+		Domain:    registration.Domain,
+		XpubID:    registration.XpubID,
+		Paymail:   registration.Paymail,
+		Step:      registration.Step,
+		Attempts:  registration.Attempts,
+		LastError: registration.LastError,
+		CreatedAt: registration.CreatedAt,
+		UpdatedAt: registration.UpdatedAt,
+	}
+}
+
+// toPendingRegistrationDto converts PendingRegistration to PendingRegistrationDto.
+func toPendingRegistrationDto(registration *users.PendingRegistration) *PendingRegistrationDto {
+	return &PendingRegistrationDto{
+		ID:        registration.ID,
+		Email:     registration.Email,
+		Xpriv:     registration.Xpriv,
+		Mnemonic:  registration.Mnemonic,
+		Alias:     registration.Alias,
+		Domain:    registration.Domain,
+		XpubID:    registration.XpubID,
+		Paymail:   registration.Paymail,
+		Step:      registration.Step,
+		Attempts:  registration.Attempts,
+		LastError: registration.LastError,
+		CreatedAt: registration.CreatedAt,
+		UpdatedAt: registration.UpdatedAt,
+	}
+}
+
+// ViewerInvitationDto is a struct that represent viewer invitation database record.
+type ViewerInvitationDto struct {
+	ID          int       `db:"id"`
+	OwnerID     int       `db:"owner_id"`
+	Email       string    `db:"email"`
+	TokenHash   string    `db:"token_hash"`
+	AccessKeyID string    `db:"access_key_id"`
+	AccessKey   string    `db:"access_key"`
+	ExpiresAt   time.Time `db:"expires_at"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// toViewerInvitation converts ViewerInvitationDto to ViewerInvitation.
+func (invitation *ViewerInvitationDto) toViewerInvitation() *users.ViewerInvitation {
+	return &users.ViewerInvitation{
+		ID:          invitation.ID,
+		OwnerID:     invitation.OwnerID,
+		Email:       invitation.Email,
+		TokenHash:   invitation.TokenHash,
+		AccessKeyID: invitation.AccessKeyID,
+		AccessKey:   invitation.AccessKey,
+		ExpiresAt:   invitation.ExpiresAt,
+		CreatedAt:   invitation.CreatedAt,
+	}
+}
+
+// APITokenDto is a struct that represent API token database record.
+type APITokenDto struct {
+	ID          int            `db:"id"`
+	UserID      int            `db:"user_id"`
+	Name        string         `db:"name"`
+	TokenHash   string         `db:"token_hash"`
+	Scopes      pq.StringArray `db:"scopes"`
+	SendLimit   uint64         `db:"send_limit"`
+	Spent       uint64         `db:"spent"`
+	AccessKeyID string         `db:"access_key_id"`
+	AccessKey   string         `db:"access_key"`
+	Xpriv       string         `db:"xpriv"`
+	ExpiresAt   time.Time      `db:"expires_at"`
+	LastUsedAt  *time.Time     `db:"last_used_at"`
+	CreatedAt   time.Time      `db:"created_at"`
+}
+
+// toAPIToken converts APITokenDto to APIToken.
+func (token *APITokenDto) toAPIToken() *users.APIToken {
+	return &users.APIToken{
+		ID:          token.ID,
+		UserID:      token.UserID,
+		Name:        token.Name,
+		TokenHash:   token.TokenHash,
+		Scopes:      token.Scopes,
+		SendLimit:   token.SendLimit,
+		Spent:       token.Spent,
+		AccessKeyID: token.AccessKeyID,
+		AccessKey:   token.AccessKey,
+		Xpriv:       token.Xpriv,
+		ExpiresAt:   token.ExpiresAt,
+		LastUsedAt:  token.LastUsedAt,
+		CreatedAt:   token.CreatedAt,
+	}
+}
+
+// SpendingPINDto is a struct that represent spending PIN database record.
+type SpendingPINDto struct {
+	UserID         int        `db:"user_id"`
+	Xpriv          string     `db:"xpriv"`
+	FailedAttempts int        `db:"failed_attempts"`
+	LockedUntil    *time.Time `db:"locked_until"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
+
+// toSpendingPIN converts SpendingPINDto to SpendingPIN.
+func (pin *SpendingPINDto) toSpendingPIN() *users.SpendingPIN {
+	return &users.SpendingPIN{
+		UserID:         pin.UserID,
+		Xpriv:          pin.Xpriv,
+		FailedAttempts: pin.FailedAttempts,
+		LockedUntil:    pin.LockedUntil,
+		UpdatedAt:      pin.UpdatedAt,
 	}
 }
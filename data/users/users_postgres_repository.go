@@ -3,6 +3,8 @@ package users
 import (
 	"context"
 	"database/sql"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -11,23 +13,133 @@ import (
 
 const (
 	postgresInsertUser = `
-	INSERT INTO users(email, xpriv, paymail, created_at)
-	VALUES($1, $2, $3, $4)
+	INSERT INTO users(email, xpriv, xpub_id, access_key_id, access_key, sso_issuer, sso_subject, created_at)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING id
+	`
+
+	postgresInsertViewer = `
+	INSERT INTO users(email, xpriv, role, owner_id, access_key_id, access_key, created_at)
+	VALUES($1, '', $2, $3, $4, $5, $6)
+	RETURNING id
+	`
+
+	// Viewers have no paymails, primary paymail of the owner is used instead.
+	postgresSelectUser = `
+	SELECT u.id, u.email, u.xpriv, COALESCE(p.paymail, ''), COALESCE(u.xpub_id, ''), u.created_at, u.disabled_at, u.sessions_revoked_at,
+		u.role, u.owner_id, u.access_key_id, u.access_key, u.sso_issuer, u.sso_subject
+	FROM users u
+	LEFT JOIN user_paymails p ON p.user_id = COALESCE(u.owner_id, u.id) AND p.is_primary
+	`
+
+	postgresGetUserByEmail = postgresSelectUser + `
+	WHERE u.email = $1
+	`
+
+	postgresGetUserByID = postgresSelectUser + `
+	WHERE u.id = $1
+	`
+
+	postgresGetUserByXpubID = postgresSelectUser + `
+	WHERE u.xpub_id = $1
+	`
+
+	postgresGetUserBySSOIdentity = postgresSelectUser + `
+	WHERE u.sso_issuer = $1 AND u.sso_subject = $2
 	`
 
-	postgresGetUserByEmail = `
-	SELECT id, email, xpriv, paymail, created_at
-	FROM users
-	WHERE email = $1
+	postgresGetViewers = postgresSelectUser + `
+	WHERE u.owner_id = $1
+	ORDER BY u.created_at
 	`
 
-	postgresGetUserByID = `
-	SELECT id, email, xpriv, paymail, created_at
-	FROM users
+	postgresUpdateUserXpubID = `
+	UPDATE users
+	SET xpub_id = $2
 	WHERE id = $1
 	`
+
+	postgresSearchUsersCondition = `
+	WHERE u.email ILIKE $1 ESCAPE '\' OR EXISTS (
+		SELECT 1 FROM user_paymails sp WHERE sp.user_id = u.id AND sp.paymail ILIKE $1 ESCAPE '\'
+	)
+	`
+
+	postgresSearchUsers = postgresSelectUser + postgresSearchUsersCondition + `
+	ORDER BY u.id
+	LIMIT $2 OFFSET $3
+	`
+
+	postgresCountUsers = `
+	SELECT COUNT(*)
+	FROM users u
+	` + postgresSearchUsersCondition
+
+	postgresSetUserDisabled = `
+	UPDATE users
+	SET disabled_at = $2
+	WHERE id = $1
+	`
+
+	postgresRevokeUserSessions = `
+	UPDATE users
+	SET sessions_revoked_at = $2
+	WHERE id = $1
+	`
+
+	postgresDeleteUser = `
+	DELETE FROM users
+	WHERE id = $1
+	`
+
+	postgresInsertUserPaymail = `
+	INSERT INTO user_paymails(user_id, paymail, is_primary, created_at)
+	VALUES($1, $2, $3, $4)
+	RETURNING id
+	`
+
+	postgresGetUserPaymails = `
+	SELECT id, user_id, paymail, is_primary, created_at
+	FROM user_paymails
+	WHERE user_id = $1
+	ORDER BY is_primary DESC, created_at
+	`
+
+	postgresGetUserPaymail = `
+	SELECT id, user_id, paymail, is_primary, created_at
+	FROM user_paymails
+	WHERE paymail = $1
+	`
+
+	postgresUnsetPrimaryPaymail = `
+	UPDATE user_paymails
+	SET is_primary = FALSE
+	WHERE user_id = $1 AND is_primary
+	`
+
+	postgresSetPrimaryPaymail = `
+	UPDATE user_paymails
+	SET is_primary = TRUE
+	WHERE user_id = $1 AND paymail = $2
+	`
+
+	postgresGetUserProfile = `
+	SELECT user_id, display_name, avatar, updated_at
+	FROM user_profiles
+	WHERE user_id = $1
+	`
+
+	postgresUpsertUserProfile = `
+	INSERT INTO user_profiles(user_id, display_name, avatar, updated_at)
+	VALUES($1, $2, $3, $4)
+	ON CONFLICT (user_id) DO UPDATE
+	SET display_name = EXCLUDED.display_name, avatar = EXCLUDED.avatar, updated_at = EXCLUDED.updated_at
+	`
 )
 
+// likeEscaper escapes wildcards of LIKE patterns, so they are matched literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 // Repository is a repository for users.
 type Repository struct {
 	db *sql.DB
@@ -40,7 +152,7 @@ func NewUsersRepository(db *sql.DB) *Repository {
 	}
 }
 
-// InsertUser inserts a user to db.
+// InsertUser inserts a user with its primary paymail to db.
 func (r *Repository) InsertUser(ctx context.Context, user *users.User) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -49,23 +161,41 @@ func (r *Repository) InsertUser(ctx context.Context, user *users.User) error {
 	defer func() {
 		_ = tx.Rollback()
 	}()
-	stmt, err := r.db.PrepareContext(ctx, postgresInsertUser)
-	if err != nil {
+	if err = insertUser(ctx, tx, user); err != nil {
+		return err
+	}
+	err = tx.Commit()
+	return errors.Wrap(err, "internal error")
+}
+
+// insertUser inserts a user with its primary paymail within the transaction.
+func insertUser(ctx context.Context, tx *sql.Tx, user *users.User) error {
+	if err := tx.QueryRowContext(ctx, postgresInsertUser, user.Email, user.Xpriv, user.XpubID,
+		user.AccessKeyID, user.AccessKey, user.SSOIssuer, user.SSOSubject, user.CreatedAt).Scan(&user.ID); err != nil {
 		return errors.Wrap(err, "internal error")
 	}
-	defer stmt.Close() //nolint:errcheck // best effort cleanup
-	if _, err = stmt.ExecContext(ctx, user.Email, user.Xpriv, user.Paymail, user.CreatedAt); err != nil {
+	if _, err := tx.ExecContext(ctx, postgresInsertUserPaymail, user.ID, user.Paymail, true, user.CreatedAt); err != nil {
 		return errors.Wrap(err, "internal error")
 	}
-	err = tx.Commit()
-	return errors.Wrap(err, "internal error")
+	return nil
 }
 
 // GetUserByEmail returns user by email. Can return nil user without an error - if no rows found.
 func (r *Repository) GetUserByEmail(ctx context.Context, email string) (*users.User, error) {
-	var user UserDto
-	row := r.db.QueryRowContext(ctx, postgresGetUserByEmail, email)
-	if err := row.Scan(&user.ID, &user.Email, &user.Xpriv, &user.Paymail, &user.CreatedAt); err != nil {
+	user, err := scanUser(r.db.QueryRowContext(ctx, postgresGetUserByEmail, email))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return user.toUser(), nil
+}
+
+// GetUserBySSOIdentity returns user by issuer and subject of the identity. Can return nil user without an error - if no rows found.
+func (r *Repository) GetUserBySSOIdentity(ctx context.Context, issuer, subject string) (*users.User, error) {
+	user, err := scanUser(r.db.QueryRowContext(ctx, postgresGetUserBySSOIdentity, issuer, subject))
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
@@ -76,10 +206,180 @@ func (r *Repository) GetUserByEmail(ctx context.Context, email string) (*users.U
 
 // GetUserByID returns user by id.
 func (r *Repository) GetUserByID(ctx context.Context, id int) (*users.User, error) {
-	var user UserDto
-	row := r.db.QueryRowContext(ctx, postgresGetUserByID, id)
-	if err := row.Scan(&user.ID, &user.Email, &user.Xpriv, &user.Paymail, &user.CreatedAt); err != nil {
+	user, err := scanUser(r.db.QueryRowContext(ctx, postgresGetUserByID, id))
+	if err != nil {
 		return nil, errors.Wrap(err, "internal error")
 	}
 	return user.toUser(), nil
 }
+
+// GetUserByXpubID returns user by xpub id. Can return nil user without an error - if no rows found.
+func (r *Repository) GetUserByXpubID(ctx context.Context, xpubID string) (*users.User, error) {
+	user, err := scanUser(r.db.QueryRowContext(ctx, postgresGetUserByXpubID, xpubID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return user.toUser(), nil
+}
+
+// UpdateUserXpubID sets xpub id of the user.
+func (r *Repository) UpdateUserXpubID(ctx context.Context, id int, xpubID string) error {
+	_, err := r.db.ExecContext(ctx, postgresUpdateUserXpubID, id, xpubID)
+	return errors.Wrap(err, "internal error")
+}
+
+// SearchUsers returns page of users which email or any paymail contains the query and the number of all such users.
+func (r *Repository) SearchUsers(ctx context.Context, query string, page, pageSize int) ([]*users.User, int64, error) {
+	pattern := "%" + likeEscaper.Replace(query) + "%"
+
+	var count int64
+	if err := r.db.QueryRowContext(ctx, postgresCountUsers, pattern).Scan(&count); err != nil {
+		return nil, 0, errors.Wrap(err, "internal error")
+	}
+
+	rows, err := r.db.QueryContext(ctx, postgresSearchUsers, pattern, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:errcheck // best effort cleanup
+
+	result := make([]*users.User, 0)
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "internal error")
+		}
+		result = append(result, user.toUser())
+	}
+	return result, count, errors.Wrap(rows.Err(), "internal error")
+}
+
+// GetViewers returns viewers invited by the owner, the first invited first.
+func (r *Repository) GetViewers(ctx context.Context, ownerID int) ([]*users.User, error) {
+	rows, err := r.db.QueryContext(ctx, postgresGetViewers, ownerID)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:errcheck // best effort cleanup
+
+	viewers := make([]*users.User, 0)
+	for rows.Next() {
+		viewer, err := scanUser(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		viewers = append(viewers, viewer.toUser())
+	}
+	return viewers, errors.Wrap(rows.Err(), "internal error")
+}
+
+// SetUserDisabled disables the user at given time, nil enables the user again.
+func (r *Repository) SetUserDisabled(ctx context.Context, id int, disabledAt *time.Time) error {
+	_, err := r.db.ExecContext(ctx, postgresSetUserDisabled, id, disabledAt)
+	return errors.Wrap(err, "internal error")
+}
+
+// RevokeUserSessions marks all sessions of the user started before given time as revoked.
+func (r *Repository) RevokeUserSessions(ctx context.Context, id int, revokedAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, postgresRevokeUserSessions, id, revokedAt)
+	return errors.Wrap(err, "internal error")
+}
+
+// DeleteUser removes the user from db, paymails, profile and tracked transactions of the user are removed with it.
+func (r *Repository) DeleteUser(ctx context.Context, id int) error {
+	_, err := r.db.ExecContext(ctx, postgresDeleteUser, id)
+	return errors.Wrap(err, "internal error")
+}
+
+// InsertUserPaymail inserts an additional paymail of the user to db.
+func (r *Repository) InsertUserPaymail(ctx context.Context, paymail *users.UserPaymail) error {
+	row := r.db.QueryRowContext(ctx, postgresInsertUserPaymail, paymail.UserID, paymail.Paymail, paymail.Primary, paymail.CreatedAt)
+	return errors.Wrap(row.Scan(&paymail.ID), "internal error")
+}
+
+// GetUserPaymails returns all paymails of the user, the primary one first.
+func (r *Repository) GetUserPaymails(ctx context.Context, userID int) ([]*users.UserPaymail, error) {
+	rows, err := r.db.QueryContext(ctx, postgresGetUserPaymails, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:errcheck // best effort cleanup
+
+	paymails := make([]*users.UserPaymail, 0)
+	for rows.Next() {
+		var paymail UserPaymailDto
+		if err = rows.Scan(&paymail.ID, &paymail.UserID, &paymail.Paymail, &paymail.Primary, &paymail.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "internal error")
+		}
+		paymails = append(paymails, paymail.toUserPaymail())
+	}
+	return paymails, errors.Wrap(rows.Err(), "internal error")
+}
+
+// GetUserPaymail returns paymail by its address. Can return nil paymail without an error - if no rows found.
+func (r *Repository) GetUserPaymail(ctx context.Context, address string) (*users.UserPaymail, error) {
+	var paymail UserPaymailDto
+	row := r.db.QueryRowContext(ctx, postgresGetUserPaymail, address)
+	if err := row.Scan(&paymail.ID, &paymail.UserID, &paymail.Paymail, &paymail.Primary, &paymail.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return paymail.toUserPaymail(), nil
+}
+
+// SetPrimaryPaymail marks the paymail as primary one of the user.
+func (r *Repository) SetPrimaryPaymail(ctx context.Context, userID int, address string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	if _, err = tx.ExecContext(ctx, postgresUnsetPrimaryPaymail, userID); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	result, err := tx.ExecContext(ctx, postgresSetPrimaryPaymail, userID, address)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected != 1 {
+		return errors.Errorf("paymail %s of user %d not found", address, userID)
+	}
+	err = tx.Commit()
+	return errors.Wrap(err, "internal error")
+}
+
+// GetUserProfile returns profile of the user. Can return nil profile without an error - if no rows found.
+func (r *Repository) GetUserProfile(ctx context.Context, userID int) (*users.Profile, error) {
+	var profile UserProfileDto
+	row := r.db.QueryRowContext(ctx, postgresGetUserProfile, userID)
+	if err := row.Scan(&profile.UserID, &profile.DisplayName, &profile.Avatar, &profile.UpdatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return profile.toProfile(), nil
+}
+
+// UpsertUserProfile inserts or updates profile of the user.
+func (r *Repository) UpsertUserProfile(ctx context.Context, profile *users.Profile) error {
+	_, err := r.db.ExecContext(ctx, postgresUpsertUserProfile, profile.UserID, profile.DisplayName, profile.Avatar, profile.UpdatedAt)
+	return errors.Wrap(err, "internal error")
+}
+
+func scanUser(row rowScanner) (*UserDto, error) {
+	var user UserDto
+	err := row.Scan(&user.ID, &user.Email, &user.Xpriv, &user.Paymail, &user.XpubID, &user.CreatedAt, &user.DisabledAt, &user.SessionsRevokedAt,
+		&user.Role, &user.OwnerID, &user.AccessKeyID, &user.AccessKey, &user.SSOIssuer, &user.SSOSubject)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error wrapped by callers
+	}
+	return &user, nil
+}
@@ -0,0 +1,73 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+)
+
+const (
+	postgresInsertViewerInvitation = `
+	INSERT INTO viewer_invitations(owner_id, email, token_hash, access_key_id, access_key, expires_at, created_at)
+	VALUES($1, $2, $3, $4, $5, $6, $7)
+	RETURNING id
+	`
+
+	postgresGetViewerInvitation = `
+	SELECT id, owner_id, email, token_hash, access_key_id, access_key, expires_at, created_at
+	FROM viewer_invitations
+	WHERE token_hash = $1
+	`
+
+	postgresDeleteViewerInvitation = `
+	DELETE FROM viewer_invitations
+	WHERE id = $1
+	`
+)
+
+// InsertViewerInvitation inserts invitation of a viewer to db.
+func (r *Repository) InsertViewerInvitation(ctx context.Context, invitation *users.ViewerInvitation) error {
+	row := r.db.QueryRowContext(ctx, postgresInsertViewerInvitation,
+		invitation.OwnerID, invitation.Email, invitation.TokenHash, invitation.AccessKeyID, invitation.AccessKey,
+		invitation.ExpiresAt, invitation.CreatedAt)
+	return errors.Wrap(row.Scan(&invitation.ID), "internal error")
+}
+
+// GetViewerInvitation returns invitation by hash of its token. Can return nil invitation without an error - if no rows found.
+func (r *Repository) GetViewerInvitation(ctx context.Context, tokenHash string) (*users.ViewerInvitation, error) {
+	var invitation ViewerInvitationDto
+	row := r.db.QueryRowContext(ctx, postgresGetViewerInvitation, tokenHash)
+	err := row.Scan(&invitation.ID, &invitation.OwnerID, &invitation.Email, &invitation.TokenHash,
+		&invitation.AccessKeyID, &invitation.AccessKey, &invitation.ExpiresAt, &invitation.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "internal error")
+	}
+	return invitation.toViewerInvitation(), nil
+}
+
+// AcceptViewerInvitation inserts the viewer and deletes the invitation in one transaction.
+func (r *Repository) AcceptViewerInvitation(ctx context.Context, viewer *users.User, invitationID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	err = tx.QueryRowContext(ctx, postgresInsertViewer,
+		viewer.Email, viewer.Role, viewer.OwnerID, viewer.AccessKeyID, viewer.AccessKey, viewer.CreatedAt).Scan(&viewer.ID)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	if _, err = tx.ExecContext(ctx, postgresDeleteViewerInvitation, invitationID); err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	err = tx.Commit()
+	return errors.Wrap(err, "internal error")
+}
@@ -15,6 +15,391 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/v1/admin/actions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get audit trail of admin actions, the newest first",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of the operator",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Action, e.g. disable_user",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of the target user",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, max 100",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_admin.ActionsPage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/audit-events": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get security-relevant events of all users, the newest first",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of the user",
+                        "name": "userId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Action, e.g. sign_in",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Result, success or failure",
+                        "name": "result",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events recorded at or after this time, RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events recorded before this time, RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, max 100",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_audit.EventsPage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/users": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Search users by email or paymail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of the operator recorded in the audit trail",
+                        "name": "X-Admin-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of email or paymail, all users are returned when empty",
+                        "name": "query",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, max 100",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_admin.UsersPage"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/users/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get user with paymails and balance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of the operator recorded in the audit trail",
+                        "name": "X-Admin-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_admin.UserDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/users/{id}/disable": {
+            "post": {
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Disable user account and close its websocket connections",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of the operator recorded in the audit trail",
+                        "name": "X-Admin-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/users/{id}/enable": {
+            "post": {
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Enable disabled user account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of the operator recorded in the audit trail",
+                        "name": "X-Admin-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/users/{id}/sign-out": {
+            "post": {
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Revoke all sessions of the user and close its websocket connections",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of the operator recorded in the audit trail",
+                        "name": "X-Admin-Actor",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/websocket/connections": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get live websocket connections of this instance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer admin token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_admin.WebsocketConnections"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/avatars/{name}": {
+            "get": {
+                "produces": [
+                    "image/png",
+                    "image/jpeg",
+                    "image/gif",
+                    "image/webp"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get avatar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Avatar name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/config": {
             "get": {
                 "produces": [
@@ -177,21 +562,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/sign-in": {
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/paymail/resolve": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "paymail"
                 ],
-                "summary": "Sign in user",
+                "summary": "Resolve paymail address with capability discovery.",
                 "parameters": [
                     {
-                        "description": "User sign in data",
+                        "type": "string",
+                        "description": "Paymail address",
+                        "name": "address",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_paymails.Resolution"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/sign-in": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Sign in user",
+                "parameters": [
+                    {
+                        "description": "User sign in data",
                         "name": "data",
                         "in": "body",
                         "required": true,
@@ -210,6 +623,59 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/sign-in/passkey/begin": {
+            "post": {
+                "description": "Returns options for navigator.credentials.get(), the sign in has to be finished in the same session.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Begin passkey sign in",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/sign-in/passkey/finish": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Finish passkey sign in",
+                "parameters": [
+                    {
+                        "description": "Passkey assertion",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_passkeys.SignInPasskey"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_passkeys.SignInResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/sign-out": {
             "post": {
                 "consumes": [
@@ -229,6 +695,91 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/sso/callback": {
+            "get": {
+                "description": "User mapped to the identity is signed in. Otherwise verified identity is kept until the user registers with spending PIN.",
+                "tags": [
+                    "sso"
+                ],
+                "summary": "Identity provider callback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "State of the authorization",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    }
+                }
+            }
+        },
+        "/api/v1/sso/login": {
+            "get": {
+                "tags": [
+                    "sso"
+                ],
+                "summary": "Sign in with identity provider",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Keep the user signed in longer",
+                        "name": "rememberMe",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    }
+                }
+            }
+        },
+        "/api/v1/sso/register": {
+            "post": {
+                "description": "Spending PIN encrypts the keys of the user, it is required to send transactions instead of password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sso"
+                ],
+                "summary": "Register user signed in with identity provider",
+                "parameters": [
+                    {
+                        "description": "Spending PIN and paymail of the user",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_sso.RegisterSSOUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_sso.RegisterResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/transaction": {
             "post": {
                 "produces": [
@@ -259,6 +810,26 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/transaction/confirmation/begin": {
+            "post": {
+                "description": "Returns options for navigator.credentials.get(), the assertion is sent as passkey of the created transaction.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "Begin transaction confirmation with passkey",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/transaction/search": {
             "post": {
                 "produces": [
@@ -308,7 +879,7 @@ const docTemplate = `{
         },
         "/api/v1/user": {
             "post": {
-                "description": "Register new user with given data, paymail is created based on username from sended email.",
+                "description": "Register new user with given data, paymail is created in chosen (or default) domain with given alias or based on username from sended email.",
                 "consumes": [
                     "application/json"
                 ],
@@ -340,96 +911,1648 @@ const docTemplate = `{
                 }
             }
         },
-        "/status": {
+        "/api/v1/user/passkeys": {
             "get": {
-                "consumes": [
-                    "*/*"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "status"
+                    "user"
                 ],
-                "summary": "Check the status of the server",
+                "summary": "Get passkeys",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_passkeys.Passkey"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/user": {
-            "get": {
-                "consumes": [
-                    "*/*"
-                ],
+        "/api/v1/user/passkeys/registration/begin": {
+            "post": {
+                "description": "Returns options for navigator.credentials.create(), the registration has to be finished in the same session.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "user"
                 ],
-                "summary": "Get user information",
+                "summary": "Begin passkey registration",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/transports_http_endpoints_api_users.UserResponse"
+                            "type": "object"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "filter.QueryParams": {
-            "type": "object",
-            "properties": {
-                "order_by_field": {
-                    "type": "string"
-                },
-                "page": {
-                    "type": "integer"
-                },
-                "page_size": {
-                    "type": "integer"
-                },
-                "sort_direction": {
-                    "type": "string"
-                }
-            }
         },
-        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_transactions.PaginatedTransactions": {
-            "type": "object",
-            "properties": {
-                "count": {
-                    "type": "integer"
-                },
-                "pages": {
-                    "type": "integer"
-                },
-                "transactions": {
-                    "type": "array",
-                    "items": {}
+        "/api/v1/user/passkeys/registration/finish": {
+            "post": {
+                "description": "Password decrypts the xpriv which is then encrypted with the output of the passkey PRF extension.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Finish passkey registration",
+                "parameters": [
+                    {
+                        "description": "Passkey registration data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_passkeys.RegisterPasskey"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_passkeys.Passkey"
+                        }
+                    }
                 }
             }
         },
-        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_users.Balance": {
-            "type": "object",
-            "properties": {
-                "bsv": {
-                    "type": "number"
-                },
-                "satoshis": {
-                    "type": "integer"
-                },
-                "usd": {
-                    "type": "number"
+        "/api/v1/user/passkeys/{id}": {
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Delete passkey",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Passkey ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User password",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_passkeys.DeletePasskey"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
                 }
             }
         },
-        "models.Contact": {
+        "/api/v1/user/paymail-availability": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Check paymail alias availability",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Paymail alias",
+                        "name": "alias",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Paymail domain, default domain is used when empty",
+                        "name": "domain",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_users.AliasAvailability"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/viewer-invitations/accept": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Accept viewer invitation",
+                "parameters": [
+                    {
+                        "description": "Invitation token and password of the viewer",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_users.AcceptViewerInvitation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/wallet-invitations/accept": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Accept team wallet invitation",
+                "parameters": [
+                    {
+                        "description": "Invitation token and user password",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_wallets.AcceptInvitation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_teams.MemberWallet"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/wallets": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Get team wallets",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_teams.MemberWallet"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Team wallet has its own key and paymail, the user becomes its first owner. Returned mnemonic is the only backup of the wallet key.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Create team wallet",
+                "parameters": [
+                    {
+                        "description": "Team wallet data and user password",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_wallets.CreateWallet"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_wallets.CreateWalletResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/wallets/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Get team wallet",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team wallet id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_teams.WalletDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/wallets/{id}/invitations": {
+            "post": {
+                "description": "Returned token has to be passed to the invited user, who accepts the invitation with it. Only owners of the team wallet can invite members.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Invite team wallet member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team wallet id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Email and role of the member and user password",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_wallets.InviteMember"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_teams.CreatedInvitation"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/wallets/{id}/members/{userId}": {
+            "delete": {
+                "description": "Owners can remove any member, other members can only leave the team wallet.",
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Remove team wallet member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team wallet id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User id of the member",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/api/v1/wallets/{id}/payments": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Get team wallet payments waiting for approvals",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team wallet id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_teams.PendingPayment"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/wallets/{id}/payments/{paymentId}/approvals": {
+            "post": {
+                "description": "Payment is sent when it has the required number of approvals, the initiator is notified about the transaction.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Approve team wallet payment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team wallet id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Payment id",
+                        "name": "paymentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User password",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_wallets.ApprovePayment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_teams.PendingPayment"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/wallets/{id}/transactions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Get team wallet transactions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team wallet id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of transactions in the page",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_teams.TransactionsPage"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Transaction is sent with the wallet key decrypted by the password of the member, the member is recorded as its initiator.\nPayment above the approval threshold is held until enough members approve it, it is returned with status 202 and the members who can approve it are notified.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wallets"
+                ],
+                "summary": "Create team wallet transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Team wallet id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create transaction data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_wallets.CreateTransaction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_teams.PendingPayment"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhook": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhook"
+                ],
+                "summary": "Receive SPV Wallet events",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/status": {
+            "get": {
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "status"
+                ],
+                "summary": "Check the status of the server",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/user": {
+            "get": {
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get user information",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_users.UserResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remaining funds are sent to given address, paymails are deleted, access keys revoked and all personal data erased.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Delete user account",
+                "parameters": [
+                    {
+                        "description": "User password and address for remaining funds",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_users.DeleteUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/user/export": {
+            "get": {
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Export user data",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/paymails": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get user paymails",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_users.UserPaymail"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Add user paymail",
+                "parameters": [
+                    {
+                        "description": "Paymail alias, optional domain and user password",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_users.AddPaymail"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_users.UserPaymail"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/paymails/primary": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Set primary paymail",
+                "parameters": [
+                    {
+                        "description": "Paymail owned by the user",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_users.SetPrimaryPaymail"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_users.UserPaymail"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/profile": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get user profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_profile.Profile"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update user display name",
+                "parameters": [
+                    {
+                        "description": "Display name and user password",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_profile.UpdateProfile"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_profile.Profile"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/profile/avatar": {
+            "put": {
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Upload user avatar",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "PNG, JPEG, GIF or WEBP image",
+                        "name": "avatar",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User password",
+                        "name": "password",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_profile.Profile"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/security-events": {
+            "get": {
+                "description": "Returns sign-ins, key unlocks, contact confirmations and payments of the user, the newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get security events",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, max 100",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_audit.EventsPage"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/spending-pin": {
+            "put": {
+                "description": "Spending PIN can be used instead of password to send transactions, it is locked after too many invalid attempts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Set spending PIN",
+                "parameters": [
+                    {
+                        "description": "User password and new spending PIN",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_users.SetSpendingPIN"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Remove spending PIN",
+                "parameters": [
+                    {
+                        "description": "User password",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_users.RemoveSpendingPIN"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/user/spending-pin/unlock": {
+            "post": {
+                "description": "Sessions opened without password, e.g. with identity provider, have to be unlocked to manage contacts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Unlock session with spending PIN",
+                "parameters": [
+                    {
+                        "description": "Spending PIN",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_users.UnlockSpendingPIN"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/user/tokens": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get API tokens",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_users.APIToken"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Returned token is shown only once, it is passed in the header \"Authorization: Bearer \u003ctoken\u003e\". Token can call only endpoints of its scopes: balance:read, transactions:read, transactions:send (up to the send limit in satoshis) and contacts:manage.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Create API token",
+                "parameters": [
+                    {
+                        "description": "Name, scopes, send limit and expiry of the token and user password",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_users.CreateAPIToken"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_users.CreatedAPIToken"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/tokens/{id}": {
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Revoke API token",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "API token id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User password",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_users.RevokeAPIToken"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/user/viewers": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get viewers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_users.User"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Returned token has to be passed to the viewer, who accepts the invitation with it. Viewer can see balance and history of the wallet, but cannot spend funds.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Invite viewer",
+                "parameters": [
+                    {
+                        "description": "Email of the viewer and user password",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_users.InviteViewer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_users.CreatedInvitation"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/viewers/{id}": {
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Remove viewer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Viewer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User password",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/transports_http_endpoints_api_users.RemoveViewer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "filter.QueryParams": {
+            "type": "object",
+            "properties": {
+                "order_by_field": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "sort_direction": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_admin.Action": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "string"
+                },
+                "target_user_id": {
+                    "type": "integer"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_admin.ActionsPage": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_admin.Action"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_admin.UserDetails": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer"
+                },
+                "paymails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_users.UserPaymail"
+                    }
+                },
+                "user": {
+                    "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_users.User"
+                },
+                "wallet_paymails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_users.WalletPaymail"
+                    }
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_admin.UsersPage": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "pages": {
+                    "type": "integer"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_users.User"
+                    }
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_audit.Event": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_audit.EventsPage": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_audit.Event"
+                    }
+                },
+                "pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_config.PaymailDomain": {
+            "type": "object",
+            "properties": {
+                "domain": {
+                    "type": "string"
+                },
+                "registration_open": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_passkeys.Passkey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_teams.CreatedInvitation": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_teams.Member": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_teams.MemberWallet": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string"
+                },
+                "wallet": {
+                    "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_teams.Wallet"
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_teams.PendingPayment": {
+            "type": "object",
+            "properties": {
+                "approved_by": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "initiator_email": {
+                    "type": "string"
+                },
+                "initiator_id": {
+                    "type": "integer"
+                },
+                "recipient": {
+                    "type": "string"
+                },
+                "required_approvals": {
+                    "type": "integer"
+                },
+                "satoshis": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "wallet_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_teams.Transaction": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "initiator_email": {
+                    "type": "string"
+                },
+                "initiator_id": {
+                    "type": "integer"
+                },
+                "recipient": {
+                    "type": "string"
+                },
+                "satoshis": {
+                    "type": "integer"
+                },
+                "transaction_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_teams.TransactionsPage": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "pages": {
+                    "type": "integer"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_teams.Transaction"
+                    }
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_teams.Wallet": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "paymail": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_teams.WalletDetails": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "description": "balance in satoshis",
+                    "type": "integer"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_teams.Member"
+                    }
+                },
+                "role": {
+                    "type": "string"
+                },
+                "wallet": {
+                    "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_teams.Wallet"
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_transactions.PaginatedTransactions": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "pages": {
+                    "type": "integer"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {}
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_users.APIToken": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "send_limit": {
+                    "description": "total amount in satoshis the token can send",
+                    "type": "integer"
+                },
+                "spent": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_users.AliasAvailability": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "available": {
+                    "type": "boolean"
+                },
+                "paymail": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_users.Balance": {
+            "type": "object",
+            "properties": {
+                "bsv": {
+                    "type": "number"
+                },
+                "satoshis": {
+                    "type": "integer"
+                },
+                "usd": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_users.CreatedAPIToken": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "send_limit": {
+                    "description": "total amount in satoshis the token can send",
+                    "type": "integer"
+                },
+                "spent": {
+                    "type": "integer"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_users.CreatedInvitation": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_users.User": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "disabled_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "owner_id": {
+                    "description": "owner of the wallet the viewer sees",
+                    "type": "integer"
+                },
+                "paymail": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_users.UserPaymail": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "paymail": {
+                    "type": "string"
+                },
+                "primary": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_domain_users.WalletPaymail": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "avatar": {
+                    "type": "string"
+                },
+                "public_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_bsv-blockchain_spv-wallet-web-backend_transports_websocket.ConnectionInfo": {
+            "type": "object",
+            "properties": {
+                "clientId": {
+                    "type": "string"
+                },
+                "connectedAt": {
+                    "type": "string"
+                },
+                "lastActivity": {
+                    "type": "string"
+                },
+                "userAgent": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Contact": {
             "type": "object",
             "properties": {
                 "created_at": {
@@ -542,22 +2665,155 @@ const docTemplate = `{
                 }
             }
         },
-        "response.ContactStatus": {
-            "type": "string",
-            "enum": [
-                "unconfirmed",
-                "awaiting",
-                "confirmed",
-                "rejected"
-            ],
-            "x-enum-varnames": [
-                "ContactNotConfirmed",
-                "ContactAwaitAccept",
-                "ContactConfirmed",
-                "ContactRejected"
-            ]
+        "response.ContactStatus": {
+            "type": "string",
+            "enum": [
+                "unconfirmed",
+                "awaiting",
+                "confirmed",
+                "rejected"
+            ],
+            "x-enum-varnames": [
+                "ContactNotConfirmed",
+                "ContactAwaitAccept",
+                "ContactConfirmed",
+                "ContactRejected"
+            ]
+        },
+        "transports_http_endpoints_api_access.SignInResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_users.Balance"
+                },
+                "paymail": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_access.SignInUser": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "rememberMe": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "transports_http_endpoints_api_admin.WebsocketConnections": {
+            "type": "object",
+            "properties": {
+                "connections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_transports_websocket.ConnectionInfo"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "transports_http_endpoints_api_config.PublicConfig": {
+            "type": "object",
+            "properties": {
+                "experimental_features": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
+                "paymail_domain": {
+                    "type": "string"
+                },
+                "paymail_domains": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_bsv-blockchain_spv-wallet-web-backend_domain_config.PaymailDomain"
+                    }
+                },
+                "sso_enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "transports_http_endpoints_api_contacts.ConfirmContact": {
+            "type": "object",
+            "properties": {
+                "contact": {
+                    "$ref": "#/definitions/models.Contact"
+                },
+                "passcode": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_contacts.SearchContact": {
+            "type": "object",
+            "properties": {
+                "conditions": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "metadata": {
+                    "$ref": "#/definitions/models.Metadata"
+                },
+                "params": {
+                    "$ref": "#/definitions/filter.QueryParams"
+                }
+            }
+        },
+        "transports_http_endpoints_api_contacts.UpsertContact": {
+            "type": "object",
+            "properties": {
+                "fullName": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {}
+                }
+            }
+        },
+        "transports_http_endpoints_api_passkeys.DeletePasskey": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_passkeys.RegisterPasskey": {
+            "type": "object",
+            "properties": {
+                "credential": {
+                    "type": "object"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_passkeys.SignInPasskey": {
+            "type": "object",
+            "properties": {
+                "credential": {
+                    "type": "object"
+                },
+                "rememberMe": {
+                    "type": "boolean"
+                }
+            }
         },
-        "transports_http_endpoints_api_access.SignInResponse": {
+        "transports_http_endpoints_api_passkeys.SignInResponse": {
             "type": "object",
             "properties": {
                 "balance": {
@@ -568,75 +2824,97 @@ const docTemplate = `{
                 }
             }
         },
-        "transports_http_endpoints_api_access.SignInUser": {
+        "transports_http_endpoints_api_paymails.Resolution": {
             "type": "object",
             "properties": {
-                "email": {
+                "address": {
                     "type": "string"
                 },
-                "password": {
+                "alias": {
+                    "type": "string"
+                },
+                "avatar": {
+                    "type": "string"
+                },
+                "domain": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "p2p": {
+                    "type": "boolean"
+                },
+                "pubKey": {
                     "type": "string"
                 }
             }
         },
-        "transports_http_endpoints_api_config.PublicConfig": {
+        "transports_http_endpoints_api_profile.Profile": {
             "type": "object",
             "properties": {
-                "experimental_features": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "boolean"
-                    }
+                "avatar_url": {
+                    "type": "string"
                 },
-                "paymail_domain": {
+                "display_name": {
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "transports_http_endpoints_api_contacts.ConfirmContact": {
+        "transports_http_endpoints_api_profile.UpdateProfile": {
             "type": "object",
             "properties": {
-                "contact": {
-                    "$ref": "#/definitions/models.Contact"
+                "displayName": {
+                    "type": "string"
                 },
-                "passcode": {
+                "password": {
                     "type": "string"
                 }
             }
         },
-        "transports_http_endpoints_api_contacts.SearchContact": {
+        "transports_http_endpoints_api_sso.RegisterResponse": {
             "type": "object",
             "properties": {
-                "conditions": {
-                    "type": "object",
-                    "additionalProperties": true
-                },
-                "metadata": {
-                    "$ref": "#/definitions/models.Metadata"
+                "mnemonic": {
+                    "type": "string"
                 },
-                "params": {
-                    "$ref": "#/definitions/filter.QueryParams"
+                "paymail": {
+                    "type": "string"
                 }
             }
         },
-        "transports_http_endpoints_api_contacts.UpsertContact": {
+        "transports_http_endpoints_api_sso.RegisterSSOUser": {
             "type": "object",
             "properties": {
-                "fullName": {
+                "alias": {
                     "type": "string"
                 },
-                "metadata": {
-                    "type": "object",
-                    "additionalProperties": {}
+                "domain": {
+                    "type": "string"
+                },
+                "pin": {
+                    "type": "string"
+                },
+                "pinConfirmation": {
+                    "type": "string"
                 }
             }
         },
         "transports_http_endpoints_api_transactions.CreateTransaction": {
             "type": "object",
             "properties": {
+                "passkey": {
+                    "type": "object"
+                },
                 "password": {
                     "type": "string"
                 },
+                "pin": {
+                    "type": "string"
+                },
                 "recipient": {
                     "type": "string"
                 },
@@ -686,6 +2964,79 @@ const docTemplate = `{
                 }
             }
         },
+        "transports_http_endpoints_api_users.AcceptViewerInvitation": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "passwordConfirmation": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_users.AddPaymail": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "domain": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_users.CreateAPIToken": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sendLimit": {
+                    "type": "integer"
+                }
+            }
+        },
+        "transports_http_endpoints_api_users.DeleteUser": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "sweepAddress": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_users.InviteViewer": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
         "transports_http_endpoints_api_users.RegisterResponse": {
             "type": "object",
             "properties": {
@@ -700,6 +3051,12 @@ const docTemplate = `{
         "transports_http_endpoints_api_users.RegisterUser": {
             "type": "object",
             "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "domain": {
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
@@ -711,6 +3068,60 @@ const docTemplate = `{
                 }
             }
         },
+        "transports_http_endpoints_api_users.RemoveSpendingPIN": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_users.RemoveViewer": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_users.RevokeAPIToken": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_users.SetPrimaryPaymail": {
+            "type": "object",
+            "properties": {
+                "paymail": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_users.SetSpendingPIN": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "pin": {
+                    "type": "string"
+                },
+                "pinConfirmation": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_users.UnlockSpendingPIN": {
+            "type": "object",
+            "properties": {
+                "pin": {
+                    "type": "string"
+                }
+            }
+        },
         "transports_http_endpoints_api_users.UserResponse": {
             "type": "object",
             "properties": {
@@ -723,10 +3134,94 @@ const docTemplate = `{
                 "paymail": {
                     "type": "string"
                 },
+                "spendingPin": {
+                    "type": "boolean"
+                },
                 "userId": {
                     "type": "integer"
                 }
             }
+        },
+        "transports_http_endpoints_api_wallets.AcceptInvitation": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_wallets.ApprovePayment": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_wallets.CreateTransaction": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "recipient": {
+                    "type": "string"
+                },
+                "satoshis": {
+                    "type": "integer"
+                }
+            }
+        },
+        "transports_http_endpoints_api_wallets.CreateWallet": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "domain": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_wallets.CreateWalletResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "mnemonic": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "paymail": {
+                    "type": "string"
+                }
+            }
+        },
+        "transports_http_endpoints_api_wallets.InviteMember": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
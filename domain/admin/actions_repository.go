@@ -0,0 +1,11 @@
+package admin
+
+import (
+	"context"
+)
+
+// ActionsRepository is an interface which defines methods for the audit trail of admin actions.
+type ActionsRepository interface {
+	InsertAction(ctx context.Context, action *Action) error
+	GetActions(ctx context.Context, filter *ActionsFilter, page, pageSize int) ([]*Action, int64, error)
+}
@@ -0,0 +1,71 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+)
+
+// Admin actions recorded in the audit trail.
+const (
+	ActionSearchUsers = "search_users"
+	ActionViewUser    = "view_user"
+	ActionDisableUser = "disable_user"
+	ActionEnableUser  = "enable_user"
+	ActionSignOutUser = "sign_out_user"
+)
+
+// Results of admin actions recorded in the audit trail.
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// Actor is a struct that contains identification of the admin performing an action.
+type Actor struct {
+	Name      string
+	IP        string
+	UserAgent string
+}
+
+// Action is a struct that contains record of the admin action.
+type Action struct {
+	ID           int       `json:"id"`
+	Actor        string    `json:"actor"`
+	Action       string    `json:"action"`
+	TargetUserID *int      `json:"target_user_id,omitempty"`
+	Details      string    `json:"details,omitempty"`
+	IP           string    `json:"ip"`
+	UserAgent    string    `json:"user_agent"`
+	Result       string    `json:"result"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ActionsFilter is a struct that contains conditions of admin actions search, empty fields are not applied.
+type ActionsFilter struct {
+	Actor        string
+	Action       string
+	TargetUserID *int
+}
+
+// UsersPage is a struct that contains page of users.
+type UsersPage struct {
+	Count int64         `json:"count"`
+	Pages int           `json:"pages"`
+	Users []*users.User `json:"users"`
+}
+
+// ActionsPage is a struct that contains page of admin actions.
+type ActionsPage struct {
+	Count   int64     `json:"count"`
+	Pages   int       `json:"pages"`
+	Actions []*Action `json:"actions"`
+}
+
+// UserDetails is a struct that contains the user with paymails and balance from SPV Wallet.
+type UserDetails struct {
+	User           *users.User            `json:"user"`
+	Paymails       []*users.UserPaymail   `json:"paymails"`
+	WalletPaymails []*users.WalletPaymail `json:"wallet_paymails"`
+	Balance        uint64                 `json:"balance"`
+}
@@ -0,0 +1,229 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+)
+
+const (
+	// defaultPageSize is the number of records returned when page size is not given.
+	defaultPageSize = 20
+	// maxPageSize is the max number of records returned in one page.
+	maxPageSize = 100
+)
+
+// Service provides management of users for admins, every action is recorded in the audit trail.
+type Service struct {
+	usersRepo         users.Repository
+	actionsRepo       ActionsRepository
+	adminWalletClient users.AdminWalletClient
+	log               *zerolog.Logger
+}
+
+// NewAdminService creates admin Service instance.
+func NewAdminService(usersRepo users.Repository, actionsRepo ActionsRepository, adminWalletClient users.AdminWalletClient, l *zerolog.Logger) *Service {
+	adminServiceLogger := l.With().Str("service", "admin-service").Logger()
+	return &Service{
+		usersRepo:         usersRepo,
+		actionsRepo:       actionsRepo,
+		adminWalletClient: adminWalletClient,
+		log:               &adminServiceLogger,
+	}
+}
+
+// SearchUsers returns page of users which email or paymail contains the query, empty query matches all users.
+func (s *Service) SearchUsers(actor *Actor, query string, page, pageSize int) (*UsersPage, error) {
+	query = strings.TrimSpace(query)
+	page, pageSize = normalizePage(page, pageSize)
+
+	result, count, err := s.usersRepo.SearchUsers(context.Background(), query, page, pageSize)
+	s.record(actor, ActionSearchUsers, nil, fmt.Sprintf("query=%q page=%d", query, page), err)
+	if err != nil {
+		s.log.Error().Msgf("Error while searching users: %v", err.Error())
+		return nil, spverrors.ErrAdminSearchUsers
+	}
+
+	return &UsersPage{
+		Count: count,
+		Pages: pagesCount(count, pageSize),
+		Users: result,
+	}, nil
+}
+
+// GetUser returns the user with paymails and balance of the user wallet in SPV Wallet.
+func (s *Service) GetUser(actor *Actor, userID int) (*UserDetails, error) {
+	details, err := s.getUserDetails(userID)
+	s.record(actor, ActionViewUser, &userID, "", err)
+	if err != nil {
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// DisableUser disables the user, disabled user cannot sign in and existing sessions of the user stop working.
+func (s *Service) DisableUser(actor *Actor, userID int) error {
+	disabledAt := time.Now().UTC()
+	err := s.updateUser(userID, func(ctx context.Context) error {
+		return s.usersRepo.SetUserDisabled(ctx, userID, &disabledAt)
+	})
+	s.record(actor, ActionDisableUser, &userID, "", err)
+	return err
+}
+
+// EnableUser enables the user disabled before.
+func (s *Service) EnableUser(actor *Actor, userID int) error {
+	err := s.updateUser(userID, func(ctx context.Context) error {
+		return s.usersRepo.SetUserDisabled(ctx, userID, nil)
+	})
+	s.record(actor, ActionEnableUser, &userID, "", err)
+	return err
+}
+
+// SignOutUser revokes all current sessions of the user.
+func (s *Service) SignOutUser(actor *Actor, userID int) error {
+	err := s.updateUser(userID, func(ctx context.Context) error {
+		return s.usersRepo.RevokeUserSessions(ctx, userID, time.Now().UTC())
+	})
+	s.record(actor, ActionSignOutUser, &userID, "", err)
+	return err
+}
+
+// GetActions returns page of recorded admin actions matching the filter, the newest first.
+func (s *Service) GetActions(filter *ActionsFilter, page, pageSize int) (*ActionsPage, error) {
+	page, pageSize = normalizePage(page, pageSize)
+
+	actions, count, err := s.actionsRepo.GetActions(context.Background(), filter, page, pageSize)
+	if err != nil {
+		s.log.Error().Msgf("Error while getting admin actions: %v", err.Error())
+		return nil, spverrors.ErrGetAdminActions
+	}
+
+	return &ActionsPage{
+		Count:   count,
+		Pages:   pagesCount(count, pageSize),
+		Actions: actions,
+	}, nil
+}
+
+func (s *Service) getUserDetails(userID int) (*UserDetails, error) {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	paymails, err := s.usersRepo.GetUserPaymails(context.Background(), userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting user paymails: %v", err.Error())
+		return nil, spverrors.ErrGetUserPaymails
+	}
+
+	details := &UserDetails{
+		User:           user,
+		Paymails:       paymails,
+		WalletPaymails: make([]*users.WalletPaymail, 0),
+	}
+
+	// Users registered before xpub ids were stored have it filled on their next sign in.
+	if user.XpubID == "" {
+		return details, nil
+	}
+
+	xpub, err := s.adminWalletClient.GetXpub(user.XpubID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting xPub: %v", err.Error())
+		return nil, spverrors.ErrGetXPub
+	}
+	details.Balance = xpub.GetCurrentBalance()
+
+	details.WalletPaymails, err = s.adminWalletClient.GetXpubPaymails(user.XpubID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting wallet paymails: %v", err.Error())
+		return nil, spverrors.ErrGetUserPaymails
+	}
+
+	return details, nil
+}
+
+func (s *Service) getUser(userID int) (*users.User, error) {
+	user, err := s.usersRepo.GetUserByID(context.Background(), userID)
+	if err != nil {
+		s.log.Debug().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting user by id: %v", err.Error())
+		return nil, spverrors.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (s *Service) updateUser(userID int, update func(ctx context.Context) error) error {
+	if _, err := s.getUser(userID); err != nil {
+		return err
+	}
+
+	if err := update(context.Background()); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while updating user: %v", err.Error())
+		return spverrors.ErrAdminUpdateUser
+	}
+	return nil
+}
+
+// record stores the action in the audit trail. Action is already done, so failure to record it is only logged.
+func (s *Service) record(actor *Actor, action string, targetUserID *int, details string, actionErr error) {
+	result := ResultSuccess
+	if actionErr != nil {
+		result = ResultFailure
+		details = strings.TrimSpace(details + " error=" + actionErr.Error())
+	}
+
+	err := s.actionsRepo.InsertAction(context.Background(), &Action{
+		Actor:        actor.Name,
+		Action:       action,
+		TargetUserID: targetUserID,
+		Details:      details,
+		IP:           actor.IP,
+		UserAgent:    actor.UserAgent,
+		Result:       result,
+		CreatedAt:    time.Now().UTC(),
+	})
+	if err != nil {
+		s.log.Error().
+			Str("actor", actor.Name).
+			Str("action", action).
+			Msgf("Error while recording admin action: %v", err.Error())
+	}
+}
+
+// normalizePage returns page and page size within allowed range.
+func normalizePage(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	return page, pageSize
+}
+
+func pagesCount(count int64, pageSize int) int {
+	return int((count + int64(pageSize) - 1) / int64(pageSize))
+}
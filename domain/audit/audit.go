@@ -0,0 +1,104 @@
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// Security-relevant actions recorded in the audit log.
+const (
+	// ActionSignIn is a sign in with email and password.
+	ActionSignIn = "sign_in"
+	// ActionKeyUnlock is a decryption of the user xpriv, or of the team wallet key of the user, with the password.
+	ActionKeyUnlock = "key_unlock"
+	// ActionContactConfirm is a confirmation of the contact with TOTP passcode.
+	ActionContactConfirm = "contact_confirm"
+	// ActionPayment is a payment sent from the user wallet.
+	ActionPayment = "payment"
+	// ActionPasskeySignIn is a sign in with passkey.
+	ActionPasskeySignIn = "passkey_sign_in"
+	// ActionPasskeyUnlock is a decryption of the user xpriv with passkey.
+	ActionPasskeyUnlock = "passkey_unlock"
+	// ActionSpendingPINUnlock is a decryption of the user xpriv with spending PIN.
+	ActionSpendingPINUnlock = "spending_pin_unlock"
+)
+
+// Results of recorded actions.
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// Event is a struct that contains record of the security-relevant action.
+type Event struct {
+	ID        int64     `json:"id"`
+	UserID    *int      `json:"user_id,omitempty"`
+	Actor     string    `json:"actor"`
+	Action    string    `json:"action"`
+	Target    string    `json:"target,omitempty"`
+	Details   string    `json:"details,omitempty"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	Result    string    `json:"result"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// EventsFilter is a struct that contains conditions of audit events search, empty fields are not applied.
+type EventsFilter struct {
+	UserID *int
+	Action string
+	Result string
+	From   *time.Time
+	To     *time.Time
+}
+
+// EventsPage is a struct that contains page of audit events.
+type EventsPage struct {
+	Count  int64    `json:"count"`
+	Pages  int      `json:"pages"`
+	Events []*Event `json:"events"`
+}
+
+// Recorder defines method to record security-relevant actions.
+type Recorder interface {
+	Record(ctx context.Context, event *Event)
+}
+
+// Origin is a struct that contains the origin of the request in which the action is done.
+type Origin struct {
+	UserID    *int
+	IP        string
+	UserAgent string
+}
+
+type contextKey int
+
+var originContextKey contextKey
+
+// WithOrigin returns context carrying origin of the request.
+func WithOrigin(ctx context.Context, origin *Origin) context.Context {
+	return context.WithValue(ctx, originContextKey, origin)
+}
+
+// WithUser returns context carrying origin of the request made by the authenticated user.
+func WithUser(ctx context.Context, userID int) context.Context {
+	origin := *OriginFromContext(ctx)
+	origin.UserID = &userID
+	return WithOrigin(ctx, &origin)
+}
+
+// OriginFromContext returns origin of the request, empty origin is returned when context does not carry it.
+func OriginFromContext(ctx context.Context) *Origin {
+	if origin, ok := ctx.Value(originContextKey).(*Origin); ok {
+		return origin
+	}
+	return &Origin{}
+}
+
+// Result returns result of the action which failed with given error.
+func Result(err error) string {
+	if err != nil {
+		return ResultFailure
+	}
+	return ResultSuccess
+}
@@ -0,0 +1,11 @@
+package audit
+
+import (
+	"context"
+)
+
+// EventsRepository is an interface which defines methods for the append-only audit log.
+type EventsRepository interface {
+	InsertEvent(ctx context.Context, event *Event) error
+	GetEvents(ctx context.Context, filter *EventsFilter, page, pageSize int) ([]*Event, int64, error)
+}
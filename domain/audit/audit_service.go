@@ -0,0 +1,88 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+)
+
+const (
+	// defaultPageSize is the number of events returned when page size is not given.
+	defaultPageSize = 20
+	// maxPageSize is the max number of events returned in one page.
+	maxPageSize = 100
+)
+
+// Service records security-relevant actions to the audit log and provides access to it.
+type Service struct {
+	repo EventsRepository
+	log  *zerolog.Logger
+}
+
+// NewAuditService creates audit Service instance.
+func NewAuditService(repo EventsRepository, l *zerolog.Logger) *Service {
+	auditServiceLogger := l.With().Str("service", "audit-service").Logger()
+	return &Service{
+		repo: repo,
+		log:  &auditServiceLogger,
+	}
+}
+
+// Record stores the event completed with origin of the request carried by the context.
+// Recorded action is already done, so failure to store the event is only logged.
+func (s *Service) Record(ctx context.Context, event *Event) {
+	origin := OriginFromContext(ctx)
+	if event.UserID == nil {
+		event.UserID = origin.UserID
+	}
+	event.IP = origin.IP
+	event.UserAgent = origin.UserAgent
+	event.CreatedAt = time.Now().UTC()
+
+	if err := s.repo.InsertEvent(context.WithoutCancel(ctx), event); err != nil {
+		s.log.Error().
+			Str("action", event.Action).
+			Str("actor", event.Actor).
+			Str("result", event.Result).
+			Msgf("Error while recording audit event: %v", err.Error())
+	}
+}
+
+// GetUserEvents returns page of the security history of the user, the newest first.
+func (s *Service) GetUserEvents(userID, page, pageSize int) (*EventsPage, error) {
+	return s.GetEvents(&EventsFilter{UserID: &userID}, page, pageSize)
+}
+
+// GetEvents returns page of audit events matching the filter, the newest first.
+func (s *Service) GetEvents(filter *EventsFilter, page, pageSize int) (*EventsPage, error) {
+	page, pageSize = normalizePage(page, pageSize)
+
+	events, count, err := s.repo.GetEvents(context.Background(), filter, page, pageSize)
+	if err != nil {
+		s.log.Error().Msgf("Error while getting audit events: %v", err.Error())
+		return nil, spverrors.ErrGetAuditEvents
+	}
+
+	return &EventsPage{
+		Count:  count,
+		Pages:  int((count + int64(pageSize) - 1) / int64(pageSize)),
+		Events: events,
+	}, nil
+}
+
+// normalizePage returns page and page size within allowed range.
+func normalizePage(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	return page, pageSize
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 
 	backendconfig "github.com/bsv-blockchain/spv-wallet-web-backend/config"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
 )
 
 const cacheTTL = 10 * time.Minute
@@ -50,6 +52,15 @@ func (s *Service) GetPublicConfig() *PublicConfig {
 	return s.publicConfig
 }
 
+// PaymailDomains returns paymail domains served by SPV Wallet, or none when shared config cannot be fetched.
+func (s *Service) PaymailDomains() []string {
+	shared := s.GetSharedConfig()
+	if shared == nil {
+		return nil
+	}
+	return shared.PaymailDomains
+}
+
 func (s *Service) makeConfigs() {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
@@ -69,14 +80,57 @@ func (s *Service) makeConfigs() {
 	s.publicConfig = s.makePublicConfig(shared)
 }
 
+// RegistrationDomain returns paymail domain in which users can register new paymails.
+// Empty domain means the default domain; other domains must be supported by SPV Wallet and open for registration.
+func (s *Service) RegistrationDomain(domain string) (string, error) {
+	publicConfig := s.GetPublicConfig()
+	if publicConfig == nil {
+		return "", spverrors.ErrGetConfig
+	}
+
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if domain == "" {
+		domain = publicConfig.PaymailDomain
+	}
+
+	for _, paymailDomain := range publicConfig.PaymailDomains {
+		if paymailDomain.Domain != domain {
+			continue
+		}
+		if !paymailDomain.RegistrationOpen {
+			return "", spverrors.ErrPaymailRegistrationClosed
+		}
+		return domain, nil
+	}
+
+	return "", spverrors.ErrPaymailDomainNotAllowed
+}
+
 func (s *Service) makePublicConfig(shared *models.SharedConfig) *PublicConfig {
 	configuredPaymailDomain := viper.GetString(backendconfig.EnvPaymailDomain)
 	if !slices.Contains(shared.PaymailDomains, configuredPaymailDomain) {
 		s.log.Warn().Str("configuredPaymailDomain", configuredPaymailDomain).Msg("Configured paymail domain is not in the list of paymail domains from SPV Wallet")
+		if len(shared.PaymailDomains) > 0 {
+			configuredPaymailDomain = shared.PaymailDomains[0]
+		}
+	}
+
+	paymailDomains := make([]PaymailDomain, 0, len(shared.PaymailDomains))
+	for _, domain := range shared.PaymailDomains {
+		settings, err := backendconfig.GetPaymailDomainSettings(domain)
+		if err != nil {
+			s.log.Warn().Err(err).Str("domain", domain).Msg("Using default paymail domain settings")
+		}
+		paymailDomains = append(paymailDomains, PaymailDomain{
+			Domain:           domain,
+			RegistrationOpen: !settings.RegistrationClosed,
+		})
 	}
 
 	return &PublicConfig{
 		PaymailDomain:        configuredPaymailDomain,
+		PaymailDomains:       paymailDomains,
 		ExperimentalFeatures: shared.ExperimentalFeatures,
+		SSOEnabled:           viper.GetBool(backendconfig.EnvSSOEnabled),
 	}
 }
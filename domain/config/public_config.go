@@ -3,5 +3,13 @@ package config
 // PublicConfig represents a config that is exposed to the public.
 type PublicConfig struct {
 	PaymailDomain        string          `json:"paymail_domain"`
+	PaymailDomains       []PaymailDomain `json:"paymail_domains"`
 	ExperimentalFeatures map[string]bool `json:"experimental_features"`
+	SSOEnabled           bool            `json:"sso_enabled"`
+}
+
+// PaymailDomain represents paymail domain which can be selected by users.
+type PaymailDomain struct {
+	Domain           string `json:"domain"`
+	RegistrationOpen bool   `json:"registration_open"`
 }
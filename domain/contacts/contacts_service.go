@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/audit"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
 )
@@ -17,22 +18,24 @@ import (
 type Service struct {
 	adminWalletClient   users.AdminWalletClient
 	walletClientFactory users.WalletClientFactory
+	recorder            audit.Recorder
 	log                 *zerolog.Logger
 }
 
 // NewContactsService creates a new instance of the contact.Service
-func NewContactsService(adminWalletClient users.AdminWalletClient, walletClientFactory users.WalletClientFactory, log *zerolog.Logger) *Service {
+func NewContactsService(adminWalletClient users.AdminWalletClient, walletClientFactory users.WalletClientFactory, recorder audit.Recorder, log *zerolog.Logger) *Service {
 	transactionServiceLogger := log.With().Str("service", "contacts-service").Logger()
 	return &Service{
 		adminWalletClient:   adminWalletClient,
 		walletClientFactory: walletClientFactory,
+		recorder:            recorder,
 		log:                 &transactionServiceLogger,
 	}
 }
 
 // UpsertContact creates or updates a contact
-func (s *Service) UpsertContact(ctx context.Context, xPriv, paymail, fullName, requesterPaymail string, metadata map[string]any) (*models.Contact, error) {
-	userWalletClient, err := s.walletClientFactory.CreateWithXpriv(xPriv)
+func (s *Service) UpsertContact(ctx context.Context, accessKey, paymail, fullName, requesterPaymail string, metadata map[string]any) (*models.Contact, error) {
+	userWalletClient, err := s.walletClientFactory.CreateWithAccessKey(accessKey)
 	if err != nil {
 		return nil, spverrors.ErrUpsertContact.Wrap(err)
 	}
@@ -83,6 +86,18 @@ func (s *Service) ConfirmContact(ctx context.Context, xPriv string, contact *mod
 	}
 
 	err = userWalletClient.ConfirmContact(ctx, contact, passcode, requesterPaymail, getConfPeriod(), getConfDigits())
+	event := &audit.Event{
+		Actor:  requesterPaymail,
+		Action: audit.ActionContactConfirm,
+		Result: audit.Result(err),
+	}
+	if contact != nil {
+		event.Target = contact.Paymail
+	}
+	if err != nil {
+		event.Details = err.Error()
+	}
+	s.recorder.Record(ctx, event)
 	if err != nil {
 		s.log.Debug().Msgf("Error during confirming contact: %s", err.Error())
 		return spverrors.ErrConfirmContact
@@ -0,0 +1,119 @@
+package contacts
+
+import (
+	"context"
+	"time"
+
+	"github.com/bsv-blockchain/spv-wallet/models"
+	"github.com/bsv-blockchain/spv-wallet/models/filter"
+	"github.com/bsv-blockchain/spv-wallet/models/response"
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/notification"
+)
+
+// watchPageSize is a number of contacts fetched with a single request while watching for changes.
+const watchPageSize = 100
+
+// WatchContacts periodically checks user contacts and notifies about new invitations and confirmed contacts.
+// Contacts existing at the moment of the first check are treated as already known, except invitations still awaiting
+// acceptance, which are notified, so invitations received while the user was offline are not missed.
+// It blocks until the provided context is done.
+func (s *Service) WatchContacts(ctx context.Context, accessKey string, notify func(event notification.ContactEvent)) {
+	userWalletClient, err := s.walletClientFactory.CreateWithAccessKey(accessKey)
+	if err != nil {
+		s.log.Error().Msgf("Cannot watch contacts: %v", err.Error())
+		return
+	}
+
+	var known map[string]response.ContactStatus
+	check := func() {
+		current, err := fetchAllContacts(ctx, userWalletClient)
+		if err != nil {
+			s.log.Debug().Msgf("Error during checking contacts for changes: %s", err.Error())
+			return
+		}
+
+		events := awaitingInvitationEvents(current)
+		if known != nil {
+			events = contactEvents(known, current)
+		}
+		for _, event := range events {
+			notify(event)
+		}
+		known = contactStatuses(current)
+	}
+
+	check()
+
+	ticker := time.NewTicker(getNotificationsInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			check()
+		}
+	}
+}
+
+func fetchAllContacts(ctx context.Context, userWalletClient users.UserWalletClient) ([]*models.Contact, error) {
+	contacts := make([]*models.Contact, 0)
+	for page := 1; ; page++ {
+		resp, err := userWalletClient.GetContacts(ctx, nil, nil, &filter.QueryParams{Page: page, PageSize: watchPageSize})
+		if err != nil {
+			return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+		}
+
+		contacts = append(contacts, resp.Content...)
+		if len(resp.Content) == 0 || page >= resp.Page.TotalPages {
+			return contacts, nil
+		}
+	}
+}
+
+func contactStatuses(contacts []*models.Contact) map[string]response.ContactStatus {
+	statuses := make(map[string]response.ContactStatus, len(contacts))
+	for _, contact := range contacts {
+		statuses[contact.ID] = contact.Status
+	}
+	return statuses
+}
+
+// contactEvents returns events for contacts which are new or which status has changed since the last check.
+func contactEvents(known map[string]response.ContactStatus, current []*models.Contact) []notification.ContactEvent {
+	events := make([]notification.ContactEvent, 0)
+	for _, contact := range current {
+		if status, ok := known[contact.ID]; ok && status == contact.Status {
+			continue
+		}
+
+		//nolint:exhaustive // only awaiting and confirmed statuses are notified
+		switch contact.Status {
+		case response.ContactAwaitAccept:
+			events = append(events, notification.PrepareContactEvent(notification.ContactInvitationEventType, contact))
+		case response.ContactConfirmed:
+			events = append(events, notification.PrepareContactEvent(notification.ContactConfirmedEventType, contact))
+		}
+	}
+	return events
+}
+
+// awaitingInvitationEvents returns events for invitations which are awaiting acceptance.
+func awaitingInvitationEvents(current []*models.Contact) []notification.ContactEvent {
+	events := make([]notification.ContactEvent, 0)
+	for _, contact := range current {
+		if contact.Status == response.ContactAwaitAccept {
+			events = append(events, notification.PrepareContactEvent(notification.ContactInvitationEventType, contact))
+		}
+	}
+	return events
+}
+
+func getNotificationsInterval() time.Duration {
+	return viper.GetDuration(config.EnvContactsNotificationsInterval)
+}
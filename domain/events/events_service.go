@@ -0,0 +1,87 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bsv-blockchain/spv-wallet/models"
+	"github.com/rs/zerolog"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/notification"
+)
+
+// AllUsers is used as user id of events which are not related to a particular user.
+const AllUsers = 0
+
+// Subscriber is a function called with every event published for the user.
+type Subscriber func(userID int, event any)
+
+// Service maps events received from SPV Wallet to users and fans them out to internal subscribers.
+type Service struct {
+	repo users.Repository
+	log  *zerolog.Logger
+
+	mutex       sync.RWMutex
+	subscribers []Subscriber
+}
+
+// NewEventsService creates new events service.
+func NewEventsService(repo users.Repository, log *zerolog.Logger) *Service {
+	eventsServiceLogger := log.With().Str("service", "events-service").Logger()
+	return &Service{
+		repo: repo,
+		log:  &eventsServiceLogger,
+	}
+}
+
+// Subscribe registers subscriber called with every published event.
+func (s *Service) Subscribe(subscriber Subscriber) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.subscribers = append(s.subscribers, subscriber)
+}
+
+// Publish passes the event of the user to all subscribers.
+func (s *Service) Publish(userID int, event any) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	for _, subscriber := range s.subscribers {
+		subscriber(userID, event)
+	}
+}
+
+// HandleTransactionEvent publishes transaction event for every user whose xpub is affected by the transaction.
+func (s *Service) HandleTransactionEvent(event *models.TransactionEvent) {
+	for _, xpubID := range affectedXpubIDs(event) {
+		user, err := s.repo.GetUserByXpubID(context.Background(), xpubID)
+		if err != nil {
+			s.log.Error().Str("xpubID", xpubID).Msgf("Error while getting user by xpub id: %v", err.Error())
+			continue
+		}
+		if user == nil {
+			// Xpub is not registered by this backend.
+			continue
+		}
+
+		s.Publish(user.ID, notification.PrepareWalletTransactionEvent(event.TransactionID, event.Status, event.XpubOutputValue[xpubID]))
+	}
+}
+
+// HandleStringEvent publishes generic message to all users.
+func (s *Service) HandleStringEvent(event *models.StringEvent) {
+	s.Publish(AllUsers, notification.PrepareWalletMessageEvent(event.Value))
+}
+
+func affectedXpubIDs(event *models.TransactionEvent) []string {
+	xpubIDs := make([]string, 0, len(event.XpubOutputValue)+1)
+	if event.XPubID != "" {
+		xpubIDs = append(xpubIDs, event.XPubID)
+	}
+	for xpubID := range event.XpubOutputValue {
+		if xpubID != event.XPubID {
+			xpubIDs = append(xpubIDs, xpubID)
+		}
+	}
+	return xpubIDs
+}
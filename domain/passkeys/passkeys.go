@@ -0,0 +1,20 @@
+package passkeys
+
+import (
+	"time"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+// Passkey is a struct that contains passkey of the user.
+// Xpriv of the user is encrypted with the PRF output of the passkey, which is never stored.
+type Passkey struct {
+	ID           int                  `json:"id"`
+	UserID       int                  `json:"-"`
+	Name         string               `json:"name"`
+	CredentialID []byte               `json:"-"`
+	Credential   *webauthn.Credential `json:"-"`
+	Xpriv        string               `json:"-"`
+	LastUsedAt   *time.Time           `json:"last_used_at,omitempty"`
+	CreatedAt    time.Time            `json:"created_at"`
+}
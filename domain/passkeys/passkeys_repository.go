@@ -0,0 +1,17 @@
+package passkeys
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+// Repository is an interface which defines methods for passkeys repository.
+type Repository interface {
+	InsertPasskey(ctx context.Context, passkey *Passkey) error
+	GetPasskeys(ctx context.Context, userID int) ([]*Passkey, error)
+	GetPasskeyByCredentialID(ctx context.Context, credentialID []byte) (*Passkey, error)
+	UpdatePasskeyCredential(ctx context.Context, id int, credential *webauthn.Credential, usedAt time.Time) error
+	DeletePasskey(ctx context.Context, userID, id int) (bool, error)
+}
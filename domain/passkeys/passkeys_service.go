@@ -0,0 +1,519 @@
+package passkeys
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/audit"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/encryption"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+)
+
+const (
+	// maxPasskeyNameLength is the max number of characters of the passkey name.
+	maxPasskeyNameLength = 64
+	// prfExtension is the identifier of the WebAuthn PRF extension.
+	prfExtension = "prf"
+	// prfOutputSize is the number of bytes of the PRF output.
+	prfOutputSize = 32
+)
+
+// prfSalt is the input of the PRF evaluated by the passkey, its output is the secret encrypting the xpriv.
+var prfSalt = sha256.Sum256([]byte("spv-wallet-web-backend/passkey/xpriv"))
+
+// errUnknownPasskey is returned when the passkey used to sign in is not registered.
+var errUnknownPasskey = errors.New("unknown passkey")
+
+// Passkeys replace the password when the user signs in or confirms a payment. The xpriv is encrypted with the output
+// of the PRF extension, which the authenticator returns only with the assertion, so the key is recoverable only after
+// a successful assertion. Passkeys are discoverable, so the user is identified by the passkey at sign in.
+// State of the ceremony is returned by begin and has to be kept by the caller until it is finished.
+
+// Service represents passkeys service and provide access to repository.
+type Service struct {
+	repo         Repository
+	usersRepo    users.Repository
+	usersService *users.UserService
+	recorder     audit.Recorder
+	webAuthn     *webauthn.WebAuthn
+	log          *zerolog.Logger
+}
+
+// NewPasskeysService creates passkeys Service instance.
+func NewPasskeysService(repo Repository, usersRepo users.Repository, usersService *users.UserService, recorder audit.Recorder, l *zerolog.Logger) (*Service, error) {
+	webAuthn, err := webauthn.New(&webauthn.Config{
+		RPID:          viper.GetString(config.EnvPasskeysRPID),
+		RPDisplayName: viper.GetString(config.EnvPasskeysRPDisplayName),
+		RPOrigins:     viper.GetStringSlice(config.EnvPasskeysRPOrigins),
+		AuthenticatorSelection: protocol.AuthenticatorSelection{
+			ResidentKey:      protocol.ResidentKeyRequirementRequired,
+			UserVerification: protocol.VerificationRequired,
+		},
+		Timeouts: webauthn.TimeoutsConfig{
+			Login:        webauthn.TimeoutConfig{Enforce: true},
+			Registration: webauthn.TimeoutConfig{Enforce: true},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid passkeys config: %w", err)
+	}
+
+	passkeysServiceLogger := l.With().Str("service", "passkeys-service").Logger()
+	return &Service{
+		repo:         repo,
+		usersRepo:    usersRepo,
+		usersService: usersService,
+		recorder:     recorder,
+		webAuthn:     webAuthn,
+		log:          &passkeysServiceLogger,
+	}, nil
+}
+
+// BeginRegistration returns options of the passkey creation for the user and state of the registration.
+func (s *Service) BeginRegistration(ctx context.Context, userID int) (*protocol.CredentialCreation, string, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	creation, session, err := s.webAuthn.BeginRegistration(user,
+		webauthn.WithExclusions(webauthn.Credentials(user.WebAuthnCredentials()).CredentialDescriptors()),
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementRequired),
+		webauthn.WithExtensions(prfEvaluation()),
+	)
+	if err != nil {
+		return nil, "", spverrors.ErrPasskeyRegistration.Wrap(err)
+	}
+
+	ceremony, err := json.Marshal(session)
+	if err != nil {
+		return nil, "", spverrors.ErrPasskeyRegistration.Wrap(err)
+	}
+
+	return creation, string(ceremony), nil
+}
+
+// FinishRegistration verifies the created passkey and stores it with the xpriv of the user encrypted with its PRF output.
+func (s *Service) FinishRegistration(ctx context.Context, userID int, password, name, ceremony string, response []byte) (*Passkey, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxPasskeyNameLength {
+		return nil, spverrors.ErrInvalidPasskeyName
+	}
+
+	xpriv, err := s.usersService.GetUserXpriv(ctx, userID, password)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error is already an SPVError
+	}
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := parseCeremony(ceremony)
+	if err != nil {
+		return nil, spverrors.ErrPasskeyRegistration
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(response)
+	if err != nil {
+		s.log.Warn().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Invalid passkey creation response: %v", err.Error())
+		return nil, spverrors.ErrPasskeyRegistration
+	}
+
+	credential, err := s.webAuthn.CreateCredential(user, *session, parsed)
+	if err != nil {
+		s.log.Warn().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while verifying passkey creation: %v", err.Error())
+		return nil, spverrors.ErrPasskeyRegistration
+	}
+
+	secret, ok := prfOutput(parsed.ClientExtensionResults)
+	if !ok {
+		return nil, spverrors.ErrPasskeyPRFUnsupported
+	}
+
+	encryptedXpriv, err := encryptXpriv(secret, xpriv)
+	if err != nil {
+		return nil, spverrors.ErrPasskeyRegistration.Wrap(err)
+	}
+
+	passkey := &Passkey{
+		UserID:       userID,
+		Name:         name,
+		CredentialID: credential.ID,
+		Credential:   credential,
+		Xpriv:        encryptedXpriv,
+		CreatedAt:    time.Now().UTC(),
+	}
+	if err = s.repo.InsertPasskey(ctx, passkey); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while inserting passkey: %v", err.Error())
+		return nil, spverrors.ErrPasskeyRegistration
+	}
+
+	return passkey, nil
+}
+
+// GetPasskeys returns passkeys of the user.
+func (s *Service) GetPasskeys(ctx context.Context, userID int) ([]*Passkey, error) {
+	passkeys, err := s.repo.GetPasskeys(ctx, userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting passkeys: %v", err.Error())
+		return nil, spverrors.ErrGetPasskeys
+	}
+	return passkeys, nil
+}
+
+// DeletePasskey deletes passkey of the user, the password is validated first, so a stolen session cannot remove passkeys.
+func (s *Service) DeletePasskey(ctx context.Context, userID, passkeyID int, password string) error {
+	if _, err := s.usersService.GetUserXpriv(ctx, userID, password); err != nil {
+		return err //nolint:wrapcheck // error is already an SPVError
+	}
+
+	deleted, err := s.repo.DeletePasskey(ctx, userID, passkeyID)
+	if err != nil {
+		s.log.Error().
+			Str("passkeyID", strconv.Itoa(passkeyID)).
+			Msgf("Error while deleting passkey: %v", err.Error())
+		return spverrors.ErrDeletePasskey
+	}
+	if !deleted {
+		return spverrors.ErrPasskeyNotFound
+	}
+	return nil
+}
+
+// BeginSignIn returns options of the assertion with any passkey and state of the sign in.
+func (s *Service) BeginSignIn() (*protocol.CredentialAssertion, string, error) {
+	assertion, session, err := s.webAuthn.BeginDiscoverableLogin(webauthn.WithAssertionExtensions(prfEvaluation()))
+	if err != nil {
+		return nil, "", spverrors.ErrPasskeyAuthentication.Wrap(err)
+	}
+	return beginAssertion(assertion, session)
+}
+
+// FinishSignIn signs in the user of the passkey with the xpriv unlocked by the assertion, every attempt is recorded in the audit log.
+func (s *Service) FinishSignIn(ctx context.Context, ceremony string, response []byte) (signInUser *users.AuthenticatedUser, err error) {
+	var passkey *Passkey
+	var user *webAuthnUser
+	defer func() {
+		s.recordAuditEvent(ctx, audit.ActionPasskeySignIn, user, passkey, err)
+	}()
+
+	session, parsed, err := parseAssertion(ceremony, response)
+	if err != nil {
+		return nil, spverrors.ErrPasskeyAuthentication
+	}
+
+	_, credential, err := s.webAuthn.ValidatePasskeyLogin(func(rawID, userHandle []byte) (webauthn.User, error) {
+		var lookupErr error
+		if passkey, user, lookupErr = s.getPasskeyUser(ctx, rawID, userHandle); lookupErr != nil {
+			return nil, lookupErr
+		}
+		return user, nil
+	}, *session, parsed)
+	if err != nil {
+		s.log.Warn().Msgf("Error while verifying passkey sign in: %v", err.Error())
+		return nil, spverrors.ErrPasskeyAuthentication
+	}
+
+	// Users of the identity provider sign in only with it, their passkeys only confirm payments.
+	if user.SSOSubject != "" {
+		return nil, spverrors.ErrPasskeyAuthentication
+	}
+
+	xpriv, err := s.unlockXpriv(ctx, passkey, credential, parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.usersService.SignInUnlockedUser(user.User, xpriv) //nolint:wrapcheck // error is already an SPVError
+}
+
+// BeginConfirmation returns options of the assertion with passkeys of the user and state of the confirmation.
+func (s *Service) BeginConfirmation(ctx context.Context, userID int) (*protocol.CredentialAssertion, string, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(user.passkeys) == 0 {
+		return nil, "", spverrors.ErrPasskeyNotFound
+	}
+
+	assertion, session, err := s.webAuthn.BeginLogin(user, webauthn.WithAssertionExtensions(prfEvaluation()))
+	if err != nil {
+		return nil, "", spverrors.ErrPasskeyAuthentication.Wrap(err)
+	}
+	return beginAssertion(assertion, session)
+}
+
+// UnlockXpriv returns xpriv of the user decrypted with the PRF output of the passkey assertion, every attempt is recorded in the audit log.
+func (s *Service) UnlockXpriv(ctx context.Context, userID int, ceremony string, response []byte) (xpriv string, err error) {
+	var passkey *Passkey
+	var user *webAuthnUser
+	defer func() {
+		s.recordAuditEvent(ctx, audit.ActionPasskeyUnlock, user, passkey, err)
+	}()
+
+	user, err = s.getUser(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	session, parsed, err := parseAssertion(ceremony, response)
+	if err != nil {
+		return "", spverrors.ErrPasskeyAuthentication
+	}
+
+	credential, err := s.webAuthn.ValidateLogin(user, *session, parsed)
+	if err != nil {
+		s.log.Warn().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while verifying passkey confirmation: %v", err.Error())
+		return "", spverrors.ErrPasskeyAuthentication
+	}
+
+	passkey = user.passkey(credential.ID)
+	return s.unlockXpriv(ctx, passkey, credential, parsed)
+}
+
+// unlockXpriv decrypts the xpriv with the PRF output of the verified assertion and stores updated sign counter of the passkey.
+func (s *Service) unlockXpriv(ctx context.Context, passkey *Passkey, credential *webauthn.Credential, parsed *protocol.ParsedCredentialAssertionData) (string, error) {
+	if passkey == nil {
+		return "", spverrors.ErrPasskeyAuthentication
+	}
+
+	// Sign counter which did not increase means the passkey may have been cloned.
+	if credential.Authenticator.CloneWarning {
+		s.log.Warn().
+			Str("passkeyID", strconv.Itoa(passkey.ID)).
+			Msg("Sign counter of passkey did not increase")
+		return "", spverrors.ErrPasskeyAuthentication
+	}
+
+	secret, ok := prfOutput(parsed.ClientExtensionResults)
+	if !ok {
+		return "", spverrors.ErrPasskeyAuthentication
+	}
+
+	xpriv, err := decryptXpriv(secret, passkey.Xpriv)
+	if err != nil {
+		return "", spverrors.ErrPasskeyAuthentication
+	}
+
+	if err = s.repo.UpdatePasskeyCredential(ctx, passkey.ID, credential, time.Now().UTC()); err != nil {
+		s.log.Error().
+			Str("passkeyID", strconv.Itoa(passkey.ID)).
+			Msgf("Error while updating passkey credential: %v", err.Error())
+	}
+
+	return xpriv, nil
+}
+
+// getUser returns the user with passkeys.
+func (s *Service) getUser(ctx context.Context, userID int) (*webAuthnUser, error) {
+	user, err := s.usersRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting user by id: %v", err.Error())
+		return nil, spverrors.ErrGetUser
+	}
+
+	passkeys, err := s.GetPasskeys(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webAuthnUser{User: user, passkeys: passkeys}, nil
+}
+
+// getPasskeyUser returns the passkey used to sign in with its user.
+func (s *Service) getPasskeyUser(ctx context.Context, credentialID, userHandle []byte) (*Passkey, *webAuthnUser, error) {
+	passkey, err := s.repo.GetPasskeyByCredentialID(ctx, credentialID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("internal error: %w", err)
+	}
+	if passkey == nil || string(userHandle) != string(webAuthnID(passkey.UserID)) {
+		return nil, nil, errUnknownPasskey
+	}
+
+	user, err := s.usersRepo.GetUserByID(ctx, passkey.UserID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("internal error: %w", err)
+	}
+
+	return passkey, &webAuthnUser{User: user, passkeys: []*Passkey{passkey}}, nil
+}
+
+// recordAuditEvent records the passkey action of the user, failed action is recorded with its error.
+func (s *Service) recordAuditEvent(ctx context.Context, action string, user *webAuthnUser, passkey *Passkey, err error) {
+	event := &audit.Event{
+		Action: action,
+		Result: audit.Result(err),
+	}
+	if user != nil {
+		event.UserID = &user.ID
+		event.Actor = user.Email
+	}
+	if passkey != nil {
+		event.Target = passkey.Name
+	}
+	if err != nil {
+		event.Details = err.Error()
+	}
+	s.recorder.Record(ctx, event)
+}
+
+// webAuthnUser is the user with passkeys, the user handle of passkeys is the user id.
+type webAuthnUser struct {
+	*users.User
+	passkeys []*Passkey
+}
+
+// WebAuthnID returns the user handle of the user.
+func (u *webAuthnUser) WebAuthnID() []byte {
+	return webAuthnID(u.ID)
+}
+
+// WebAuthnName returns the name of the user shown by the authenticator.
+func (u *webAuthnUser) WebAuthnName() string {
+	return u.Email
+}
+
+// WebAuthnDisplayName returns the display name of the user shown by the authenticator.
+func (u *webAuthnUser) WebAuthnDisplayName() string {
+	return u.Paymail
+}
+
+// WebAuthnCredentials returns credentials of passkeys of the user.
+func (u *webAuthnUser) WebAuthnCredentials() []webauthn.Credential {
+	credentials := make([]webauthn.Credential, 0, len(u.passkeys))
+	for _, passkey := range u.passkeys {
+		credentials = append(credentials, *passkey.Credential)
+	}
+	return credentials
+}
+
+// passkey returns passkey of the user with the credential id.
+func (u *webAuthnUser) passkey(credentialID []byte) *Passkey {
+	for _, passkey := range u.passkeys {
+		if string(passkey.CredentialID) == string(credentialID) {
+			return passkey
+		}
+	}
+	return nil
+}
+
+func webAuthnID(userID int) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(userID)) //nolint:gosec // user ids are positive
+}
+
+// prfEvaluation returns extensions asking the authenticator to evaluate the PRF with the salt.
+func prfEvaluation() protocol.AuthenticationExtensions {
+	return protocol.AuthenticationExtensions{
+		prfExtension: map[string]any{
+			"eval": map[string]any{
+				"first": protocol.URLEncodedBase64(prfSalt[:]),
+			},
+		},
+	}
+}
+
+// prfOutput returns the PRF output from client extension results, encoded as base64url by the client.
+func prfOutput(results protocol.AuthenticationExtensionsClientOutputs) (string, bool) {
+	prf, ok := results[prfExtension].(map[string]any)
+	if !ok {
+		return "", false
+	}
+	prfResults, ok := prf["results"].(map[string]any)
+	if !ok {
+		return "", false
+	}
+	first, ok := prfResults["first"].(string)
+	if !ok {
+		return "", false
+	}
+	output, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(first, "="))
+	if err != nil || len(output) != prfOutputSize {
+		return "", false
+	}
+	return hex.EncodeToString(output), true
+}
+
+func beginAssertion(assertion *protocol.CredentialAssertion, session *webauthn.SessionData) (*protocol.CredentialAssertion, string, error) {
+	ceremony, err := json.Marshal(session)
+	if err != nil {
+		return nil, "", spverrors.ErrPasskeyAuthentication.Wrap(err)
+	}
+	return assertion, string(ceremony), nil
+}
+
+func parseAssertion(ceremony string, response []byte) (*webauthn.SessionData, *protocol.ParsedCredentialAssertionData, error) {
+	session, err := parseCeremony(ceremony)
+	if err != nil {
+		return nil, nil, err
+	}
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(response)
+	if err != nil {
+		return nil, nil, err //nolint:wrapcheck // error replaced by the caller
+	}
+	return session, parsed, nil
+}
+
+func parseCeremony(ceremony string) (*webauthn.SessionData, error) {
+	var session webauthn.SessionData
+	if err := json.Unmarshal([]byte(ceremony), &session); err != nil {
+		return nil, err //nolint:wrapcheck // error replaced by the caller
+	}
+	return &session, nil
+}
+
+// encryptXpriv encrypts the xpriv with the PRF output.
+func encryptXpriv(secret, xpriv string) (string, error) {
+	hashedSecret, err := encryption.Hash(secret)
+	if err != nil {
+		return "", err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	return encryption.Encrypt(hashedSecret, xpriv) //nolint:wrapcheck // error wrapped higher in call stack
+}
+
+// decryptXpriv decrypts the xpriv with the PRF output.
+func decryptXpriv(secret, encryptedXpriv string) (string, error) {
+	hashedSecret, err := encryption.Hash(secret)
+	if err != nil {
+		return "", fmt.Errorf("internal error: %w", err)
+	}
+
+	xpriv := encryption.Decrypt(hashedSecret, encryptedXpriv)
+	if xpriv == "" {
+		return "", spverrors.ErrInvalidCredentials
+	}
+
+	return xpriv, nil
+}
@@ -0,0 +1,76 @@
+package paymail
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// resolutionCache is a concurrent-safe cache of paymail resolutions limited in size,
+// which evicts the least recently used resolution when it's full.
+type resolutionCache struct {
+	mutex   sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type cachedResolution struct {
+	address    string
+	resolution *Resolution
+	expiresAt  time.Time
+}
+
+func newResolutionCache() *resolutionCache {
+	return &resolutionCache{
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// get returns not expired resolution of the address.
+func (c *resolutionCache) get(address string) *Resolution {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[address]
+	if !ok {
+		return nil
+	}
+	entry := entryOf(element)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(element)
+		delete(c.entries, address)
+		return nil
+	}
+	c.order.MoveToFront(element)
+	return entry.resolution
+}
+
+// put stores resolution of the address, evicting the least recently used ones above the max entries.
+func (c *resolutionCache) put(address string, resolution *Resolution, ttl time.Duration, maxEntries int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if maxEntries <= 0 {
+		return
+	}
+
+	entry := &cachedResolution{address: address, resolution: resolution, expiresAt: time.Now().Add(ttl)}
+	if element, ok := c.entries[address]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+	} else {
+		c.entries[address] = c.order.PushFront(entry)
+	}
+
+	for c.order.Len() > maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, entryOf(oldest).address)
+	}
+}
+
+func entryOf(element *list.Element) *cachedResolution {
+	entry, _ := element.Value.(*cachedResolution)
+	return entry
+}
@@ -0,0 +1,70 @@
+package paymail
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// maxRedirects limits number of redirects followed by paymail requests.
+const maxRedirects = 3
+
+// Shared address space used by carrier-grade NAT, which is not routable in the internet.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// NewPublicHTTPClient creates http client for paymail services, which are selected by other parties, so it connects
+// only to public addresses to not let them reach services in the network of the backend.
+func NewPublicHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		// Address is checked after resolving the host, so the host can't resolve to a different address later.
+		Control: func(_, address string, _ syscall.RawConn) error {
+			return checkPublicAddress(address)
+		},
+	}
+	transport := &http.Transport{
+		// Proxy would be dialed instead of the paymail service, so its address would be checked.
+		Proxy:               nil,
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: timeout,
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return errors.New("too many redirects")
+			}
+			return checkURL(req.URL)
+		},
+	}
+}
+
+// checkURL allows only https urls of paymail services.
+func checkURL(u *url.URL) error {
+	if u.Scheme != "https" {
+		return fmt.Errorf("paymail service url %s is not https", u.Redacted())
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("paymail service url %s has no host", u.Redacted())
+	}
+	return nil
+}
+
+// checkPublicAddress allows only addresses routable in the internet, e.g. not loopback, private or link-local ones.
+func checkPublicAddress(address string) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid paymail service address %s: %w", address, err)
+	}
+	ip := addrPort.Addr().Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() || sharedAddressSpace.Contains(ip) {
+		return fmt.Errorf("paymail service address %s is not public", ip)
+	}
+	return nil
+}
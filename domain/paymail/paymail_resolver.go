@@ -0,0 +1,256 @@
+package paymail
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+)
+
+// Paymail capabilities (BRFC identifiers) used by the resolver.
+const (
+	capabilityPKI                   = "pki"
+	capabilityPublicProfile         = "f12f968c92d6"
+	capabilityP2PPaymentDestination = "2a40af698840"
+	capabilityP2PReceiveTransaction = "5f1323cddf31"
+)
+
+// maxResponseSize limits size of responses read from paymail services.
+const maxResponseSize = 1 << 20
+
+// maxProfileFieldLength limits length of public profile fields kept in cache.
+const maxProfileFieldLength = 2048
+
+var addressRegex = regexp.MustCompile(`^[a-z0-9._+-]+@[a-z0-9-]+(\.[a-z0-9-]+)+$`)
+
+// Resolution represents paymail address resolved with capability discovery.
+type Resolution struct {
+	Address string `json:"address"`
+	Alias   string `json:"alias"`
+	Domain  string `json:"domain"`
+	PubKey  string `json:"pubKey"`
+	P2P     bool   `json:"p2p"`
+	Name    string `json:"name,omitempty"`
+	Avatar  string `json:"avatar,omitempty"`
+}
+
+// HostLookup returns host and port of the paymail service of the domain.
+type HostLookup func(ctx context.Context, domain string) string
+
+// LocalDomains returns paymail domains served by SPV Wallet of the backend.
+type LocalDomains func() []string
+
+// Resolver resolves paymail addresses and caches the results.
+type Resolver struct {
+	client       *http.Client
+	lookup       HostLookup
+	localDomains LocalDomains
+	log          *zerolog.Logger
+	cache        *resolutionCache
+}
+
+// NewResolver creates paymail resolver using the http client and lookup of paymail service hosts.
+// Client should connect only to public addresses, see NewPublicHTTPClient.
+func NewResolver(client *http.Client, lookup HostLookup, localDomains LocalDomains, log *zerolog.Logger) *Resolver {
+	resolverLogger := log.With().Str("service", "paymail-resolver").Logger()
+	return &Resolver{
+		client:       client,
+		lookup:       lookup,
+		localDomains: localDomains,
+		log:          &resolverLogger,
+		cache:        newResolutionCache(),
+	}
+}
+
+// LookupSRV finds paymail service host with bsvalias SRV record, falling back to the domain itself.
+func LookupSRV(ctx context.Context, domain string) string {
+	_, records, err := net.DefaultResolver.LookupSRV(ctx, "bsvalias", "tcp", domain)
+	if err != nil || len(records) == 0 {
+		return net.JoinHostPort(domain, "443")
+	}
+	target := strings.TrimSuffix(records[0].Target, ".")
+	return net.JoinHostPort(target, strconv.Itoa(int(records[0].Port)))
+}
+
+// ValidateAddress checks format of paymail address and returns it normalized.
+func ValidateAddress(address string) (string, error) {
+	address = strings.ToLower(strings.TrimSpace(address))
+	if !addressRegex.MatchString(address) {
+		return "", spverrors.ErrInvalidPaymailAddress
+	}
+	return address, nil
+}
+
+// Resolve performs capability discovery of the paymail, checks if it exists and gets its public profile.
+func (r *Resolver) Resolve(ctx context.Context, address string) (*Resolution, error) {
+	address, err := ValidateAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if resolution := r.cache.get(address); resolution != nil {
+		return resolution, nil
+	}
+
+	alias, domain, _ := strings.Cut(address, "@")
+	capabilities, err := r.getCapabilities(ctx, domain)
+	if err != nil {
+		r.log.Debug().Msgf("Cannot get capabilities of %s: %v", domain, err.Error())
+		return nil, spverrors.ErrPaymailDomainNotSupported
+	}
+
+	pkiURL, ok := capabilities[capabilityPKI].(string)
+	if !ok {
+		return nil, spverrors.ErrPaymailDomainNotSupported
+	}
+	var pki struct {
+		PubKey string `json:"pubkey"`
+	}
+	status, err := r.get(ctx, capabilityURL(pkiURL, alias, domain), &pki)
+	if status == http.StatusNotFound {
+		return nil, spverrors.ErrPaymailNotFound
+	}
+	if err != nil {
+		r.log.Debug().Msgf("Cannot get PKI of %s: %v", address, err.Error())
+		return nil, spverrors.ErrResolvePaymail
+	}
+
+	resolution := &Resolution{
+		Address: address,
+		Alias:   alias,
+		Domain:  domain,
+		PubKey:  pki.PubKey,
+		P2P:     hasCapability(capabilities, capabilityP2PPaymentDestination) && hasCapability(capabilities, capabilityP2PReceiveTransaction),
+	}
+
+	// Public profile is optional, paymail is resolved also without it.
+	if profileURL, ok := capabilities[capabilityPublicProfile].(string); ok {
+		var profile struct {
+			Name   string `json:"name"`
+			Avatar string `json:"avatar"`
+		}
+		if _, err = r.get(ctx, capabilityURL(profileURL, alias, domain), &profile); err != nil {
+			r.log.Debug().Msgf("Cannot get public profile of %s: %v", address, err.Error())
+		} else if len(profile.Name) > maxProfileFieldLength || len(profile.Avatar) > maxProfileFieldLength {
+			r.log.Debug().Msgf("Public profile of %s is too large", address)
+		} else {
+			resolution.Name = profile.Name
+			resolution.Avatar = profile.Avatar
+		}
+	}
+
+	r.cache.put(address, resolution, viper.GetDuration(config.EnvCachePaymailTTL), viper.GetInt(config.EnvCachePaymailMaxEntries))
+	return resolution, nil
+}
+
+// ResolveRecipient resolves paymail and checks if it can receive P2P transactions.
+func (r *Resolver) ResolveRecipient(ctx context.Context, address string) (*Resolution, error) {
+	resolution, err := r.Resolve(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if !resolution.P2P {
+		return nil, spverrors.ErrPaymailP2PNotSupported
+	}
+	return resolution, nil
+}
+
+// ValidateRecipient resolves the recipient if it's a paymail and returns it normalized. Other recipients,
+// e.g. BSV addresses, are returned as they are and validated by SPV Wallet when transaction is drafted.
+// Paymails in domains of SPV Wallet of the backend are only normalized, SPV Wallet resolves them itself
+// and they can be served from addresses which are not public.
+func (r *Resolver) ValidateRecipient(ctx context.Context, recipient string) (string, error) {
+	recipient = strings.TrimSpace(recipient)
+	if !IsPaymail(recipient) {
+		return recipient, nil
+	}
+	address, err := ValidateAddress(recipient)
+	if err != nil {
+		return "", err
+	}
+	if _, domain, _ := strings.Cut(address, "@"); slices.Contains(r.localDomains(), domain) {
+		return address, nil
+	}
+	resolution, err := r.ResolveRecipient(ctx, address)
+	if err != nil {
+		return "", err
+	}
+	return resolution.Address, nil
+}
+
+// IsPaymail checks if the recipient is a paymail address rather than e.g. BSV address.
+func IsPaymail(recipient string) bool {
+	return strings.Contains(recipient, "@")
+}
+
+func (r *Resolver) getCapabilities(ctx context.Context, domain string) (map[string]any, error) {
+	var wellKnown struct {
+		Capabilities map[string]any `json:"capabilities"`
+	}
+	url := fmt.Sprintf("https://%s/.well-known/bsvalias", r.lookup(ctx, domain))
+	if _, err := r.get(ctx, url, &wellKnown); err != nil {
+		return nil, err
+	}
+	if len(wellKnown.Capabilities) == 0 {
+		return nil, fmt.Errorf("no capabilities found")
+	}
+	return wellKnown.Capabilities, nil
+}
+
+// get fetches JSON from the url and returns status code of the response.
+// Urls of capabilities are returned by paymail services, so only https urls are fetched.
+func (r *Resolver) get(ctx context.Context, url string, result any) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error during creating paymail request: %w", err)
+	}
+	if err = checkURL(req.URL); err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := r.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error during paymail request: %w", err)
+	}
+	defer res.Body.Close() //nolint:errcheck // best effort cleanup
+
+	if res.StatusCode != http.StatusOK {
+		return res.StatusCode, fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxResponseSize))
+	if err != nil {
+		return res.StatusCode, fmt.Errorf("error during reading response body: %w", err)
+	}
+	if err = json.Unmarshal(body, result); err != nil {
+		return res.StatusCode, fmt.Errorf("error during unmarshalling response body: %w", err)
+	}
+	return res.StatusCode, nil
+}
+
+// capabilityURL fills the paymail template of the capability url.
+func capabilityURL(template, alias, domain string) string {
+	return strings.NewReplacer("{alias}", alias, "{domain.tld}", domain).Replace(template)
+}
+
+func hasCapability(capabilities map[string]any, capability string) bool {
+	value, ok := capabilities[capability]
+	if !ok {
+		return false
+	}
+	enabled, isBool := value.(bool)
+	return !isBool || enabled
+}
@@ -15,12 +15,17 @@ import (
 	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
 )
 
+// RateChangedHandler is called when cached exchange rate changes.
+type RateChangedHandler func(rate float64)
+
 // Service is a service for fetching and caching BSV exchange rates.
 type Service struct {
 	exchangeRate *float64
+	log          *zerolog.Logger
 
 	mutex     sync.Mutex
 	lastFetch time.Time
+	handlers  []RateChangedHandler
 }
 
 // ExchangeRate is a struct that contains exchange rate data.
@@ -32,6 +37,7 @@ type ExchangeRate struct {
 func NewRatesService(log *zerolog.Logger) *Service {
 	s := &Service{
 		exchangeRate: nil,
+		log:          log,
 	}
 
 	err := s.loadExchangeRate()
@@ -52,22 +58,64 @@ func (s *Service) GetExchangeRate() (*float64, error) {
 	return s.exchangeRate, nil
 }
 
-func (s *Service) loadExchangeRate() error {
+// OnRateChanged registers handler called whenever the cached exchange rate changes.
+func (s *Service) OnRateChanged(handler RateChangedHandler) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
+	s.handlers = append(s.handlers, handler)
+}
+
+// Run refreshes the cached exchange rate every cache TTL, so rate changes are noticed without requests for the rate.
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(viper.GetDuration(config.EnvCacheSettingsTTL))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.refreshExchangeRate(); err != nil {
+				s.log.Error().Msg(err.Error())
+			}
+		}
+	}
+}
 
-	if s.useCachedValue() {
+func (s *Service) loadExchangeRate() error {
+	return s.updateExchangeRate(false)
+}
+
+func (s *Service) refreshExchangeRate() error {
+	return s.updateExchangeRate(true)
+}
+
+func (s *Service) updateExchangeRate(force bool) error {
+	s.mutex.Lock()
+
+	if !force && s.useCachedValue() {
+		s.mutex.Unlock()
 		return nil
 	}
 
 	exchangeRate, err := s.fetchExchangeRate()
 	if err != nil {
+		s.mutex.Unlock()
 		return err
 	}
 
+	changed := s.exchangeRate == nil || *s.exchangeRate != *exchangeRate
 	s.lastFetch = time.Now()
 	s.exchangeRate = exchangeRate
-
+	handlers := s.handlers
+	s.mutex.Unlock()
+
+	// Handlers are called without lock, so they can read the exchange rate.
+	if changed {
+		for _, handler := range handlers {
+			handler(*exchangeRate)
+		}
+	}
 	return nil
 }
 
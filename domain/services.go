@@ -1,13 +1,29 @@
 package domain
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 
+	db_admin "github.com/bsv-blockchain/spv-wallet-web-backend/data/admin"
+	db_audit "github.com/bsv-blockchain/spv-wallet-web-backend/data/audit"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/data/avatars"
+	db_passkeys "github.com/bsv-blockchain/spv-wallet-web-backend/data/passkeys"
+	db_teams "github.com/bsv-blockchain/spv-wallet-web-backend/data/teams"
+	db_transactions "github.com/bsv-blockchain/spv-wallet-web-backend/data/transactions"
 	db_users "github.com/bsv-blockchain/spv-wallet-web-backend/data/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/admin"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/audit"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/config"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/contacts"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/events"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/passkeys"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/paymail"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/rates"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/sso"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/teams"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/transactions"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/spvwallet"
@@ -16,15 +32,27 @@ import (
 // Services is a struct that contains all services.
 type Services struct {
 	UsersService        *users.UserService
+	ProfileService      *users.ProfileService
 	TransactionsService *transactions.TransactionService
+	TransactionTracker  *transactions.Tracker
 	ContactsService     *contacts.Service
+	EventsService       *events.Service
+	PaymailResolver     *paymail.Resolver
 	WalletClientFactory users.WalletClientFactory
 	ConfigService       *config.Service
 	RatesService        *rates.Service
+	AdminService        *admin.Service
+	AuditService        *audit.Service
+	TeamsService        *teams.Service
+	SSOService          *sso.Service
+	PasskeysService     *passkeys.Service
 }
 
 // NewServices creates services instance.
-func NewServices(usersRepo *db_users.Repository, log *zerolog.Logger) (*Services, error) {
+func NewServices(usersRepo *db_users.Repository, trackingRepo *db_transactions.TrackingRepository, actionsRepo *db_admin.ActionsRepository,
+	auditRepo *db_audit.EventsRepository, walletsRepo *db_teams.WalletsRepository, passkeysRepo *db_passkeys.Repository, avatarStorage *avatars.FileStorage,
+	log *zerolog.Logger,
+) (*Services, error) {
 	walletClientFactory := spvwallet.NewWalletClientFactory(log)
 	adminWalletClient, err := walletClientFactory.CreateAdminClient()
 	if err != nil {
@@ -32,14 +60,38 @@ func NewServices(usersRepo *db_users.Repository, log *zerolog.Logger) (*Services
 	}
 
 	rService := rates.NewRatesService(log)
-	uService := users.NewUserService(usersRepo, adminWalletClient, walletClientFactory, rService, log)
+	auditService := audit.NewAuditService(auditRepo, log)
+	uService := users.NewUserService(usersRepo, adminWalletClient, walletClientFactory, rService, auditService, log)
+	pService := users.NewProfileService(usersRepo, uService, adminWalletClient, avatarStorage, log)
+	uService.SubscribeAccountDeletion(pService.RemoveAvatar)
+	tracker := transactions.NewTracker(trackingRepo, adminWalletClient, log)
+	tService := transactions.NewTransactionService(adminWalletClient, walletClientFactory, auditService, log)
+
+	passkeysService, err := passkeys.NewPasskeysService(passkeysRepo, usersRepo, uService, auditService, log)
+	if err != nil {
+		return nil, errors.Wrap(err, "internal error")
+	}
+
+	configService := config.NewConfigService(adminWalletClient, log)
+
+	eService := events.NewEventsService(usersRepo, log)
+	eService.Subscribe(tracker.TrackWalletEvent)
 
 	return &Services{
 		RatesService:        rService,
 		UsersService:        uService,
+		ProfileService:      pService,
 		WalletClientFactory: walletClientFactory,
-		TransactionsService: transactions.NewTransactionService(adminWalletClient, walletClientFactory, log),
-		ContactsService:     contacts.NewContactsService(adminWalletClient, walletClientFactory, log),
-		ConfigService:       config.NewConfigService(adminWalletClient, log),
+		TransactionsService: tService,
+		TransactionTracker:  tracker,
+		ContactsService:     contacts.NewContactsService(adminWalletClient, walletClientFactory, auditService, log),
+		EventsService:       eService,
+		PaymailResolver:     paymail.NewResolver(paymail.NewPublicHTTPClient(10*time.Second), paymail.LookupSRV, configService.PaymailDomains, log),
+		ConfigService:       configService,
+		AdminService:        admin.NewAdminService(usersRepo, actionsRepo, adminWalletClient, log),
+		AuditService:        auditService,
+		TeamsService:        teams.NewTeamsService(walletsRepo, usersRepo, uService, tService, adminWalletClient, log),
+		SSOService:          sso.NewSSOService(&http.Client{Timeout: 10 * time.Second}, log),
+		PasskeysService:     passkeysService,
 	}, nil
 }
@@ -0,0 +1,195 @@
+package sso
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"golang.org/x/oauth2"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+)
+
+// randomValueSize is the number of random bytes of state and nonce of the authorization.
+const randomValueSize = 32
+
+// Users are signed in with authorization code flow with PKCE. State, nonce and code verifier are generated when
+// the user is redirected to the identity provider and kept by the client until the callback, where the code is
+// exchanged for ID token. The token is verified with keys of the provider and has to contain the nonce.
+
+// Service is a service signing in users with the OpenID Connect identity provider.
+type Service struct {
+	httpClient *http.Client
+	log        *zerolog.Logger
+
+	provider *oidc.Provider
+	mutex    sync.Mutex
+}
+
+// Authorization is a struct that contains the url of the identity provider the user is redirected to,
+// with values which have to be kept until the callback to complete the sign in.
+type Authorization struct {
+	URL      string `json:"-"`
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+}
+
+// claims are the claims of ID token used to create the user.
+type claims struct {
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+}
+
+// NewSSOService creates new sso service.
+func NewSSOService(httpClient *http.Client, log *zerolog.Logger) *Service {
+	serviceLogger := log.With().Str("service", "sso-service").Logger()
+	return &Service{
+		httpClient: httpClient,
+		log:        &serviceLogger,
+	}
+}
+
+// Enabled checks if users can sign in with the identity provider.
+func (s *Service) Enabled() bool {
+	return viper.GetBool(config.EnvSSOEnabled)
+}
+
+// StartAuthorization returns authorization with the url of the identity provider to which the user is redirected to sign in.
+func (s *Service) StartAuthorization(ctx context.Context) (*Authorization, error) {
+	oauth2Config, _, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := randomValue()
+	if err != nil {
+		return nil, spverrors.ErrSSOAuthorization.Wrap(err)
+	}
+	nonce, err := randomValue()
+	if err != nil {
+		return nil, spverrors.ErrSSOAuthorization.Wrap(err)
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	return &Authorization{
+		URL:      oauth2Config.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)),
+		State:    state,
+		Nonce:    nonce,
+		Verifier: verifier,
+	}, nil
+}
+
+// CompleteAuthorization exchanges the code returned to the callback with the state for ID token of the user and returns identity from it.
+func (s *Service) CompleteAuthorization(ctx context.Context, authorization *Authorization, state, code string) (*users.SSOIdentity, error) {
+	if authorization == nil || subtle.ConstantTimeCompare([]byte(authorization.State), []byte(state)) != 1 {
+		return nil, spverrors.ErrSSOAuthorization
+	}
+
+	oauth2Config, verifier, err := s.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = oidc.ClientContext(ctx, s.httpClient)
+	token, err := oauth2Config.Exchange(ctx, code, oauth2.VerifierOption(authorization.Verifier))
+	if err != nil {
+		s.log.Warn().Msgf("Error while exchanging authorization code: %v", err.Error())
+		return nil, spverrors.ErrSSOAuthorization
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		s.log.Warn().Msg("Identity provider did not return ID token")
+		return nil, spverrors.ErrSSOAuthorization
+	}
+
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		s.log.Warn().Msgf("Error while verifying ID token: %v", err.Error())
+		return nil, spverrors.ErrSSOAuthorization
+	}
+	if subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(authorization.Nonce)) != 1 {
+		return nil, spverrors.ErrSSOAuthorization
+	}
+
+	var idClaims claims
+	if err = idToken.Claims(&idClaims); err != nil {
+		s.log.Warn().Msgf("Error while reading claims of ID token: %v", err.Error())
+		return nil, spverrors.ErrSSOAuthorization
+	}
+	if idClaims.Email == "" || !idClaims.EmailVerified {
+		return nil, spverrors.ErrSSOEmailNotVerified
+	}
+
+	return &users.SSOIdentity{
+		Issuer:  idToken.Issuer,
+		Subject: idToken.Subject,
+		Email:   strings.TrimSpace(idClaims.Email),
+	}, nil
+}
+
+// client returns OAuth2 config and ID token verifier of the identity provider, which is discovered on first use.
+func (s *Service) client(ctx context.Context) (*oauth2.Config, *oidc.IDTokenVerifier, error) {
+	if !s.Enabled() {
+		return nil, nil, spverrors.ErrSSODisabled
+	}
+
+	provider, err := s.getProvider(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientID := viper.GetString(config.EnvSSOClientID)
+	oauth2Config := &oauth2.Config{
+		ClientID:     clientID,
+		ClientSecret: viper.GetString(config.EnvSSOClientSecret),
+		Endpoint:     provider.Endpoint(),
+		RedirectURL:  viper.GetString(config.EnvSSOCallbackURL),
+		Scopes:       []string{oidc.ScopeOpenID, "email", "profile"},
+	}
+	return oauth2Config, provider.Verifier(&oidc.Config{ClientID: clientID}), nil
+}
+
+// getProvider returns the identity provider, failed discovery is repeated on next use.
+func (s *Service) getProvider(ctx context.Context) (*oidc.Provider, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.provider != nil {
+		return s.provider, nil
+	}
+
+	if viper.GetString(config.EnvSSOAccessKeySecret) == "" {
+		s.log.Error().Msg("Secret encrypting access keys of identity provider users is not configured")
+		return nil, spverrors.ErrSSOProvider
+	}
+
+	// Keys of the provider are fetched with the context of discovery, so it must not be canceled with the request.
+	discoveryCtx := oidc.ClientContext(context.WithoutCancel(ctx), s.httpClient)
+	provider, err := oidc.NewProvider(discoveryCtx, viper.GetString(config.EnvSSOIssuer))
+	if err != nil {
+		s.log.Error().Msgf("Error while discovering identity provider: %v", err.Error())
+		return nil, spverrors.ErrSSOProvider
+	}
+
+	s.provider = provider
+	return provider, nil
+}
+
+func randomValue() (string, error) {
+	random := make([]byte, randomValueSize)
+	if _, err := rand.Read(random); err != nil {
+		return "", err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+	return base64.RawURLEncoding.EncodeToString(random), nil
+}
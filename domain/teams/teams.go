@@ -0,0 +1,128 @@
+package teams
+
+import (
+	"time"
+)
+
+// Roles of team wallet members.
+const (
+	// MemberRoleOwner is a role of the member managing the team wallet, owner can invite and remove members and spend.
+	MemberRoleOwner = "owner"
+	// MemberRoleSpender is a role of the member who can spend from the team wallet.
+	MemberRoleSpender = "spender"
+	// MemberRoleViewer is a role of the member who can only see balance and history of the team wallet.
+	MemberRoleViewer = "viewer"
+)
+
+// Wallet is a struct that contains team wallet data. Team wallet is not bound to any login,
+// its members sign in with their own credentials.
+type Wallet struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Paymail   string    `json:"paymail"`
+	XpubID    string    `json:"-"` // ID of wallet's xPub in SPV Wallet
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Member is a struct that contains member of the team wallet.
+type Member struct {
+	WalletID  int       `json:"-"`
+	UserID    int       `json:"user_id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	Xpriv     string    `json:"-"` // wallet xPriv encrypted with member password
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// MemberWallet is a struct that contains team wallet with the role of the member.
+type MemberWallet struct {
+	Wallet *Wallet `json:"wallet"`
+	Role   string  `json:"role"`
+}
+
+// WalletDetails is a struct that contains team wallet with its balance and members.
+type WalletDetails struct {
+	Wallet  *Wallet   `json:"wallet"`
+	Role    string    `json:"role"`
+	Balance uint64    `json:"balance"` // balance in satoshis
+	Members []*Member `json:"members"`
+}
+
+// CreatedWallet is a struct that contains new team wallet with mnemonic of its key.
+type CreatedWallet struct {
+	Wallet   *Wallet
+	Mnemonic string
+}
+
+// Invitation is a struct that contains invitation of a user to the team wallet.
+// Wallet xPriv is encrypted with the invitation token, only hash of the token is stored.
+type Invitation struct {
+	ID        int
+	WalletID  int
+	Email     string
+	Role      string
+	TokenHash string
+	Xpriv     string
+	InvitedBy int
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// CreatedInvitation is a struct that contains invitation token passed by the owner to the invited user.
+type CreatedInvitation struct {
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Transaction is a struct that contains transaction sent from the team wallet with the member who initiated it.
+type Transaction struct {
+	ID             int       `json:"-"`
+	WalletID       int       `json:"-"`
+	TransactionID  string    `json:"transaction_id"`
+	InitiatorID    int       `json:"initiator_id"`
+	InitiatorEmail string    `json:"initiator_email"`
+	Recipient      string    `json:"recipient"`
+	Satoshis       uint64    `json:"satoshis"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// TransactionsPage is a struct that contains page of team wallet transactions.
+type TransactionsPage struct {
+	Count        int64          `json:"count"`
+	Pages        int            `json:"pages"`
+	Transactions []*Transaction `json:"transactions"`
+}
+
+// Statuses of payments waiting for approvals.
+const (
+	// PaymentStatusPending is a status of the payment waiting for approvals.
+	PaymentStatusPending = "pending"
+	// PaymentStatusSending is a status of the payment approved by required number of members, which is being sent.
+	PaymentStatusSending = "sending"
+	// PaymentStatusApproved is a status of the payment approved by required number of members and sent.
+	PaymentStatusApproved = "approved"
+	// PaymentStatusFailed is a status of the payment approved by required number of members, which could not be sent.
+	PaymentStatusFailed = "failed"
+	// PaymentStatusExpired is a status of the payment not approved in time and discarded.
+	PaymentStatusExpired = "expired"
+)
+
+// PendingPayment is a struct that contains payment from the team wallet above the approval threshold.
+// Payment is drafted and recorded only when required number of members approve it.
+type PendingPayment struct {
+	ID                int       `json:"id"`
+	WalletID          int       `json:"wallet_id"`
+	InitiatorID       int       `json:"initiator_id"`
+	InitiatorEmail    string    `json:"initiator_email"`
+	Recipient         string    `json:"recipient"`
+	Satoshis          uint64    `json:"satoshis"`
+	Status            string    `json:"status"`
+	RequiredApprovals int       `json:"required_approvals"`
+	ApprovedBy        []int     `json:"approved_by"`
+	ExpiresAt         time.Time `json:"expires_at"`
+	CreatedAt         time.Time `json:"created_at"`
+
+	Approvers []int `json:"-"` // members who can still approve the payment
+}
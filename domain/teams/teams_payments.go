@@ -0,0 +1,211 @@
+package teams
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/notification"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+)
+
+// Payment above the approval threshold is held until the required number of members who can spend approve it
+// with their passwords. The initiator is the first approver. The member giving the last approval drafts and records
+// the payment with the wallet key decrypted by own password, so no draft reserving the wallet funds is held while
+// the payment waits for approvals. Payment not approved in time is discarded.
+
+// RunPaymentsCleanup periodically discards expired payments waiting for approvals until the context is canceled.
+func (s *Service) RunPaymentsCleanup(ctx context.Context) {
+	ticker := time.NewTicker(viper.GetDuration(config.EnvTeamsApprovalCleanupInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.ExpirePendingPayments(ctx)
+		}
+	}
+}
+
+// ExpirePendingPayments discards payments which were not approved in time.
+func (s *Service) ExpirePendingPayments(ctx context.Context) {
+	expired, err := s.repo.ExpirePendingPayments(ctx, time.Now().UTC())
+	if err != nil {
+		s.log.Error().Msgf("Error while discarding expired payments: %v", err.Error())
+		return
+	}
+	if expired > 0 {
+		s.log.Info().Msgf("Discarded %d expired payments waiting for approvals", expired)
+	}
+}
+
+// GetPendingPayments returns payments of the team wallet waiting for approvals, if the user is its member.
+func (s *Service) GetPendingPayments(walletID, userID int) ([]*PendingPayment, error) {
+	if _, err := s.getMember(context.Background(), walletID, userID); err != nil {
+		return nil, err
+	}
+
+	payments, err := s.repo.GetPendingPayments(context.Background(), walletID)
+	if err != nil {
+		s.log.Error().
+			Str("walletID", strconv.Itoa(walletID)).
+			Msgf("Error while getting pending payments: %v", err.Error())
+		return nil, spverrors.ErrGetTeamTransactions
+	}
+	return payments, nil
+}
+
+// ApprovePayment approves the payment waiting for approvals by the member. When the approval is the last required one,
+// the payment is sent and the result is passed to the events channel. Payment is approved when it's recorded
+// in SPV Wallet, or failed when it can't be sent.
+func (s *Service) ApprovePayment(ctx context.Context, walletID, paymentID, userID int, password string, events chan notification.TransactionEvent) (*PendingPayment, error) {
+	_, xpriv, err := s.unlockSpender(ctx, walletID, userID, password)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := s.repo.GetPendingPayment(ctx, paymentID)
+	if err != nil {
+		s.log.Error().
+			Str("paymentID", strconv.Itoa(paymentID)).
+			Msgf("Error while getting pending payment: %v", err.Error())
+		return nil, spverrors.ErrApprovePayment
+	}
+	if payment == nil || payment.WalletID != walletID {
+		return nil, spverrors.ErrPaymentNotFound
+	}
+	if payment.Status != PaymentStatusPending || time.Now().After(payment.ExpiresAt) {
+		return nil, spverrors.ErrPaymentNotPending
+	}
+	if slices.Contains(payment.ApprovedBy, userID) {
+		return nil, spverrors.ErrPaymentAlreadyApproved
+	}
+
+	// Approvals are counted after the approval is inserted, so concurrent approvals do not miss each other.
+	approvedBy, err := s.repo.InsertPaymentApproval(ctx, paymentID, userID, time.Now().UTC())
+	if err != nil {
+		s.log.Error().
+			Str("paymentID", strconv.Itoa(paymentID)).
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while inserting payment approval: %v", err.Error())
+		return nil, spverrors.ErrApprovePayment
+	}
+	payment.ApprovedBy = approvedBy
+
+	if len(payment.ApprovedBy) < payment.RequiredApprovals {
+		return payment, nil
+	}
+
+	// Only the approval which changes the status sends the payment, concurrent last approvals do not send it twice.
+	sending, err := s.repo.UpdatePaymentStatus(ctx, paymentID, PaymentStatusPending, PaymentStatusSending)
+	if err != nil {
+		s.log.Error().
+			Str("paymentID", strconv.Itoa(paymentID)).
+			Msgf("Error while updating payment status: %v", err.Error())
+		return nil, spverrors.ErrApprovePayment
+	}
+	if !sending {
+		return nil, spverrors.ErrPaymentNotPending
+	}
+	payment.Status = PaymentStatusSending
+
+	wallet, err := s.getWallet(ctx, walletID)
+	if err != nil {
+		s.finishPayment(paymentID, PaymentStatusFailed)
+		return nil, err
+	}
+
+	walletEvents := make(chan notification.TransactionEvent)
+	err = s.transactionsService.CreateInitiatedTransaction(ctx, wallet.Paymail, payment.InitiatorEmail, xpriv, payment.Recipient, payment.Satoshis, walletEvents)
+	if err != nil {
+		s.finishPayment(paymentID, PaymentStatusFailed)
+		return nil, err //nolint:wrapcheck // error is already an SPVError
+	}
+
+	// Transaction is recorded in the background, the payment is finished with its result before it's forwarded.
+	recordedEvents := make(chan notification.TransactionEvent)
+	go func() {
+		event := <-walletEvents
+		if event.Transaction != nil {
+			s.finishPayment(paymentID, PaymentStatusApproved)
+		} else {
+			s.finishPayment(paymentID, PaymentStatusFailed)
+		}
+		recordedEvents <- event
+	}()
+	s.forwardTransaction(&Transaction{
+		WalletID:       walletID,
+		InitiatorID:    payment.InitiatorID,
+		InitiatorEmail: payment.InitiatorEmail,
+		Recipient:      payment.Recipient,
+		Satoshis:       payment.Satoshis,
+	}, recordedEvents, events)
+
+	return payment, nil
+}
+
+// finishPayment changes status of the payment being sent to the result of sending it.
+func (s *Service) finishPayment(paymentID int, status string) {
+	if _, err := s.repo.UpdatePaymentStatus(context.Background(), paymentID, PaymentStatusSending, status); err != nil {
+		s.log.Error().
+			Str("paymentID", strconv.Itoa(paymentID)).
+			Msgf("Error while changing status of sent payment to %s: %v", status, err.Error())
+	}
+}
+
+// holdPayment stores the payment until it is approved by the required number of members.
+func (s *Service) holdPayment(ctx context.Context, wallet *Wallet, initiator *Member, recipient string, satoshis uint64) (*PendingPayment, error) {
+	members, err := s.repo.GetMembers(ctx, wallet.ID)
+	if err != nil {
+		s.log.Error().
+			Str("walletID", strconv.Itoa(wallet.ID)).
+			Msgf("Error while getting team wallet members: %v", err.Error())
+		return nil, spverrors.ErrApprovePayment
+	}
+
+	approvers := make([]int, 0)
+	for _, member := range members {
+		if canSpend(member.Role) && member.UserID != initiator.UserID {
+			approvers = append(approvers, member.UserID)
+		}
+	}
+	requiredApprovals := viper.GetInt(config.EnvTeamsRequiredApprovals)
+	if len(approvers)+1 < requiredApprovals {
+		return nil, spverrors.ErrNotEnoughApprovers
+	}
+
+	now := time.Now().UTC()
+	payment := &PendingPayment{
+		WalletID:          wallet.ID,
+		InitiatorID:       initiator.UserID,
+		InitiatorEmail:    initiator.Email,
+		Recipient:         recipient,
+		Satoshis:          satoshis,
+		Status:            PaymentStatusPending,
+		RequiredApprovals: requiredApprovals,
+		ApprovedBy:        []int{initiator.UserID},
+		ExpiresAt:         now.Add(viper.GetDuration(config.EnvTeamsApprovalTTL)),
+		CreatedAt:         now,
+	}
+	if err = s.repo.InsertPendingPayment(ctx, payment); err != nil {
+		s.log.Error().
+			Str("walletID", strconv.Itoa(wallet.ID)).
+			Msgf("Error while inserting pending payment: %v", err.Error())
+		return nil, spverrors.ErrApprovePayment
+	}
+	payment.Approvers = approvers
+
+	return payment, nil
+}
+
+// requiresApproval checks if the payment is above the approval threshold and has to be approved by more members.
+func requiresApproval(satoshis uint64) bool {
+	threshold := viper.GetUint64(config.EnvTeamsApprovalThreshold)
+	return threshold > 0 && satoshis > threshold && viper.GetInt(config.EnvTeamsRequiredApprovals) > 1
+}
@@ -0,0 +1,618 @@
+package teams
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/libsv/go-bk/bip32"
+	"github.com/libsv/go-bk/bip39"
+	"github.com/libsv/go-bk/chaincfg"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/transactions"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/encryption"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/notification"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+)
+
+const (
+	// invitationTokenSize is the number of random bytes of the member invitation token.
+	invitationTokenSize = 32
+	// maxWalletNameLength is the max number of characters of the team wallet name.
+	maxWalletNameLength = 100
+	// defaultPageSize is the number of transactions returned when page size is not given.
+	defaultPageSize = 20
+	// maxPageSize is the max number of transactions returned in one page.
+	maxPageSize = 100
+)
+
+// Team wallet has its own key, which is encrypted with the password of every member separately, so members sign in
+// with their own credentials and no member knows the password of another one. Member invited by an owner gets the key
+// encrypted with a random invitation token, which is re-encrypted with the member password when the invitation is accepted.
+// Members signing in with the identity provider use spending PIN instead, so their key is encrypted and limited as their xpriv.
+
+// Service represents team wallets service and provide access to repository.
+type Service struct {
+	repo                WalletsRepository
+	usersRepo           users.Repository
+	usersService        *users.UserService
+	transactionsService *transactions.TransactionService
+	adminWalletClient   users.AdminWalletClient
+	log                 *zerolog.Logger
+}
+
+// NewTeamsService creates team wallets Service instance.
+func NewTeamsService(repo WalletsRepository, usersRepo users.Repository, usersService *users.UserService, tService *transactions.TransactionService,
+	adminWalletClient users.AdminWalletClient, l *zerolog.Logger,
+) *Service {
+	teamsServiceLogger := l.With().Str("service", "teams-service").Logger()
+	return &Service{
+		repo:                repo,
+		usersRepo:           usersRepo,
+		usersService:        usersService,
+		transactionsService: tService,
+		adminWalletClient:   adminWalletClient,
+		log:                 &teamsServiceLogger,
+	}
+}
+
+// CreateWallet creates team wallet with a new key and paymail with the alias, the user becomes its first owner.
+func (s *Service) CreateWallet(ctx context.Context, userID int, password, name, alias, domain string) (*CreatedWallet, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > maxWalletNameLength {
+		return nil, spverrors.ErrInvalidTeamWalletName
+	}
+
+	availability, err := s.usersService.CheckAliasAvailability(alias, domain)
+	if err != nil {
+		return nil, err
+	}
+	if !availability.Available {
+		return nil, spverrors.ErrPaymailAliasTaken
+	}
+	_, paymailDomain, _ := strings.Cut(availability.Paymail, "@")
+
+	mnemonic, xpriv, err := generateWalletKey()
+	if err != nil {
+		s.log.Error().Msgf("Error while generating team wallet key: %v", err.Error())
+		return nil, spverrors.ErrGenerateXPriv
+	}
+
+	xpub, err := xpriv.Neuter()
+	if err != nil {
+		s.log.Error().Msgf("Error while getting xPub from xPriv: %v", err.Error())
+		return nil, spverrors.ErrGenerateXPriv
+	}
+
+	// Validates password, the wallet key is encrypted with it.
+	encryptedXpriv, err := s.usersService.EncryptSharedKey(ctx, userID, password, xpriv.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = s.adminWalletClient.RegisterXpub(xpriv); err != nil {
+		s.log.Error().Msgf("Error while registering xPub: %v", err.Error())
+		return nil, spverrors.ErrRegisterXPub
+	}
+
+	xpubID := getXpubID(xpub.String())
+	paymail, err := s.adminWalletClient.RegisterPaymail(availability.Alias, paymailDomain, xpub.String(), &users.PaymailProfile{PublicName: name})
+	if err != nil {
+		s.log.Error().
+			Str("alias", availability.Alias).
+			Msgf("Error while registering paymail: %v", err.Error())
+		s.compensateWallet(xpubID)
+		return nil, spverrors.ErrRegisterPaymail
+	}
+
+	now := time.Now().UTC()
+	wallet := &Wallet{
+		Name:      name,
+		Paymail:   paymail,
+		XpubID:    xpubID,
+		CreatedAt: now,
+	}
+	owner := &Member{
+		UserID:    userID,
+		Role:      MemberRoleOwner,
+		Xpriv:     encryptedXpriv,
+		CreatedAt: now,
+	}
+	if err = s.repo.InsertWallet(ctx, wallet, owner); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while inserting team wallet: %v", err.Error())
+		s.compensateWallet(xpubID)
+		return nil, spverrors.ErrCreateTeamWallet
+	}
+
+	return &CreatedWallet{
+		Wallet:   wallet,
+		Mnemonic: mnemonic,
+	}, nil
+}
+
+// compensateWallet rolls back the steps done in SPV Wallet for team wallet which was not created, the same as for
+// rolled back registration of the user. Paymails of the wallet key are deleted, so the wallet can be created again
+// with the same alias, also when the paymail was registered but SPV Wallet returned an error.
+func (s *Service) compensateWallet(xpubID string) {
+	paymails, err := s.adminWalletClient.GetXpubPaymails(xpubID)
+	if err != nil {
+		s.log.Warn().
+			Str("xpubId", xpubID).
+			Msgf("Error while getting paymails of team wallet not created: %v", err.Error())
+	}
+	for _, paymail := range paymails {
+		if err = s.adminWalletClient.DeletePaymail(paymail.Address); err != nil {
+			s.log.Warn().
+				Str("paymail", paymail.Address).
+				Msgf("Error while deleting paymail of team wallet not created: %v", err.Error())
+		}
+	}
+
+	// SPV Wallet admin API does not allow to delete xpub, so it is left unused.
+	s.log.Warn().
+		Str("xpubId", xpubID).
+		Msg("xPub of team wallet not created is registered in SPV Wallet and is left unused")
+}
+
+// GetUserWallets returns team wallets the user is a member of.
+func (s *Service) GetUserWallets(userID int) ([]*MemberWallet, error) {
+	wallets, err := s.repo.GetMemberWallets(context.Background(), userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting team wallets: %v", err.Error())
+		return nil, spverrors.ErrGetTeamWallets
+	}
+	return wallets, nil
+}
+
+// GetWallet returns team wallet with its balance and members, if the user is its member.
+func (s *Service) GetWallet(walletID, userID int) (*WalletDetails, error) {
+	member, err := s.getMember(context.Background(), walletID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := s.getWallet(context.Background(), walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.repo.GetMembers(context.Background(), walletID)
+	if err != nil {
+		s.log.Error().
+			Str("walletID", strconv.Itoa(walletID)).
+			Msgf("Error while getting team wallet members: %v", err.Error())
+		return nil, spverrors.ErrGetTeamWallets
+	}
+
+	xpub, err := s.adminWalletClient.GetXpub(wallet.XpubID)
+	if err != nil {
+		s.log.Error().
+			Str("walletID", strconv.Itoa(walletID)).
+			Msgf("Error while getting xPub of team wallet: %v", err.Error())
+		return nil, spverrors.ErrGetBalance
+	}
+
+	return &WalletDetails{
+		Wallet:  wallet,
+		Role:    member.Role,
+		Balance: xpub.GetCurrentBalance(),
+		Members: members,
+	}, nil
+}
+
+// InviteMember returns invitation of the user with the email to the team wallet, only owner can invite members.
+func (s *Service) InviteMember(ctx context.Context, walletID, ownerID int, password, email, role string) (*CreatedInvitation, error) {
+	if !validRole(role) {
+		return nil, spverrors.ErrInvalidMemberRole
+	}
+
+	owner, err := s.getMember(ctx, walletID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if owner.Role != MemberRoleOwner {
+		return nil, spverrors.ErrForbidden
+	}
+
+	xpriv, err := s.usersService.DecryptSharedKey(ctx, ownerID, password, owner.Xpriv)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only users with their own login can become members, viewers of a personal wallet cannot.
+	email = strings.TrimSpace(email)
+	user, err := s.usersRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		s.log.Error().
+			Str("userEmail", email).
+			Msgf("Error while getting user by email: %v", err.Error())
+		return nil, spverrors.ErrInviteMember
+	}
+	if user == nil || user.Role != users.RoleOwner {
+		return nil, spverrors.ErrUserNotFound
+	}
+
+	if err = s.checkNotMember(ctx, walletID, user.ID); err != nil {
+		return nil, err
+	}
+
+	token, err := generateInvitationToken()
+	if err != nil {
+		return nil, spverrors.ErrInviteMember.Wrap(err)
+	}
+
+	encryptedXpriv, err := encryptKey(token, xpriv)
+	if err != nil {
+		return nil, spverrors.ErrInviteMember.Wrap(err)
+	}
+
+	now := time.Now().UTC()
+	invitation := &Invitation{
+		WalletID:  walletID,
+		Email:     user.Email,
+		Role:      role,
+		TokenHash: hashInvitationToken(token),
+		Xpriv:     encryptedXpriv,
+		InvitedBy: ownerID,
+		ExpiresAt: now.Add(viper.GetDuration(config.EnvTeamsInvitationTTL)),
+		CreatedAt: now,
+	}
+	if err = s.repo.InsertInvitation(ctx, invitation); err != nil {
+		s.log.Error().
+			Str("walletID", strconv.Itoa(walletID)).
+			Msgf("Error while inserting member invitation: %v", err.Error())
+		return nil, spverrors.ErrInviteMember
+	}
+
+	return &CreatedInvitation{
+		Email:     invitation.Email,
+		Role:      invitation.Role,
+		Token:     token,
+		ExpiresAt: invitation.ExpiresAt,
+	}, nil
+}
+
+// AcceptInvitation makes the signed-in user a member of the team wallet, the wallet key is encrypted with the password of the user.
+func (s *Service) AcceptInvitation(ctx context.Context, userID int, token, password string) (*MemberWallet, error) {
+	invitation, err := s.repo.GetInvitation(ctx, hashInvitationToken(token))
+	if err != nil {
+		s.log.Error().Msgf("Error while getting member invitation: %v", err.Error())
+		return nil, spverrors.ErrAcceptInvitation
+	}
+	if invitation == nil || time.Now().After(invitation.ExpiresAt) {
+		return nil, spverrors.ErrInvalidInvitation
+	}
+
+	user, err := s.usersService.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		return nil, spverrors.ErrInvalidInvitation
+	}
+
+	if err = s.checkNotMember(ctx, invitation.WalletID, userID); err != nil {
+		return nil, err
+	}
+
+	xpriv, err := decryptKey(token, invitation.Xpriv)
+	if err != nil {
+		return nil, spverrors.ErrInvalidInvitation
+	}
+
+	// Validates password, the wallet key is encrypted with it.
+	encryptedXpriv, err := s.usersService.EncryptSharedKey(ctx, userID, password, xpriv)
+	if err != nil {
+		return nil, err
+	}
+
+	member := &Member{
+		WalletID:  invitation.WalletID,
+		UserID:    userID,
+		Email:     user.Email,
+		Role:      invitation.Role,
+		Xpriv:     encryptedXpriv,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err = s.repo.AcceptInvitation(ctx, member, invitation.ID); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while accepting member invitation: %v", err.Error())
+		return nil, spverrors.ErrAcceptInvitation
+	}
+
+	wallet, err := s.getWallet(ctx, invitation.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MemberWallet{
+		Wallet: wallet,
+		Role:   member.Role,
+	}, nil
+}
+
+// RemoveMember removes the member from the team wallet. Owner can remove any member, other members can only leave.
+func (s *Service) RemoveMember(ctx context.Context, walletID, userID, memberID int) error {
+	user, err := s.getMember(ctx, walletID, userID)
+	if err != nil {
+		return err
+	}
+	if user.Role != MemberRoleOwner && userID != memberID {
+		return spverrors.ErrForbidden
+	}
+
+	members, err := s.repo.GetMembers(ctx, walletID)
+	if err != nil {
+		s.log.Error().
+			Str("walletID", strconv.Itoa(walletID)).
+			Msgf("Error while getting team wallet members: %v", err.Error())
+		return spverrors.ErrRemoveMember
+	}
+
+	var removed *Member
+	owners := 0
+	for _, member := range members {
+		if member.UserID == memberID {
+			removed = member
+		}
+		if member.Role == MemberRoleOwner {
+			owners++
+		}
+	}
+	if removed == nil {
+		return spverrors.ErrMemberNotFound
+	}
+	if removed.Role == MemberRoleOwner && owners == 1 {
+		return spverrors.ErrLastTeamWalletOwner
+	}
+
+	if err = s.repo.DeleteMember(ctx, walletID, memberID); err != nil {
+		s.log.Error().
+			Str("walletID", strconv.Itoa(walletID)).
+			Str("userID", strconv.Itoa(memberID)).
+			Msgf("Error while deleting team wallet member: %v", err.Error())
+		return spverrors.ErrRemoveMember
+	}
+
+	return nil
+}
+
+// CreateTransaction creates transaction from the team wallet initiated by the member, only owners and spenders can spend.
+// Recorded transaction is stored with the member who initiated it and then passed to the events channel.
+// Payment above the approval threshold is only stored and returned as pending, it is sent when enough members approve it.
+func (s *Service) CreateTransaction(ctx context.Context, walletID, userID int, password, recipient string, satoshis uint64,
+	events chan notification.TransactionEvent,
+) (*PendingPayment, error) {
+	member, xpriv, err := s.unlockSpender(ctx, walletID, userID, password)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := s.getWallet(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	if requiresApproval(satoshis) {
+		return s.holdPayment(ctx, wallet, member, recipient, satoshis)
+	}
+
+	walletEvents := make(chan notification.TransactionEvent)
+	err = s.transactionsService.CreateInitiatedTransaction(ctx, wallet.Paymail, member.Email, xpriv, recipient, satoshis, walletEvents)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error is already an SPVError
+	}
+	s.forwardTransaction(&Transaction{
+		WalletID:       walletID,
+		InitiatorID:    userID,
+		InitiatorEmail: member.Email,
+		Recipient:      recipient,
+		Satoshis:       satoshis,
+	}, walletEvents, events)
+
+	return nil, nil
+}
+
+// GetTransactions returns page of transactions sent from the team wallet, the newest first.
+func (s *Service) GetTransactions(walletID, userID, page, pageSize int) (*TransactionsPage, error) {
+	if _, err := s.getMember(context.Background(), walletID, userID); err != nil {
+		return nil, err
+	}
+
+	page, pageSize = normalizePage(page, pageSize)
+	txs, count, err := s.repo.GetTransactions(context.Background(), walletID, page, pageSize)
+	if err != nil {
+		s.log.Error().
+			Str("walletID", strconv.Itoa(walletID)).
+			Msgf("Error while getting team wallet transactions: %v", err.Error())
+		return nil, spverrors.ErrGetTeamTransactions
+	}
+
+	return &TransactionsPage{
+		Count:        count,
+		Pages:        int((count + int64(pageSize) - 1) / int64(pageSize)),
+		Transactions: txs,
+	}, nil
+}
+
+// forwardTransaction stores the transaction recorded in SPV Wallet and passes the event to the events channel.
+// Transaction is already recorded, so failure to store it is only logged.
+func (s *Service) forwardTransaction(transaction *Transaction, walletEvents, events chan notification.TransactionEvent) {
+	go func() {
+		event := <-walletEvents
+		if event.Transaction != nil {
+			transaction.TransactionID = event.Transaction.ID
+			transaction.CreatedAt = time.Now().UTC()
+			if err := s.repo.InsertTransaction(context.Background(), transaction); err != nil {
+				s.log.Error().
+					Str("walletID", strconv.Itoa(transaction.WalletID)).
+					Str("transactionID", transaction.TransactionID).
+					Msgf("Error while inserting team wallet transaction: %v", err.Error())
+			}
+		}
+		events <- event
+	}()
+}
+
+// unlockSpender returns the member allowed to spend from the team wallet and the wallet xpriv decrypted with the member password.
+func (s *Service) unlockSpender(ctx context.Context, walletID, userID int, password string) (*Member, string, error) {
+	member, err := s.getMember(ctx, walletID, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !canSpend(member.Role) {
+		return nil, "", spverrors.ErrForbidden
+	}
+
+	xpriv, err := s.usersService.DecryptSharedKey(ctx, userID, password, member.Xpriv)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return member, xpriv, nil
+}
+
+// getMember returns the member of the team wallet, wallets of other users are reported as not found.
+func (s *Service) getMember(ctx context.Context, walletID, userID int) (*Member, error) {
+	member, err := s.repo.GetMember(ctx, walletID, userID)
+	if err != nil {
+		s.log.Error().
+			Str("walletID", strconv.Itoa(walletID)).
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting team wallet member: %v", err.Error())
+		return nil, spverrors.ErrGetTeamWallets
+	}
+	if member == nil {
+		return nil, spverrors.ErrTeamWalletNotFound
+	}
+	return member, nil
+}
+
+func (s *Service) getWallet(ctx context.Context, walletID int) (*Wallet, error) {
+	wallet, err := s.repo.GetWallet(ctx, walletID)
+	if err != nil {
+		s.log.Error().
+			Str("walletID", strconv.Itoa(walletID)).
+			Msgf("Error while getting team wallet: %v", err.Error())
+		return nil, spverrors.ErrGetTeamWallets
+	}
+	if wallet == nil {
+		return nil, spverrors.ErrTeamWalletNotFound
+	}
+	return wallet, nil
+}
+
+func (s *Service) checkNotMember(ctx context.Context, walletID, userID int) error {
+	member, err := s.repo.GetMember(ctx, walletID, userID)
+	if err != nil {
+		s.log.Error().
+			Str("walletID", strconv.Itoa(walletID)).
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting team wallet member: %v", err.Error())
+		return spverrors.ErrInviteMember
+	}
+	if member != nil {
+		return spverrors.ErrMemberAlreadyExists
+	}
+	return nil
+}
+
+func canSpend(role string) bool {
+	return role == MemberRoleOwner || role == MemberRoleSpender
+}
+
+func validRole(role string) bool {
+	return role == MemberRoleOwner || role == MemberRoleSpender || role == MemberRoleViewer
+}
+
+// normalizePage returns page and page size within allowed range.
+func normalizePage(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	return page, pageSize
+}
+
+// generateWalletKey generates mnemonic and xpriv of a new team wallet.
+func generateWalletKey() (string, *bip32.ExtendedKey, error) {
+	entropy, err := bip39.GenerateEntropy(160)
+	if err != nil {
+		return "", nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	mnemonic, seed, err := bip39.Mnemonic(entropy, "")
+	if err != nil {
+		return "", nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	xpriv, err := bip32.NewMaster(seed, &chaincfg.MainNet)
+	if err != nil {
+		return "", nil, err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	return mnemonic, xpriv, nil
+}
+
+// getXpubID returns id of the xpub - SPV Wallet identifies xpubs by sha256 hash of their string representation.
+func getXpubID(xpub string) string {
+	hash := sha256.Sum256([]byte(xpub))
+	return hex.EncodeToString(hash[:])
+}
+
+// encryptKey encrypts the wallet key with invitation token.
+func encryptKey(token, key string) (string, error) {
+	hashedToken, err := encryption.Hash(token)
+	if err != nil {
+		return "", err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	return encryption.Encrypt(hashedToken, key) //nolint:wrapcheck // error wrapped higher in call stack
+}
+
+// decryptKey decrypts the wallet key with invitation token.
+func decryptKey(token, encryptedKey string) (string, error) {
+	hashedToken, err := encryption.Hash(token)
+	if err != nil {
+		return "", fmt.Errorf("internal error: %w", err)
+	}
+
+	key := encryption.Decrypt(hashedToken, encryptedKey)
+	if key == "" {
+		return "", spverrors.ErrInvalidCredentials
+	}
+
+	return key, nil
+}
+
+func generateInvitationToken() (string, error) {
+	random := make([]byte, invitationTokenSize)
+	if _, err := rand.Read(random); err != nil {
+		return "", err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+	return hex.EncodeToString(random), nil
+}
+
+func hashInvitationToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,27 @@
+package teams
+
+import (
+	"context"
+	"time"
+)
+
+// WalletsRepository is an interface which defines methods for team wallets repository.
+type WalletsRepository interface {
+	InsertWallet(ctx context.Context, wallet *Wallet, owner *Member) error
+	GetWallet(ctx context.Context, id int) (*Wallet, error)
+	GetMemberWallets(ctx context.Context, userID int) ([]*MemberWallet, error)
+	GetMembers(ctx context.Context, walletID int) ([]*Member, error)
+	GetMember(ctx context.Context, walletID, userID int) (*Member, error)
+	DeleteMember(ctx context.Context, walletID, userID int) error
+	InsertInvitation(ctx context.Context, invitation *Invitation) error
+	GetInvitation(ctx context.Context, tokenHash string) (*Invitation, error)
+	AcceptInvitation(ctx context.Context, member *Member, invitationID int) error
+	InsertTransaction(ctx context.Context, transaction *Transaction) error
+	GetTransactions(ctx context.Context, walletID, page, pageSize int) ([]*Transaction, int64, error)
+	InsertPendingPayment(ctx context.Context, payment *PendingPayment) error
+	GetPendingPayment(ctx context.Context, id int) (*PendingPayment, error)
+	GetPendingPayments(ctx context.Context, walletID int) ([]*PendingPayment, error)
+	InsertPaymentApproval(ctx context.Context, paymentID, userID int, approvedAt time.Time) ([]int, error)
+	UpdatePaymentStatus(ctx context.Context, id int, from, to string) (bool, error)
+	ExpirePendingPayments(ctx context.Context, expiredBefore time.Time) (int64, error)
+}
@@ -0,0 +1,28 @@
+package transactions
+
+import "time"
+
+// Tracked transaction statuses.
+const (
+	// TrackingStatusPending is a status of transaction which is not mined yet.
+	TrackingStatusPending = "pending"
+	// TrackingStatusMined is a status of transaction included in a block.
+	TrackingStatusMined = "mined"
+	// TrackingStatusReorged is a status of transaction removed from a block by chain reorganization.
+	TrackingStatusReorged = "reorged"
+	// TrackingStatusRejected is a status of transaction rejected by the network.
+	TrackingStatusRejected = "rejected"
+	// TrackingStatusConfirmed is a status of transaction which reached required confirmation depth.
+	TrackingStatusConfirmed = "confirmed"
+)
+
+// TrackedTransaction represents transaction followed until it reaches required confirmation depth.
+type TrackedTransaction struct {
+	TransactionID string
+	UserID        int
+	Status        string
+	BlockHash     string
+	BlockHeight   uint64
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
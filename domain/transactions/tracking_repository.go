@@ -0,0 +1,13 @@
+package transactions
+
+import (
+	"context"
+)
+
+// TrackingRepository is an interface which defines methods for tracked transactions repository.
+type TrackingRepository interface {
+	InsertTrackedTransaction(ctx context.Context, tx *TrackedTransaction) error
+	GetTrackedTransactions(ctx context.Context) ([]*TrackedTransaction, error)
+	UpdateTrackedTransaction(ctx context.Context, tx *TrackedTransaction) error
+	DeleteTrackedTransaction(ctx context.Context, transactionID string, userID int) error
+}
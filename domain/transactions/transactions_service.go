@@ -1,6 +1,8 @@
 package transactions
 
 import (
+	"context"
+	"fmt"
 	"math"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/bsv-blockchain/spv-wallet/models/filter"
 	"github.com/rs/zerolog"
 
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/audit"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/notification"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
@@ -19,49 +22,89 @@ import (
 type TransactionService struct {
 	adminWalletClient   users.AdminWalletClient
 	walletClientFactory users.WalletClientFactory
+	recorder            audit.Recorder
 	log                 *zerolog.Logger
 }
 
 // NewTransactionService creates new transaction service.
-func NewTransactionService(adminWalletClient users.AdminWalletClient, walletClientFactory users.WalletClientFactory, log *zerolog.Logger) *TransactionService {
+func NewTransactionService(adminWalletClient users.AdminWalletClient, walletClientFactory users.WalletClientFactory, recorder audit.Recorder, log *zerolog.Logger) *TransactionService {
 	transactionServiceLogger := log.With().Str("service", "transaction-service").Logger()
 	return &TransactionService{
 		adminWalletClient:   adminWalletClient,
 		walletClientFactory: walletClientFactory,
+		recorder:            recorder,
 		log:                 &transactionServiceLogger,
 	}
 }
 
-// CreateTransaction creates transaction.
-func (s *TransactionService) CreateTransaction(userPaymail, xpriv, recipient string, satoshis uint64, events chan notification.TransactionEvent) error {
+// CreateTransaction creates transaction. Payment is recorded in the audit log when it is recorded in SPV Wallet or fails.
+func (s *TransactionService) CreateTransaction(ctx context.Context, userPaymail, xpriv, recipient string, satoshis uint64, events chan notification.TransactionEvent) error {
+	return s.createTransaction(ctx, userPaymail, xpriv, recipient, satoshis, map[string]any{}, events)
+}
+
+// CreateInitiatedTransaction creates transaction of a shared wallet, the member who initiated it is stored in its metadata.
+func (s *TransactionService) CreateInitiatedTransaction(ctx context.Context, walletPaymail, initiator, xpriv, recipient string, satoshis uint64, events chan notification.TransactionEvent) error {
+	return s.createTransaction(ctx, walletPaymail, xpriv, recipient, satoshis, initiatorMetadata(initiator), events)
+}
+
+func (s *TransactionService) createTransaction(ctx context.Context, userPaymail, xpriv, recipient string, satoshis uint64, metadata map[string]any, events chan notification.TransactionEvent) error {
 	userWalletClient, err := s.walletClientFactory.CreateWithXpriv(xpriv)
 	if err != nil {
 		return spverrors.ErrCreateTransaction.Wrap(err)
 	}
 
+	draftTransaction, err := s.draftTransaction(ctx, userWalletClient, userPaymail, recipient, satoshis, metadata)
+	if err != nil {
+		return err
+	}
+
+	s.recordTransaction(ctx, userWalletClient, userPaymail, recipient, satoshis, draftTransaction, metadata, events)
+	return nil
+}
+
+func (s *TransactionService) draftTransaction(ctx context.Context, userWalletClient users.UserWalletClient, userPaymail, recipient string, satoshis uint64,
+	metadata map[string]any,
+) (users.DraftTransaction, error) {
 	recipients := []*commands.Recipients{{Satoshis: satoshis, To: recipient}}
-	metadata := map[string]any{"receiver": recipient, "sender": userPaymail}
+	metadata["receiver"] = recipient
+	metadata["sender"] = userPaymail
 
 	draftTransaction, err := userWalletClient.CreateAndFinalizeTransaction(recipients, metadata)
 	if err != nil {
 		s.log.Debug().Msgf("Error during create transaction: %s", err.Error())
-		return spverrors.ErrCreateTransaction
+		s.recordPayment(ctx, userPaymail, recipient, fmt.Sprintf("satoshis=%d error=%s", satoshis, err.Error()), err)
+		return nil, spverrors.ErrCreateTransaction
 	}
 
-	go func() {
-		if err == nil {
-			return
-		}
+	return draftTransaction, nil
+}
 
+// recordTransaction records the draft transaction in the background and passes the result to the events channel.
+func (s *TransactionService) recordTransaction(ctx context.Context, userWalletClient users.UserWalletClient, userPaymail, recipient string, satoshis uint64,
+	draftTransaction users.DraftTransaction, metadata map[string]any, events chan notification.TransactionEvent,
+) {
+	// Request is finished before the transaction is recorded, so the payment is recorded with the origin only.
+	ctx = context.WithoutCancel(ctx)
+	go func() {
 		tx, err := tryRecordTransaction(userWalletClient, draftTransaction, metadata, s.log)
 		if err != nil {
+			s.recordPayment(ctx, userPaymail, recipient, fmt.Sprintf("satoshis=%d error=%s", satoshis, err.Error()), err)
 			events <- notification.PrepareTransactionErrorEvent(err)
 		} else if tx != nil {
+			s.recordPayment(ctx, userPaymail, recipient, fmt.Sprintf("satoshis=%d transaction=%s", satoshis, tx.ID), nil)
 			events <- notification.PrepareTransactionEvent(tx)
 		}
 	}()
+}
 
-	return nil
+func (s *TransactionService) recordPayment(ctx context.Context, sender, recipient, details string, err error) {
+	s.recorder.Record(ctx, &audit.Event{
+		Actor:   sender,
+		Action:  audit.ActionPayment,
+		Target:  recipient,
+		Details: details,
+		Result:  audit.Result(err),
+	})
 }
 
 // GetTransaction returns transaction by id.
@@ -113,6 +156,11 @@ func (s *TransactionService) GetTransactions(accessKey, userPaymail string, quer
 	return pTransactions, nil
 }
 
+// initiatorMetadata returns transaction metadata with the member of a shared wallet who initiated it.
+func initiatorMetadata(initiator string) map[string]any {
+	return map[string]any{"initiator": initiator}
+}
+
 func tryRecordTransaction(userWalletClient users.UserWalletClient, draftTx users.DraftTransaction, metadata map[string]any, log *zerolog.Logger) (*models.Transaction, error) {
 	retries := uint(3)
 	tx, recordErr := tryRecord(userWalletClient, draftTx, metadata, log, retries)
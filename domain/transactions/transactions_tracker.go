@@ -0,0 +1,229 @@
+package transactions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/notification"
+)
+
+// rejectedStatuses are SPV Wallet transaction statuses which mean that transaction will not be mined.
+var rejectedStatuses = map[string]struct{}{
+	"REJECTED":    {},
+	"REVERTED":    {},
+	"PROBLEMATIC": {},
+}
+
+// StatusChangedHandler is a function called when tracked transaction of the user changes its status.
+type StatusChangedHandler func(userID int, event notification.TransactionStatusChangedEvent)
+
+// Tracker follows sent and received transactions until they reach required confirmation depth.
+// Tracked transactions are stored in the repository, so tracking is continued after restart.
+type Tracker struct {
+	repo              TrackingRepository
+	adminWalletClient users.AdminWalletClient
+	log               *zerolog.Logger
+
+	mutex    sync.RWMutex
+	handlers []StatusChangedHandler
+}
+
+// ChainInfo is a struct that contains blockchain info data.
+type ChainInfo struct {
+	Blocks uint64 `json:"blocks"`
+}
+
+// NewTracker creates new transactions tracker.
+func NewTracker(repo TrackingRepository, adminWalletClient users.AdminWalletClient, log *zerolog.Logger) *Tracker {
+	trackerLogger := log.With().Str("service", "transaction-tracker").Logger()
+	return &Tracker{
+		repo:              repo,
+		adminWalletClient: adminWalletClient,
+		log:               &trackerLogger,
+	}
+}
+
+// OnStatusChanged registers handler called on every status change of tracked transaction.
+func (t *Tracker) OnStatusChanged(handler StatusChangedHandler) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.handlers = append(t.handlers, handler)
+}
+
+// Track starts tracking of the transaction for the user. Transactions which are already tracked are ignored.
+func (t *Tracker) Track(ctx context.Context, userID int, transactionID string) error {
+	now := time.Now().UTC()
+	err := t.repo.InsertTrackedTransaction(ctx, &TrackedTransaction{
+		TransactionID: transactionID,
+		UserID:        userID,
+		Status:        TrackingStatusPending,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+	if err != nil {
+		t.log.Error().Str("transactionId", transactionID).Msgf("Cannot track transaction: %v", err.Error())
+		return fmt.Errorf("cannot track transaction %s: %w", transactionID, err)
+	}
+	return nil
+}
+
+// TrackWalletEvent starts tracking of transactions notified by SPV Wallet.
+func (t *Tracker) TrackWalletEvent(userID int, event any) {
+	txEvent, ok := event.(notification.WalletTransactionEvent)
+	if !ok || txEvent.Transaction == nil {
+		return
+	}
+	_ = t.Track(context.Background(), userID, txEvent.Transaction.ID)
+}
+
+// Run periodically checks tracked transactions. It blocks until the provided context is done.
+func (t *Tracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(viper.GetDuration(config.EnvTransactionsTrackingInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.CheckTrackedTransactions(ctx)
+		}
+	}
+}
+
+// CheckTrackedTransactions checks all tracked transactions and notifies about their status changes.
+func (t *Tracker) CheckTrackedTransactions(ctx context.Context) {
+	tracked, err := t.repo.GetTrackedTransactions(ctx)
+	if err != nil {
+		t.log.Error().Msgf("Cannot get tracked transactions: %v", err.Error())
+		return
+	}
+	if len(tracked) == 0 {
+		return
+	}
+
+	chainHeight, err := fetchChainHeight(ctx)
+	if err != nil {
+		// Status changes can be still detected, only confirmations are not counted.
+		t.log.Warn().Msgf("Cannot get current chain height: %v", err.Error())
+	}
+
+	for _, tx := range tracked {
+		t.check(ctx, tx, chainHeight)
+	}
+}
+
+func (t *Tracker) check(ctx context.Context, tracked *TrackedTransaction, chainHeight uint64) {
+	current, err := t.adminWalletClient.GetTransaction(tracked.TransactionID)
+	if err != nil {
+		t.log.Debug().Str("transactionId", tracked.TransactionID).Msgf("Error during checking tracked transaction: %s", err.Error())
+		return
+	}
+
+	status := nextTrackingStatus(tracked, current)
+	confirmations := countConfirmations(current.GetTransactionBlockHeight(), chainHeight)
+	if status != TrackingStatusRejected && confirmations > 0 && confirmations >= viper.GetUint64(config.EnvTransactionsTrackingConfirmations) {
+		status = TrackingStatusConfirmed
+	}
+
+	if status == tracked.Status && current.GetTransactionBlockHash() == tracked.BlockHash {
+		return
+	}
+
+	if status == TrackingStatusRejected || status == TrackingStatusConfirmed {
+		err = t.repo.DeleteTrackedTransaction(ctx, tracked.TransactionID, tracked.UserID)
+	} else {
+		tracked.Status = status
+		tracked.BlockHash = current.GetTransactionBlockHash()
+		tracked.BlockHeight = current.GetTransactionBlockHeight()
+		tracked.UpdatedAt = time.Now().UTC()
+		err = t.repo.UpdateTrackedTransaction(ctx, tracked)
+	}
+	if err != nil {
+		// Status change will be detected and notified again with the next check.
+		t.log.Error().Str("transactionId", tracked.TransactionID).Msgf("Cannot save tracked transaction: %v", err.Error())
+		return
+	}
+
+	t.notify(tracked.UserID, notification.PrepareTransactionStatusChangedEvent(
+		tracked.TransactionID,
+		status,
+		current.GetTransactionBlockHash(),
+		current.GetTransactionBlockHeight(),
+		confirmations,
+	))
+}
+
+func (t *Tracker) notify(userID int, event notification.TransactionStatusChangedEvent) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	for _, handler := range t.handlers {
+		handler(userID, event)
+	}
+}
+
+// nextTrackingStatus returns status of tracked transaction based on its current state in SPV Wallet.
+func nextTrackingStatus(tracked *TrackedTransaction, current users.FullTransaction) string {
+	blockHeight := current.GetTransactionBlockHeight()
+
+	switch {
+	case isRejected(current.GetTransactionStatus()):
+		return TrackingStatusRejected
+	case blockHeight == 0 && tracked.BlockHeight > 0:
+		return TrackingStatusReorged
+	case blockHeight > 0 && tracked.BlockHeight > 0 && current.GetTransactionBlockHash() != tracked.BlockHash:
+		// Transaction was moved to another block.
+		return TrackingStatusReorged
+	case blockHeight > 0 && tracked.BlockHeight == 0:
+		return TrackingStatusMined
+	}
+	return tracked.Status
+}
+
+func isRejected(status string) bool {
+	_, ok := rejectedStatuses[strings.ToUpper(status)]
+	return ok
+}
+
+func countConfirmations(blockHeight, chainHeight uint64) uint64 {
+	if blockHeight == 0 || chainHeight < blockHeight {
+		return 0
+	}
+	return chainHeight - blockHeight + 1
+}
+
+func fetchChainHeight(ctx context.Context) (uint64, error) {
+	chainInfoURL := viper.GetString(config.EnvEndpointsChainInfo)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, chainInfoURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error during creating chain info request: %w", err)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error during getting chain info: %w", err)
+	}
+	defer res.Body.Close() //nolint:errcheck // best effort cleanup
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return 0, fmt.Errorf("error during reading response body: %w", err)
+	}
+
+	var chainInfo ChainInfo
+	if err = json.Unmarshal(bodyBytes, &chainInfo); err != nil {
+		return 0, fmt.Errorf("error during unmarshalling response body: %w", err)
+	}
+	return chainInfo.Blocks, nil
+}
@@ -0,0 +1,95 @@
+package transactions
+
+import (
+	"context"
+	"time"
+
+	"github.com/bsv-blockchain/spv-wallet/models/filter"
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+)
+
+// watchPageSize is a number of the newest transactions checked for incoming payments.
+const watchPageSize = 20
+
+const incomingDirection = "incoming"
+
+// WatchIncomingTransactions periodically checks the newest user transactions and notifies about incoming ones.
+// Transactions existing at the moment of the first check are treated as already known, later ones are notified
+// when they are newer than the newest transaction seen so far.
+// It blocks until the provided context is done.
+func (s *TransactionService) WatchIncomingTransactions(ctx context.Context, accessKey, userPaymail string, notify func(tx users.Transaction)) {
+	userWalletClient, err := s.walletClientFactory.CreateWithAccessKey(accessKey)
+	if err != nil {
+		s.log.Error().Msgf("Cannot watch transactions: %v", err.Error())
+		return
+	}
+
+	var mark *highWaterMark
+	check := func() {
+		current, err := userWalletClient.GetTransactions(&filter.QueryParams{Page: 1, PageSize: watchPageSize}, userPaymail)
+		if err != nil {
+			s.log.Debug().Msgf("Error during checking transactions for incoming payments: %s", err.Error())
+			return
+		}
+
+		first := mark == nil
+		if first {
+			mark = &highWaterMark{}
+		}
+
+		// Notify in chronological order - transactions are returned from the newest.
+		for i := len(current) - 1; i >= 0; i-- {
+			tx := current[i]
+			if mark.advance(tx) && !first && tx.GetTransactionDirection() == incomingDirection {
+				notify(tx)
+			}
+		}
+	}
+
+	check()
+
+	ticker := time.NewTicker(getNotificationsInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			check()
+		}
+	}
+}
+
+// highWaterMark is the creation time of the newest transaction seen by the watcher, with transactions created
+// at exactly that time, so watching doesn't remember the whole history of the user.
+type highWaterMark struct {
+	createdAt time.Time
+	ids       map[string]struct{}
+}
+
+// advance moves the mark to the transaction and reports if it's newer than the transactions seen so far.
+func (m *highWaterMark) advance(tx users.Transaction) bool {
+	createdAt := tx.GetTransactionCreatedDate()
+	switch {
+	case m.ids == nil || createdAt.After(m.createdAt):
+		m.createdAt = createdAt
+		m.ids = map[string]struct{}{tx.GetTransactionID(): {}}
+		return true
+	case createdAt.Equal(m.createdAt):
+		if _, ok := m.ids[tx.GetTransactionID()]; ok {
+			return false
+		}
+		m.ids[tx.GetTransactionID()] = struct{}{}
+		return true
+	default:
+		return false
+	}
+}
+
+func getNotificationsInterval() time.Duration {
+	return viper.GetDuration(config.EnvTransactionsNotificationsInterval)
+}
@@ -62,10 +62,12 @@ type (
 		CreateAccessKey() (AccKey, error)
 		GetAccessKey(accessKeyID string) (AccKey, error)
 		RevokeAccessKey(accessKeyID string) (AccKey, error)
+		RevokeAllAccessKeys() error
 		// XPub Key methods
 		GetXPub() (PubKey, error)
 		// Transaction methods
 		SendToRecipients(recipients []*commands.Recipients, senderPaymail string) (Transaction, error)
+		SendAll(to, senderPaymail string) (Transaction, error)
 		GetTransactions(queryParam *filter.QueryParams, userPaymail string) ([]Transaction, error)
 		GetTransaction(transactionID, userPaymail string) (FullTransaction, error)
 		GetTransactionsCount() (int64, error)
@@ -83,8 +85,21 @@ type (
 	// AdminWalletClient defines methods which are available for an admin with admin key.
 	AdminWalletClient interface {
 		RegisterXpub(xpriv *bip32.ExtendedKey) (string, error)
-		RegisterPaymail(alias, xpub string) (string, error)
+		RegisterPaymail(alias, domain, xpub string, profile *PaymailProfile) (string, error)
+		UpdatePaymailProfile(address, xpub string, profile *PaymailProfile) error
+		DeletePaymail(address string) error
+		IsPaymailAvailable(alias, domain string) (bool, error)
+		GetXpub(xpubID string) (PubKey, error)
+		GetXpubPaymails(xpubID string) ([]*WalletPaymail, error)
 		GetSharedConfig() (*models.SharedConfig, error)
+		GetTransaction(transactionID string) (FullTransaction, error)
+	}
+
+	// AvatarStorage defines methods to store uploaded user avatars.
+	AvatarStorage interface {
+		Save(name string, data []byte) error
+		Delete(name string) error
+		Path(name string) (string, error)
 	}
 
 	// WalletClientFactory defines methods to create user and admin clients.
@@ -0,0 +1,266 @@
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+)
+
+// maxDisplayNameLength is max number of characters of the display name.
+const maxDisplayNameLength = 100
+
+// avatarExtensions maps supported avatar content types to file extensions.
+var avatarExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// ProfileService manages public profiles of users and propagates them to their paymails in SPV Wallet.
+type ProfileService struct {
+	repo              Repository
+	usersService      *UserService
+	adminWalletClient AdminWalletClient
+	storage           AvatarStorage
+	log               *zerolog.Logger
+}
+
+// NewProfileService creates ProfileService instance.
+func NewProfileService(repo Repository, usersService *UserService, adminWalletClient AdminWalletClient, storage AvatarStorage, l *zerolog.Logger) *ProfileService {
+	profileServiceLogger := l.With().Str("service", "profile-service").Logger()
+	return &ProfileService{
+		repo:              repo,
+		usersService:      usersService,
+		adminWalletClient: adminWalletClient,
+		storage:           storage,
+		log:               &profileServiceLogger,
+	}
+}
+
+// GetProfile returns profile of the user, empty profile is returned if user has not set it yet.
+func (s *ProfileService) GetProfile(userID int) (*Profile, error) {
+	profile, err := s.repo.GetUserProfile(context.Background(), userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting user profile: %v", err.Error())
+		return nil, spverrors.ErrGetProfile
+	}
+
+	if profile == nil {
+		profile = &Profile{UserID: userID}
+	}
+	profile.AvatarURL = avatarURL(profile.Avatar)
+
+	return profile, nil
+}
+
+// UpdateDisplayName sets public display name of the user and propagates it to user paymails.
+func (s *ProfileService) UpdateDisplayName(ctx context.Context, userID int, password, displayName string) (*Profile, error) {
+	displayName = strings.TrimSpace(displayName)
+	if !validDisplayName(displayName) {
+		return nil, spverrors.ErrInvalidDisplayName
+	}
+
+	xpriv, err := s.usersService.GetUserXpriv(ctx, userID, password)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := s.GetProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := toPaymailProfile(profile)
+	profile.DisplayName = displayName
+	if err = s.updateProfile(profile, previous, xpriv); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// UpdateAvatar stores uploaded avatar of the user and propagates it to user paymails.
+func (s *ProfileService) UpdateAvatar(ctx context.Context, userID int, password string, avatar []byte) (*Profile, error) {
+	extension, ok := avatarExtensions[http.DetectContentType(avatar)]
+	if !ok || len(avatar) == 0 || len(avatar) > viper.GetInt(config.EnvAvatarsMaxSize) {
+		return nil, spverrors.ErrInvalidAvatar
+	}
+
+	xpriv, err := s.usersService.GetUserXpriv(ctx, userID, password)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := s.GetProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := avatarName(userID, extension)
+	if err != nil {
+		s.log.Error().Msgf("Error while generating avatar name: %v", err.Error())
+		return nil, spverrors.ErrSaveProfile
+	}
+
+	if err = s.storage.Save(name, avatar); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while storing avatar: %v", err.Error())
+		return nil, spverrors.ErrSaveProfile
+	}
+
+	previous := toPaymailProfile(profile)
+	previousAvatar := profile.Avatar
+	profile.Avatar = name
+	profile.AvatarURL = avatarURL(name)
+	if err = s.updateProfile(profile, previous, xpriv); err != nil {
+		s.deleteAvatar(name)
+		return nil, err
+	}
+
+	if previousAvatar != "" {
+		s.deleteAvatar(previousAvatar)
+	}
+
+	return profile, nil
+}
+
+// AvatarPath returns location of the stored avatar.
+func (s *ProfileService) AvatarPath(name string) (string, error) {
+	path, err := s.storage.Path(name)
+	if err != nil {
+		return "", spverrors.ErrAvatarNotFound
+	}
+	return path, nil
+}
+
+// RemoveAvatar deletes stored avatar of the user, it is used to clean up after deleted accounts.
+func (s *ProfileService) RemoveAvatar(userID int) {
+	profile, err := s.repo.GetUserProfile(context.Background(), userID)
+	if err != nil {
+		s.log.Warn().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting user profile: %v", err.Error())
+		return
+	}
+
+	if profile != nil && profile.Avatar != "" {
+		s.deleteAvatar(profile.Avatar)
+	}
+}
+
+func (s *ProfileService) saveProfile(profile *Profile) error {
+	profile.UpdatedAt = time.Now()
+	if err := s.repo.UpsertUserProfile(context.Background(), profile); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(profile.UserID)).
+			Msgf("Error while saving user profile: %v", err.Error())
+		return spverrors.ErrSaveProfile
+	}
+	return nil
+}
+
+// updateProfile updates public name and avatar of all user paymails in SPV Wallet and then saves the profile.
+// When any paymail or the profile cannot be updated, paymails already updated are restored to the previous profile,
+// so paymails and the saved profile stay the same.
+func (s *ProfileService) updateProfile(profile *Profile, previous *PaymailProfile, xpriv string) error {
+	xpub, err := getXpub(xpriv)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(profile.UserID)).
+			Msgf("Error while getting xPub from xPriv: %v", err.Error())
+		return spverrors.ErrUpdatePaymailProfile
+	}
+
+	paymails, err := s.repo.GetUserPaymails(context.Background(), profile.UserID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(profile.UserID)).
+			Msgf("Error while getting user paymails: %v", err.Error())
+		return spverrors.ErrUpdatePaymailProfile
+	}
+
+	updated, err := s.updatePaymailProfiles(paymails, xpub, toPaymailProfile(profile))
+	if err == nil {
+		err = s.saveProfile(profile)
+	}
+	if err != nil {
+		_, _ = s.updatePaymailProfiles(updated, xpub, previous)
+		return err
+	}
+	return nil
+}
+
+// updatePaymailProfiles sets public name and avatar of the paymails until any of them fails, and returns updated paymails.
+func (s *ProfileService) updatePaymailProfiles(paymails []*UserPaymail, xpub string, profile *PaymailProfile) ([]*UserPaymail, error) {
+	updated := make([]*UserPaymail, 0, len(paymails))
+	for _, paymail := range paymails {
+		if err := s.adminWalletClient.UpdatePaymailProfile(paymail.Paymail, xpub, profile); err != nil {
+			s.log.Error().
+				Str("paymail", paymail.Paymail).
+				Msgf("Error while updating paymail profile: %v", err.Error())
+			return updated, spverrors.ErrUpdatePaymailProfile
+		}
+		updated = append(updated, paymail)
+	}
+	return updated, nil
+}
+
+func (s *ProfileService) deleteAvatar(name string) {
+	if err := s.storage.Delete(name); err != nil {
+		s.log.Warn().
+			Str("avatar", name).
+			Msgf("Error while deleting avatar: %v", err.Error())
+	}
+}
+
+// toPaymailProfile converts user profile to public name and avatar of paymail, nil means paymail defaults.
+func toPaymailProfile(profile *Profile) *PaymailProfile {
+	if profile == nil {
+		return nil
+	}
+	return &PaymailProfile{
+		PublicName: profile.DisplayName,
+		Avatar:     avatarURL(profile.Avatar),
+	}
+}
+
+// avatarURL returns public url of the stored avatar.
+func avatarURL(name string) string {
+	if name == "" {
+		return ""
+	}
+	return strings.TrimSuffix(viper.GetString(config.EnvAvatarsURL), "/") + "/" + name
+}
+
+// avatarName generates unique name of the avatar file.
+func avatarName(userID int, extension string) (string, error) {
+	random := make([]byte, 8)
+	if _, err := rand.Read(random); err != nil {
+		return "", err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+	return fmt.Sprintf("%d-%s%s", userID, hex.EncodeToString(random), extension), nil
+}
+
+func validDisplayName(displayName string) bool {
+	if displayName == "" || utf8.RuneCountInString(displayName) > maxDisplayNameLength {
+		return false
+	}
+	return strings.IndexFunc(displayName, unicode.IsControl) == -1
+}
@@ -4,13 +4,47 @@ import (
 	"time"
 )
 
+// Roles of users.
+const (
+	// RoleOwner is a role of the user owning the wallet, owner can use all features.
+	RoleOwner = "owner"
+	// RoleViewer is a role of the user invited by the owner, viewer can only see balance and history of the owner wallet.
+	RoleViewer = "viewer"
+)
+
 // User is a struct that contains user data.
 type User struct {
 	ID        int       `json:"id"`
 	Email     string    `json:"email"`
 	Xpriv     string    `json:"-"` // xPriv encrypted with user password
 	Paymail   string    `json:"paymail"`
+	XpubID    string    `json:"-"` // ID of user's xPub in SPV Wallet
 	CreatedAt time.Time `json:"created_at"`
+
+	DisabledAt        *time.Time `json:"disabled_at,omitempty"`
+	SessionsRevokedAt *time.Time `json:"-"` // sessions started before are not valid anymore
+
+	Role        string `json:"role"`
+	OwnerID     *int   `json:"owner_id,omitempty"` // owner of the wallet the viewer sees
+	AccessKeyID string `json:"-"`                  // ID of viewer's access key to the owner wallet
+	AccessKey   string `json:"-"`                  // viewer's access key to the owner wallet encrypted with viewer password
+
+	SSOIssuer  string `json:"-"` // issuer of the identity the user signs in with, empty for users signing in with password
+	SSOSubject string `json:"-"` // subject of the identity in the identity provider
+}
+
+// SSOIdentity is a struct that contains identity of the user verified by the identity provider.
+type SSOIdentity struct {
+	Issuer  string
+	Subject string
+	Email   string
+}
+
+// WalletPaymail is a struct that contains paymail registered in SPV Wallet.
+type WalletPaymail struct {
+	Address    string `json:"address"`
+	PublicName string `json:"public_name"`
+	Avatar     string `json:"avatar"`
 }
 
 // CreatedUser is a struct that contains new user information used to create http response.
@@ -25,6 +59,8 @@ type AuthenticatedUser struct {
 	AccessKey AccessKey
 	Balance   Balance
 	Xpriv     string `json:"-"` // xPriv should not be exposed to the client
+
+	SignedInAt time.Time `json:"-"`
 }
 
 // AccessKey is a struct that contains access key data.
@@ -33,6 +69,71 @@ type AccessKey struct {
 	Key string `json:"key"`
 }
 
+// ViewerInvitation is a struct that contains invitation of a viewer to the owner wallet.
+// Access key created for the viewer is encrypted with the invitation token, only hash of the token is stored.
+type ViewerInvitation struct {
+	ID          int
+	OwnerID     int
+	Email       string
+	TokenHash   string
+	AccessKeyID string
+	AccessKey   string
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+}
+
+// CreatedInvitation is a struct that contains invitation token passed by the owner to the invited viewer.
+type CreatedInvitation struct {
+	Email     string    `json:"email"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Scopes of API tokens.
+const (
+	// APITokenScopeBalanceRead allows to get the user and balance of the wallet.
+	APITokenScopeBalanceRead = "balance:read"
+	// APITokenScopeTransactionsRead allows to search and get transactions of the wallet.
+	APITokenScopeTransactionsRead = "transactions:read"
+	// APITokenScopeTransactionsSend allows to send transactions up to the send limit of the token.
+	APITokenScopeTransactionsSend = "transactions:send"
+	// APITokenScopeContactsManage allows to search, create, accept and reject contacts. Confirming contacts needs the xpriv,
+	// so it is allowed only in the session.
+	APITokenScopeContactsManage = "contacts:manage"
+)
+
+// APIToken is a struct that contains personal API token of the user.
+// Access key and xpriv of the token are encrypted with the token, only hash of the token is stored.
+type APIToken struct {
+	ID          int        `json:"id"`
+	UserID      int        `json:"-"`
+	Name        string     `json:"name"`
+	TokenHash   string     `json:"-"`
+	Scopes      []string   `json:"scopes"`
+	SendLimit   uint64     `json:"send_limit"` // total amount in satoshis the token can send
+	Spent       uint64     `json:"spent"`
+	AccessKeyID string     `json:"-"`
+	AccessKey   string     `json:"-"`
+	Xpriv       string     `json:"-"` // stored only for scopes which need to sign
+	ExpiresAt   time.Time  `json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// CreatedAPIToken is a struct that contains created API token, the token itself is returned only once.
+type CreatedAPIToken struct {
+	*APIToken
+	Token string `json:"token"`
+}
+
+// AuthorizedAPIToken is a struct that contains API token authorizing the request with its user and decrypted keys.
+type AuthorizedAPIToken struct {
+	Token     *APIToken
+	User      *User
+	AccessKey string
+	Xpriv     string
+}
+
 // ExchangeRate is a struct that contains exchange rate data.
 type ExchangeRate struct {
 	Rate float64
@@ -44,3 +145,65 @@ type Balance struct {
 	Bsv      float64 `json:"bsv"`
 	Satoshis uint64  `json:"satoshis"`
 }
+
+// UserPaymail is a struct that contains paymail owned by the user.
+type UserPaymail struct {
+	ID        int       `json:"-"`
+	UserID    int       `json:"-"`
+	Paymail   string    `json:"paymail"`
+	Primary   bool      `json:"primary"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Profile is a struct that contains public profile of the user presented on paymails.
+type Profile struct {
+	UserID      int       `json:"-"`
+	DisplayName string    `json:"display_name"`
+	Avatar      string    `json:"-"`
+	AvatarURL   string    `json:"avatar_url"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// SpendingPIN is a struct that contains spending PIN of the user with state of its attempt limiter.
+// Xpriv is a copy of the user xpriv encrypted with the PIN, empty for users signing in with the identity provider.
+type SpendingPIN struct {
+	UserID         int
+	Xpriv          string
+	FailedAttempts int
+	LockedUntil    *time.Time
+	UpdatedAt      time.Time
+}
+
+// PaymailProfile is a struct that contains public name and avatar set on paymail in SPV Wallet.
+type PaymailProfile struct {
+	PublicName string
+	Avatar     string
+}
+
+// Registration steps. Every finished step is persisted, so an interrupted registration is resumed from the first step not done.
+const (
+	// RegistrationStepCreated is a step of registration which has generated keys, but nothing registered in SPV Wallet.
+	RegistrationStepCreated = "created"
+	// RegistrationStepXpubRegistered is a step of registration which has xpub registered in SPV Wallet.
+	RegistrationStepXpubRegistered = "xpub_registered"
+	// RegistrationStepPaymailRegistered is a step of registration which has xpub and paymail registered in SPV Wallet.
+	RegistrationStepPaymailRegistered = "paymail_registered"
+)
+
+// PendingRegistration is a struct that contains state of the user registration which is not finished yet.
+// Xpriv and Mnemonic are encrypted with the user password, so the same keys are used when registration is resumed.
+type PendingRegistration struct {
+	ID        int
+	Email     string
+	Xpriv     string
+	Mnemonic  string
+	Alias     string
+	Domain    string
+	XpubID    string
+	Paymail   string
+	Step      string
+	Attempts  int
+	LastError string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,266 @@
+package users
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/bsv-blockchain/spv-wallet/models"
+	"github.com/bsv-blockchain/spv-wallet/models/filter"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+)
+
+// exportPageSize is the number of contacts and transactions fetched per request during data export.
+const exportPageSize = 100
+
+// AccountDeletionSubscriber is a function called before data of the deleted user are erased.
+type AccountDeletionSubscriber func(userID int)
+
+// ExportedData is a struct that contains all personal data of the user.
+type ExportedData struct {
+	User         *User                  `json:"user"`
+	Profile      *Profile               `json:"profile"`
+	Paymails     []*UserPaymail         `json:"paymails"`
+	Contacts     []*ExportedContact     `json:"contacts"`
+	Transactions []*ExportedTransaction `json:"transactions"`
+	ExportedAt   time.Time              `json:"exported_at"`
+}
+
+// ExportedContact is a struct that contains contact of the user included in data export.
+type ExportedContact struct {
+	FullName  string    `json:"full_name"`
+	Paymail   string    `json:"paymail"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ExportedTransaction is a struct that contains transaction of the user included in data export.
+type ExportedTransaction struct {
+	ID         string    `json:"id"`
+	Direction  string    `json:"direction"`
+	TotalValue uint64    `json:"total_value"`
+	Fee        uint64    `json:"fee"`
+	Status     string    `json:"status"`
+	Sender     string    `json:"sender"`
+	Receiver   string    `json:"receiver"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// SubscribeAccountDeletion registers subscriber called for every deleted account, before its data are erased.
+func (s *UserService) SubscribeAccountDeletion(subscriber AccountDeletionSubscriber) {
+	s.deletionSubscribers = append(s.deletionSubscribers, subscriber)
+}
+
+// DeleteAccount sends remaining funds of the user to sweepTo, deletes user paymails in SPV Wallet,
+// revokes all access keys and erases encrypted xpriv and personal data of the user.
+// Account with funds is not deleted if sweepTo is empty or funds cannot be sent.
+// Every step can be repeated, so failed deletion can be retried.
+func (s *UserService) DeleteAccount(ctx context.Context, userID int, password, sweepTo string) error {
+	xpriv, err := s.GetUserXpriv(ctx, userID, password)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	userWalletClient, err := s.walletClientFactory.CreateWithXpriv(xpriv)
+	if err != nil {
+		return spverrors.ErrDeleteAccount.Wrap(err)
+	}
+
+	if err = s.sweepFunds(userWalletClient, user, sweepTo); err != nil {
+		return err
+	}
+
+	paymails, err := s.repo.GetUserPaymails(ctx, userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting user paymails: %v", err.Error())
+		return spverrors.ErrDeleteAccount
+	}
+
+	for _, paymail := range paymails {
+		if err = s.adminWalletClient.DeletePaymail(paymail.Paymail); err != nil {
+			s.log.Error().
+				Str("paymail", paymail.Paymail).
+				Msgf("Error while deleting paymail: %v", err.Error())
+			return spverrors.ErrDeleteAccount
+		}
+	}
+
+	if err = userWalletClient.RevokeAllAccessKeys(); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while revoking access keys: %v", err.Error())
+		return spverrors.ErrDeleteAccount
+	}
+
+	for _, subscriber := range s.deletionSubscribers {
+		subscriber(userID)
+	}
+
+	if err = s.repo.DeleteUser(ctx, userID); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while deleting user: %v", err.Error())
+		return spverrors.ErrDeleteAccount
+	}
+
+	s.log.Info().
+		Str("userID", strconv.Itoa(userID)).
+		Msg("User account deleted")
+
+	return nil
+}
+
+// ExportData returns profile, paymails, contacts and transaction history of the user.
+func (s *UserService) ExportData(userID int, accessKey string) (*ExportedData, error) {
+	user, err := s.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := s.repo.GetUserProfile(context.Background(), userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting user profile: %v", err.Error())
+		return nil, spverrors.ErrExportData
+	}
+	if profile != nil {
+		profile.AvatarURL = avatarURL(profile.Avatar)
+	}
+
+	paymails, err := s.repo.GetUserPaymails(context.Background(), userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting user paymails: %v", err.Error())
+		return nil, spverrors.ErrExportData
+	}
+
+	userWalletClient, err := s.walletClientFactory.CreateWithAccessKey(accessKey)
+	if err != nil {
+		return nil, spverrors.ErrExportData.Wrap(err)
+	}
+
+	contacts, err := exportContacts(userWalletClient)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while exporting contacts: %v", err.Error())
+		return nil, spverrors.ErrExportData
+	}
+
+	transactions, err := exportTransactions(userWalletClient, user.Paymail)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while exporting transactions: %v", err.Error())
+		return nil, spverrors.ErrExportData
+	}
+
+	return &ExportedData{
+		User:         user,
+		Profile:      profile,
+		Paymails:     paymails,
+		Contacts:     contacts,
+		Transactions: transactions,
+		ExportedAt:   time.Now(),
+	}, nil
+}
+
+// sweepFunds sends all funds of the user to sweepTo, nothing is sent if the balance is empty.
+func (s *UserService) sweepFunds(userWalletClient UserWalletClient, user *User, sweepTo string) error {
+	xpub, err := userWalletClient.GetXPub()
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(user.ID)).
+			Msgf("Error while getting xPub: %v", err.Error())
+		return spverrors.ErrGetXPub
+	}
+
+	if xpub.GetCurrentBalance() == 0 {
+		return nil
+	}
+
+	if emptyString(sweepTo) {
+		return spverrors.ErrSweepAddressRequired
+	}
+
+	transaction, err := userWalletClient.SendAll(sweepTo, user.Paymail)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(user.ID)).
+			Msgf("Error while sending remaining funds: %v", err.Error())
+		return spverrors.ErrSweepFunds
+	}
+
+	s.log.Info().
+		Str("userID", strconv.Itoa(user.ID)).
+		Str("transactionID", transaction.GetTransactionID()).
+		Msg("Remaining funds of deleted account sent")
+
+	return nil
+}
+
+func exportContacts(userWalletClient UserWalletClient) ([]*ExportedContact, error) {
+	contacts := make([]*ExportedContact, 0)
+	for page := 1; ; page++ {
+		resp, err := userWalletClient.GetContacts(context.Background(), nil, nil, &filter.QueryParams{Page: page, PageSize: exportPageSize})
+		if err != nil {
+			return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+		}
+
+		for _, contact := range resp.Content {
+			contacts = append(contacts, toExportedContact(contact))
+		}
+		if len(resp.Content) == 0 || page >= resp.Page.TotalPages {
+			return contacts, nil
+		}
+	}
+}
+
+func exportTransactions(userWalletClient UserWalletClient, userPaymail string) ([]*ExportedTransaction, error) {
+	transactions := make([]*ExportedTransaction, 0)
+	for page := 1; ; page++ {
+		resp, err := userWalletClient.GetTransactions(&filter.QueryParams{Page: page, PageSize: exportPageSize}, userPaymail)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // error wrapped higher in call stack
+		}
+
+		for _, transaction := range resp {
+			transactions = append(transactions, toExportedTransaction(transaction))
+		}
+		if len(resp) < exportPageSize {
+			return transactions, nil
+		}
+	}
+}
+
+func toExportedContact(contact *models.Contact) *ExportedContact {
+	return &ExportedContact{
+		FullName:  contact.FullName,
+		Paymail:   contact.Paymail,
+		Status:    string(contact.Status),
+		CreatedAt: contact.CreatedAt,
+	}
+}
+
+func toExportedTransaction(transaction Transaction) *ExportedTransaction {
+	return &ExportedTransaction{
+		ID:         transaction.GetTransactionID(),
+		Direction:  transaction.GetTransactionDirection(),
+		TotalValue: transaction.GetTransactionTotalValue(),
+		Fee:        transaction.GetTransactionFee(),
+		Status:     transaction.GetTransactionStatus(),
+		Sender:     transaction.GetTransactionSender(),
+		Receiver:   transaction.GetTransactionReceiver(),
+		CreatedAt:  transaction.GetTransactionCreatedDate(),
+	}
+}
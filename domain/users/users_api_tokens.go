@@ -0,0 +1,279 @@
+package users
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+)
+
+const (
+	// apiTokenPrefix makes API tokens recognizable, e.g. by secret scanners.
+	apiTokenPrefix = "spvw_"
+	// maxAPITokenNameLength is the max number of characters of the API token name.
+	maxAPITokenNameLength = 64
+)
+
+// apiTokenScopes are the known scopes of API tokens.
+var apiTokenScopes = []string{
+	APITokenScopeBalanceRead,
+	APITokenScopeTransactionsRead,
+	APITokenScopeTransactionsSend,
+	APITokenScopeContactsManage,
+}
+
+// Personal API tokens let scripts use the API without the session. When the token is created, an access key to the
+// user wallet is created for it and encrypted with the token, the same as for invited viewers. Only tokens which can
+// send transactions need to sign, so only their xpriv is encrypted with the token as well. Amount sent with the token
+// is reserved from its send limit before the transaction is created and refunded if the transaction is not sent.
+
+// CreateAPIToken creates the API token of the user with the scopes, expiring at the given time or after default TTL if it is zero.
+func (s *UserService) CreateAPIToken(ctx context.Context, userID int, password, name string, scopes []string, sendLimit uint64, expiresAt time.Time) (*CreatedAPIToken, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxAPITokenNameLength {
+		return nil, spverrors.ErrInvalidAPITokenName
+	}
+
+	scopes, err := normalizeAPITokenScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	if slices.Contains(scopes, APITokenScopeTransactionsSend) != (sendLimit > 0) {
+		return nil, spverrors.ErrInvalidAPITokenSendLimit
+	}
+
+	now := time.Now().UTC()
+	if expiresAt.IsZero() {
+		expiresAt = now.Add(viper.GetDuration(config.EnvAPITokensDefaultTTL))
+	}
+	if !expiresAt.After(now) || expiresAt.After(now.Add(viper.GetDuration(config.EnvAPITokensMaxTTL))) {
+		return nil, spverrors.ErrInvalidAPITokenExpiry
+	}
+
+	xpriv, err := s.GetUserXpriv(ctx, userID, password)
+	if err != nil {
+		return nil, err
+	}
+
+	userWalletClient, err := s.walletClientFactory.CreateWithXpriv(xpriv)
+	if err != nil {
+		return nil, spverrors.ErrCreateAPIToken.Wrap(err)
+	}
+
+	accessKey, err := userWalletClient.CreateAccessKey()
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while creating access key for API token: %v", err.Error())
+		return nil, spverrors.ErrCreateAccessKey
+	}
+
+	token, err := generateAPIToken()
+	if err != nil {
+		return nil, spverrors.ErrCreateAPIToken.Wrap(err)
+	}
+
+	encryptedAccessKey, err := encryptXpriv(token, accessKey.GetAccessKey())
+	if err != nil {
+		return nil, spverrors.ErrCreateAPIToken.Wrap(err)
+	}
+
+	encryptedXpriv := ""
+	if apiTokenSigns(scopes) {
+		if encryptedXpriv, err = encryptXpriv(token, xpriv); err != nil {
+			return nil, spverrors.ErrCreateAPIToken.Wrap(err)
+		}
+	}
+
+	apiToken := &APIToken{
+		UserID:      userID,
+		Name:        name,
+		TokenHash:   hashAPIToken(token),
+		Scopes:      scopes,
+		SendLimit:   sendLimit,
+		AccessKeyID: accessKey.GetAccessKeyID(),
+		AccessKey:   encryptedAccessKey,
+		Xpriv:       encryptedXpriv,
+		ExpiresAt:   expiresAt,
+		CreatedAt:   now,
+	}
+	if err = s.repo.InsertAPIToken(ctx, apiToken); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while inserting API token: %v", err.Error())
+		return nil, spverrors.ErrCreateAPIToken
+	}
+
+	return &CreatedAPIToken{
+		APIToken: apiToken,
+		Token:    token,
+	}, nil
+}
+
+// GetAPITokens returns API tokens of the user.
+func (s *UserService) GetAPITokens(userID int) ([]*APIToken, error) {
+	tokens, err := s.repo.GetAPITokens(context.Background(), userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting API tokens: %v", err.Error())
+		return nil, spverrors.ErrGetAPITokens
+	}
+	return tokens, nil
+}
+
+// RevokeAPIToken revokes access key of the API token and deletes the token.
+func (s *UserService) RevokeAPIToken(ctx context.Context, userID, tokenID int, password string) error {
+	apiToken, err := s.repo.GetAPITokenByID(ctx, tokenID)
+	if err != nil {
+		s.log.Error().
+			Str("tokenID", strconv.Itoa(tokenID)).
+			Msgf("Error while getting API token: %v", err.Error())
+		return spverrors.ErrRevokeAPIToken
+	}
+	if apiToken == nil || apiToken.UserID != userID {
+		return spverrors.ErrAPITokenNotFound
+	}
+
+	xpriv, err := s.GetUserXpriv(ctx, userID, password)
+	if err != nil {
+		return err
+	}
+
+	userWalletClient, err := s.walletClientFactory.CreateWithXpriv(xpriv)
+	if err != nil {
+		return spverrors.ErrRevokeAPIToken.Wrap(err)
+	}
+
+	if _, err = userWalletClient.RevokeAccessKey(apiToken.AccessKeyID); err != nil {
+		s.log.Error().
+			Str("tokenID", strconv.Itoa(tokenID)).
+			Msgf("Error while revoking access key of API token: %v", err.Error())
+		return spverrors.ErrRevokeAPIToken
+	}
+
+	if err = s.repo.DeleteAPIToken(ctx, tokenID); err != nil {
+		s.log.Error().
+			Str("tokenID", strconv.Itoa(tokenID)).
+			Msgf("Error while deleting API token: %v", err.Error())
+		return spverrors.ErrRevokeAPIToken
+	}
+
+	return nil
+}
+
+// AuthorizeAPIToken returns the valid API token with its user and keys decrypted with the token.
+func (s *UserService) AuthorizeAPIToken(ctx context.Context, token string) (*AuthorizedAPIToken, error) {
+	apiToken, err := s.repo.GetAPIToken(ctx, hashAPIToken(token))
+	if err != nil {
+		s.log.Error().Msgf("Error while getting API token: %v", err.Error())
+		return nil, spverrors.ErrInvalidAPIToken
+	}
+	if apiToken == nil || time.Now().After(apiToken.ExpiresAt) {
+		return nil, spverrors.ErrInvalidAPIToken
+	}
+
+	user, err := s.repo.GetUserByID(ctx, apiToken.UserID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(apiToken.UserID)).
+			Msgf("Error while getting user by id: %v", err.Error())
+		return nil, spverrors.ErrInvalidAPIToken
+	}
+	if user.DisabledAt != nil {
+		return nil, spverrors.ErrAccountDisabled
+	}
+
+	accessKey, err := decryptXpriv(token, apiToken.AccessKey)
+	if err != nil {
+		return nil, spverrors.ErrInvalidAPIToken
+	}
+
+	xpriv := ""
+	if apiToken.Xpriv != "" {
+		if xpriv, err = decryptXpriv(token, apiToken.Xpriv); err != nil {
+			return nil, spverrors.ErrInvalidAPIToken
+		}
+	}
+
+	if err = s.repo.UpdateAPITokenLastUsed(ctx, apiToken.ID, time.Now().UTC()); err != nil {
+		s.log.Error().
+			Str("tokenID", strconv.Itoa(apiToken.ID)).
+			Msgf("Error while updating last use of API token: %v", err.Error())
+	}
+
+	return &AuthorizedAPIToken{
+		Token:     apiToken,
+		User:      user,
+		AccessKey: accessKey,
+		Xpriv:     xpriv,
+	}, nil
+}
+
+// SpendAPIToken reserves the amount from the send limit of the API token.
+func (s *UserService) SpendAPIToken(ctx context.Context, tokenID int, satoshis uint64) error {
+	spent, err := s.repo.SpendAPIToken(ctx, tokenID, satoshis)
+	if err != nil {
+		s.log.Error().
+			Str("tokenID", strconv.Itoa(tokenID)).
+			Msgf("Error while spending from API token limit: %v", err.Error())
+		return spverrors.ErrCreateTransaction
+	}
+	if !spent {
+		return spverrors.ErrAPITokenSendLimitExceeded
+	}
+	return nil
+}
+
+// RefundAPIToken returns the amount reserved from the send limit of the API token, when the transaction was not sent.
+func (s *UserService) RefundAPIToken(ctx context.Context, tokenID int, satoshis uint64) {
+	if err := s.repo.RefundAPIToken(ctx, tokenID, satoshis); err != nil {
+		s.log.Error().
+			Str("tokenID", strconv.Itoa(tokenID)).
+			Msgf("Error while refunding to API token limit: %v", err.Error())
+	}
+}
+
+// normalizeAPITokenScopes validates the scopes and returns them sorted without duplicates.
+func normalizeAPITokenScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, spverrors.ErrInvalidAPITokenScopes
+	}
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !slices.Contains(apiTokenScopes, scope) {
+			return nil, spverrors.ErrInvalidAPITokenScopes
+		}
+		normalized = append(normalized, scope)
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized), nil
+}
+
+// apiTokenSigns checks if any of the scopes needs xpriv to sign.
+func apiTokenSigns(scopes []string) bool {
+	return slices.Contains(scopes, APITokenScopeTransactionsSend)
+}
+
+func generateAPIToken() (string, error) {
+	random, err := generateInvitationToken()
+	if err != nil {
+		return "", err
+	}
+	return apiTokenPrefix + random, nil
+}
+
+func hashAPIToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
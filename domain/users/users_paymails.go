@@ -0,0 +1,203 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/libsv/go-bk/bip32"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+)
+
+// aliasPattern allows lowercase alphanumeric aliases with inner dots, dashes and underscores.
+var aliasPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9._-]{0,62}[a-z0-9])?$`)
+
+// AliasAvailability describes whether the paymail alias can be registered.
+type AliasAvailability struct {
+	Alias     string `json:"alias"`
+	Paymail   string `json:"paymail"`
+	Available bool   `json:"available"`
+}
+
+// CheckAliasAvailability checks if paymail with given alias can be registered in the domain.
+func (s *UserService) CheckAliasAvailability(alias, domain string) (*AliasAvailability, error) {
+	alias = normalizeAlias(alias)
+	domain = paymailDomain(domain)
+
+	err := s.validateAlias(alias, domain)
+	if err != nil && !errors.Is(err, spverrors.ErrPaymailAliasTaken) {
+		return nil, err
+	}
+
+	return &AliasAvailability{
+		Alias:     alias,
+		Paymail:   paymailAddress(alias, domain),
+		Available: err == nil,
+	}, nil
+}
+
+// GetUserPaymails returns all paymails of the user.
+func (s *UserService) GetUserPaymails(userID int) ([]*UserPaymail, error) {
+	paymails, err := s.repo.GetUserPaymails(context.Background(), userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting user paymails: %v", err.Error())
+		return nil, spverrors.ErrGetUserPaymails
+	}
+
+	return paymails, nil
+}
+
+// AddPaymail registers an additional paymail with given alias and domain for the user.
+func (s *UserService) AddPaymail(ctx context.Context, userID int, password, alias, domain string) (*UserPaymail, error) {
+	alias = normalizeAlias(alias)
+	domain = paymailDomain(domain)
+	if err := s.validateAlias(alias, domain); err != nil {
+		return nil, err
+	}
+
+	xpriv, err := s.GetUserXpriv(ctx, userID, password)
+	if err != nil {
+		return nil, err
+	}
+
+	xpub, err := getXpub(xpriv)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting xPub from xPriv: %v", err.Error())
+		return nil, spverrors.ErrGetXPub
+	}
+
+	// New paymail presents the same public profile as the other paymails of the user.
+	profile, err := s.repo.GetUserProfile(context.Background(), userID)
+	if err != nil {
+		s.log.Warn().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting user profile: %v", err.Error())
+	}
+
+	address, err := s.adminWalletClient.RegisterPaymail(alias, domain, xpub, toPaymailProfile(profile))
+	if err != nil {
+		s.log.Error().
+			Str("alias", alias).
+			Msgf("Error while registering paymail: %v", err.Error())
+		return nil, spverrors.ErrRegisterPaymail
+	}
+
+	paymail := &UserPaymail{
+		UserID:    userID,
+		Paymail:   address,
+		CreatedAt: time.Now(),
+	}
+	if err = s.repo.InsertUserPaymail(context.Background(), paymail); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Str("paymail", address).
+			Msgf("Error while inserting user paymail: %v", err.Error())
+		return nil, spverrors.ErrRegisterPaymail
+	}
+
+	return paymail, nil
+}
+
+// SetPrimaryPaymail makes the paymail owned by the user the one used as sender.
+func (s *UserService) SetPrimaryPaymail(userID int, address string) (*UserPaymail, error) {
+	address = strings.ToLower(strings.TrimSpace(address))
+
+	paymail, err := s.repo.GetUserPaymail(context.Background(), address)
+	if err != nil {
+		s.log.Error().
+			Str("paymail", address).
+			Msgf("Error while getting user paymail: %v", err.Error())
+		return nil, spverrors.ErrGetUserPaymails
+	}
+
+	if paymail == nil || paymail.UserID != userID {
+		return nil, spverrors.ErrPaymailNotFound
+	}
+
+	if err = s.repo.SetPrimaryPaymail(context.Background(), userID, address); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Str("paymail", address).
+			Msgf("Error while setting primary paymail: %v", err.Error())
+		return nil, spverrors.ErrSetPrimaryPaymail
+	}
+
+	paymail.Primary = true
+	return paymail, nil
+}
+
+// validateAlias checks the format of the alias and that it is not used in the domain neither locally nor in SPV Wallet.
+func (s *UserService) validateAlias(alias, domain string) error {
+	if !aliasPattern.MatchString(alias) {
+		return spverrors.ErrInvalidPaymailAlias
+	}
+
+	existing, err := s.repo.GetUserPaymail(context.Background(), paymailAddress(alias, domain))
+	if err != nil {
+		s.log.Error().
+			Str("alias", alias).
+			Msgf("Error while getting user paymail: %v", err.Error())
+		return spverrors.ErrCheckPaymailAlias
+	}
+	if existing != nil {
+		return spverrors.ErrPaymailAliasTaken
+	}
+
+	available, err := s.adminWalletClient.IsPaymailAvailable(alias, domain)
+	if err != nil {
+		s.log.Error().
+			Str("alias", alias).
+			Msgf("Error while checking paymail availability: %v", err.Error())
+		return spverrors.ErrCheckPaymailAlias
+	}
+	if !available {
+		return spverrors.ErrPaymailAliasTaken
+	}
+
+	return nil
+}
+
+// normalizeAlias lowercases and trims the alias.
+func normalizeAlias(alias string) string {
+	return strings.ToLower(strings.TrimSpace(alias))
+}
+
+// paymailDomain normalizes the domain, falling back to the configured paymail domain.
+func paymailDomain(domain string) string {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if domain == "" {
+		return viper.GetString(config.EnvPaymailDomain)
+	}
+	return domain
+}
+
+// paymailAddress creates paymail address from alias and domain.
+func paymailAddress(alias, domain string) string {
+	return fmt.Sprintf("%s@%s", alias, domain)
+}
+
+// getXpub returns xpub of the xpriv.
+func getXpub(xpriv string) (string, error) {
+	key, err := bip32.NewKeyFromString(xpriv)
+	if err != nil {
+		return "", err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	xpub, err := key.Neuter()
+	if err != nil {
+		return "", err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	return xpub.String(), nil
+}
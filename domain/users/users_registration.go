@@ -0,0 +1,287 @@
+package users
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/libsv/go-bk/bip32"
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+)
+
+// Registration is modeled as a saga: keys are generated and persisted first, then xpub and paymail
+// are registered in SPV Wallet and finally the user is inserted. Progress is persisted after every step,
+// so failed registration is resumed with the same keys. Registration which fails too many times or is
+// not finished in time is rolled back by compensating the steps done in SPV Wallet.
+
+// RunRegistrationCleanup periodically rolls back expired unfinished registrations until the context is canceled.
+func (s *UserService) RunRegistrationCleanup(ctx context.Context) {
+	ticker := time.NewTicker(viper.GetDuration(config.EnvRegistrationCleanupInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.CleanupPendingRegistrations(ctx)
+		}
+	}
+}
+
+// CleanupPendingRegistrations rolls back unfinished registrations which have expired.
+func (s *UserService) CleanupPendingRegistrations(ctx context.Context) {
+	expiredBefore := time.Now().Add(-viper.GetDuration(config.EnvRegistrationPendingTTL))
+	registrations, err := s.repo.GetPendingRegistrationsBefore(ctx, expiredBefore)
+	if err != nil {
+		s.log.Error().Msgf("Error while getting expired registrations: %v", err.Error())
+		return
+	}
+
+	for _, registration := range registrations {
+		s.compensateRegistration(registration, "registration expired")
+	}
+}
+
+// pendingRegistration returns unfinished registration for the email which can be resumed with the password.
+func (s *UserService) pendingRegistration(email, password string) (*PendingRegistration, error) {
+	registration, err := s.repo.GetPendingRegistration(context.Background(), email)
+	if err != nil {
+		s.log.Error().
+			Str("userEmail", email).
+			Msgf("Error while getting pending registration: %v", err.Error())
+		return nil, spverrors.ErrInsertUser
+	}
+	if registration == nil {
+		return nil, nil
+	}
+
+	if time.Since(registration.CreatedAt) > viper.GetDuration(config.EnvRegistrationPendingTTL) {
+		if !s.compensateRegistration(registration, "registration expired") {
+			return nil, spverrors.ErrRegistrationInProgress
+		}
+		return nil, nil
+	}
+
+	if _, err = decryptXpriv(password, registration.Xpriv); err != nil {
+		return nil, spverrors.ErrRegistrationInProgress
+	}
+
+	return registration, nil
+}
+
+// startRegistration validates requested paymail, generates keys and persists them as a new pending registration.
+func (s *UserService) startRegistration(email, password, alias, domain string) (*PendingRegistration, error) {
+	if emptyString(alias) {
+		alias, _ = splitEmail(email)
+	}
+	alias = normalizeAlias(alias)
+	domain = paymailDomain(domain)
+
+	if err := s.validateAlias(alias, domain); err != nil {
+		return nil, err
+	}
+
+	mnemonic, seed, err := generateMnemonic()
+	if err != nil {
+		s.log.Error().Msgf("Error while generating mnemonic: %v", err.Error())
+		return nil, spverrors.ErrGenerateMnemonic
+	}
+
+	xpriv, err := generateXpriv(seed)
+	if err != nil {
+		s.log.Error().Msgf("Error while generating xPriv: %v", err.Error())
+		return nil, spverrors.ErrGenerateXPriv
+	}
+
+	xpub, err := xpriv.Neuter()
+	if err != nil {
+		s.log.Error().Msgf("Error while getting xPub from xPriv: %v", err.Error())
+		return nil, spverrors.ErrGenerateXPriv
+	}
+
+	encryptedXpriv, err := encryptXpriv(password, xpriv.String())
+	if err != nil {
+		s.log.Error().Msgf("Error while encrypting xPriv: %v", err.Error())
+		return nil, spverrors.ErrEncryptXPriv
+	}
+
+	encryptedMnemonic, err := encryptXpriv(password, mnemonic)
+	if err != nil {
+		s.log.Error().Msgf("Error while encrypting mnemonic: %v", err.Error())
+		return nil, spverrors.ErrEncryptXPriv
+	}
+
+	now := time.Now()
+	registration := &PendingRegistration{
+		Email:     email,
+		Xpriv:     encryptedXpriv,
+		Mnemonic:  encryptedMnemonic,
+		Alias:     alias,
+		Domain:    domain,
+		XpubID:    getXpubID(xpub.String()),
+		Step:      RegistrationStepCreated,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err = s.repo.InsertPendingRegistration(context.Background(), registration); err != nil {
+		s.log.Error().
+			Str("userEmail", email).
+			Msgf("Error while inserting pending registration: %v", err.Error())
+		return nil, spverrors.ErrInsertUser
+	}
+
+	return registration, nil
+}
+
+// resumeRegistration runs the steps of the registration which are not done yet.
+// Users registering after sign in with the identity provider are created with the identity.
+func (s *UserService) resumeRegistration(registration *PendingRegistration, password string, identity *SSOIdentity) (*CreatedUser, error) {
+	decryptedXpriv, err := decryptXpriv(password, registration.Xpriv)
+	if err != nil {
+		return nil, spverrors.ErrRegistrationInProgress
+	}
+
+	mnemonic, err := decryptXpriv(password, registration.Mnemonic)
+	if err != nil {
+		return nil, spverrors.ErrRegistrationInProgress
+	}
+
+	xpriv, err := bip32.NewKeyFromString(decryptedXpriv)
+	if err != nil {
+		s.log.Error().Msgf("Error while parsing xPriv: %v", err.Error())
+		return nil, spverrors.ErrGenerateXPriv
+	}
+
+	if registration.Step == RegistrationStepCreated {
+		if _, err = s.adminWalletClient.RegisterXpub(xpriv); err != nil {
+			s.log.Error().Msgf("Error while registering xPub: %v", err.Error())
+			return nil, s.failRegistration(registration, err, spverrors.ErrRegisterXPub)
+		}
+		registration.Step = RegistrationStepXpubRegistered
+		s.saveRegistration(registration)
+	}
+
+	if registration.Step == RegistrationStepXpubRegistered {
+		if err = s.registerPaymail(registration, xpriv); err != nil {
+			return nil, err
+		}
+		registration.Step = RegistrationStepPaymailRegistered
+		s.saveRegistration(registration)
+	}
+
+	user := &User{
+		Email:     registration.Email,
+		Xpriv:     registration.Xpriv,
+		Paymail:   registration.Paymail,
+		XpubID:    registration.XpubID,
+		CreatedAt: time.Now(),
+		Role:      RoleOwner,
+	}
+
+	if identity != nil {
+		if err = s.setSSOIdentity(user, identity, decryptedXpriv); err != nil {
+			return nil, s.failRegistration(registration, err, spverrors.ErrCreateAccessKey)
+		}
+	}
+
+	if err = s.repo.CompleteRegistration(context.Background(), user, registration.ID); err != nil {
+		s.log.Error().Msgf("Error while inserting user: %v", err.Error())
+		return nil, s.failRegistration(registration, err, spverrors.ErrInsertUser)
+	}
+
+	return &CreatedUser{
+		User:     user,
+		Mnemonic: mnemonic,
+	}, nil
+}
+
+// registerPaymail registers paymail of the registration. Paymail taken by someone else in the meantime
+// fails the registration permanently, so it is rolled back at once.
+func (s *UserService) registerPaymail(registration *PendingRegistration, xpriv *bip32.ExtendedKey) error {
+	xpub, err := xpriv.Neuter()
+	if err != nil {
+		s.log.Error().Msgf("Error while getting xPub from xPriv: %v", err.Error())
+		return spverrors.ErrGenerateXPriv
+	}
+
+	paymail, err := s.adminWalletClient.RegisterPaymail(registration.Alias, registration.Domain, xpub.String(), nil)
+	if err == nil {
+		registration.Paymail = paymail
+		return nil
+	}
+
+	s.log.Error().
+		Str("alias", registration.Alias).
+		Msgf("Error while registering paymail: %v", err.Error())
+
+	if available, checkErr := s.adminWalletClient.IsPaymailAvailable(registration.Alias, registration.Domain); checkErr == nil && !available {
+		s.compensateRegistration(registration, "paymail alias taken")
+		return spverrors.ErrPaymailAliasTaken
+	}
+
+	return s.failRegistration(registration, err, spverrors.ErrRegisterPaymail)
+}
+
+// failRegistration records failed attempt of the registration and rolls it back when attempts are exhausted.
+func (s *UserService) failRegistration(registration *PendingRegistration, cause error, userErr error) error {
+	registration.Attempts++
+	registration.LastError = cause.Error()
+	s.saveRegistration(registration)
+
+	if registration.Attempts >= max(viper.GetInt(config.EnvRegistrationMaxAttempts), 1) {
+		s.compensateRegistration(registration, "too many failed attempts")
+	}
+
+	return userErr
+}
+
+// saveRegistration persists progress of the registration. Failure is only logged,
+// steps of the registration are idempotent, so they are repeated on resume.
+func (s *UserService) saveRegistration(registration *PendingRegistration) {
+	registration.UpdatedAt = time.Now()
+	if err := s.repo.UpdatePendingRegistration(context.Background(), registration); err != nil {
+		s.log.Warn().
+			Str("userEmail", registration.Email).
+			Msgf("Error while updating pending registration: %v", err.Error())
+	}
+}
+
+// compensateRegistration rolls back the steps of the registration done in SPV Wallet and removes it.
+// Returns false when the registration could not be rolled back and is kept for next cleanup.
+func (s *UserService) compensateRegistration(registration *PendingRegistration, reason string) bool {
+	s.log.Warn().
+		Str("userEmail", registration.Email).
+		Str("step", registration.Step).
+		Str("lastError", registration.LastError).
+		Msgf("Rolling back registration: %s", reason)
+
+	if registration.Step == RegistrationStepPaymailRegistered {
+		if err := s.adminWalletClient.DeletePaymail(registration.Paymail); err != nil {
+			s.log.Error().
+				Str("paymail", registration.Paymail).
+				Msgf("Error while deleting paymail of rolled back registration: %v", err.Error())
+			return false
+		}
+	}
+
+	// SPV Wallet admin API does not allow to delete xpub, so it is left unused.
+	if registration.Step != RegistrationStepCreated || registration.Attempts > 0 {
+		s.log.Warn().
+			Str("xpubId", registration.XpubID).
+			Msg("xPub of rolled back registration may be registered in SPV Wallet and is left unused")
+	}
+
+	if err := s.repo.DeletePendingRegistration(context.Background(), registration.ID); err != nil {
+		s.log.Error().
+			Str("registrationID", strconv.Itoa(registration.ID)).
+			Msgf("Error while deleting pending registration: %v", err.Error())
+		return false
+	}
+
+	return true
+}
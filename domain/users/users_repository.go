@@ -2,6 +2,7 @@ package users
 
 import (
 	"context"
+	"time"
 )
 
 // Repository is an interface which defines methods for Repository.
@@ -9,4 +10,41 @@ type Repository interface {
 	InsertUser(ctx context.Context, user *User) error
 	GetUserByEmail(ctx context.Context, email string) (*User, error)
 	GetUserByID(ctx context.Context, id int) (*User, error)
+	GetUserByXpubID(ctx context.Context, xpubID string) (*User, error)
+	GetUserBySSOIdentity(ctx context.Context, issuer, subject string) (*User, error)
+	UpdateUserXpubID(ctx context.Context, id int, xpubID string) error
+	DeleteUser(ctx context.Context, id int) error
+	SearchUsers(ctx context.Context, query string, page, pageSize int) ([]*User, int64, error)
+	SetUserDisabled(ctx context.Context, id int, disabledAt *time.Time) error
+	RevokeUserSessions(ctx context.Context, id int, revokedAt time.Time) error
+	InsertUserPaymail(ctx context.Context, paymail *UserPaymail) error
+	GetUserPaymails(ctx context.Context, userID int) ([]*UserPaymail, error)
+	GetUserPaymail(ctx context.Context, address string) (*UserPaymail, error)
+	SetPrimaryPaymail(ctx context.Context, userID int, address string) error
+	GetUserProfile(ctx context.Context, userID int) (*Profile, error)
+	UpsertUserProfile(ctx context.Context, profile *Profile) error
+	GetSpendingPIN(ctx context.Context, userID int) (*SpendingPIN, error)
+	UpsertSpendingPIN(ctx context.Context, pin *SpendingPIN) error
+	DeleteSpendingPIN(ctx context.Context, userID int) error
+	ReserveSpendingPINAttempt(ctx context.Context, userID int, attemptedAt time.Time) (int, bool, error)
+	LockSpendingPIN(ctx context.Context, userID int, lockedUntil time.Time) error
+	ResetSpendingPINAttempts(ctx context.Context, userID int) error
+	InsertPendingRegistration(ctx context.Context, registration *PendingRegistration) error
+	GetPendingRegistration(ctx context.Context, email string) (*PendingRegistration, error)
+	GetPendingRegistrationsBefore(ctx context.Context, createdBefore time.Time) ([]*PendingRegistration, error)
+	UpdatePendingRegistration(ctx context.Context, registration *PendingRegistration) error
+	DeletePendingRegistration(ctx context.Context, id int) error
+	CompleteRegistration(ctx context.Context, user *User, registrationID int) error
+	GetViewers(ctx context.Context, ownerID int) ([]*User, error)
+	InsertViewerInvitation(ctx context.Context, invitation *ViewerInvitation) error
+	GetViewerInvitation(ctx context.Context, tokenHash string) (*ViewerInvitation, error)
+	AcceptViewerInvitation(ctx context.Context, viewer *User, invitationID int) error
+	InsertAPIToken(ctx context.Context, token *APIToken) error
+	GetAPITokens(ctx context.Context, userID int) ([]*APIToken, error)
+	GetAPIToken(ctx context.Context, tokenHash string) (*APIToken, error)
+	GetAPITokenByID(ctx context.Context, id int) (*APIToken, error)
+	DeleteAPIToken(ctx context.Context, id int) error
+	UpdateAPITokenLastUsed(ctx context.Context, id int, usedAt time.Time) error
+	SpendAPIToken(ctx context.Context, id int, satoshis uint64) (bool, error)
+	RefundAPIToken(ctx context.Context, id int, satoshis uint64) error
 }
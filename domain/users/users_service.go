@@ -2,6 +2,8 @@ package users
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/mail"
 	"strconv"
@@ -14,6 +16,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/audit"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/rates"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/encryption"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
@@ -25,17 +28,20 @@ type UserService struct {
 	ratesService        *rates.Service
 	adminWalletClient   AdminWalletClient
 	walletClientFactory WalletClientFactory
+	recorder            audit.Recorder
+	deletionSubscribers []AccountDeletionSubscriber
 	log                 *zerolog.Logger
 }
 
 // NewUserService creates UserService instance.
-func NewUserService(repo Repository, adminWalletClient AdminWalletClient, walletClientFactory WalletClientFactory, rService *rates.Service, l *zerolog.Logger) *UserService {
+func NewUserService(repo Repository, adminWalletClient AdminWalletClient, walletClientFactory WalletClientFactory, rService *rates.Service, recorder audit.Recorder, l *zerolog.Logger) *UserService {
 	userServiceLogger := l.With().Str("service", "user-service").Logger()
 	s := &UserService{
 		repo:                repo,
 		adminWalletClient:   adminWalletClient,
 		walletClientFactory: walletClientFactory,
 		ratesService:        rService,
+		recorder:            recorder,
 		log:                 &userServiceLogger,
 	}
 
@@ -51,8 +57,10 @@ func (s *UserService) InsertUser(user *User) error {
 	return nil
 }
 
-// CreateNewUser creates new user.
-func (s *UserService) CreateNewUser(email, password string) (*CreatedUser, error) {
+// CreateNewUser creates new user with paymail in given domain, empty domain means the configured paymail domain.
+// When alias is empty, the username part of the email is used as paymail alias.
+// Registration which failed before is resumed with the same keys when the same email and password are used.
+func (s *UserService) CreateNewUser(email, password, alias, domain string) (*CreatedUser, error) {
 	if emptyString(password) {
 		return nil, spverrors.ErrEmptyPassword
 	}
@@ -61,62 +69,33 @@ func (s *UserService) CreateNewUser(email, password string) (*CreatedUser, error
 		return nil, err
 	}
 
-	mnemonic, seed, err := generateMnemonic()
+	registration, err := s.pendingRegistration(email, password)
 	if err != nil {
-		s.log.Error().Msgf("Error while generating mnemonic: %v", err.Error())
-		return nil, spverrors.ErrGenerateMnemonic
-	}
-
-	xpriv, err := generateXpriv(seed)
-	if err != nil {
-		s.log.Error().Msgf("Error while generating xPriv: %v", err.Error())
-		return nil, spverrors.ErrGenerateXPriv
-	}
-
-	encryptedXpriv, err := encryptXpriv(password, xpriv.String())
-	if err != nil {
-		s.log.Error().Msgf("Error while encrypting xPriv: %v", err.Error())
-		return nil, spverrors.ErrEncryptXPriv
-	}
-
-	xpub, err := s.adminWalletClient.RegisterXpub(xpriv)
-	if err != nil {
-		s.log.Error().Msgf("Error while registering xPub: %v", err.Error())
-		return nil, spverrors.ErrRegisterXPub
-	}
-
-	username, _ := splitEmail(email)
-
-	paymail, err := s.adminWalletClient.RegisterPaymail(username, xpub)
-	if err != nil {
-		s.log.Error().
-			Str("alias", username).
-			Msgf("Error while registering paymail: %v", err.Error())
-		return nil, spverrors.ErrRegisterPaymail
-	}
-
-	user := &User{
-		Email:     email,
-		Xpriv:     encryptedXpriv,
-		Paymail:   paymail,
-		CreatedAt: time.Now(),
-	}
-
-	if err = s.InsertUser(user); err != nil {
-		return nil, spverrors.ErrInsertUser
+		return nil, err
 	}
 
-	newUSerData := &CreatedUser{
-		User:     user,
-		Mnemonic: mnemonic,
+	if registration == nil {
+		registration, err = s.startRegistration(email, password, alias, domain)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return newUSerData, err
+	return s.resumeRegistration(registration, password, nil)
 }
 
-// SignInUser signs in user.
-func (s *UserService) SignInUser(email, password string) (*AuthenticatedUser, error) {
-	user, err := s.repo.GetUserByEmail(context.Background(), email)
+// SignInUser signs in user, every attempt is recorded in the audit log.
+func (s *UserService) SignInUser(ctx context.Context, email, password string) (signInUser *AuthenticatedUser, err error) {
+	var user *User
+	defer func() {
+		var userID *int
+		if user != nil {
+			userID = &user.ID
+		}
+		s.recordAuditEvent(ctx, audit.ActionSignIn, email, userID, err)
+	}()
+
+	user, err = s.repo.GetUserByEmail(ctx, email)
 	if err != nil {
 		s.log.Error().
 			Str("userEmail", email).
@@ -128,6 +107,15 @@ func (s *UserService) SignInUser(email, password string) (*AuthenticatedUser, er
 		return nil, spverrors.ErrInvalidCredentials
 	}
 
+	if user.Role == RoleViewer {
+		return s.signInViewer(user, password)
+	}
+
+	// Users of the identity provider sign in only with it, their xpriv is unlocked with spending PIN.
+	if user.SSOSubject != "" {
+		return nil, spverrors.ErrInvalidCredentials
+	}
+
 	decryptedXpriv, err := decryptXpriv(password, user.Xpriv)
 	if err != nil {
 		s.log.Error().
@@ -136,6 +124,16 @@ func (s *UserService) SignInUser(email, password string) (*AuthenticatedUser, er
 		return nil, spverrors.ErrInvalidCredentials
 	}
 
+	return s.SignInUnlockedUser(user, decryptedXpriv)
+}
+
+// SignInUnlockedUser signs in the user whose xpriv was already unlocked, e.g. with password or passkey.
+func (s *UserService) SignInUnlockedUser(user *User, decryptedXpriv string) (*AuthenticatedUser, error) {
+	// Checked after the xpriv is unlocked, so disabled accounts are not revealed to anyone without credentials.
+	if user.DisabledAt != nil {
+		return nil, spverrors.ErrAccountDisabled
+	}
+
 	userWalletClient, err := s.walletClientFactory.CreateWithXpriv(decryptedXpriv)
 	if err != nil {
 		return nil, spverrors.ErrInvalidCredentials.Wrap(err)
@@ -144,7 +142,7 @@ func (s *UserService) SignInUser(email, password string) (*AuthenticatedUser, er
 	accessKey, err := userWalletClient.CreateAccessKey()
 	if err != nil {
 		s.log.Error().
-			Str("userEmail", email).
+			Str("userEmail", user.Email).
 			Msgf("Error while creating access key: %v", err.Error())
 		return nil, spverrors.ErrCreateAccessKey
 	}
@@ -152,11 +150,22 @@ func (s *UserService) SignInUser(email, password string) (*AuthenticatedUser, er
 	xpub, err := userWalletClient.GetXPub()
 	if err != nil {
 		s.log.Error().
-			Str("userEmail", email).
+			Str("userEmail", user.Email).
 			Msgf("Error while getting xPub: %v", err.Error())
 		return nil, spverrors.ErrGetXPub
 	}
 
+	// Users registered before xpub ids were stored have it filled on sign in.
+	if user.XpubID != xpub.GetID() {
+		if err = s.repo.UpdateUserXpubID(context.Background(), user.ID, xpub.GetID()); err != nil {
+			s.log.Warn().
+				Str("userEmail", user.Email).
+				Msgf("Error while updating user xPub ID: %v", err.Error())
+		} else {
+			user.XpubID = xpub.GetID()
+		}
+	}
+
 	exchangeRate, err := s.ratesService.GetExchangeRate()
 	if err != nil {
 		s.log.Error().
@@ -166,17 +175,35 @@ func (s *UserService) SignInUser(email, password string) (*AuthenticatedUser, er
 
 	balance := calculateBalance(xpub.GetCurrentBalance(), exchangeRate)
 
-	signInUser := &AuthenticatedUser{
+	return &AuthenticatedUser{
 		User: user,
 		AccessKey: AccessKey{
 			ID:  accessKey.GetAccessKeyID(),
 			Key: accessKey.GetAccessKey(),
 		},
-		Balance: *balance,
-		Xpriv:   decryptedXpriv,
+		Balance:    *balance,
+		Xpriv:      decryptedXpriv,
+		SignedInAt: time.Now().UTC(),
+	}, nil
+}
+
+// AuthorizeSession checks if session of the user started at signedInAt is still valid,
+// i.e. the user is not disabled and sessions of the user were not revoked since then.
+func (s *UserService) AuthorizeSession(userID int, signedInAt time.Time) error {
+	user, err := s.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.DisabledAt != nil {
+		return spverrors.ErrAccountDisabled
 	}
 
-	return signInUser, nil
+	if user.SessionsRevokedAt != nil && signedInAt.Before(*user.SessionsRevokedAt) {
+		return spverrors.ErrSessionRevoked
+	}
+
+	return nil
 }
 
 // GetUserByID returns user by id.
@@ -217,9 +244,57 @@ func (s *UserService) GetUserBalance(accessKey string) (*Balance, error) {
 	return balance, nil
 }
 
-// GetUserXpriv gets user by id and decrypt xpriv.
-func (s *UserService) GetUserXpriv(userID int, password string) (string, error) {
-	user, err := s.repo.GetUserByID(context.Background(), userID)
+// GetUserXpriv gets user by id and decrypt xpriv, every attempt is recorded in the audit log.
+func (s *UserService) GetUserXpriv(ctx context.Context, userID int, password string) (string, error) {
+	return s.unlockUserKey(ctx, userID, password, func(user *User) string { return user.Xpriv })
+}
+
+// EncryptSharedKey encrypts key shared with the user, e.g. key of the team wallet, with the password of the user.
+// The password is validated first. Key of the user signing in with the identity provider is encrypted with spending PIN
+// the same way as the xpriv of the user, so the short PIN is not guessed offline from the key either.
+func (s *UserService) EncryptSharedKey(ctx context.Context, userID int, password, key string) (string, error) {
+	if _, err := s.GetUserXpriv(ctx, userID, password); err != nil {
+		return "", err
+	}
+
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting user by id: %v", err.Error())
+		return "", spverrors.ErrGetUser
+	}
+
+	encryptionKey := password
+	if user.SSOSubject != "" {
+		encryptionKey = spendingPINKey(user, password)
+	}
+
+	encryptedKey, err := encryptXpriv(encryptionKey, key)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while encrypting shared key: %v", err.Error())
+		return "", spverrors.ErrEncryptXPriv
+	}
+
+	return encryptedKey, nil
+}
+
+// DecryptSharedKey decrypts key shared with the user encrypted by EncryptSharedKey, every attempt is recorded in the audit log
+// and attempts with spending PIN are limited, as when the xpriv of the user is unlocked.
+func (s *UserService) DecryptSharedKey(ctx context.Context, userID int, password, encryptedKey string) (string, error) {
+	return s.unlockUserKey(ctx, userID, password, func(*User) string { return encryptedKey })
+}
+
+// unlockUserKey decrypts the key of the user with the password, every attempt is recorded in the audit log.
+func (s *UserService) unlockUserKey(ctx context.Context, userID int, password string, encryptedKey func(user *User) string) (key string, err error) {
+	actor := strconv.Itoa(userID)
+	defer func() {
+		s.recordAuditEvent(ctx, audit.ActionKeyUnlock, actor, &userID, err)
+	}()
+
+	user, err := s.repo.GetUserByID(ctx, userID)
 	if err != nil {
 		s.log.Error().
 			Str("userID", strconv.Itoa(userID)).
@@ -227,9 +302,15 @@ func (s *UserService) GetUserXpriv(userID int, password string) (string, error)
 
 		return "", spverrors.ErrGetUser
 	}
+	actor = user.Email
 
-	// Decrypt xpriv.
-	decryptedXpriv, err := decryptXpriv(password, user.Xpriv)
+	// Keys of users signing in with the identity provider are encrypted with spending PIN, so its attempts are limited.
+	if user.SSOSubject != "" {
+		return s.unlockWithSpendingPIN(ctx, user, password, encryptedKey(user))
+	}
+
+	// Decrypt key.
+	key, err = decryptXpriv(password, encryptedKey(user))
 	if err != nil {
 		s.log.Error().
 			Str("userID", strconv.Itoa(userID)).
@@ -237,7 +318,21 @@ func (s *UserService) GetUserXpriv(userID int, password string) (string, error)
 		return "", spverrors.ErrInvalidCredentials
 	}
 
-	return decryptedXpriv, nil
+	return key, nil
+}
+
+// recordAuditEvent records security-relevant action of the user, failed action is recorded with its error.
+func (s *UserService) recordAuditEvent(ctx context.Context, action, actor string, userID *int, err error) {
+	event := &audit.Event{
+		UserID: userID,
+		Actor:  actor,
+		Action: action,
+		Result: audit.Result(err),
+	}
+	if err != nil {
+		event.Details = err.Error()
+	}
+	s.recorder.Record(ctx, event)
 }
 
 func (s *UserService) validateUser(email string) error {
@@ -281,6 +376,12 @@ func generateXpriv(seed []byte) (*bip32.ExtendedKey, error) {
 	return xpriv, nil
 }
 
+// getXpubID returns id of the xpub - SPV Wallet identifies xpubs by sha256 hash of their string representation.
+func getXpubID(xpub string) string {
+	hash := sha256.Sum256([]byte(xpub))
+	return hex.EncodeToString(hash[:])
+}
+
 // encryptXpriv encrypts xpriv with password.
 func encryptXpriv(password, xpriv string) (string, error) {
 	// Create hash from password
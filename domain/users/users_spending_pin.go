@@ -0,0 +1,226 @@
+package users
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/audit"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/encryption"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+)
+
+// Spending PIN unlocks a copy of the xpriv encrypted with it, so the password is not typed on every payment.
+// Users signing in with the identity provider have the xpriv encrypted with the PIN since registration,
+// so they have no copy and their PIN is limited the same way wherever it unlocks the xpriv.
+// Short PIN is easy to guess, so the key is derived from it with memory-hard function and server pepper, which makes
+// guessing it offline slow, and attempts are counted before the key is derived and lock the PIN after too many invalid
+// ones, which stops guessing it online, also with concurrent requests.
+
+// SetSpendingPIN sets spending PIN of the user, the password decrypts the xpriv which is then encrypted with the PIN.
+func (s *UserService) SetSpendingPIN(ctx context.Context, userID int, password, pin string) error {
+	if !validSpendingPIN(pin) {
+		return spverrors.ErrInvalidSpendingPIN
+	}
+
+	user, err := s.checkSpendingPINManageable(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	xpriv, err := s.GetUserXpriv(ctx, userID, password)
+	if err != nil {
+		return err
+	}
+
+	encryptedXpriv, err := encryptXpriv(spendingPINKey(user, pin), xpriv)
+	if err != nil {
+		return spverrors.ErrSetSpendingPIN.Wrap(err)
+	}
+
+	spendingPIN := &SpendingPIN{
+		UserID:    userID,
+		Xpriv:     encryptedXpriv,
+		UpdatedAt: time.Now().UTC(),
+	}
+	if err = s.repo.UpsertSpendingPIN(ctx, spendingPIN); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while setting spending PIN: %v", err.Error())
+		return spverrors.ErrSetSpendingPIN
+	}
+
+	return nil
+}
+
+// RemoveSpendingPIN removes spending PIN of the user, the password is required to remove it.
+func (s *UserService) RemoveSpendingPIN(ctx context.Context, userID int, password string) error {
+	if _, err := s.checkSpendingPINManageable(ctx, userID); err != nil {
+		return err
+	}
+
+	if _, err := s.GetUserXpriv(ctx, userID, password); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteSpendingPIN(ctx, userID); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while removing spending PIN: %v", err.Error())
+		return spverrors.ErrRemoveSpendingPIN
+	}
+
+	return nil
+}
+
+// HasSpendingPIN checks if the user can unlock the xpriv with spending PIN.
+func (s *UserService) HasSpendingPIN(ctx context.Context, user *User) (bool, error) {
+	if user.SSOSubject != "" {
+		return true, nil
+	}
+
+	spendingPIN, err := s.repo.GetSpendingPIN(ctx, user.ID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(user.ID)).
+			Msgf("Error while getting spending PIN: %v", err.Error())
+		return false, spverrors.ErrGetUser
+	}
+
+	return spendingPIN != nil && spendingPIN.Xpriv != "", nil
+}
+
+// UnlockWithSpendingPIN returns xpriv of the user decrypted with spending PIN, every attempt is recorded in the audit log.
+func (s *UserService) UnlockWithSpendingPIN(ctx context.Context, userID int, pin string) (xpriv string, err error) {
+	actor := strconv.Itoa(userID)
+	defer func() {
+		s.recordAuditEvent(ctx, audit.ActionSpendingPINUnlock, actor, &userID, err)
+	}()
+
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting user by id: %v", err.Error())
+		return "", spverrors.ErrGetUser
+	}
+	actor = user.Email
+
+	if user.SSOSubject != "" {
+		return s.unlockSSOUserXpriv(ctx, user, pin)
+	}
+
+	spendingPIN, err := s.repo.GetSpendingPIN(ctx, userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting spending PIN: %v", err.Error())
+		return "", spverrors.ErrGetUser
+	}
+	if spendingPIN == nil || spendingPIN.Xpriv == "" {
+		return "", spverrors.ErrSpendingPINNotSet
+	}
+
+	return s.unlockWithSpendingPIN(ctx, user, pin, spendingPIN.Xpriv)
+}
+
+// unlockSSOUserXpriv decrypts xpriv of the user signing in with the identity provider with spending PIN.
+func (s *UserService) unlockSSOUserXpriv(ctx context.Context, user *User, pin string) (string, error) {
+	return s.unlockWithSpendingPIN(ctx, user, pin, user.Xpriv)
+}
+
+// unlockWithSpendingPIN decrypts the xpriv, or other key of the user, with spending PIN. The attempt is counted before the key is derived, so
+// locked PIN is not tried at all. Invalid attempt which exhausts the attempts locks the PIN, valid one resets them.
+func (s *UserService) unlockWithSpendingPIN(ctx context.Context, user *User, pin, encryptedXpriv string) (string, error) {
+	now := time.Now().UTC()
+	attempt, err := s.reserveSpendingPINAttempt(ctx, user.ID, now)
+	if err != nil {
+		return "", err
+	}
+
+	xpriv, err := decryptXpriv(spendingPINKey(user, pin), encryptedXpriv)
+	if err != nil {
+		if attempt < maxSpendingPINAttempts() {
+			return "", spverrors.ErrInvalidCredentials
+		}
+		s.lockSpendingPIN(ctx, user.ID, now)
+		return "", spverrors.ErrSpendingPINLocked
+	}
+
+	if err = s.repo.ResetSpendingPINAttempts(ctx, user.ID); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(user.ID)).
+			Msgf("Error while resetting spending PIN attempts: %v", err.Error())
+	}
+
+	return xpriv, nil
+}
+
+// reserveSpendingPINAttempt counts attempt of spending PIN before it is tried and returns its number.
+// Attempt of locked PIN or over the limit, e.g. of concurrent requests, is rejected.
+func (s *UserService) reserveSpendingPINAttempt(ctx context.Context, userID int, attemptedAt time.Time) (int, error) {
+	attempt, reserved, err := s.repo.ReserveSpendingPINAttempt(ctx, userID, attemptedAt)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while counting spending PIN attempt: %v", err.Error())
+		return 0, spverrors.ErrGetUser
+	}
+	if !reserved {
+		return 0, spverrors.ErrSpendingPINLocked
+	}
+	if attempt > maxSpendingPINAttempts() {
+		s.lockSpendingPIN(ctx, userID, attemptedAt)
+		return 0, spverrors.ErrSpendingPINLocked
+	}
+	return attempt, nil
+}
+
+// lockSpendingPIN locks spending PIN of the user after too many invalid attempts.
+func (s *UserService) lockSpendingPIN(ctx context.Context, userID int, failedAt time.Time) {
+	if err := s.repo.LockSpendingPIN(ctx, userID, failedAt.Add(viper.GetDuration(config.EnvSpendingPINLockout))); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while locking spending PIN: %v", err.Error())
+	}
+	s.log.Warn().
+		Str("userID", strconv.Itoa(userID)).
+		Msg("Spending PIN locked after too many invalid attempts")
+}
+
+func maxSpendingPINAttempts() int {
+	return max(viper.GetInt(config.EnvSpendingPINMaxAttempts), 1)
+}
+
+// checkSpendingPINManageable returns the user if spending PIN of the user can be set and removed, users signing in with
+// the identity provider have it set at registration.
+func (s *UserService) checkSpendingPINManageable(ctx context.Context, userID int) (*User, error) {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(userID)).
+			Msgf("Error while getting user by id: %v", err.Error())
+		return nil, spverrors.ErrGetUser
+	}
+	if user.SSOSubject != "" {
+		return nil, spverrors.ErrSpendingPINManagedBySSO
+	}
+	return user, nil
+}
+
+// spendingPINKey derives key encrypting xpriv of the user from spending PIN.
+func spendingPINKey(user *User, pin string) string {
+	if user.SSOSubject != "" {
+		return ssoSpendingPINKey(user.SSOIssuer, user.SSOSubject, pin)
+	}
+	return encryption.PINKey(pin, "user:"+strconv.Itoa(user.ID))
+}
+
+// ssoSpendingPINKey derives key encrypting xpriv of the user of the identity provider from spending PIN,
+// the key is salted with the identity, because it is used before the user is created at registration.
+func ssoSpendingPINKey(issuer, subject, pin string) string {
+	return encryption.PINKey(pin, "sso:"+issuer+":"+subject)
+}
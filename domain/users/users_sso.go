@@ -0,0 +1,142 @@
+package users
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/audit"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+)
+
+const (
+	// minSpendingPINLength is the min number of digits of the spending PIN.
+	minSpendingPINLength = 6
+	// maxSpendingPINLength is the max number of digits of the spending PIN.
+	maxSpendingPINLength = 12
+)
+
+// Users signing in with the identity provider have no password. They set spending PIN when they register,
+// which encrypts the xpriv instead of the password, so signing in and authorizing payments stay separate.
+// To open sessions without the PIN, an access key is created at registration and encrypted with the server secret.
+
+// SignInSSOUser signs in user mapped to the identity verified by the identity provider, every attempt is recorded in the audit log.
+func (s *UserService) SignInSSOUser(ctx context.Context, identity *SSOIdentity) (signInUser *AuthenticatedUser, err error) {
+	var user *User
+	defer func() {
+		var userID *int
+		if user != nil {
+			userID = &user.ID
+		}
+		s.recordAuditEvent(ctx, audit.ActionSignIn, identity.Email, userID, err)
+	}()
+
+	user, err = s.repo.GetUserBySSOIdentity(ctx, identity.Issuer, identity.Subject)
+	if err != nil {
+		s.log.Error().
+			Str("subject", identity.Subject).
+			Msgf("Error while getting user by identity: %v", err.Error())
+		return nil, spverrors.ErrGetUser
+	}
+	if user == nil {
+		return nil, spverrors.ErrSSORegistrationRequired
+	}
+
+	if user.DisabledAt != nil {
+		return nil, spverrors.ErrAccountDisabled
+	}
+
+	accessKey, err := decryptXpriv(viper.GetString(config.EnvSSOAccessKeySecret), user.AccessKey)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(user.ID)).
+			Msgf("Error while decrypting access key: %v", err.Error())
+		return nil, spverrors.ErrSSOAuthorization
+	}
+
+	balance, err := s.GetUserBalance(accessKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthenticatedUser{
+		User: user,
+		AccessKey: AccessKey{
+			ID:  user.AccessKeyID,
+			Key: accessKey,
+		},
+		Balance:    *balance,
+		SignedInAt: time.Now().UTC(),
+	}, nil
+}
+
+// RegisterSSOUser creates new user mapped to the identity verified by the identity provider, with xpriv encrypted with the spending PIN.
+func (s *UserService) RegisterSSOUser(identity *SSOIdentity, pin, alias, domain string) (*CreatedUser, error) {
+	if !validSpendingPIN(pin) {
+		return nil, spverrors.ErrInvalidSpendingPIN
+	}
+
+	if err := s.validateUser(identity.Email); err != nil {
+		return nil, err
+	}
+
+	// Keys are encrypted with the key derived from the PIN already while the registration is pending.
+	key := ssoSpendingPINKey(identity.Issuer, identity.Subject, pin)
+
+	registration, err := s.pendingRegistration(identity.Email, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if registration == nil {
+		registration, err = s.startRegistration(identity.Email, key, alias, domain)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.resumeRegistration(registration, key, identity)
+}
+
+// setSSOIdentity maps the user to the identity and creates access key used to open sessions of the user.
+func (s *UserService) setSSOIdentity(user *User, identity *SSOIdentity, xpriv string) error {
+	userWalletClient, err := s.walletClientFactory.CreateWithXpriv(xpriv)
+	if err != nil {
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	accessKey, err := userWalletClient.CreateAccessKey()
+	if err != nil {
+		s.log.Error().
+			Str("userEmail", user.Email).
+			Msgf("Error while creating access key for identity provider sessions: %v", err.Error())
+		return err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+
+	encryptedAccessKey, err := encryptXpriv(viper.GetString(config.EnvSSOAccessKeySecret), accessKey.GetAccessKey())
+	if err != nil {
+		return err
+	}
+
+	user.SSOIssuer = identity.Issuer
+	user.SSOSubject = identity.Subject
+	user.AccessKeyID = accessKey.GetAccessKeyID()
+	user.AccessKey = encryptedAccessKey
+	return nil
+}
+
+// validSpendingPIN checks if the spending PIN consists of the allowed number of digits.
+func validSpendingPIN(pin string) bool {
+	if len(pin) < minSpendingPINLength || len(pin) > maxSpendingPINLength {
+		return false
+	}
+	for _, r := range pin {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,212 @@
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+)
+
+// invitationTokenSize is the number of random bytes of the viewer invitation token.
+const invitationTokenSize = 32
+
+// Viewers have no keys of their own. When the owner invites a viewer, an access key to the owner wallet is created
+// and encrypted with a random invitation token, which the owner passes to the viewer. Viewer accepting the invitation
+// sets a password, the access key is then encrypted with it, so the viewer can sign in without the owner.
+
+// InviteViewer creates an access key to the wallet of the owner and returns invitation of a viewer with the email.
+func (s *UserService) InviteViewer(ctx context.Context, ownerID int, password, email string) (*CreatedInvitation, error) {
+	email = strings.TrimSpace(email)
+	if err := s.validateUser(email); err != nil {
+		return nil, err
+	}
+
+	xpriv, err := s.GetUserXpriv(ctx, ownerID, password)
+	if err != nil {
+		return nil, err
+	}
+
+	userWalletClient, err := s.walletClientFactory.CreateWithXpriv(xpriv)
+	if err != nil {
+		return nil, spverrors.ErrInviteViewer.Wrap(err)
+	}
+
+	accessKey, err := userWalletClient.CreateAccessKey()
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(ownerID)).
+			Msgf("Error while creating access key for viewer: %v", err.Error())
+		return nil, spverrors.ErrCreateAccessKey
+	}
+
+	token, err := generateInvitationToken()
+	if err != nil {
+		return nil, spverrors.ErrInviteViewer.Wrap(err)
+	}
+
+	encryptedAccessKey, err := encryptXpriv(token, accessKey.GetAccessKey())
+	if err != nil {
+		return nil, spverrors.ErrInviteViewer.Wrap(err)
+	}
+
+	now := time.Now().UTC()
+	invitation := &ViewerInvitation{
+		OwnerID:     ownerID,
+		Email:       email,
+		TokenHash:   hashInvitationToken(token),
+		AccessKeyID: accessKey.GetAccessKeyID(),
+		AccessKey:   encryptedAccessKey,
+		ExpiresAt:   now.Add(viper.GetDuration(config.EnvViewersInvitationTTL)),
+		CreatedAt:   now,
+	}
+	if err = s.repo.InsertViewerInvitation(ctx, invitation); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(ownerID)).
+			Msgf("Error while inserting viewer invitation: %v", err.Error())
+		return nil, spverrors.ErrInviteViewer
+	}
+
+	return &CreatedInvitation{
+		Email:     email,
+		Token:     token,
+		ExpiresAt: invitation.ExpiresAt,
+	}, nil
+}
+
+// AcceptViewerInvitation creates the viewer account signing in with the password.
+func (s *UserService) AcceptViewerInvitation(ctx context.Context, token, password string) (*User, error) {
+	invitation, err := s.repo.GetViewerInvitation(ctx, hashInvitationToken(token))
+	if err != nil {
+		s.log.Error().Msgf("Error while getting viewer invitation: %v", err.Error())
+		return nil, spverrors.ErrAcceptInvitation
+	}
+	if invitation == nil || time.Now().After(invitation.ExpiresAt) {
+		return nil, spverrors.ErrInvalidInvitation
+	}
+
+	// Email could be registered since the invitation was created.
+	if err = s.validateUser(invitation.Email); err != nil {
+		return nil, err
+	}
+
+	accessKey, err := decryptXpriv(token, invitation.AccessKey)
+	if err != nil {
+		return nil, spverrors.ErrInvalidInvitation
+	}
+
+	encryptedAccessKey, err := encryptXpriv(password, accessKey)
+	if err != nil {
+		return nil, spverrors.ErrAcceptInvitation.Wrap(err)
+	}
+
+	viewer := &User{
+		Email:       invitation.Email,
+		Role:        RoleViewer,
+		OwnerID:     &invitation.OwnerID,
+		AccessKeyID: invitation.AccessKeyID,
+		AccessKey:   encryptedAccessKey,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err = s.repo.AcceptViewerInvitation(ctx, viewer, invitation.ID); err != nil {
+		s.log.Error().
+			Str("userEmail", invitation.Email).
+			Msgf("Error while accepting viewer invitation: %v", err.Error())
+		return nil, spverrors.ErrAcceptInvitation
+	}
+
+	return viewer, nil
+}
+
+// GetViewers returns viewers of the owner wallet.
+func (s *UserService) GetViewers(ownerID int) ([]*User, error) {
+	viewers, err := s.repo.GetViewers(context.Background(), ownerID)
+	if err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(ownerID)).
+			Msgf("Error while getting viewers: %v", err.Error())
+		return nil, spverrors.ErrGetViewers
+	}
+	return viewers, nil
+}
+
+// RemoveViewer revokes access key of the viewer and deletes the viewer account.
+func (s *UserService) RemoveViewer(ctx context.Context, ownerID, viewerID int, password string) error {
+	viewer, err := s.repo.GetUserByID(ctx, viewerID)
+	if err != nil || viewer.OwnerID == nil || *viewer.OwnerID != ownerID {
+		return spverrors.ErrViewerNotFound
+	}
+
+	xpriv, err := s.GetUserXpriv(ctx, ownerID, password)
+	if err != nil {
+		return err
+	}
+
+	userWalletClient, err := s.walletClientFactory.CreateWithXpriv(xpriv)
+	if err != nil {
+		return spverrors.ErrRemoveViewer.Wrap(err)
+	}
+
+	if _, err = userWalletClient.RevokeAccessKey(viewer.AccessKeyID); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(viewerID)).
+			Msgf("Error while revoking access key of viewer: %v", err.Error())
+		return spverrors.ErrRemoveViewer
+	}
+
+	if err = s.repo.DeleteUser(ctx, viewerID); err != nil {
+		s.log.Error().
+			Str("userID", strconv.Itoa(viewerID)).
+			Msgf("Error while deleting viewer: %v", err.Error())
+		return spverrors.ErrRemoveViewer
+	}
+
+	return nil
+}
+
+// signInViewer signs in the viewer with access key to the owner wallet decrypted with the password.
+func (s *UserService) signInViewer(user *User, password string) (*AuthenticatedUser, error) {
+	accessKey, err := decryptXpriv(password, user.AccessKey)
+	if err != nil {
+		return nil, spverrors.ErrInvalidCredentials
+	}
+
+	if user.DisabledAt != nil {
+		return nil, spverrors.ErrAccountDisabled
+	}
+
+	balance, err := s.GetUserBalance(accessKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthenticatedUser{
+		User: user,
+		AccessKey: AccessKey{
+			ID:  user.AccessKeyID,
+			Key: accessKey,
+		},
+		Balance:    *balance,
+		SignedInAt: time.Now().UTC(),
+	}, nil
+}
+
+func generateInvitationToken() (string, error) {
+	random := make([]byte, invitationTokenSize)
+	if _, err := rand.Read(random); err != nil {
+		return "", err //nolint:wrapcheck // error wrapped higher in call stack
+	}
+	return hex.EncodeToString(random), nil
+}
+
+func hashInvitationToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
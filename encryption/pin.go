@@ -0,0 +1,30 @@
+package encryption
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/spf13/viper"
+	"golang.org/x/crypto/argon2"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+)
+
+// Parameters of argon2id deriving keys from PINs, as recommended by RFC 9106 for memory constrained environments.
+const (
+	pinKeyTime    = 3
+	pinKeyMemory  = 64 * 1024
+	pinKeyThreads = 4
+	pinKeyLength  = 32
+)
+
+// PINKey derives key from the PIN with memory-hard argon2id, so the few digits of the PIN cannot be guessed
+// offline quickly. The PIN is mixed with the server pepper first, which is not stored with encrypted data.
+// Salt has to be unique for every user.
+func PINKey(pin, salt string) string {
+	mac := hmac.New(sha256.New, []byte(viper.GetString(config.EnvSpendingPINPepper)))
+	mac.Write([]byte(pin))
+	key := argon2.IDKey(mac.Sum(nil), []byte(salt), pinKeyTime, pinKeyMemory, pinKeyThreads, pinKeyLength)
+	return hex.EncodeToString(key)
+}
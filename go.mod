@@ -8,7 +8,9 @@ require (
 	github.com/bsv-blockchain/spv-wallet-go-client v1.2.1
 	github.com/bsv-blockchain/spv-wallet/models v1.0.1
 	github.com/centrifugal/centrifuge v0.38.0
+	github.com/coreos/go-oidc/v3 v3.20.0
 	github.com/gin-contrib/sessions v1.1.0
+	github.com/go-webauthn/webauthn v0.17.4
 	github.com/golang/mock v1.7.0-rc.1
 	github.com/libsv/go-bk v0.1.6
 	github.com/rs/zerolog v1.35.1
@@ -19,10 +21,20 @@ require (
 	github.com/swaggo/swag v1.16.6
 	github.com/xdg-go/pbkdf2 v1.0.0
 	go.elastic.co/ecszerolog v0.2.0
+	golang.org/x/crypto v0.54.0
+	golang.org/x/oauth2 v0.36.0
 )
 
 require (
+	github.com/fxamacker/cbor/v2 v2.9.2 // indirect
+	github.com/go-jose/go-jose/v4 v4.1.4 // indirect
 	github.com/go-openapi/swag/pools v0.27.3 // indirect
+	github.com/go-webauthn/x v0.2.6 // indirect
+	github.com/golang-jwt/jwt/v5 v5.3.1 // indirect
+	github.com/google/go-tpm v0.9.8 // indirect
+	github.com/philhofer/fwd v1.2.0 // indirect
+	github.com/tinylib/msgp v1.6.4 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
 	go.mongodb.org/mongo-driver/v2 v2.8.0 // indirect
 )
 
@@ -65,7 +77,7 @@ require (
 	github.com/golang-migrate/migrate/v4 v4.19.1
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/gorilla/context v1.1.2 // indirect
-	github.com/gorilla/securecookie v1.1.2 // indirect
+	github.com/gorilla/securecookie v1.1.2
 	github.com/gorilla/sessions v1.4.0 // indirect
 	github.com/josharian/intern v1.0.0 // indirect
 	github.com/json-iterator/go v1.1.12 // indirect
@@ -106,7 +118,6 @@ require (
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
 	go.yaml.in/yaml/v3 v3.0.4 // indirect
 	golang.org/x/arch v0.29.0 // indirect
-	golang.org/x/crypto v0.54.0 // indirect
 	golang.org/x/exp v0.0.0-20240719175910-8a7402abbf56 // indirect
 	golang.org/x/mod v0.38.0 // indirect
 	golang.org/x/net v0.57.0 // indirect
@@ -7,9 +7,38 @@ import (
 	"github.com/bsv-blockchain/spv-wallet/models"
 	"github.com/bsv-blockchain/spv-wallet/models/response"
 
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/spvwallet"
 )
 
+// Contact event types.
+const (
+	// ContactInvitationEventType is emitted when a new contact invitation is awaiting user's decision.
+	ContactInvitationEventType = "contact_invitation"
+	// ContactConfirmedEventType is emitted when a contact becomes confirmed.
+	ContactConfirmedEventType = "contact_confirmed"
+)
+
+// IncomingTransactionEventType is emitted when a new incoming transaction is found for the user.
+const IncomingTransactionEventType = "incoming_transaction"
+
+// TransactionStatusChangedEventType is emitted when a tracked transaction is mined, reorged, rejected or confirmed.
+const TransactionStatusChangedEventType = "transaction_status_changed"
+
+// Wallet event types forwarded from SPV Wallet webhook.
+const (
+	// WalletTransactionEventType is emitted when SPV Wallet notifies about a transaction change.
+	WalletTransactionEventType = "wallet_transaction"
+	// WalletMessageEventType is emitted when SPV Wallet sends a generic message.
+	WalletMessageEventType = "wallet_message"
+)
+
+// ExchangeRateChangedEventType is emitted when BSV exchange rate changes.
+const ExchangeRateChangedEventType = "exchange_rate_changed"
+
+// PaymentApprovalRequestedEventType is emitted to members of a team wallet who can approve a payment waiting for approvals.
+const PaymentApprovalRequestedEventType = "payment_approval_requested"
+
 // BaseEvent represents base of notification.
 type BaseEvent struct {
 	Status    string  `json:"status"`
@@ -17,6 +46,11 @@ type BaseEvent struct {
 	EventType string  `json:"eventType"`
 }
 
+// GetEventType returns type of the event.
+func (e BaseEvent) GetEventType() string {
+	return e.EventType
+}
+
 // TransactionEvent represents notification about new transaction.
 type TransactionEvent struct {
 	BaseEvent
@@ -24,6 +58,92 @@ type TransactionEvent struct {
 	Transaction *Transaction `json:"transaction"`
 }
 
+// IncomingTransactionEvent represents notification about transaction received by the user.
+type IncomingTransactionEvent struct {
+	BaseEvent
+
+	Transaction *Transaction   `json:"transaction"`
+	Balance     *users.Balance `json:"balance"`
+}
+
+// TransactionStatusChangedEvent represents notification about status change of a tracked transaction.
+type TransactionStatusChangedEvent struct {
+	BaseEvent
+
+	Transaction *TransactionStatus `json:"transaction"`
+}
+
+// WalletTransactionEvent represents notification about transaction change received from SPV Wallet.
+type WalletTransactionEvent struct {
+	BaseEvent
+
+	Transaction *WalletTransaction `json:"transaction"`
+}
+
+// WalletTransaction represents transaction change which is return in notification.
+type WalletTransaction struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Value  int64  `json:"value"`
+}
+
+// WalletMessageEvent represents notification with generic message received from SPV Wallet.
+type WalletMessageEvent struct {
+	BaseEvent
+
+	Message string `json:"message"`
+}
+
+// ExchangeRateChangedEvent represents notification about new BSV exchange rate.
+type ExchangeRateChangedEvent struct {
+	BaseEvent
+
+	Rate float64 `json:"rate"`
+}
+
+// PaymentApprovalEvent represents notification about payment from a team wallet waiting for approvals.
+type PaymentApprovalEvent struct {
+	BaseEvent
+
+	Payment *PendingPayment `json:"payment"`
+}
+
+// PendingPayment represents payment waiting for approvals which is return in notification.
+type PendingPayment struct {
+	ID                int       `json:"id"`
+	WalletID          int       `json:"walletId"`
+	Initiator         string    `json:"initiator"`
+	Recipient         string    `json:"recipient"`
+	Satoshis          uint64    `json:"satoshis"`
+	Approvals         int       `json:"approvals"`
+	RequiredApprovals int       `json:"requiredApprovals"`
+	ExpiresAt         time.Time `json:"expiresAt"`
+}
+
+// ContactEvent represents notification about new contact invitation or contact status change.
+type ContactEvent struct {
+	BaseEvent
+
+	Contact *Contact `json:"contact"`
+}
+
+// Contact represents simplified contact which is return in notification.
+type Contact struct {
+	ID       string `json:"id"`
+	FullName string `json:"fullName"`
+	Paymail  string `json:"paymail"`
+	Status   string `json:"status"`
+}
+
+// TransactionStatus represents state of tracked transaction which is return in notification.
+type TransactionStatus struct {
+	ID            string `json:"id"`
+	Status        string `json:"status"`
+	BlockHash     string `json:"blockHash"`
+	BlockHeight   uint64 `json:"blockHeight"`
+	Confirmations uint64 `json:"confirmations"`
+}
+
 // Transaction represents simplified transaction which is return in webhook.
 type Transaction struct {
 	ID         string    `json:"id"`
@@ -89,3 +209,111 @@ func PrepareTransactionErrorEvent(err error) TransactionEvent {
 		Transaction: nil,
 	}
 }
+
+// PrepareIncomingTransactionEvent prepares event in IncomingTransactionEvent struct.
+func PrepareIncomingTransactionEvent(tx users.Transaction, balance *users.Balance) IncomingTransactionEvent {
+	return IncomingTransactionEvent{
+		BaseEvent: BaseEvent{
+			Status:    "success",
+			Error:     nil,
+			EventType: IncomingTransactionEventType,
+		},
+		Transaction: &Transaction{
+			ID:         tx.GetTransactionID(),
+			Receiver:   tx.GetTransactionReceiver(),
+			Sender:     tx.GetTransactionSender(),
+			Status:     tx.GetTransactionStatus(),
+			Direction:  tx.GetTransactionDirection(),
+			TotalValue: tx.GetTransactionTotalValue(),
+			CreatedAt:  tx.GetTransactionCreatedDate(),
+		},
+		Balance: balance,
+	}
+}
+
+// PrepareContactEvent prepares event of given type in ContactEvent struct.
+func PrepareContactEvent(eventType string, contact *models.Contact) ContactEvent {
+	return ContactEvent{
+		BaseEvent: BaseEvent{
+			Status:    "success",
+			Error:     nil,
+			EventType: eventType,
+		},
+		Contact: &Contact{
+			ID:       contact.ID,
+			FullName: contact.FullName,
+			Paymail:  contact.Paymail,
+			Status:   string(contact.Status),
+		},
+	}
+}
+
+// PrepareTransactionStatusChangedEvent prepares event in TransactionStatusChangedEvent struct.
+func PrepareTransactionStatusChangedEvent(txID, status, blockHash string, blockHeight, confirmations uint64) TransactionStatusChangedEvent {
+	return TransactionStatusChangedEvent{
+		BaseEvent: BaseEvent{
+			Status:    "success",
+			Error:     nil,
+			EventType: TransactionStatusChangedEventType,
+		},
+		Transaction: &TransactionStatus{
+			ID:            txID,
+			Status:        status,
+			BlockHash:     blockHash,
+			BlockHeight:   blockHeight,
+			Confirmations: confirmations,
+		},
+	}
+}
+
+// PrepareWalletTransactionEvent prepares event in WalletTransactionEvent struct.
+func PrepareWalletTransactionEvent(txID, status string, value int64) WalletTransactionEvent {
+	return WalletTransactionEvent{
+		BaseEvent: BaseEvent{
+			Status:    "success",
+			Error:     nil,
+			EventType: WalletTransactionEventType,
+		},
+		Transaction: &WalletTransaction{
+			ID:     txID,
+			Status: status,
+			Value:  value,
+		},
+	}
+}
+
+// PrepareWalletMessageEvent prepares event in WalletMessageEvent struct.
+func PrepareWalletMessageEvent(message string) WalletMessageEvent {
+	return WalletMessageEvent{
+		BaseEvent: BaseEvent{
+			Status:    "success",
+			Error:     nil,
+			EventType: WalletMessageEventType,
+		},
+		Message: message,
+	}
+}
+
+// PrepareExchangeRateChangedEvent prepares event in ExchangeRateChangedEvent struct.
+func PrepareExchangeRateChangedEvent(rate float64) ExchangeRateChangedEvent {
+	return ExchangeRateChangedEvent{
+		BaseEvent: BaseEvent{
+			Status:    "success",
+			Error:     nil,
+			EventType: ExchangeRateChangedEventType,
+		},
+		Rate: rate,
+	}
+}
+
+// PreparePaymentApprovalEvent prepares event in PaymentApprovalEvent struct.
+func PreparePaymentApprovalEvent(payment *PendingPayment) PaymentApprovalEvent {
+	return PaymentApprovalEvent{
+		BaseEvent: BaseEvent{
+			Status:    "success",
+			Error:     nil,
+			EventType: PaymentApprovalRequestedEventType,
+		},
+		Payment: payment,
+	}
+}
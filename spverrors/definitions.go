@@ -110,6 +110,13 @@ var ErrUserAlreadyExists = models.SPVError{
 	Code:       "error-user-already-exists",
 }
 
+// ErrRegistrationInProgress indicates the registration with the email was started with different password and is not finished yet
+var ErrRegistrationInProgress = models.SPVError{
+	Message:    "Registration with this email is in progress",
+	StatusCode: http.StatusConflict,
+	Code:       "error-registration-in-progress",
+}
+
 // ErrInsertUser indicates failure to insert a new user
 var ErrInsertUser = models.SPVError{
 	Message:    "Cannot insert new user",
@@ -152,6 +159,41 @@ var ErrRegisterPaymail = models.SPVError{
 	Code:       "error-paymail-register",
 }
 
+// ErrInvalidPaymailAlias indicates the paymail alias has invalid format
+var ErrInvalidPaymailAlias = models.SPVError{
+	Message:    "Invalid paymail alias",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-paymail-alias-invalid",
+}
+
+// ErrPaymailAliasTaken indicates the paymail alias is already used
+var ErrPaymailAliasTaken = models.SPVError{
+	Message:    "Paymail alias is already taken",
+	StatusCode: http.StatusConflict,
+	Code:       "error-paymail-alias-taken",
+}
+
+// ErrCheckPaymailAlias indicates failure to check availability of the paymail alias
+var ErrCheckPaymailAlias = models.SPVError{
+	Message:    "Cannot check paymail alias availability",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-paymail-alias-check",
+}
+
+// ErrGetUserPaymails indicates failure to get paymails of the user
+var ErrGetUserPaymails = models.SPVError{
+	Message:    "Cannot get user paymails",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-user-paymails-get",
+}
+
+// ErrSetPrimaryPaymail indicates failure to change the primary paymail of the user
+var ErrSetPrimaryPaymail = models.SPVError{
+	Message:    "Cannot set primary paymail",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-paymail-primary-set",
+}
+
 // ErrGenerateMnemonic indicates failure to generate a mnemonic
 var ErrGenerateMnemonic = models.SPVError{
 	Message:    "Cannot generate mnemonic",
@@ -201,6 +243,20 @@ var ErrGetBalance = models.SPVError{
 	Code:       "error-balance-get",
 }
 
+// ErrAccountDisabled indicates the user account was disabled by an admin
+var ErrAccountDisabled = models.SPVError{
+	Message:    "Account is disabled",
+	StatusCode: http.StatusForbidden,
+	Code:       "error-account-disabled",
+}
+
+// ErrSessionRevoked indicates sessions of the user were revoked by an admin
+var ErrSessionRevoked = models.SPVError{
+	Message:    "Session was revoked",
+	StatusCode: http.StatusUnauthorized,
+	Code:       "error-session-revoked",
+}
+
 // ErrSessionUpdate indicates failure to update the session
 var ErrSessionUpdate = models.SPVError{
 	Message:    "Cannot update session",
@@ -215,6 +271,496 @@ var ErrSessionTerminate = models.SPVError{
 	Code:       "error-session-terminate",
 }
 
+// ErrInvalidDisplayName indicates the display name is empty, too long or contains control characters
+var ErrInvalidDisplayName = models.SPVError{
+	Message:    "Invalid display name",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-display-name-invalid",
+}
+
+// ErrInvalidAvatar indicates the uploaded avatar is too large or is not a supported image
+var ErrInvalidAvatar = models.SPVError{
+	Message:    "Invalid avatar, supported are PNG, JPEG, GIF and WEBP images",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-avatar-invalid",
+}
+
+// ErrAvatarNotFound indicates the requested avatar does not exist
+var ErrAvatarNotFound = models.SPVError{
+	Message:    "Avatar not found",
+	StatusCode: http.StatusNotFound,
+	Code:       "error-avatar-not-found",
+}
+
+// ErrGetProfile indicates failure to get the user profile
+var ErrGetProfile = models.SPVError{
+	Message:    "Cannot get user profile",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-profile-get",
+}
+
+// ErrSaveProfile indicates failure to save the user profile
+var ErrSaveProfile = models.SPVError{
+	Message:    "Cannot save user profile",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-profile-save",
+}
+
+// ErrUpdatePaymailProfile indicates failure to propagate the user profile to paymails in SPV Wallet
+var ErrUpdatePaymailProfile = models.SPVError{
+	Message:    "Profile saved, but cannot update paymails",
+	StatusCode: http.StatusBadGateway,
+	Code:       "error-paymail-profile-update",
+}
+
+// ErrSweepAddressRequired indicates the account with remaining funds is deleted without an address to sweep them to
+var ErrSweepAddressRequired = models.SPVError{
+	Message:    "Address for remaining funds is required",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-sweep-address-required",
+}
+
+// ErrSweepFunds indicates failure to send remaining funds of the deleted account
+var ErrSweepFunds = models.SPVError{
+	Message:    "Cannot send remaining funds, account was not deleted",
+	StatusCode: http.StatusBadGateway,
+	Code:       "error-sweep-funds",
+}
+
+// ErrDeleteAccount indicates failure to delete the user account
+var ErrDeleteAccount = models.SPVError{
+	Message:    "Cannot delete account",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-account-delete",
+}
+
+// ErrExportData indicates failure to export the user data
+var ErrExportData = models.SPVError{
+	Message:    "Cannot export user data",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-data-export",
+}
+
+// ////////////////////////////////// VIEWER ERRORS
+
+// ErrForbidden indicates the role of the user does not allow the request
+var ErrForbidden = models.SPVError{
+	Message:    "Not allowed for your role",
+	StatusCode: http.StatusForbidden,
+	Code:       "error-forbidden",
+}
+
+// ErrInviteViewer indicates failure to invite a viewer
+var ErrInviteViewer = models.SPVError{
+	Message:    "Cannot invite viewer",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-viewer-invite",
+}
+
+// ErrInvalidInvitation indicates the invitation token is unknown or the invitation expired
+var ErrInvalidInvitation = models.SPVError{
+	Message:    "Invitation is invalid or expired",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-invitation-invalid",
+}
+
+// ErrAcceptInvitation indicates failure to create the viewer account from the invitation
+var ErrAcceptInvitation = models.SPVError{
+	Message:    "Cannot accept invitation",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-invitation-accept",
+}
+
+// ErrViewerNotFound indicates the viewer does not exist or was not invited by the user
+var ErrViewerNotFound = models.SPVError{
+	Message:    "Viewer not found",
+	StatusCode: http.StatusNotFound,
+	Code:       "error-viewer-not-found",
+}
+
+// ErrGetViewers indicates failure to get viewers of the wallet
+var ErrGetViewers = models.SPVError{
+	Message:    "Cannot get viewers",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-viewers-get",
+}
+
+// ErrRemoveViewer indicates failure to remove the viewer
+var ErrRemoveViewer = models.SPVError{
+	Message:    "Cannot remove viewer",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-viewer-remove",
+}
+
+// ////////////////////////////////// API TOKEN ERRORS
+
+// ErrInvalidAPIToken indicates the API token does not exist, expired or its user cannot use the wallet
+var ErrInvalidAPIToken = models.SPVError{
+	Message:    "Invalid or expired API token",
+	StatusCode: http.StatusUnauthorized,
+	Code:       "error-api-token-invalid",
+}
+
+// ErrInvalidAPITokenName indicates the API token name is empty or too long
+var ErrInvalidAPITokenName = models.SPVError{
+	Message:    "API token name has to have from 1 to 64 characters",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-api-token-name-invalid",
+}
+
+// ErrInvalidAPITokenScopes indicates no scopes or unknown scope of the API token
+var ErrInvalidAPITokenScopes = models.SPVError{
+	Message:    "API token has to have at least one of the known scopes",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-api-token-scopes-invalid",
+}
+
+// ErrInvalidAPITokenSendLimit indicates the send limit is missing for the token which can send transactions or is set for the one which cannot
+var ErrInvalidAPITokenSendLimit = models.SPVError{
+	Message:    "Send limit is required for API token which can send transactions and not allowed for other tokens",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-api-token-send-limit-invalid",
+}
+
+// ErrInvalidAPITokenExpiry indicates the API token expiry is in the past or too far in the future
+var ErrInvalidAPITokenExpiry = models.SPVError{
+	Message:    "API token expiry has to be in the future and within the maximum token lifetime",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-api-token-expiry-invalid",
+}
+
+// ErrCreateAPIToken indicates failure to create the API token
+var ErrCreateAPIToken = models.SPVError{
+	Message:    "Cannot create API token",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-api-token-create",
+}
+
+// ErrGetAPITokens indicates failure to get API tokens of the user
+var ErrGetAPITokens = models.SPVError{
+	Message:    "Cannot get API tokens",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-api-tokens-get",
+}
+
+// ErrAPITokenNotFound indicates the API token does not exist or belongs to other user
+var ErrAPITokenNotFound = models.SPVError{
+	Message:    "API token not found",
+	StatusCode: http.StatusNotFound,
+	Code:       "error-api-token-not-found",
+}
+
+// ErrRevokeAPIToken indicates failure to revoke the API token
+var ErrRevokeAPIToken = models.SPVError{
+	Message:    "Cannot revoke API token",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-api-token-revoke",
+}
+
+// ErrAPITokenSendLimitExceeded indicates the payment exceeds the amount the API token can still spend
+var ErrAPITokenSendLimitExceeded = models.SPVError{
+	Message:    "Payment exceeds remaining send limit of the API token",
+	StatusCode: http.StatusForbidden,
+	Code:       "error-api-token-send-limit-exceeded",
+}
+
+// ErrAPITokenScope indicates scopes of the API token do not allow the request
+var ErrAPITokenScope = models.SPVError{
+	Message:    "Not allowed for scopes of the API token",
+	StatusCode: http.StatusForbidden,
+	Code:       "error-api-token-scope",
+}
+
+// ////////////////////////////////// SSO ERRORS
+
+// ErrSSODisabled indicates sign in with the identity provider is not enabled
+var ErrSSODisabled = models.SPVError{
+	Message:    "Sign in with identity provider is disabled",
+	StatusCode: http.StatusNotFound,
+	Code:       "error-sso-disabled",
+}
+
+// ErrSSOProvider indicates the identity provider cannot be reached or its configuration is invalid
+var ErrSSOProvider = models.SPVError{
+	Message:    "Identity provider is not available",
+	StatusCode: http.StatusBadGateway,
+	Code:       "error-sso-provider",
+}
+
+// ErrSSOAuthorization indicates the authorization by the identity provider failed or cannot be verified
+var ErrSSOAuthorization = models.SPVError{
+	Message:    "Sign in with identity provider failed",
+	StatusCode: http.StatusUnauthorized,
+	Code:       "error-sso-authorization",
+}
+
+// ErrSSOEmailNotVerified indicates the identity provider did not return verified email of the user
+var ErrSSOEmailNotVerified = models.SPVError{
+	Message:    "Identity provider did not confirm your email",
+	StatusCode: http.StatusForbidden,
+	Code:       "error-sso-email-not-verified",
+}
+
+// ErrSSORegistrationRequired indicates no user is mapped to the identity, the user has to register with spending PIN first
+var ErrSSORegistrationRequired = models.SPVError{
+	Message:    "Account has to be created with spending PIN",
+	StatusCode: http.StatusNotFound,
+	Code:       "error-sso-registration-required",
+}
+
+// ErrInvalidSpendingPIN indicates the spending PIN has invalid format
+var ErrInvalidSpendingPIN = models.SPVError{
+	Message:    "Spending PIN has to have from 6 to 12 digits",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-spending-pin-invalid",
+}
+
+// ErrSpendingPINMismatch indicates the spending PIN and its confirmation do not match
+var ErrSpendingPINMismatch = models.SPVError{
+	Message:    "Spending PIN and confirmation do not match",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-spending-pin-mismatch",
+}
+
+// ////////////////////////////////// SPENDING PIN ERRORS
+
+// ErrSpendingPINNotSet indicates the user has no spending PIN
+var ErrSpendingPINNotSet = models.SPVError{
+	Message:    "Spending PIN is not set",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-spending-pin-not-set",
+}
+
+// ErrSpendingPINLocked indicates the spending PIN is locked after too many invalid attempts
+var ErrSpendingPINLocked = models.SPVError{
+	Message:    "Too many invalid spending PIN attempts, try again later",
+	StatusCode: http.StatusTooManyRequests,
+	Code:       "error-spending-pin-locked",
+}
+
+// ErrSpendingPINManagedBySSO indicates spending PIN of the user signing in with the identity provider cannot be set or removed
+var ErrSpendingPINManagedBySSO = models.SPVError{
+	Message:    "Spending PIN of account signing in with identity provider is set at registration",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-spending-pin-managed-by-sso",
+}
+
+// ErrSetSpendingPIN is when spending PIN cannot be set
+var ErrSetSpendingPIN = models.SPVError{
+	Message:    "Error while setting spending PIN",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-spending-pin-set",
+}
+
+// ErrRemoveSpendingPIN is when spending PIN cannot be removed
+var ErrRemoveSpendingPIN = models.SPVError{
+	Message:    "Error while removing spending PIN",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-spending-pin-remove",
+}
+
+// ////////////////////////////////// PASSKEY ERRORS
+
+// ErrInvalidPasskeyName indicates the passkey name is empty or too long
+var ErrInvalidPasskeyName = models.SPVError{
+	Message:    "Passkey name has to have from 1 to 64 characters",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-passkey-name-invalid",
+}
+
+// ErrPasskeyRegistration indicates the passkey could not be verified when it was registered
+var ErrPasskeyRegistration = models.SPVError{
+	Message:    "Cannot register passkey",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-passkey-registration",
+}
+
+// ErrPasskeyPRFUnsupported indicates the passkey did not return PRF output needed to encrypt the wallet key
+var ErrPasskeyPRFUnsupported = models.SPVError{
+	Message:    "Passkey does not support PRF extension required to unlock the wallet",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-passkey-prf-unsupported",
+}
+
+// ErrPasskeyAuthentication indicates the passkey assertion is invalid or the wallet key cannot be unlocked with it
+var ErrPasskeyAuthentication = models.SPVError{
+	Message:    "Passkey authentication failed",
+	StatusCode: http.StatusUnauthorized,
+	Code:       "error-passkey-authentication",
+}
+
+// ErrGetPasskeys indicates failure to get passkeys of the user
+var ErrGetPasskeys = models.SPVError{
+	Message:    "Cannot get passkeys",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-passkeys-get",
+}
+
+// ErrPasskeyNotFound indicates the passkey does not exist or belongs to other user
+var ErrPasskeyNotFound = models.SPVError{
+	Message:    "Passkey not found",
+	StatusCode: http.StatusNotFound,
+	Code:       "error-passkey-not-found",
+}
+
+// ErrDeletePasskey indicates failure to delete the passkey
+var ErrDeletePasskey = models.SPVError{
+	Message:    "Cannot delete passkey",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-passkey-delete",
+}
+
+// ////////////////////////////////// TEAM WALLET ERRORS
+
+// ErrTeamWalletNotFound indicates the team wallet does not exist or the user is not its member
+var ErrTeamWalletNotFound = models.SPVError{
+	Message:    "Team wallet not found",
+	StatusCode: http.StatusNotFound,
+	Code:       "error-team-wallet-not-found",
+}
+
+// ErrCreateTeamWallet indicates failure to create a team wallet
+var ErrCreateTeamWallet = models.SPVError{
+	Message:    "Cannot create team wallet",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-team-wallet-create",
+}
+
+// ErrGetTeamWallets indicates failure to get team wallets or their members
+var ErrGetTeamWallets = models.SPVError{
+	Message:    "Cannot get team wallets",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-team-wallets-get",
+}
+
+// ErrInvalidTeamWalletName indicates the name of the team wallet is empty or too long
+var ErrInvalidTeamWalletName = models.SPVError{
+	Message:    "Team wallet name must have between 1 and 100 characters",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-team-wallet-name-invalid",
+}
+
+// ErrInvalidMemberRole indicates the role of the team wallet member is unknown
+var ErrInvalidMemberRole = models.SPVError{
+	Message:    "Member role must be one of: owner, spender, viewer",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-member-role-invalid",
+}
+
+// ErrInviteMember indicates failure to invite a member to the team wallet
+var ErrInviteMember = models.SPVError{
+	Message:    "Cannot invite member",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-member-invite",
+}
+
+// ErrMemberAlreadyExists indicates the invited user is already a member of the team wallet
+var ErrMemberAlreadyExists = models.SPVError{
+	Message:    "User is already a member of the team wallet",
+	StatusCode: http.StatusConflict,
+	Code:       "error-member-already-exists",
+}
+
+// ErrMemberNotFound indicates the user is not a member of the team wallet
+var ErrMemberNotFound = models.SPVError{
+	Message:    "Member not found",
+	StatusCode: http.StatusNotFound,
+	Code:       "error-member-not-found",
+}
+
+// ErrLastTeamWalletOwner indicates the last owner cannot leave the team wallet
+var ErrLastTeamWalletOwner = models.SPVError{
+	Message:    "Team wallet must have at least one owner",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-team-wallet-last-owner",
+}
+
+// ErrRemoveMember indicates failure to remove the member of the team wallet
+var ErrRemoveMember = models.SPVError{
+	Message:    "Cannot remove member",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-member-remove",
+}
+
+// ErrGetTeamTransactions indicates failure to get transactions of the team wallet
+var ErrGetTeamTransactions = models.SPVError{
+	Message:    "Cannot get team wallet transactions",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-team-transactions-get",
+}
+
+// ErrPaymentNotFound indicates the payment waiting for approvals does not exist in the team wallet
+var ErrPaymentNotFound = models.SPVError{
+	Message:    "Payment not found",
+	StatusCode: http.StatusNotFound,
+	Code:       "error-payment-not-found",
+}
+
+// ErrPaymentNotPending indicates the payment was already sent or discarded
+var ErrPaymentNotPending = models.SPVError{
+	Message:    "Payment is not waiting for approvals",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-payment-not-pending",
+}
+
+// ErrPaymentAlreadyApproved indicates the member already approved the payment
+var ErrPaymentAlreadyApproved = models.SPVError{
+	Message:    "Payment is already approved by you",
+	StatusCode: http.StatusConflict,
+	Code:       "error-payment-already-approved",
+}
+
+// ErrNotEnoughApprovers indicates the team wallet has less members allowed to spend than required approvals
+var ErrNotEnoughApprovers = models.SPVError{
+	Message:    "Team wallet has not enough members to approve the payment",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-payment-not-enough-approvers",
+}
+
+// ErrApprovePayment indicates failure to hold or approve the payment
+var ErrApprovePayment = models.SPVError{
+	Message:    "Cannot approve payment",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-payment-approve",
+}
+
+// ////////////////////////////////// ADMIN ERRORS
+
+// ErrUserNotFound indicates the user managed by an admin does not exist
+var ErrUserNotFound = models.SPVError{
+	Message:    "User not found",
+	StatusCode: http.StatusNotFound,
+	Code:       "error-user-not-found",
+}
+
+// ErrAdminSearchUsers indicates failure to search users
+var ErrAdminSearchUsers = models.SPVError{
+	Message:    "Cannot search users",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-admin-users-search",
+}
+
+// ErrAdminUpdateUser indicates failure to change the user by an admin
+var ErrAdminUpdateUser = models.SPVError{
+	Message:    "Cannot update user",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-admin-user-update",
+}
+
+// ErrGetAdminActions indicates failure to get the audit trail of admin actions
+var ErrGetAdminActions = models.SPVError{
+	Message:    "Cannot get admin actions",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-admin-actions-get",
+}
+
+// ErrGetAuditEvents indicates failure to get the audit log
+var ErrGetAuditEvents = models.SPVError{
+	Message:    "Cannot get audit events",
+	StatusCode: http.StatusInternalServerError,
+	Code:       "error-audit-events-get",
+}
+
 // ////////////////////////////////// RATE ERRORS
 
 // ErrRateNotFound indicates the requested rate was not found
@@ -224,6 +770,57 @@ var ErrRateNotFound = models.SPVError{
 	Code:       "error-rate-not-found",
 }
 
+// ////////////////////////////////// PAYMAIL ERRORS
+
+// ErrInvalidPaymailAddress indicates the paymail address has invalid format
+var ErrInvalidPaymailAddress = models.SPVError{
+	Message:    "Invalid paymail address",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-paymail-address-invalid",
+}
+
+// ErrPaymailDomainNotSupported indicates the paymail domain does not provide paymail capabilities
+var ErrPaymailDomainNotSupported = models.SPVError{
+	Message:    "Paymail domain does not support paymail",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-paymail-domain-not-supported",
+}
+
+// ErrPaymailNotFound indicates the paymail does not exist on its domain
+var ErrPaymailNotFound = models.SPVError{
+	Message:    "Paymail not found",
+	StatusCode: http.StatusNotFound,
+	Code:       "error-paymail-not-found",
+}
+
+// ErrPaymailP2PNotSupported indicates the paymail cannot receive P2P transactions
+var ErrPaymailP2PNotSupported = models.SPVError{
+	Message:    "Paymail does not support P2P transactions",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-paymail-p2p-not-supported",
+}
+
+// ErrResolvePaymail indicates failure to resolve the paymail
+var ErrResolvePaymail = models.SPVError{
+	Message:    "Cannot resolve paymail",
+	StatusCode: http.StatusBadGateway,
+	Code:       "error-paymail-resolve",
+}
+
+// ErrPaymailDomainNotAllowed indicates the paymail domain is not supported by SPV Wallet
+var ErrPaymailDomainNotAllowed = models.SPVError{
+	Message:    "Paymail domain is not allowed",
+	StatusCode: http.StatusBadRequest,
+	Code:       "error-paymail-domain-not-allowed",
+}
+
+// ErrPaymailRegistrationClosed indicates the paymail domain does not accept new paymails
+var ErrPaymailRegistrationClosed = models.SPVError{
+	Message:    "Registration of new paymails in this domain is closed",
+	StatusCode: http.StatusForbidden,
+	Code:       "error-paymail-registration-closed",
+}
+
 // ////////////////////////////////// BINDING ERRORS
 
 // ErrCannotBindRequest is when request body cannot be bind into struct
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/admin/actions_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	admin "github.com/bsv-blockchain/spv-wallet-web-backend/domain/admin"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockActionsRepository is a mock of ActionsRepository interface.
+type MockActionsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockActionsRepositoryMockRecorder
+}
+
+// MockActionsRepositoryMockRecorder is the mock recorder for MockActionsRepository.
+type MockActionsRepositoryMockRecorder struct {
+	mock *MockActionsRepository
+}
+
+// NewMockActionsRepository creates a new mock instance.
+func NewMockActionsRepository(ctrl *gomock.Controller) *MockActionsRepository {
+	mock := &MockActionsRepository{ctrl: ctrl}
+	mock.recorder = &MockActionsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActionsRepository) EXPECT() *MockActionsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetActions mocks base method.
+func (m *MockActionsRepository) GetActions(ctx context.Context, filter *admin.ActionsFilter, page, pageSize int) ([]*admin.Action, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActions", ctx, filter, page, pageSize)
+	ret0, _ := ret[0].([]*admin.Action)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetActions indicates an expected call of GetActions.
+func (mr *MockActionsRepositoryMockRecorder) GetActions(ctx, filter, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActions", reflect.TypeOf((*MockActionsRepository)(nil).GetActions), ctx, filter, page, pageSize)
+}
+
+// InsertAction mocks base method.
+func (m *MockActionsRepository) InsertAction(ctx context.Context, action *admin.Action) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAction", ctx, action)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertAction indicates an expected call of InsertAction.
+func (mr *MockActionsRepositoryMockRecorder) InsertAction(ctx, action interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAction", reflect.TypeOf((*MockActionsRepository)(nil).InsertAction), ctx, action)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/audit/audit_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	audit "github.com/bsv-blockchain/spv-wallet-web-backend/domain/audit"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEventsRepository is a mock of EventsRepository interface.
+type MockEventsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventsRepositoryMockRecorder
+}
+
+// MockEventsRepositoryMockRecorder is the mock recorder for MockEventsRepository.
+type MockEventsRepositoryMockRecorder struct {
+	mock *MockEventsRepository
+}
+
+// NewMockEventsRepository creates a new mock instance.
+func NewMockEventsRepository(ctrl *gomock.Controller) *MockEventsRepository {
+	mock := &MockEventsRepository{ctrl: ctrl}
+	mock.recorder = &MockEventsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventsRepository) EXPECT() *MockEventsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetEvents mocks base method.
+func (m *MockEventsRepository) GetEvents(ctx context.Context, filter *audit.EventsFilter, page, pageSize int) ([]*audit.Event, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvents", ctx, filter, page, pageSize)
+	ret0, _ := ret[0].([]*audit.Event)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEvents indicates an expected call of GetEvents.
+func (mr *MockEventsRepositoryMockRecorder) GetEvents(ctx, filter, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockEventsRepository)(nil).GetEvents), ctx, filter, page, pageSize)
+}
+
+// InsertEvent mocks base method.
+func (m *MockEventsRepository) InsertEvent(ctx context.Context, event *audit.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertEvent indicates an expected call of InsertEvent.
+func (mr *MockEventsRepositoryMockRecorder) InsertEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertEvent", reflect.TypeOf((*MockEventsRepository)(nil).InsertEvent), ctx, event)
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/passkeys/passkeys_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	passkeys "github.com/bsv-blockchain/spv-wallet-web-backend/domain/passkeys"
+	webauthn "github.com/go-webauthn/webauthn/webauthn"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPasskeysRepository is a mock of Repository interface.
+type MockPasskeysRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasskeysRepositoryMockRecorder
+}
+
+// MockPasskeysRepositoryMockRecorder is the mock recorder for MockPasskeysRepository.
+type MockPasskeysRepositoryMockRecorder struct {
+	mock *MockPasskeysRepository
+}
+
+// NewMockPasskeysRepository creates a new mock instance.
+func NewMockPasskeysRepository(ctrl *gomock.Controller) *MockPasskeysRepository {
+	mock := &MockPasskeysRepository{ctrl: ctrl}
+	mock.recorder = &MockPasskeysRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasskeysRepository) EXPECT() *MockPasskeysRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeletePasskey mocks base method.
+func (m *MockPasskeysRepository) DeletePasskey(ctx context.Context, userID, id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePasskey", ctx, userID, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePasskey indicates an expected call of DeletePasskey.
+func (mr *MockPasskeysRepositoryMockRecorder) DeletePasskey(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePasskey", reflect.TypeOf((*MockPasskeysRepository)(nil).DeletePasskey), ctx, userID, id)
+}
+
+// GetPasskeyByCredentialID mocks base method.
+func (m *MockPasskeysRepository) GetPasskeyByCredentialID(ctx context.Context, credentialID []byte) (*passkeys.Passkey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasskeyByCredentialID", ctx, credentialID)
+	ret0, _ := ret[0].(*passkeys.Passkey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasskeyByCredentialID indicates an expected call of GetPasskeyByCredentialID.
+func (mr *MockPasskeysRepositoryMockRecorder) GetPasskeyByCredentialID(ctx, credentialID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasskeyByCredentialID", reflect.TypeOf((*MockPasskeysRepository)(nil).GetPasskeyByCredentialID), ctx, credentialID)
+}
+
+// GetPasskeys mocks base method.
+func (m *MockPasskeysRepository) GetPasskeys(ctx context.Context, userID int) ([]*passkeys.Passkey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasskeys", ctx, userID)
+	ret0, _ := ret[0].([]*passkeys.Passkey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasskeys indicates an expected call of GetPasskeys.
+func (mr *MockPasskeysRepositoryMockRecorder) GetPasskeys(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasskeys", reflect.TypeOf((*MockPasskeysRepository)(nil).GetPasskeys), ctx, userID)
+}
+
+// InsertPasskey mocks base method.
+func (m *MockPasskeysRepository) InsertPasskey(ctx context.Context, passkey *passkeys.Passkey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPasskey", ctx, passkey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertPasskey indicates an expected call of InsertPasskey.
+func (mr *MockPasskeysRepositoryMockRecorder) InsertPasskey(ctx, passkey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPasskey", reflect.TypeOf((*MockPasskeysRepository)(nil).InsertPasskey), ctx, passkey)
+}
+
+// UpdatePasskeyCredential mocks base method.
+func (m *MockPasskeysRepository) UpdatePasskeyCredential(ctx context.Context, id int, credential *webauthn.Credential, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePasskeyCredential", ctx, id, credential, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePasskeyCredential indicates an expected call of UpdatePasskeyCredential.
+func (mr *MockPasskeysRepositoryMockRecorder) UpdatePasskeyCredential(ctx, id, credential, usedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePasskeyCredential", reflect.TypeOf((*MockPasskeysRepository)(nil).UpdatePasskeyCredential), ctx, id, credential, usedAt)
+}
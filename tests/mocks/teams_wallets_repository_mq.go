@@ -0,0 +1,287 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/teams/wallets_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	teams "github.com/bsv-blockchain/spv-wallet-web-backend/domain/teams"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWalletsRepository is a mock of WalletsRepository interface.
+type MockWalletsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletsRepositoryMockRecorder
+}
+
+// MockWalletsRepositoryMockRecorder is the mock recorder for MockWalletsRepository.
+type MockWalletsRepositoryMockRecorder struct {
+	mock *MockWalletsRepository
+}
+
+// NewMockWalletsRepository creates a new mock instance.
+func NewMockWalletsRepository(ctrl *gomock.Controller) *MockWalletsRepository {
+	mock := &MockWalletsRepository{ctrl: ctrl}
+	mock.recorder = &MockWalletsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWalletsRepository) EXPECT() *MockWalletsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AcceptInvitation mocks base method.
+func (m *MockWalletsRepository) AcceptInvitation(ctx context.Context, member *teams.Member, invitationID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, member, invitationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockWalletsRepositoryMockRecorder) AcceptInvitation(ctx, member, invitationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockWalletsRepository)(nil).AcceptInvitation), ctx, member, invitationID)
+}
+
+// DeleteMember mocks base method.
+func (m *MockWalletsRepository) DeleteMember(ctx context.Context, walletID, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMember", ctx, walletID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMember indicates an expected call of DeleteMember.
+func (mr *MockWalletsRepositoryMockRecorder) DeleteMember(ctx, walletID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMember", reflect.TypeOf((*MockWalletsRepository)(nil).DeleteMember), ctx, walletID, userID)
+}
+
+// ExpirePendingPayments mocks base method.
+func (m *MockWalletsRepository) ExpirePendingPayments(ctx context.Context, expiredBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePendingPayments", ctx, expiredBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpirePendingPayments indicates an expected call of ExpirePendingPayments.
+func (mr *MockWalletsRepositoryMockRecorder) ExpirePendingPayments(ctx, expiredBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePendingPayments", reflect.TypeOf((*MockWalletsRepository)(nil).ExpirePendingPayments), ctx, expiredBefore)
+}
+
+// GetInvitation mocks base method.
+func (m *MockWalletsRepository) GetInvitation(ctx context.Context, tokenHash string) (*teams.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitation", ctx, tokenHash)
+	ret0, _ := ret[0].(*teams.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitation indicates an expected call of GetInvitation.
+func (mr *MockWalletsRepositoryMockRecorder) GetInvitation(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitation", reflect.TypeOf((*MockWalletsRepository)(nil).GetInvitation), ctx, tokenHash)
+}
+
+// GetMember mocks base method.
+func (m *MockWalletsRepository) GetMember(ctx context.Context, walletID, userID int) (*teams.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMember", ctx, walletID, userID)
+	ret0, _ := ret[0].(*teams.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMember indicates an expected call of GetMember.
+func (mr *MockWalletsRepositoryMockRecorder) GetMember(ctx, walletID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMember", reflect.TypeOf((*MockWalletsRepository)(nil).GetMember), ctx, walletID, userID)
+}
+
+// GetMemberWallets mocks base method.
+func (m *MockWalletsRepository) GetMemberWallets(ctx context.Context, userID int) ([]*teams.MemberWallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberWallets", ctx, userID)
+	ret0, _ := ret[0].([]*teams.MemberWallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberWallets indicates an expected call of GetMemberWallets.
+func (mr *MockWalletsRepositoryMockRecorder) GetMemberWallets(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberWallets", reflect.TypeOf((*MockWalletsRepository)(nil).GetMemberWallets), ctx, userID)
+}
+
+// GetMembers mocks base method.
+func (m *MockWalletsRepository) GetMembers(ctx context.Context, walletID int) ([]*teams.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", ctx, walletID)
+	ret0, _ := ret[0].([]*teams.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockWalletsRepositoryMockRecorder) GetMembers(ctx, walletID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockWalletsRepository)(nil).GetMembers), ctx, walletID)
+}
+
+// GetPendingPayment mocks base method.
+func (m *MockWalletsRepository) GetPendingPayment(ctx context.Context, id int) (*teams.PendingPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingPayment", ctx, id)
+	ret0, _ := ret[0].(*teams.PendingPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingPayment indicates an expected call of GetPendingPayment.
+func (mr *MockWalletsRepositoryMockRecorder) GetPendingPayment(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingPayment", reflect.TypeOf((*MockWalletsRepository)(nil).GetPendingPayment), ctx, id)
+}
+
+// GetPendingPayments mocks base method.
+func (m *MockWalletsRepository) GetPendingPayments(ctx context.Context, walletID int) ([]*teams.PendingPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingPayments", ctx, walletID)
+	ret0, _ := ret[0].([]*teams.PendingPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingPayments indicates an expected call of GetPendingPayments.
+func (mr *MockWalletsRepositoryMockRecorder) GetPendingPayments(ctx, walletID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingPayments", reflect.TypeOf((*MockWalletsRepository)(nil).GetPendingPayments), ctx, walletID)
+}
+
+// GetTransactions mocks base method.
+func (m *MockWalletsRepository) GetTransactions(ctx context.Context, walletID, page, pageSize int) ([]*teams.Transaction, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactions", ctx, walletID, page, pageSize)
+	ret0, _ := ret[0].([]*teams.Transaction)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTransactions indicates an expected call of GetTransactions.
+func (mr *MockWalletsRepositoryMockRecorder) GetTransactions(ctx, walletID, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactions", reflect.TypeOf((*MockWalletsRepository)(nil).GetTransactions), ctx, walletID, page, pageSize)
+}
+
+// GetWallet mocks base method.
+func (m *MockWalletsRepository) GetWallet(ctx context.Context, id int) (*teams.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWallet", ctx, id)
+	ret0, _ := ret[0].(*teams.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWallet indicates an expected call of GetWallet.
+func (mr *MockWalletsRepositoryMockRecorder) GetWallet(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallet", reflect.TypeOf((*MockWalletsRepository)(nil).GetWallet), ctx, id)
+}
+
+// InsertInvitation mocks base method.
+func (m *MockWalletsRepository) InsertInvitation(ctx context.Context, invitation *teams.Invitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertInvitation", ctx, invitation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertInvitation indicates an expected call of InsertInvitation.
+func (mr *MockWalletsRepositoryMockRecorder) InsertInvitation(ctx, invitation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertInvitation", reflect.TypeOf((*MockWalletsRepository)(nil).InsertInvitation), ctx, invitation)
+}
+
+// InsertPaymentApproval mocks base method.
+func (m *MockWalletsRepository) InsertPaymentApproval(ctx context.Context, paymentID, userID int, approvedAt time.Time) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPaymentApproval", ctx, paymentID, userID, approvedAt)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertPaymentApproval indicates an expected call of InsertPaymentApproval.
+func (mr *MockWalletsRepositoryMockRecorder) InsertPaymentApproval(ctx, paymentID, userID, approvedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPaymentApproval", reflect.TypeOf((*MockWalletsRepository)(nil).InsertPaymentApproval), ctx, paymentID, userID, approvedAt)
+}
+
+// InsertPendingPayment mocks base method.
+func (m *MockWalletsRepository) InsertPendingPayment(ctx context.Context, payment *teams.PendingPayment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPendingPayment", ctx, payment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertPendingPayment indicates an expected call of InsertPendingPayment.
+func (mr *MockWalletsRepositoryMockRecorder) InsertPendingPayment(ctx, payment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPendingPayment", reflect.TypeOf((*MockWalletsRepository)(nil).InsertPendingPayment), ctx, payment)
+}
+
+// InsertTransaction mocks base method.
+func (m *MockWalletsRepository) InsertTransaction(ctx context.Context, transaction *teams.Transaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertTransaction", ctx, transaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertTransaction indicates an expected call of InsertTransaction.
+func (mr *MockWalletsRepositoryMockRecorder) InsertTransaction(ctx, transaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertTransaction", reflect.TypeOf((*MockWalletsRepository)(nil).InsertTransaction), ctx, transaction)
+}
+
+// InsertWallet mocks base method.
+func (m *MockWalletsRepository) InsertWallet(ctx context.Context, wallet *teams.Wallet, owner *teams.Member) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWallet", ctx, wallet, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertWallet indicates an expected call of InsertWallet.
+func (mr *MockWalletsRepositoryMockRecorder) InsertWallet(ctx, wallet, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWallet", reflect.TypeOf((*MockWalletsRepository)(nil).InsertWallet), ctx, wallet, owner)
+}
+
+// UpdatePaymentStatus mocks base method.
+func (m *MockWalletsRepository) UpdatePaymentStatus(ctx context.Context, id int, from, to string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymentStatus", ctx, id, from, to)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePaymentStatus indicates an expected call of UpdatePaymentStatus.
+func (mr *MockWalletsRepositoryMockRecorder) UpdatePaymentStatus(ctx, id, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymentStatus", reflect.TypeOf((*MockWalletsRepository)(nil).UpdatePaymentStatus), ctx, id, from, to)
+}
@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/transactions/tracking_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	transactions "github.com/bsv-blockchain/spv-wallet-web-backend/domain/transactions"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTrackingRepository is a mock of TrackingRepository interface.
+type MockTrackingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrackingRepositoryMockRecorder
+}
+
+// MockTrackingRepositoryMockRecorder is the mock recorder for MockTrackingRepository.
+type MockTrackingRepositoryMockRecorder struct {
+	mock *MockTrackingRepository
+}
+
+// NewMockTrackingRepository creates a new mock instance.
+func NewMockTrackingRepository(ctrl *gomock.Controller) *MockTrackingRepository {
+	mock := &MockTrackingRepository{ctrl: ctrl}
+	mock.recorder = &MockTrackingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrackingRepository) EXPECT() *MockTrackingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteTrackedTransaction mocks base method.
+func (m *MockTrackingRepository) DeleteTrackedTransaction(ctx context.Context, transactionID string, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTrackedTransaction", ctx, transactionID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTrackedTransaction indicates an expected call of DeleteTrackedTransaction.
+func (mr *MockTrackingRepositoryMockRecorder) DeleteTrackedTransaction(ctx, transactionID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTrackedTransaction", reflect.TypeOf((*MockTrackingRepository)(nil).DeleteTrackedTransaction), ctx, transactionID, userID)
+}
+
+// GetTrackedTransactions mocks base method.
+func (m *MockTrackingRepository) GetTrackedTransactions(ctx context.Context) ([]*transactions.TrackedTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrackedTransactions", ctx)
+	ret0, _ := ret[0].([]*transactions.TrackedTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrackedTransactions indicates an expected call of GetTrackedTransactions.
+func (mr *MockTrackingRepositoryMockRecorder) GetTrackedTransactions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrackedTransactions", reflect.TypeOf((*MockTrackingRepository)(nil).GetTrackedTransactions), ctx)
+}
+
+// InsertTrackedTransaction mocks base method.
+func (m *MockTrackingRepository) InsertTrackedTransaction(ctx context.Context, tx *transactions.TrackedTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertTrackedTransaction", ctx, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertTrackedTransaction indicates an expected call of InsertTrackedTransaction.
+func (mr *MockTrackingRepositoryMockRecorder) InsertTrackedTransaction(ctx, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertTrackedTransaction", reflect.TypeOf((*MockTrackingRepository)(nil).InsertTrackedTransaction), ctx, tx)
+}
+
+// UpdateTrackedTransaction mocks base method.
+func (m *MockTrackingRepository) UpdateTrackedTransaction(ctx context.Context, tx *transactions.TrackedTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTrackedTransaction", ctx, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTrackedTransaction indicates an expected call of UpdateTrackedTransaction.
+func (mr *MockTrackingRepositoryMockRecorder) UpdateTrackedTransaction(ctx, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTrackedTransaction", reflect.TypeOf((*MockTrackingRepository)(nil).UpdateTrackedTransaction), ctx, tx)
+}
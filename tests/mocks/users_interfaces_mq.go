@@ -726,6 +726,35 @@ func (mr *MockUserWalletClientMockRecorder) RevokeAccessKey(accessKeyID interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAccessKey", reflect.TypeOf((*MockUserWalletClient)(nil).RevokeAccessKey), accessKeyID)
 }
 
+// RevokeAllAccessKeys mocks base method.
+func (m *MockUserWalletClient) RevokeAllAccessKeys() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllAccessKeys")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAllAccessKeys indicates an expected call of RevokeAllAccessKeys.
+func (mr *MockUserWalletClientMockRecorder) RevokeAllAccessKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllAccessKeys", reflect.TypeOf((*MockUserWalletClient)(nil).RevokeAllAccessKeys))
+}
+
+// SendAll mocks base method.
+func (m *MockUserWalletClient) SendAll(to, senderPaymail string) (users.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAll", to, senderPaymail)
+	ret0, _ := ret[0].(users.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendAll indicates an expected call of SendAll.
+func (mr *MockUserWalletClientMockRecorder) SendAll(to, senderPaymail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAll", reflect.TypeOf((*MockUserWalletClient)(nil).SendAll), to, senderPaymail)
+}
+
 // SendToRecipients mocks base method.
 func (m *MockUserWalletClient) SendToRecipients(recipients []*commands.Recipients, senderPaymail string) (users.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -779,6 +808,20 @@ func (m *MockAdminWalletClient) EXPECT() *MockAdminWalletClientMockRecorder {
 	return m.recorder
 }
 
+// DeletePaymail mocks base method.
+func (m *MockAdminWalletClient) DeletePaymail(address string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePaymail", address)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePaymail indicates an expected call of DeletePaymail.
+func (mr *MockAdminWalletClientMockRecorder) DeletePaymail(address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePaymail", reflect.TypeOf((*MockAdminWalletClient)(nil).DeletePaymail), address)
+}
+
 // GetSharedConfig mocks base method.
 func (m *MockAdminWalletClient) GetSharedConfig() (*models.SharedConfig, error) {
 	m.ctrl.T.Helper()
@@ -794,19 +837,79 @@ func (mr *MockAdminWalletClientMockRecorder) GetSharedConfig() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedConfig", reflect.TypeOf((*MockAdminWalletClient)(nil).GetSharedConfig))
 }
 
+// GetTransaction mocks base method.
+func (m *MockAdminWalletClient) GetTransaction(transactionID string) (users.FullTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransaction", transactionID)
+	ret0, _ := ret[0].(users.FullTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransaction indicates an expected call of GetTransaction.
+func (mr *MockAdminWalletClientMockRecorder) GetTransaction(transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransaction", reflect.TypeOf((*MockAdminWalletClient)(nil).GetTransaction), transactionID)
+}
+
+// GetXpub mocks base method.
+func (m *MockAdminWalletClient) GetXpub(xpubID string) (users.PubKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetXpub", xpubID)
+	ret0, _ := ret[0].(users.PubKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetXpub indicates an expected call of GetXpub.
+func (mr *MockAdminWalletClientMockRecorder) GetXpub(xpubID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetXpub", reflect.TypeOf((*MockAdminWalletClient)(nil).GetXpub), xpubID)
+}
+
+// GetXpubPaymails mocks base method.
+func (m *MockAdminWalletClient) GetXpubPaymails(xpubID string) ([]*users.WalletPaymail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetXpubPaymails", xpubID)
+	ret0, _ := ret[0].([]*users.WalletPaymail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetXpubPaymails indicates an expected call of GetXpubPaymails.
+func (mr *MockAdminWalletClientMockRecorder) GetXpubPaymails(xpubID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetXpubPaymails", reflect.TypeOf((*MockAdminWalletClient)(nil).GetXpubPaymails), xpubID)
+}
+
+// IsPaymailAvailable mocks base method.
+func (m *MockAdminWalletClient) IsPaymailAvailable(alias, domain string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPaymailAvailable", alias, domain)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsPaymailAvailable indicates an expected call of IsPaymailAvailable.
+func (mr *MockAdminWalletClientMockRecorder) IsPaymailAvailable(alias, domain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPaymailAvailable", reflect.TypeOf((*MockAdminWalletClient)(nil).IsPaymailAvailable), alias, domain)
+}
+
 // RegisterPaymail mocks base method.
-func (m *MockAdminWalletClient) RegisterPaymail(alias, xpub string) (string, error) {
+func (m *MockAdminWalletClient) RegisterPaymail(alias, domain, xpub string, profile *users.PaymailProfile) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RegisterPaymail", alias, xpub)
+	ret := m.ctrl.Call(m, "RegisterPaymail", alias, domain, xpub, profile)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RegisterPaymail indicates an expected call of RegisterPaymail.
-func (mr *MockAdminWalletClientMockRecorder) RegisterPaymail(alias, xpub interface{}) *gomock.Call {
+func (mr *MockAdminWalletClientMockRecorder) RegisterPaymail(alias, domain, xpub, profile interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterPaymail", reflect.TypeOf((*MockAdminWalletClient)(nil).RegisterPaymail), alias, xpub)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterPaymail", reflect.TypeOf((*MockAdminWalletClient)(nil).RegisterPaymail), alias, domain, xpub, profile)
 }
 
 // RegisterXpub mocks base method.
@@ -824,6 +927,86 @@ func (mr *MockAdminWalletClientMockRecorder) RegisterXpub(xpriv interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterXpub", reflect.TypeOf((*MockAdminWalletClient)(nil).RegisterXpub), xpriv)
 }
 
+// UpdatePaymailProfile mocks base method.
+func (m *MockAdminWalletClient) UpdatePaymailProfile(address, xpub string, profile *users.PaymailProfile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePaymailProfile", address, xpub, profile)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePaymailProfile indicates an expected call of UpdatePaymailProfile.
+func (mr *MockAdminWalletClientMockRecorder) UpdatePaymailProfile(address, xpub, profile interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePaymailProfile", reflect.TypeOf((*MockAdminWalletClient)(nil).UpdatePaymailProfile), address, xpub, profile)
+}
+
+// MockAvatarStorage is a mock of AvatarStorage interface.
+type MockAvatarStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockAvatarStorageMockRecorder
+}
+
+// MockAvatarStorageMockRecorder is the mock recorder for MockAvatarStorage.
+type MockAvatarStorageMockRecorder struct {
+	mock *MockAvatarStorage
+}
+
+// NewMockAvatarStorage creates a new mock instance.
+func NewMockAvatarStorage(ctrl *gomock.Controller) *MockAvatarStorage {
+	mock := &MockAvatarStorage{ctrl: ctrl}
+	mock.recorder = &MockAvatarStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAvatarStorage) EXPECT() *MockAvatarStorageMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockAvatarStorage) Delete(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAvatarStorageMockRecorder) Delete(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAvatarStorage)(nil).Delete), name)
+}
+
+// Path mocks base method.
+func (m *MockAvatarStorage) Path(name string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Path", name)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Path indicates an expected call of Path.
+func (mr *MockAvatarStorageMockRecorder) Path(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Path", reflect.TypeOf((*MockAvatarStorage)(nil).Path), name)
+}
+
+// Save mocks base method.
+func (m *MockAvatarStorage) Save(name string, data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", name, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockAvatarStorageMockRecorder) Save(name, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockAvatarStorage)(nil).Save), name, data)
+}
+
 // MockWalletClientFactory is a mock of WalletClientFactory interface.
 type MockWalletClientFactory struct {
 	ctrl     *gomock.Controller
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	users "github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +36,180 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AcceptViewerInvitation mocks base method.
+func (m *MockRepository) AcceptViewerInvitation(ctx context.Context, viewer *users.User, invitationID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptViewerInvitation", ctx, viewer, invitationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptViewerInvitation indicates an expected call of AcceptViewerInvitation.
+func (mr *MockRepositoryMockRecorder) AcceptViewerInvitation(ctx, viewer, invitationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptViewerInvitation", reflect.TypeOf((*MockRepository)(nil).AcceptViewerInvitation), ctx, viewer, invitationID)
+}
+
+// CompleteRegistration mocks base method.
+func (m *MockRepository) CompleteRegistration(ctx context.Context, user *users.User, registrationID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteRegistration", ctx, user, registrationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteRegistration indicates an expected call of CompleteRegistration.
+func (mr *MockRepositoryMockRecorder) CompleteRegistration(ctx, user, registrationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteRegistration", reflect.TypeOf((*MockRepository)(nil).CompleteRegistration), ctx, user, registrationID)
+}
+
+// DeleteAPIToken mocks base method.
+func (m *MockRepository) DeleteAPIToken(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAPIToken", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAPIToken indicates an expected call of DeleteAPIToken.
+func (mr *MockRepositoryMockRecorder) DeleteAPIToken(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAPIToken", reflect.TypeOf((*MockRepository)(nil).DeleteAPIToken), ctx, id)
+}
+
+// DeletePendingRegistration mocks base method.
+func (m *MockRepository) DeletePendingRegistration(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePendingRegistration", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePendingRegistration indicates an expected call of DeletePendingRegistration.
+func (mr *MockRepositoryMockRecorder) DeletePendingRegistration(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePendingRegistration", reflect.TypeOf((*MockRepository)(nil).DeletePendingRegistration), ctx, id)
+}
+
+// DeleteSpendingPIN mocks base method.
+func (m *MockRepository) DeleteSpendingPIN(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSpendingPIN", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSpendingPIN indicates an expected call of DeleteSpendingPIN.
+func (mr *MockRepositoryMockRecorder) DeleteSpendingPIN(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSpendingPIN", reflect.TypeOf((*MockRepository)(nil).DeleteSpendingPIN), ctx, userID)
+}
+
+// DeleteUser mocks base method.
+func (m *MockRepository) DeleteUser(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockRepositoryMockRecorder) DeleteUser(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockRepository)(nil).DeleteUser), ctx, id)
+}
+
+// GetAPIToken mocks base method.
+func (m *MockRepository) GetAPIToken(ctx context.Context, tokenHash string) (*users.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIToken", ctx, tokenHash)
+	ret0, _ := ret[0].(*users.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIToken indicates an expected call of GetAPIToken.
+func (mr *MockRepositoryMockRecorder) GetAPIToken(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIToken", reflect.TypeOf((*MockRepository)(nil).GetAPIToken), ctx, tokenHash)
+}
+
+// GetAPITokenByID mocks base method.
+func (m *MockRepository) GetAPITokenByID(ctx context.Context, id int) (*users.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPITokenByID", ctx, id)
+	ret0, _ := ret[0].(*users.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPITokenByID indicates an expected call of GetAPITokenByID.
+func (mr *MockRepositoryMockRecorder) GetAPITokenByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPITokenByID", reflect.TypeOf((*MockRepository)(nil).GetAPITokenByID), ctx, id)
+}
+
+// GetAPITokens mocks base method.
+func (m *MockRepository) GetAPITokens(ctx context.Context, userID int) ([]*users.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPITokens", ctx, userID)
+	ret0, _ := ret[0].([]*users.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPITokens indicates an expected call of GetAPITokens.
+func (mr *MockRepositoryMockRecorder) GetAPITokens(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPITokens", reflect.TypeOf((*MockRepository)(nil).GetAPITokens), ctx, userID)
+}
+
+// GetPendingRegistration mocks base method.
+func (m *MockRepository) GetPendingRegistration(ctx context.Context, email string) (*users.PendingRegistration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingRegistration", ctx, email)
+	ret0, _ := ret[0].(*users.PendingRegistration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingRegistration indicates an expected call of GetPendingRegistration.
+func (mr *MockRepositoryMockRecorder) GetPendingRegistration(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingRegistration", reflect.TypeOf((*MockRepository)(nil).GetPendingRegistration), ctx, email)
+}
+
+// GetPendingRegistrationsBefore mocks base method.
+func (m *MockRepository) GetPendingRegistrationsBefore(ctx context.Context, createdBefore time.Time) ([]*users.PendingRegistration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingRegistrationsBefore", ctx, createdBefore)
+	ret0, _ := ret[0].([]*users.PendingRegistration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingRegistrationsBefore indicates an expected call of GetPendingRegistrationsBefore.
+func (mr *MockRepositoryMockRecorder) GetPendingRegistrationsBefore(ctx, createdBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingRegistrationsBefore", reflect.TypeOf((*MockRepository)(nil).GetPendingRegistrationsBefore), ctx, createdBefore)
+}
+
+// GetSpendingPIN mocks base method.
+func (m *MockRepository) GetSpendingPIN(ctx context.Context, userID int) (*users.SpendingPIN, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSpendingPIN", ctx, userID)
+	ret0, _ := ret[0].(*users.SpendingPIN)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSpendingPIN indicates an expected call of GetSpendingPIN.
+func (mr *MockRepositoryMockRecorder) GetSpendingPIN(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpendingPIN", reflect.TypeOf((*MockRepository)(nil).GetSpendingPIN), ctx, userID)
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockRepository) GetUserByEmail(ctx context.Context, email string) (*users.User, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +240,139 @@ func (mr *MockRepositoryMockRecorder) GetUserByID(ctx, id interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockRepository)(nil).GetUserByID), ctx, id)
 }
 
+// GetUserBySSOIdentity mocks base method.
+func (m *MockRepository) GetUserBySSOIdentity(ctx context.Context, issuer, subject string) (*users.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserBySSOIdentity", ctx, issuer, subject)
+	ret0, _ := ret[0].(*users.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserBySSOIdentity indicates an expected call of GetUserBySSOIdentity.
+func (mr *MockRepositoryMockRecorder) GetUserBySSOIdentity(ctx, issuer, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserBySSOIdentity", reflect.TypeOf((*MockRepository)(nil).GetUserBySSOIdentity), ctx, issuer, subject)
+}
+
+// GetUserByXpubID mocks base method.
+func (m *MockRepository) GetUserByXpubID(ctx context.Context, xpubID string) (*users.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByXpubID", ctx, xpubID)
+	ret0, _ := ret[0].(*users.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByXpubID indicates an expected call of GetUserByXpubID.
+func (mr *MockRepositoryMockRecorder) GetUserByXpubID(ctx, xpubID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByXpubID", reflect.TypeOf((*MockRepository)(nil).GetUserByXpubID), ctx, xpubID)
+}
+
+// GetUserPaymail mocks base method.
+func (m *MockRepository) GetUserPaymail(ctx context.Context, address string) (*users.UserPaymail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPaymail", ctx, address)
+	ret0, _ := ret[0].(*users.UserPaymail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPaymail indicates an expected call of GetUserPaymail.
+func (mr *MockRepositoryMockRecorder) GetUserPaymail(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPaymail", reflect.TypeOf((*MockRepository)(nil).GetUserPaymail), ctx, address)
+}
+
+// GetUserPaymails mocks base method.
+func (m *MockRepository) GetUserPaymails(ctx context.Context, userID int) ([]*users.UserPaymail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPaymails", ctx, userID)
+	ret0, _ := ret[0].([]*users.UserPaymail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPaymails indicates an expected call of GetUserPaymails.
+func (mr *MockRepositoryMockRecorder) GetUserPaymails(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPaymails", reflect.TypeOf((*MockRepository)(nil).GetUserPaymails), ctx, userID)
+}
+
+// GetUserProfile mocks base method.
+func (m *MockRepository) GetUserProfile(ctx context.Context, userID int) (*users.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserProfile", ctx, userID)
+	ret0, _ := ret[0].(*users.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserProfile indicates an expected call of GetUserProfile.
+func (mr *MockRepositoryMockRecorder) GetUserProfile(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfile", reflect.TypeOf((*MockRepository)(nil).GetUserProfile), ctx, userID)
+}
+
+// GetViewerInvitation mocks base method.
+func (m *MockRepository) GetViewerInvitation(ctx context.Context, tokenHash string) (*users.ViewerInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetViewerInvitation", ctx, tokenHash)
+	ret0, _ := ret[0].(*users.ViewerInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetViewerInvitation indicates an expected call of GetViewerInvitation.
+func (mr *MockRepositoryMockRecorder) GetViewerInvitation(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetViewerInvitation", reflect.TypeOf((*MockRepository)(nil).GetViewerInvitation), ctx, tokenHash)
+}
+
+// GetViewers mocks base method.
+func (m *MockRepository) GetViewers(ctx context.Context, ownerID int) ([]*users.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetViewers", ctx, ownerID)
+	ret0, _ := ret[0].([]*users.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetViewers indicates an expected call of GetViewers.
+func (mr *MockRepositoryMockRecorder) GetViewers(ctx, ownerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetViewers", reflect.TypeOf((*MockRepository)(nil).GetViewers), ctx, ownerID)
+}
+
+// InsertAPIToken mocks base method.
+func (m *MockRepository) InsertAPIToken(ctx context.Context, token *users.APIToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAPIToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertAPIToken indicates an expected call of InsertAPIToken.
+func (mr *MockRepositoryMockRecorder) InsertAPIToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAPIToken", reflect.TypeOf((*MockRepository)(nil).InsertAPIToken), ctx, token)
+}
+
+// InsertPendingRegistration mocks base method.
+func (m *MockRepository) InsertPendingRegistration(ctx context.Context, registration *users.PendingRegistration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertPendingRegistration", ctx, registration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertPendingRegistration indicates an expected call of InsertPendingRegistration.
+func (mr *MockRepositoryMockRecorder) InsertPendingRegistration(ctx, registration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertPendingRegistration", reflect.TypeOf((*MockRepository)(nil).InsertPendingRegistration), ctx, registration)
+}
+
 // InsertUser mocks base method.
 func (m *MockRepository) InsertUser(ctx context.Context, user *users.User) error {
 	m.ctrl.T.Helper()
@@ -78,3 +386,232 @@ func (mr *MockRepositoryMockRecorder) InsertUser(ctx, user interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUser", reflect.TypeOf((*MockRepository)(nil).InsertUser), ctx, user)
 }
+
+// InsertUserPaymail mocks base method.
+func (m *MockRepository) InsertUserPaymail(ctx context.Context, paymail *users.UserPaymail) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUserPaymail", ctx, paymail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertUserPaymail indicates an expected call of InsertUserPaymail.
+func (mr *MockRepositoryMockRecorder) InsertUserPaymail(ctx, paymail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUserPaymail", reflect.TypeOf((*MockRepository)(nil).InsertUserPaymail), ctx, paymail)
+}
+
+// InsertViewerInvitation mocks base method.
+func (m *MockRepository) InsertViewerInvitation(ctx context.Context, invitation *users.ViewerInvitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertViewerInvitation", ctx, invitation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertViewerInvitation indicates an expected call of InsertViewerInvitation.
+func (mr *MockRepositoryMockRecorder) InsertViewerInvitation(ctx, invitation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertViewerInvitation", reflect.TypeOf((*MockRepository)(nil).InsertViewerInvitation), ctx, invitation)
+}
+
+// LockSpendingPIN mocks base method.
+func (m *MockRepository) LockSpendingPIN(ctx context.Context, userID int, lockedUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockSpendingPIN", ctx, userID, lockedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockSpendingPIN indicates an expected call of LockSpendingPIN.
+func (mr *MockRepositoryMockRecorder) LockSpendingPIN(ctx, userID, lockedUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockSpendingPIN", reflect.TypeOf((*MockRepository)(nil).LockSpendingPIN), ctx, userID, lockedUntil)
+}
+
+// RefundAPIToken mocks base method.
+func (m *MockRepository) RefundAPIToken(ctx context.Context, id int, satoshis uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundAPIToken", ctx, id, satoshis)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefundAPIToken indicates an expected call of RefundAPIToken.
+func (mr *MockRepositoryMockRecorder) RefundAPIToken(ctx, id, satoshis interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundAPIToken", reflect.TypeOf((*MockRepository)(nil).RefundAPIToken), ctx, id, satoshis)
+}
+
+// ReserveSpendingPINAttempt mocks base method.
+func (m *MockRepository) ReserveSpendingPINAttempt(ctx context.Context, userID int, attemptedAt time.Time) (int, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveSpendingPINAttempt", ctx, userID, attemptedAt)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReserveSpendingPINAttempt indicates an expected call of ReserveSpendingPINAttempt.
+func (mr *MockRepositoryMockRecorder) ReserveSpendingPINAttempt(ctx, userID, attemptedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveSpendingPINAttempt", reflect.TypeOf((*MockRepository)(nil).ReserveSpendingPINAttempt), ctx, userID, attemptedAt)
+}
+
+// ResetSpendingPINAttempts mocks base method.
+func (m *MockRepository) ResetSpendingPINAttempts(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetSpendingPINAttempts", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetSpendingPINAttempts indicates an expected call of ResetSpendingPINAttempts.
+func (mr *MockRepositoryMockRecorder) ResetSpendingPINAttempts(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetSpendingPINAttempts", reflect.TypeOf((*MockRepository)(nil).ResetSpendingPINAttempts), ctx, userID)
+}
+
+// RevokeUserSessions mocks base method.
+func (m *MockRepository) RevokeUserSessions(ctx context.Context, id int, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSessions", ctx, id, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserSessions indicates an expected call of RevokeUserSessions.
+func (mr *MockRepositoryMockRecorder) RevokeUserSessions(ctx, id, revokedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*MockRepository)(nil).RevokeUserSessions), ctx, id, revokedAt)
+}
+
+// SearchUsers mocks base method.
+func (m *MockRepository) SearchUsers(ctx context.Context, query string, page, pageSize int) ([]*users.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchUsers", ctx, query, page, pageSize)
+	ret0, _ := ret[0].([]*users.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchUsers indicates an expected call of SearchUsers.
+func (mr *MockRepositoryMockRecorder) SearchUsers(ctx, query, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockRepository)(nil).SearchUsers), ctx, query, page, pageSize)
+}
+
+// SetPrimaryPaymail mocks base method.
+func (m *MockRepository) SetPrimaryPaymail(ctx context.Context, userID int, address string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrimaryPaymail", ctx, userID, address)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPrimaryPaymail indicates an expected call of SetPrimaryPaymail.
+func (mr *MockRepositoryMockRecorder) SetPrimaryPaymail(ctx, userID, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrimaryPaymail", reflect.TypeOf((*MockRepository)(nil).SetPrimaryPaymail), ctx, userID, address)
+}
+
+// SetUserDisabled mocks base method.
+func (m *MockRepository) SetUserDisabled(ctx context.Context, id int, disabledAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserDisabled", ctx, id, disabledAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserDisabled indicates an expected call of SetUserDisabled.
+func (mr *MockRepositoryMockRecorder) SetUserDisabled(ctx, id, disabledAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserDisabled", reflect.TypeOf((*MockRepository)(nil).SetUserDisabled), ctx, id, disabledAt)
+}
+
+// SpendAPIToken mocks base method.
+func (m *MockRepository) SpendAPIToken(ctx context.Context, id int, satoshis uint64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SpendAPIToken", ctx, id, satoshis)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SpendAPIToken indicates an expected call of SpendAPIToken.
+func (mr *MockRepositoryMockRecorder) SpendAPIToken(ctx, id, satoshis interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpendAPIToken", reflect.TypeOf((*MockRepository)(nil).SpendAPIToken), ctx, id, satoshis)
+}
+
+// UpdateAPITokenLastUsed mocks base method.
+func (m *MockRepository) UpdateAPITokenLastUsed(ctx context.Context, id int, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAPITokenLastUsed", ctx, id, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAPITokenLastUsed indicates an expected call of UpdateAPITokenLastUsed.
+func (mr *MockRepositoryMockRecorder) UpdateAPITokenLastUsed(ctx, id, usedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPITokenLastUsed", reflect.TypeOf((*MockRepository)(nil).UpdateAPITokenLastUsed), ctx, id, usedAt)
+}
+
+// UpdatePendingRegistration mocks base method.
+func (m *MockRepository) UpdatePendingRegistration(ctx context.Context, registration *users.PendingRegistration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePendingRegistration", ctx, registration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePendingRegistration indicates an expected call of UpdatePendingRegistration.
+func (mr *MockRepositoryMockRecorder) UpdatePendingRegistration(ctx, registration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePendingRegistration", reflect.TypeOf((*MockRepository)(nil).UpdatePendingRegistration), ctx, registration)
+}
+
+// UpdateUserXpubID mocks base method.
+func (m *MockRepository) UpdateUserXpubID(ctx context.Context, id int, xpubID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserXpubID", ctx, id, xpubID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserXpubID indicates an expected call of UpdateUserXpubID.
+func (mr *MockRepositoryMockRecorder) UpdateUserXpubID(ctx, id, xpubID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserXpubID", reflect.TypeOf((*MockRepository)(nil).UpdateUserXpubID), ctx, id, xpubID)
+}
+
+// UpsertSpendingPIN mocks base method.
+func (m *MockRepository) UpsertSpendingPIN(ctx context.Context, pin *users.SpendingPIN) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertSpendingPIN", ctx, pin)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertSpendingPIN indicates an expected call of UpsertSpendingPIN.
+func (mr *MockRepositoryMockRecorder) UpsertSpendingPIN(ctx, pin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSpendingPIN", reflect.TypeOf((*MockRepository)(nil).UpsertSpendingPIN), ctx, pin)
+}
+
+// UpsertUserProfile mocks base method.
+func (m *MockRepository) UpsertUserProfile(ctx context.Context, profile *users.Profile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserProfile", ctx, profile)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertUserProfile indicates an expected call of UpsertUserProfile.
+func (mr *MockRepositoryMockRecorder) UpsertUserProfile(ctx, profile interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserProfile", reflect.TypeOf((*MockRepository)(nil).UpsertUserProfile), ctx, profile)
+}
@@ -0,0 +1,122 @@
+package admin_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/admin"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+	mock "github.com/bsv-blockchain/spv-wallet-web-backend/tests/mocks"
+)
+
+var testActor = &admin.Actor{Name: "ops", IP: "10.0.0.1", UserAgent: "curl"}
+
+func TestDisableUser_RecordsAction(t *testing.T) {
+	testLogger := zerolog.Nop()
+	cases := []struct {
+		name           string
+		arrange        func(repoMq *mock.MockRepository)
+		expectedErr    error
+		expectedResult string
+	}{
+		{
+			name: "User disabled",
+			arrange: func(repoMq *mock.MockRepository) {
+				repoMq.EXPECT().GetUserByID(gomock.Any(), 7).Return(&users.User{ID: 7}, nil)
+				repoMq.EXPECT().SetUserDisabled(gomock.Any(), 7, gomock.Not(gomock.Nil()))
+			},
+			expectedResult: admin.ResultSuccess,
+		},
+		{
+			name: "User not found",
+			arrange: func(repoMq *mock.MockRepository) {
+				repoMq.EXPECT().GetUserByID(gomock.Any(), 7).Return(nil, errors.New("no rows"))
+			},
+			expectedErr:    spverrors.ErrUserNotFound,
+			expectedResult: admin.ResultFailure,
+		},
+		{
+			name: "Update fails",
+			arrange: func(repoMq *mock.MockRepository) {
+				repoMq.EXPECT().GetUserByID(gomock.Any(), 7).Return(&users.User{ID: 7}, nil)
+				repoMq.EXPECT().SetUserDisabled(gomock.Any(), 7, gomock.Any()).Return(errors.New("db unavailable"))
+			},
+			expectedErr:    spverrors.ErrAdminUpdateUser,
+			expectedResult: admin.ResultFailure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repoMq := mock.NewMockRepository(ctrl)
+			actionsRepoMq := mock.NewMockActionsRepository(ctrl)
+			tc.arrange(repoMq)
+
+			var recorded *admin.Action
+			actionsRepoMq.EXPECT().
+				InsertAction(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ any, action *admin.Action) error {
+					recorded = action
+					return nil
+				})
+
+			sut := admin.NewAdminService(repoMq, actionsRepoMq, nil, &testLogger)
+
+			// Act
+			err := sut.DisableUser(testActor, 7)
+
+			// Assert
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NotNil(t, recorded)
+			assert.Equal(t, "ops", recorded.Actor)
+			assert.Equal(t, admin.ActionDisableUser, recorded.Action)
+			assert.Equal(t, 7, *recorded.TargetUserID)
+			assert.Equal(t, "10.0.0.1", recorded.IP)
+			assert.Equal(t, tc.expectedResult, recorded.Result)
+		})
+	}
+}
+
+func TestGetUser_ReturnsWalletPaymailsAndBalance(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockRepository(ctrl)
+	actionsRepoMq := mock.NewMockActionsRepository(ctrl)
+	adminClientMq := mock.NewMockAdminWalletClient(ctrl)
+	xpubMq := mock.NewMockPubKey(ctrl)
+
+	repoMq.EXPECT().GetUserByID(gomock.Any(), 7).Return(&users.User{ID: 7, XpubID: "xpub-id"}, nil)
+	repoMq.EXPECT().GetUserPaymails(gomock.Any(), 7).Return([]*users.UserPaymail{{UserID: 7, Paymail: "homer@example.com", Primary: true}}, nil)
+	adminClientMq.EXPECT().GetXpub("xpub-id").Return(xpubMq, nil)
+	xpubMq.EXPECT().GetCurrentBalance().Return(uint64(1500))
+	adminClientMq.EXPECT().GetXpubPaymails("xpub-id").Return([]*users.WalletPaymail{{Address: "homer@example.com"}}, nil)
+	actionsRepoMq.EXPECT().InsertAction(gomock.Any(), gomock.Any())
+
+	sut := admin.NewAdminService(repoMq, actionsRepoMq, adminClientMq, &testLogger)
+
+	// Act
+	details, err := sut.GetUser(testActor, 7)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1500), details.Balance)
+	assert.Len(t, details.Paymails, 1)
+	assert.Equal(t, "homer@example.com", details.WalletPaymails[0].Address)
+}
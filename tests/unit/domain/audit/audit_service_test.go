@@ -0,0 +1,132 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/audit"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+	mock "github.com/bsv-blockchain/spv-wallet-web-backend/tests/mocks"
+)
+
+func TestRecord_FillsOriginFromContext(t *testing.T) {
+	testLogger := zerolog.Nop()
+	otherUserID := 9
+	cases := []struct {
+		name           string
+		event          *audit.Event
+		expectedUserID int
+	}{
+		{
+			name:           "Event without user",
+			event:          &audit.Event{Action: audit.ActionSignIn, Result: audit.ResultSuccess},
+			expectedUserID: 7,
+		},
+		{
+			name:           "Event with user",
+			event:          &audit.Event{UserID: &otherUserID, Action: audit.ActionSignIn, Result: audit.ResultFailure},
+			expectedUserID: otherUserID,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var inserted *audit.Event
+			repoMq := mock.NewMockEventsRepository(ctrl)
+			repoMq.EXPECT().
+				InsertEvent(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, event *audit.Event) error {
+					inserted = event
+					return nil
+				})
+
+			ctx := audit.WithOrigin(context.Background(), &audit.Origin{IP: "10.0.0.1", UserAgent: "browser"})
+			ctx = audit.WithUser(ctx, 7)
+
+			sut := audit.NewAuditService(repoMq, &testLogger)
+
+			// Act
+			sut.Record(ctx, tc.event)
+
+			// Assert
+			require.NotNil(t, inserted)
+			assert.Equal(t, tc.expectedUserID, *inserted.UserID)
+			assert.Equal(t, "10.0.0.1", inserted.IP)
+			assert.Equal(t, "browser", inserted.UserAgent)
+			assert.False(t, inserted.CreatedAt.IsZero())
+		})
+	}
+}
+
+func TestRecord_IgnoresInsertError(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockEventsRepository(ctrl)
+	repoMq.EXPECT().InsertEvent(gomock.Any(), gomock.Any()).Return(errors.New("db unavailable"))
+
+	sut := audit.NewAuditService(repoMq, &testLogger)
+
+	// Act & Assert
+	assert.NotPanics(t, func() {
+		sut.Record(context.Background(), &audit.Event{Action: audit.ActionPayment, Result: audit.ResultSuccess})
+	})
+}
+
+func TestGetUserEvents(t *testing.T) {
+	testLogger := zerolog.Nop()
+	t.Run("Returns page of user events", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockEventsRepository(ctrl)
+		repoMq.EXPECT().
+			GetEvents(gomock.Any(), gomock.Any(), 1, 20).
+			DoAndReturn(func(_ context.Context, filter *audit.EventsFilter, _, _ int) ([]*audit.Event, int64, error) {
+				assert.Equal(t, 7, *filter.UserID)
+				return []*audit.Event{{Action: audit.ActionSignIn}}, 21, nil
+			})
+
+		sut := audit.NewAuditService(repoMq, &testLogger)
+
+		// Act
+		result, err := sut.GetUserEvents(7, 0, 0)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int64(21), result.Count)
+		assert.Equal(t, 2, result.Pages)
+		assert.Len(t, result.Events, 1)
+	})
+
+	t.Run("Returns error when events cannot be read", func(t *testing.T) {
+		// Arrange
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMq := mock.NewMockEventsRepository(ctrl)
+		repoMq.EXPECT().GetEvents(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, int64(0), errors.New("db unavailable"))
+
+		sut := audit.NewAuditService(repoMq, &testLogger)
+
+		// Act
+		result, err := sut.GetUserEvents(7, 1, 10)
+
+		// Assert
+		require.ErrorIs(t, err, spverrors.ErrGetAuditEvents)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,106 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/spv-wallet/models"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	backendconfig "github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+	mock "github.com/bsv-blockchain/spv-wallet-web-backend/tests/mocks"
+)
+
+func TestRegistrationDomain(t *testing.T) {
+	viper.Set(backendconfig.EnvPaymailDomain, "springfield.com")
+	defer viper.Set(backendconfig.EnvPaymailDomain, nil)
+	viper.Set(backendconfig.EnvPaymailDomainSettings, `{"shelbyville.com": {"registrationClosed": true}}`)
+	defer viper.Set(backendconfig.EnvPaymailDomainSettings, nil)
+
+	cases := []struct {
+		name           string
+		domain         string
+		expectedDomain string
+		expectedErr    error
+	}{
+		{
+			name:           "Default domain",
+			domain:         "",
+			expectedDomain: "springfield.com",
+		},
+		{
+			name:           "Chosen domain",
+			domain:         " Capital-City.com ",
+			expectedDomain: "capital-city.com",
+		},
+		{
+			name:        "Domain with closed registration",
+			domain:      "shelbyville.com",
+			expectedErr: spverrors.ErrPaymailRegistrationClosed,
+		},
+		{
+			name:        "Domain not supported by SPV Wallet",
+			domain:      "ogdenville.com",
+			expectedErr: spverrors.ErrPaymailDomainNotAllowed,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			sut := newConfigService(t)
+
+			// Act
+			domain, err := sut.RegistrationDomain(tc.domain)
+
+			// Assert
+			if tc.expectedErr != nil {
+				require.EqualError(t, err, tc.expectedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedDomain, domain)
+		})
+	}
+}
+
+func TestGetPublicConfig_ExposesPaymailDomains(t *testing.T) {
+	// Arrange
+	viper.Set(backendconfig.EnvPaymailDomain, "not-in-spv-wallet.com")
+	defer viper.Set(backendconfig.EnvPaymailDomain, nil)
+	viper.Set(backendconfig.EnvPaymailDomainSettings, `{"shelbyville.com": {"registrationClosed": true}}`)
+	defer viper.Set(backendconfig.EnvPaymailDomainSettings, nil)
+
+	sut := newConfigService(t)
+
+	// Act
+	publicConfig := sut.GetPublicConfig()
+
+	// Assert
+	require.NotNil(t, publicConfig)
+	assert.Equal(t, "springfield.com", publicConfig.PaymailDomain)
+	assert.Equal(t, []config.PaymailDomain{
+		{Domain: "springfield.com", RegistrationOpen: true},
+		{Domain: "capital-city.com", RegistrationOpen: true},
+		{Domain: "shelbyville.com", RegistrationOpen: false},
+	}, publicConfig.PaymailDomains)
+}
+
+func newConfigService(t *testing.T) *config.Service {
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+
+	adminClientMq := mock.NewMockAdminWalletClient(ctrl)
+	adminClientMq.EXPECT().
+		GetSharedConfig().
+		Return(&models.SharedConfig{
+			PaymailDomains: []string{"springfield.com", "capital-city.com", "shelbyville.com"},
+		}, nil)
+
+	return config.NewConfigService(adminClientMq, &testLogger)
+}
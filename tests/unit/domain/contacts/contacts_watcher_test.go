@@ -0,0 +1,157 @@
+package contacts_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/spv-wallet/models"
+	"github.com/bsv-blockchain/spv-wallet/models/response"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/contacts"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/notification"
+	mock "github.com/bsv-blockchain/spv-wallet-web-backend/tests/mocks"
+)
+
+func TestWatchContacts_NotifiesAboutChanges(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	viper.Set(config.EnvContactsNotificationsInterval, 10*time.Millisecond)
+	defer viper.Set(config.EnvContactsNotificationsInterval, nil)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessKey := "access-key"
+	known := contact("known", "known@example.com", response.ContactNotConfirmed)
+	invitation := contact("invitation", "invitation@example.com", response.ContactAwaitAccept)
+	confirmed := contact("known", "known@example.com", response.ContactConfirmed)
+
+	mockUserWalletClient := mock.NewMockUserWalletClient(ctrl)
+	gomock.InOrder(
+		mockUserWalletClient.EXPECT().
+			GetContacts(gomock.Any(), nil, nil, gomock.Any()).
+			Return(contactsPage(known), nil),
+		mockUserWalletClient.EXPECT().
+			GetContacts(gomock.Any(), nil, nil, gomock.Any()).
+			Return(contactsPage(known, invitation), nil),
+		mockUserWalletClient.EXPECT().
+			GetContacts(gomock.Any(), nil, nil, gomock.Any()).
+			Return(contactsPage(confirmed, invitation), nil).
+			AnyTimes(),
+	)
+
+	clientFctrMq := mock.NewMockWalletClientFactory(ctrl)
+	clientFctrMq.EXPECT().
+		CreateWithAccessKey(accessKey).
+		Return(mockUserWalletClient, nil)
+
+	sut := contacts.NewContactsService(mock.NewMockAdminWalletClient(ctrl), clientFctrMq, nil, &testLogger)
+
+	// Act
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan notification.ContactEvent, 10)
+	done := make(chan struct{})
+	go func() {
+		sut.WatchContacts(ctx, accessKey, func(event notification.ContactEvent) {
+			events <- event
+		})
+		close(done)
+	}()
+
+	received := make([]notification.ContactEvent, 0, 2)
+	for len(received) < 2 {
+		select {
+		case event := <-events:
+			received = append(received, event)
+		case <-time.After(time.Second):
+			t.Fatal("timeout while waiting for contact events")
+		}
+	}
+	cancel()
+	<-done
+
+	// Assert
+	assert.Equal(t, notification.ContactInvitationEventType, received[0].EventType)
+	assert.Equal(t, invitation.Paymail, received[0].Contact.Paymail)
+	assert.Equal(t, notification.ContactConfirmedEventType, received[1].EventType)
+	assert.Equal(t, confirmed.Paymail, received[1].Contact.Paymail)
+	assert.Empty(t, events)
+}
+
+func TestWatchContacts_FirstCheck_NotifiesAwaitingInvitations(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	viper.Set(config.EnvContactsNotificationsInterval, time.Hour)
+	defer viper.Set(config.EnvContactsNotificationsInterval, nil)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accessKey := "access-key"
+	invitation := contact("invitation", "invitation@example.com", response.ContactAwaitAccept)
+	confirmed := contact("confirmed", "confirmed@example.com", response.ContactConfirmed)
+	notConfirmed := contact("not-confirmed", "not-confirmed@example.com", response.ContactNotConfirmed)
+
+	mockUserWalletClient := mock.NewMockUserWalletClient(ctrl)
+	mockUserWalletClient.EXPECT().
+		GetContacts(gomock.Any(), nil, nil, gomock.Any()).
+		Return(contactsPage(confirmed, invitation, notConfirmed), nil)
+
+	clientFctrMq := mock.NewMockWalletClientFactory(ctrl)
+	clientFctrMq.EXPECT().
+		CreateWithAccessKey(accessKey).
+		Return(mockUserWalletClient, nil)
+
+	sut := contacts.NewContactsService(mock.NewMockAdminWalletClient(ctrl), clientFctrMq, nil, &testLogger)
+
+	// Act
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan notification.ContactEvent, 10)
+	done := make(chan struct{})
+	go func() {
+		sut.WatchContacts(ctx, accessKey, func(event notification.ContactEvent) {
+			events <- event
+		})
+		close(done)
+	}()
+
+	var received notification.ContactEvent
+	select {
+	case received = <-events:
+	case <-time.After(time.Second):
+		t.Fatal("timeout while waiting for contact events")
+	}
+	cancel()
+	<-done
+
+	// Assert
+	assert.Equal(t, notification.ContactInvitationEventType, received.EventType)
+	assert.Equal(t, invitation.Paymail, received.Contact.Paymail)
+	assert.Empty(t, events)
+}
+
+func contact(id, paymail string, status response.ContactStatus) *models.Contact {
+	return &models.Contact{
+		ID:      id,
+		Paymail: paymail,
+		Status:  status,
+	}
+}
+
+func contactsPage(content ...*models.Contact) *models.SearchContactsResponse {
+	return &models.SearchContactsResponse{
+		Content: content,
+		Page: models.Page{
+			TotalElements: int64(len(content)),
+			TotalPages:    1,
+			Size:          len(content),
+			Number:        1,
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/bsv-blockchain/spv-wallet/models"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/events"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/notification"
+	mock "github.com/bsv-blockchain/spv-wallet-web-backend/tests/mocks"
+)
+
+func TestHandleTransactionEvent_PublishesEventForAffectedUsers(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockRepository(ctrl)
+	repoMq.EXPECT().
+		GetUserByXpubID(gomock.Any(), "sender-xpub").
+		Return(&users.User{ID: 1}, nil)
+	repoMq.EXPECT().
+		GetUserByXpubID(gomock.Any(), "receiver-xpub").
+		Return(&users.User{ID: 2}, nil)
+	repoMq.EXPECT().
+		GetUserByXpubID(gomock.Any(), "foreign-xpub").
+		Return(nil, nil)
+
+	sut := events.NewEventsService(repoMq, &testLogger)
+
+	published := make(map[int]notification.WalletTransactionEvent)
+	sut.Subscribe(func(userID int, event any) {
+		published[userID] = event.(notification.WalletTransactionEvent)
+	})
+
+	// Act
+	sut.HandleTransactionEvent(&models.TransactionEvent{
+		UserEvent:     models.UserEvent{XPubID: "sender-xpub"},
+		TransactionID: "tx",
+		Status:        "BROADCASTED",
+		XpubOutputValue: map[string]int64{
+			"sender-xpub":   -600,
+			"receiver-xpub": 500,
+			"foreign-xpub":  100,
+		},
+	})
+
+	// Assert
+	assert.Len(t, published, 2)
+	assert.Equal(t, int64(-600), published[1].Transaction.Value)
+	assert.Equal(t, int64(500), published[2].Transaction.Value)
+	assert.Equal(t, "tx", published[2].Transaction.ID)
+	assert.Equal(t, notification.WalletTransactionEventType, published[2].EventType)
+}
+
+func TestHandleStringEvent_PublishesEventForAllUsers(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sut := events.NewEventsService(mock.NewMockRepository(ctrl), &testLogger)
+
+	var publishedTo []int
+	sut.Subscribe(func(userID int, _ any) {
+		publishedTo = append(publishedTo, userID)
+	})
+
+	// Act
+	sut.HandleStringEvent(&models.StringEvent{Value: "hello"})
+
+	// Assert
+	assert.Equal(t, []int{events.AllUsers}, publishedTo)
+}
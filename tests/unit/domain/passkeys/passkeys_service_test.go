@@ -0,0 +1,512 @@
+package passkeys_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/passkeys"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/encryption"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+	mock "github.com/bsv-blockchain/spv-wallet-web-backend/tests/mocks"
+)
+
+const (
+	testRPID     = "localhost"
+	testOrigin   = "http://localhost:3002"
+	testPassword = "strongP4$$word"
+	testXpriv    = "xprv-of-the-user"
+)
+
+func TestPasskey_RegisteredPasskeyUnlocksXpriv(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := testUser(t)
+	key := newAuthenticator(t, user.ID)
+	repoMq, usersRepoMq := mock.NewMockPasskeysRepository(ctrl), mock.NewMockRepository(ctrl)
+	usersRepoMq.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil).AnyTimes()
+
+	var stored *passkeys.Passkey
+	repoMq.EXPECT().GetPasskeys(gomock.Any(), user.ID).DoAndReturn(func(context.Context, int) ([]*passkeys.Passkey, error) {
+		if stored == nil {
+			return nil, nil
+		}
+		return []*passkeys.Passkey{stored}, nil
+	}).AnyTimes()
+	repoMq.EXPECT().InsertPasskey(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, passkey *passkeys.Passkey) error {
+		stored = passkey
+		stored.ID = 3
+		return nil
+	})
+	repoMq.EXPECT().UpdatePasskeyCredential(gomock.Any(), 3, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int, credential *webauthn.Credential, _ time.Time) error {
+			assert.Equal(t, uint32(2), credential.Authenticator.SignCount)
+			return nil
+		})
+
+	sut := newService(t, ctrl, repoMq, usersRepoMq)
+
+	// Act
+	creation, registration, err := sut.BeginRegistration(context.Background(), user.ID)
+	require.NoError(t, err)
+	passkey, err := sut.FinishRegistration(context.Background(), user.ID, testPassword, " laptop ", registration, key.create(t, creation))
+	require.NoError(t, err)
+
+	assertion, confirmation, err := sut.BeginConfirmation(context.Background(), user.ID)
+	require.NoError(t, err)
+	xpriv, err := sut.UnlockXpriv(context.Background(), user.ID, confirmation, key.get(t, assertion))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, testXpriv, xpriv)
+	assert.Equal(t, "laptop", passkey.Name)
+	assert.Equal(t, key.credentialID, passkey.CredentialID)
+	assert.NotContains(t, passkey.Xpriv, testXpriv)
+}
+
+func TestPasskey_SignIn_IdentifiesUserByPasskey(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	disabledAt := time.Now()
+	user := testUser(t)
+	user.DisabledAt = &disabledAt
+	key := newAuthenticator(t, user.ID)
+	passkey := key.passkey(t)
+
+	repoMq, usersRepoMq := mock.NewMockPasskeysRepository(ctrl), mock.NewMockRepository(ctrl)
+	repoMq.EXPECT().GetPasskeyByCredentialID(gomock.Any(), key.credentialID).Return(passkey, nil)
+	repoMq.EXPECT().UpdatePasskeyCredential(gomock.Any(), passkey.ID, gomock.Any(), gomock.Any()).Return(nil)
+	usersRepoMq.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+
+	sut := newService(t, ctrl, repoMq, usersRepoMq)
+
+	// Act
+	assertion, ceremony, err := sut.BeginSignIn()
+	require.NoError(t, err)
+	signInUser, err := sut.FinishSignIn(context.Background(), ceremony, key.get(t, assertion))
+
+	// Assert
+	// Disabled account is checked once the xpriv is unlocked, so the whole ceremony has passed.
+	require.ErrorIs(t, err, spverrors.ErrAccountDisabled)
+	assert.Nil(t, signInUser)
+}
+
+func TestPasskey_SignIn_SSOUser_ReturnsError(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := testUser(t)
+	user.SSOIssuer = "https://idp.example.com"
+	user.SSOSubject = "homer-subject"
+	key := newAuthenticator(t, user.ID)
+
+	repoMq, usersRepoMq := mock.NewMockPasskeysRepository(ctrl), mock.NewMockRepository(ctrl)
+	repoMq.EXPECT().GetPasskeyByCredentialID(gomock.Any(), key.credentialID).Return(key.passkey(t), nil)
+	repoMq.EXPECT().UpdatePasskeyCredential(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	usersRepoMq.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+
+	sut := newService(t, ctrl, repoMq, usersRepoMq)
+
+	// Act
+	assertion, ceremony, err := sut.BeginSignIn()
+	require.NoError(t, err)
+	signInUser, err := sut.FinishSignIn(context.Background(), ceremony, key.get(t, assertion))
+
+	// Assert
+	// Users of the identity provider cannot bypass it with their passkey.
+	require.ErrorIs(t, err, spverrors.ErrPasskeyAuthentication)
+	assert.Nil(t, signInUser)
+}
+
+func TestPasskey_UnlockXpriv_InvalidAssertion_ReturnsError(t *testing.T) {
+	cases := []struct {
+		name   string
+		modify func(key *authenticator, passkey *passkeys.Passkey)
+	}{
+		{
+			name: "Different PRF output",
+			modify: func(key *authenticator, _ *passkeys.Passkey) {
+				key.prf = bytes.Repeat([]byte{9}, 32)
+			},
+		},
+		{
+			name: "No PRF output",
+			modify: func(key *authenticator, _ *passkeys.Passkey) {
+				key.prf = nil
+			},
+		},
+		{
+			name: "Sign counter did not increase",
+			modify: func(_ *authenticator, passkey *passkeys.Passkey) {
+				passkey.Credential.Authenticator.SignCount = 10
+			},
+		},
+		{
+			name: "Passkey of another user",
+			modify: func(key *authenticator, _ *passkeys.Passkey) {
+				other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				require.NoError(t, err)
+				key.key = other
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := testUser(t)
+			key := newAuthenticator(t, user.ID)
+			passkey := key.passkey(t)
+			tc.modify(key, passkey)
+
+			repoMq, usersRepoMq := mock.NewMockPasskeysRepository(ctrl), mock.NewMockRepository(ctrl)
+			repoMq.EXPECT().GetPasskeys(gomock.Any(), user.ID).Return([]*passkeys.Passkey{passkey}, nil).Times(2)
+			usersRepoMq.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil).Times(2)
+
+			sut := newService(t, ctrl, repoMq, usersRepoMq)
+			assertion, ceremony, err := sut.BeginConfirmation(context.Background(), user.ID)
+			require.NoError(t, err)
+
+			// Act
+			xpriv, err := sut.UnlockXpriv(context.Background(), user.ID, ceremony, key.get(t, assertion))
+
+			// Assert
+			require.ErrorIs(t, err, spverrors.ErrPasskeyAuthentication)
+			assert.Empty(t, xpriv)
+		})
+	}
+}
+
+func TestPasskey_FinishRegistration_InvalidRequest_ReturnsError(t *testing.T) {
+	cases := []struct {
+		name          string
+		passkeyName   string
+		password      string
+		noPRF         bool
+		expectedError error
+	}{
+		{
+			name:          "Empty name",
+			passkeyName:   " ",
+			password:      testPassword,
+			expectedError: spverrors.ErrInvalidPasskeyName,
+		},
+		{
+			name:          "Too long name",
+			passkeyName:   string(bytes.Repeat([]byte{'a'}, 65)),
+			password:      testPassword,
+			expectedError: spverrors.ErrInvalidPasskeyName,
+		},
+		{
+			name:          "Invalid password",
+			passkeyName:   "laptop",
+			password:      "invalid",
+			expectedError: spverrors.ErrInvalidCredentials,
+		},
+		{
+			name:          "Authenticator without PRF",
+			passkeyName:   "laptop",
+			password:      testPassword,
+			noPRF:         true,
+			expectedError: spverrors.ErrPasskeyPRFUnsupported,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			user := testUser(t)
+			key := newAuthenticator(t, user.ID)
+			if tc.noPRF {
+				key.prf = nil
+			}
+
+			repoMq, usersRepoMq := mock.NewMockPasskeysRepository(ctrl), mock.NewMockRepository(ctrl)
+			repoMq.EXPECT().GetPasskeys(gomock.Any(), user.ID).Return(nil, nil).AnyTimes()
+			usersRepoMq.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil).AnyTimes()
+
+			sut := newService(t, ctrl, repoMq, usersRepoMq)
+			creation, ceremony, err := sut.BeginRegistration(context.Background(), user.ID)
+			require.NoError(t, err)
+
+			// Act
+			passkey, err := sut.FinishRegistration(context.Background(), user.ID, tc.password, tc.passkeyName, ceremony, key.create(t, creation))
+
+			// Assert
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Nil(t, passkey)
+		})
+	}
+}
+
+func TestPasskey_BeginConfirmation_NoPasskeys_ReturnsError(t *testing.T) {
+	// Arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq, usersRepoMq := mock.NewMockPasskeysRepository(ctrl), mock.NewMockRepository(ctrl)
+	repoMq.EXPECT().GetPasskeys(gomock.Any(), 1).Return(nil, nil)
+	usersRepoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(testUser(t), nil)
+
+	sut := newService(t, ctrl, repoMq, usersRepoMq)
+
+	// Act
+	assertion, ceremony, err := sut.BeginConfirmation(context.Background(), 1)
+
+	// Assert
+	require.ErrorIs(t, err, spverrors.ErrPasskeyNotFound)
+	assert.Nil(t, assertion)
+	assert.Empty(t, ceremony)
+}
+
+func TestPasskey_DeletePasskey(t *testing.T) {
+	cases := []struct {
+		name          string
+		password      string
+		expectDelete  bool
+		deleted       bool
+		expectedError error
+	}{
+		{
+			name:         "Passkey is deleted",
+			password:     testPassword,
+			expectDelete: true,
+			deleted:      true,
+		},
+		{
+			name:          "Invalid password does not delete passkey",
+			password:      "wrongP4$$word",
+			expectedError: spverrors.ErrInvalidCredentials,
+		},
+		{
+			name:          "Passkey of other user",
+			password:      testPassword,
+			expectDelete:  true,
+			expectedError: spverrors.ErrPasskeyNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repoMq, usersRepoMq := mock.NewMockPasskeysRepository(ctrl), mock.NewMockRepository(ctrl)
+			usersRepoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(testUser(t), nil)
+			if tc.expectDelete {
+				repoMq.EXPECT().DeletePasskey(gomock.Any(), 1, 3).Return(tc.deleted, nil)
+			}
+
+			sut := newService(t, ctrl, repoMq, usersRepoMq)
+
+			// Act
+			err := sut.DeletePasskey(context.Background(), 1, 3, tc.password)
+
+			// Assert
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func newService(t *testing.T, ctrl *gomock.Controller, repo passkeys.Repository, usersRepo users.Repository) *passkeys.Service {
+	viper.Set(config.EnvPasskeysRPID, testRPID)
+	viper.Set(config.EnvPasskeysRPDisplayName, "SPV Wallet")
+	viper.Set(config.EnvPasskeysRPOrigins, []string{testOrigin})
+	t.Cleanup(func() {
+		viper.Set(config.EnvPasskeysRPID, nil)
+		viper.Set(config.EnvPasskeysRPDisplayName, nil)
+		viper.Set(config.EnvPasskeysRPOrigins, nil)
+	})
+
+	testLogger := zerolog.Nop()
+	recorderMq := mock.NewMockRecorder(ctrl)
+	recorderMq.EXPECT().Record(gomock.Any(), gomock.Any()).AnyTimes()
+	usersService := users.NewUserService(usersRepo, nil, nil, nil, recorderMq, &testLogger)
+
+	sut, err := passkeys.NewPasskeysService(repo, usersRepo, usersService, recorderMq, &testLogger)
+	require.NoError(t, err)
+	return sut
+}
+
+func testUser(t *testing.T) *users.User {
+	return &users.User{ID: 1, Email: "homer@example.com", Paymail: "homer@example.com", Xpriv: encrypt(t, testPassword, testXpriv)}
+}
+
+func encrypt(t *testing.T, secret, value string) string {
+	hashedSecret, err := encryption.Hash(secret)
+	require.NoError(t, err)
+	encrypted, err := encryption.Encrypt(hashedSecret, value)
+	require.NoError(t, err)
+	return encrypted
+}
+
+// authenticator is a software passkey with ES256 key and PRF extension, making "none" attestation.
+type authenticator struct {
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	userHandle   []byte
+	signCount    uint32
+	prf          []byte
+}
+
+func newAuthenticator(t *testing.T, userID int) *authenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	credentialID := make([]byte, 16)
+	_, err = rand.Read(credentialID)
+	require.NoError(t, err)
+
+	return &authenticator{
+		key:          key,
+		credentialID: credentialID,
+		userHandle:   binary.BigEndian.AppendUint64(nil, uint64(userID)), //nolint:gosec // test user ids are positive
+		prf:          bytes.Repeat([]byte{7}, 32),
+	}
+}
+
+// passkey returns passkey registered with the authenticator, as stored after the registration.
+func (a *authenticator) passkey(t *testing.T) *passkeys.Passkey {
+	return &passkeys.Passkey{
+		ID:           3,
+		UserID:       1,
+		Name:         "laptop",
+		CredentialID: a.credentialID,
+		Credential: &webauthn.Credential{
+			ID:              a.credentialID,
+			PublicKey:       a.publicKey(t),
+			AttestationType: "none",
+			Flags:           webauthn.CredentialFlags{UserPresent: true, UserVerified: true},
+		},
+		Xpriv: encrypt(t, hex.EncodeToString(a.prf), testXpriv),
+	}
+}
+
+// create returns response of navigator.credentials.create() with the options.
+func (a *authenticator) create(t *testing.T, options *protocol.CredentialCreation) []byte {
+	a.signCount++
+	authData := a.authData(0x45) // user present, user verified, attested credential data
+	authData = append(authData, make([]byte, 16)...)
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(a.credentialID))) //nolint:gosec // credential id is short
+	authData = append(authData, a.credentialID...)
+	authData = append(authData, a.publicKey(t)...)
+
+	attestationObject, err := webauthncbor.Marshal(map[string]any{
+		"fmt":      "none",
+		"attStmt":  map[string]any{},
+		"authData": authData,
+	})
+	require.NoError(t, err)
+
+	return a.response(t, map[string]any{
+		"clientDataJSON":    encode(a.clientData(t, "webauthn.create", options.Response.Challenge)),
+		"attestationObject": encode(attestationObject),
+	})
+}
+
+// get returns response of navigator.credentials.get() with the options.
+func (a *authenticator) get(t *testing.T, options *protocol.CredentialAssertion) []byte {
+	a.signCount++
+	authData := a.authData(0x05) // user present, user verified
+	clientData := a.clientData(t, "webauthn.get", options.Response.Challenge)
+
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(bytes.Clone(authData), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	require.NoError(t, err)
+
+	return a.response(t, map[string]any{
+		"clientDataJSON":    encode(clientData),
+		"authenticatorData": encode(authData),
+		"signature":         encode(signature),
+		"userHandle":        encode(a.userHandle),
+	})
+}
+
+func (a *authenticator) response(t *testing.T, response map[string]any) []byte {
+	extensions := map[string]any{}
+	if a.prf != nil {
+		extensions["prf"] = map[string]any{"results": map[string]any{"first": encode(a.prf)}}
+	}
+	credential, err := json.Marshal(map[string]any{
+		"id":                     encode(a.credentialID),
+		"rawId":                  encode(a.credentialID),
+		"type":                   "public-key",
+		"response":               response,
+		"clientExtensionResults": extensions,
+	})
+	require.NoError(t, err)
+	return credential
+}
+
+func (a *authenticator) authData(flags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(testRPID))
+	authData := append(rpIDHash[:], flags)
+	return binary.BigEndian.AppendUint32(authData, a.signCount)
+}
+
+func (a *authenticator) clientData(t *testing.T, ceremony string, challenge protocol.URLEncodedBase64) []byte {
+	clientData, err := json.Marshal(map[string]any{
+		"type":      ceremony,
+		"challenge": encode(challenge),
+		"origin":    testOrigin,
+	})
+	require.NoError(t, err)
+	return clientData
+}
+
+// publicKey returns COSE encoded public key of the authenticator.
+func (a *authenticator) publicKey(t *testing.T) []byte {
+	publicKey, err := a.key.PublicKey.ECDH()
+	require.NoError(t, err)
+	point := publicKey.Bytes()
+
+	cose, err := webauthncbor.Marshal(map[int]any{
+		1:  2,  // key type EC2
+		3:  -7, // algorithm ES256
+		-1: 1,  // curve P-256
+		-2: point[1:33],
+		-3: point[33:],
+	})
+	require.NoError(t, err)
+	return cose
+}
+
+func encode(value []byte) string {
+	return base64.RawURLEncoding.EncodeToString(value)
+}
@@ -0,0 +1,262 @@
+package paymail_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/paymail"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+)
+
+// stubPaymailServer starts local paymail service supporting P2P transactions with alice@example.com and bob@example.com paymails.
+func stubPaymailServer(t *testing.T, requests *atomic.Int32) (*paymail.Resolver, func()) {
+	mux := http.NewServeMux()
+	var server *httptest.Server
+	writeJSON := func(w http.ResponseWriter, v any) {
+		requests.Add(1)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+
+	mux.HandleFunc("/.well-known/bsvalias", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{
+			"bsvalias": "1.0",
+			"capabilities": map[string]any{
+				"pki":          server.URL + "/id/{alias}@{domain.tld}",
+				"f12f968c92d6": server.URL + "/profile/{alias}@{domain.tld}",
+				"2a40af698840": server.URL + "/p2p-destination/{alias}@{domain.tld}",
+				"5f1323cddf31": server.URL + "/receive-tx/{alias}@{domain.tld}",
+			},
+		})
+	})
+	mux.HandleFunc("/id/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/id/alice@example.com" && r.URL.Path != "/id/bob@example.com" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(w, map[string]any{"bsvalias": "1.0", "handle": strings.TrimPrefix(r.URL.Path, "/id/"), "pubkey": "02abc"})
+	})
+	mux.HandleFunc("/profile/", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{"name": "Alice", "avatar": "https://example.com/alice.png"})
+	})
+
+	server = httptest.NewTLSServer(mux)
+	testLogger := zerolog.Nop()
+	lookup := func(_ context.Context, _ string) string {
+		return server.Listener.Addr().String()
+	}
+	return paymail.NewResolver(server.Client(), lookup, localDomains, &testLogger), server.Close
+}
+
+func TestResolve_ResolvesPaymailWithProfile(t *testing.T) {
+	// Arrange
+	setCacheLimits(t, 10)
+	var requests atomic.Int32
+	sut, stop := stubPaymailServer(t, &requests)
+	defer stop()
+
+	// Act
+	resolution, err := sut.Resolve(context.Background(), " Alice@Example.com ")
+	require.NoError(t, err)
+	cached, err := sut.Resolve(context.Background(), "alice@example.com")
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, &paymail.Resolution{
+		Address: "alice@example.com",
+		Alias:   "alice",
+		Domain:  "example.com",
+		PubKey:  "02abc",
+		P2P:     true,
+		Name:    "Alice",
+		Avatar:  "https://example.com/alice.png",
+	}, resolution)
+	assert.Equal(t, resolution, cached)
+	assert.Equal(t, int32(3), requests.Load(), "second resolution should be served from cache")
+}
+
+func TestResolve_RejectsInvalidRecipients(t *testing.T) {
+	var requests atomic.Int32
+	sut, stop := stubPaymailServer(t, &requests)
+	defer stop()
+
+	cases := []struct {
+		name    string
+		address string
+		err     error
+	}{
+		{name: "Invalid address", address: "alice", err: spverrors.ErrInvalidPaymailAddress},
+		{name: "Address with invalid domain", address: "alice@example", err: spverrors.ErrInvalidPaymailAddress},
+		{name: "Unknown alias", address: "carol@example.com", err: spverrors.ErrPaymailNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			resolution, err := sut.ResolveRecipient(context.Background(), tc.address)
+
+			// Assert
+			assert.Nil(t, resolution)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestResolve_DomainWithoutPaymail(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+	lookup := func(_ context.Context, _ string) string {
+		return server.Listener.Addr().String()
+	}
+	sut := paymail.NewResolver(server.Client(), lookup, localDomains, &testLogger)
+
+	// Act
+	resolution, err := sut.Resolve(context.Background(), "alice@example.com")
+
+	// Assert
+	assert.Nil(t, resolution)
+	assert.ErrorIs(t, err, spverrors.ErrPaymailDomainNotSupported)
+}
+
+func TestResolve_EvictsLeastRecentlyUsedResolution(t *testing.T) {
+	// Arrange
+	setCacheLimits(t, 1)
+	var requests atomic.Int32
+	sut, stop := stubPaymailServer(t, &requests)
+	defer stop()
+
+	// Act
+	_, err := sut.Resolve(context.Background(), "alice@example.com")
+	require.NoError(t, err)
+	_, err = sut.Resolve(context.Background(), "bob@example.com")
+	require.NoError(t, err)
+	_, err = sut.Resolve(context.Background(), "bob@example.com")
+	require.NoError(t, err)
+	_, err = sut.Resolve(context.Background(), "alice@example.com")
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, int32(9), requests.Load(), "only the most recent resolution should be served from cache")
+}
+
+func TestResolve_RejectsNotHTTPSCapability(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	var internalRequests atomic.Int32
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		internalRequests.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{"pubkey": "02abc"})
+	}))
+	defer internal.Close()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"capabilities": map[string]any{"pki": internal.URL + "/id/{alias}@{domain.tld}"},
+		})
+	}))
+	defer server.Close()
+	lookup := func(_ context.Context, _ string) string {
+		return server.Listener.Addr().String()
+	}
+	sut := paymail.NewResolver(server.Client(), lookup, localDomains, &testLogger)
+
+	// Act
+	resolution, err := sut.Resolve(context.Background(), "alice@example.com")
+
+	// Assert
+	assert.Nil(t, resolution)
+	assert.ErrorIs(t, err, spverrors.ErrResolvePaymail)
+	assert.Zero(t, internalRequests.Load())
+}
+
+func TestNewPublicHTTPClient_RejectsNotPublicAddresses(t *testing.T) {
+	// Arrange
+	var requests atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+	sut := paymail.NewPublicHTTPClient(time.Second)
+
+	for _, url := range []string{server.URL, "https://[::1]:1", "https://10.0.0.1:1", "https://169.254.169.254", "https://100.64.0.1:1"} {
+		t.Run(url, func(t *testing.T) {
+			// Act
+			res, err := sut.Get(url) //nolint:noctx // test request
+
+			// Assert
+			if res != nil {
+				_ = res.Body.Close()
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "is not public")
+		})
+	}
+	assert.Zero(t, requests.Load())
+}
+
+func localDomains() []string {
+	return []string{"wallet.local"}
+}
+
+func setCacheLimits(t *testing.T, maxEntries int) {
+	viper.Set(config.EnvCachePaymailTTL, time.Minute)
+	viper.Set(config.EnvCachePaymailMaxEntries, maxEntries)
+	t.Cleanup(func() {
+		viper.Set(config.EnvCachePaymailTTL, nil)
+		viper.Set(config.EnvCachePaymailMaxEntries, nil)
+	})
+}
+
+func TestValidateRecipient(t *testing.T) {
+	setCacheLimits(t, 10)
+	var requests atomic.Int32
+	sut, stop := stubPaymailServer(t, &requests)
+	defer stop()
+
+	cases := []struct {
+		name      string
+		recipient string
+		expected  string
+		err       error
+		local     bool
+	}{
+		{name: "Paymail is resolved", recipient: " Alice@Example.com ", expected: "alice@example.com"},
+		{name: "Paymail in local domain is not resolved", recipient: " Dave@Wallet.Local ", expected: "dave@wallet.local", local: true},
+		{name: "Invalid paymail in local domain", recipient: "dave smith@wallet.local", err: spverrors.ErrInvalidPaymailAddress},
+		{name: "Unknown paymail", recipient: "carol@example.com", err: spverrors.ErrPaymailNotFound},
+		{name: "BSV address is not resolved", recipient: " 1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2 ", expected: "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			requests.Store(0)
+
+			// Act
+			recipient, err := sut.ValidateRecipient(context.Background(), tc.recipient)
+
+			// Assert
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, recipient)
+			if !paymail.IsPaymail(tc.recipient) || tc.local {
+				assert.Zero(t, requests.Load())
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package rates_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/rates"
+)
+
+func TestGetExchangeRate_NotifiesOnlyAboutChangedRate(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	var rate atomic.Value
+	rate.Store("50.5")
+	exchangeRateAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"rate": %s}`, rate.Load())
+	}))
+	defer exchangeRateAPI.Close()
+
+	viper.Set(config.EnvEndpointsExchangeRate, exchangeRateAPI.URL)
+	defer viper.Set(config.EnvEndpointsExchangeRate, nil)
+	viper.Set(config.EnvCacheSettingsTTL, 0)
+	defer viper.Set(config.EnvCacheSettingsTTL, nil)
+
+	sut := rates.NewRatesService(&testLogger)
+
+	var changes []float64
+	sut.OnRateChanged(func(rate float64) {
+		changes = append(changes, rate)
+	})
+
+	// Act
+	_, err := sut.GetExchangeRate()
+	require.NoError(t, err)
+	rate.Store("51.25")
+	current, err := sut.GetExchangeRate()
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []float64{51.25}, changes)
+	assert.InDelta(t, 51.25, *current, 0)
+}
@@ -0,0 +1,234 @@
+package sso_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/sso"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+)
+
+const testClientID = "spv-wallet"
+
+// issuer is a fake OpenID Connect provider which issues ID token for the code of the last authorization.
+type issuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	challenge     string
+	nonce         string
+	emailVerified bool
+}
+
+func TestCompleteAuthorization_ValidCode_ReturnsIdentity(t *testing.T) {
+	// Arrange
+	provider := newIssuer(t)
+	sut := newSSOService(t, provider)
+
+	authorization, err := sut.StartAuthorization(context.Background())
+	require.NoError(t, err)
+	provider.authorize(t, authorization.URL)
+
+	// Act
+	identity, err := sut.CompleteAuthorization(context.Background(), authorization, authorization.State, "code")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, provider.server.URL, identity.Issuer)
+	assert.Equal(t, "subject-1", identity.Subject)
+	assert.Equal(t, "homer@example.com", identity.Email)
+}
+
+func TestCompleteAuthorization_InvalidAuthorization_ReturnsError(t *testing.T) {
+	cases := []struct {
+		name          string
+		modify        func(provider *issuer, authorization *sso.Authorization) string
+		expectedError error
+	}{
+		{
+			name: "State mismatch",
+			modify: func(_ *issuer, _ *sso.Authorization) string {
+				return "other-state"
+			},
+			expectedError: spverrors.ErrSSOAuthorization,
+		},
+		{
+			name: "Nonce mismatch",
+			modify: func(provider *issuer, authorization *sso.Authorization) string {
+				provider.nonce = "other-nonce"
+				return authorization.State
+			},
+			expectedError: spverrors.ErrSSOAuthorization,
+		},
+		{
+			name: "Code verifier mismatch",
+			modify: func(_ *issuer, authorization *sso.Authorization) string {
+				authorization.Verifier = "other-verifier"
+				return authorization.State
+			},
+			expectedError: spverrors.ErrSSOAuthorization,
+		},
+		{
+			name: "Email not verified",
+			modify: func(provider *issuer, authorization *sso.Authorization) string {
+				provider.emailVerified = false
+				return authorization.State
+			},
+			expectedError: spverrors.ErrSSOEmailNotVerified,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			provider := newIssuer(t)
+			sut := newSSOService(t, provider)
+
+			authorization, err := sut.StartAuthorization(context.Background())
+			require.NoError(t, err)
+			provider.authorize(t, authorization.URL)
+			state := tc.modify(provider, authorization)
+
+			// Act
+			identity, err := sut.CompleteAuthorization(context.Background(), authorization, state, "code")
+
+			// Assert
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Nil(t, identity)
+		})
+	}
+}
+
+func TestStartAuthorization_Disabled_ReturnsError(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	sut := sso.NewSSOService(http.DefaultClient, &testLogger)
+
+	// Act
+	authorization, err := sut.StartAuthorization(context.Background())
+
+	// Assert
+	require.ErrorIs(t, err, spverrors.ErrSSODisabled)
+	assert.Nil(t, authorization)
+}
+
+func newSSOService(t *testing.T, provider *issuer) *sso.Service {
+	viper.Set(config.EnvSSOEnabled, true)
+	viper.Set(config.EnvSSOIssuer, provider.server.URL)
+	viper.Set(config.EnvSSOClientID, testClientID)
+	viper.Set(config.EnvSSOClientSecret, "client-secret")
+	viper.Set(config.EnvSSOCallbackURL, "http://localhost/api/v1/sso/callback")
+	viper.Set(config.EnvSSOAccessKeySecret, "access-key-secret")
+	t.Cleanup(func() {
+		for _, key := range []string{config.EnvSSOEnabled, config.EnvSSOIssuer, config.EnvSSOClientID, config.EnvSSOClientSecret, config.EnvSSOCallbackURL, config.EnvSSOAccessKeySecret} {
+			viper.Set(key, nil)
+		}
+	})
+
+	testLogger := zerolog.Nop()
+	return sso.NewSSOService(provider.server.Client(), &testLogger)
+}
+
+func newIssuer(t *testing.T) *issuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	provider := &issuer{key: key, emailVerified: true}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{
+			"issuer":                                provider.server.URL,
+			"authorization_endpoint":                provider.server.URL + "/authorize",
+			"token_endpoint":                        provider.server.URL + "/token",
+			"jwks_uri":                              provider.server.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"alg": "RS256",
+				"use": "sig",
+				"kid": "key-1",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		verifier := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+		if r.FormValue("code") != "code" || base64.RawURLEncoding.EncodeToString(verifier[:]) != provider.challenge {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, map[string]any{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"expires_in":   300,
+			"id_token":     provider.idToken(t),
+		})
+	})
+	provider.server = httptest.NewServer(mux)
+	t.Cleanup(provider.server.Close)
+
+	return provider
+}
+
+// authorize reads values of the authorization the user is redirected with to the provider.
+func (i *issuer) authorize(t *testing.T, authorizationURL string) {
+	parsed, err := url.Parse(authorizationURL)
+	require.NoError(t, err)
+	query := parsed.Query()
+	require.Equal(t, testClientID, query.Get("client_id"))
+	require.Equal(t, "S256", query.Get("code_challenge_method"))
+	i.challenge = query.Get("code_challenge")
+	i.nonce = query.Get("nonce")
+}
+
+// idToken returns ID token signed with RS256.
+func (i *issuer) idToken(t *testing.T) string {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": "key-1"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(map[string]any{
+		"iss":            i.server.URL,
+		"sub":            "subject-1",
+		"aud":            testClientID,
+		"exp":            time.Now().Add(time.Minute).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          i.nonce,
+		"email":          " homer@example.com ",
+		"email_verified": i.emailVerified,
+	})
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, i.key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	return strings.Join([]string{signingInput, base64.RawURLEncoding.EncodeToString(signature)}, ".")
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
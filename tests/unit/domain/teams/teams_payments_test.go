@@ -0,0 +1,345 @@
+package teams_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/spv-wallet/models"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/teams"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/transactions"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/notification"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+	mock "github.com/bsv-blockchain/spv-wallet-web-backend/tests/mocks"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/spvwallet"
+)
+
+func TestCreateTransaction_AboveThreshold_HoldsPayment(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	setApprovalPolicy(t, 100, 2)
+
+	repoMq := mock.NewMockWalletsRepository(ctrl)
+	repoMq.EXPECT().GetMember(gomock.Any(), 3, 2).Return(spender(t), nil)
+	repoMq.EXPECT().GetWallet(gomock.Any(), 3).Return(&teams.Wallet{ID: 3, Paymail: "team@example.com"}, nil)
+	repoMq.EXPECT().GetMembers(gomock.Any(), 3).Return(teamMembers(), nil)
+
+	var stored *teams.PendingPayment
+	repoMq.EXPECT().
+		InsertPendingPayment(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, payment *teams.PendingPayment) error {
+			stored = payment
+			return nil
+		})
+
+	// Payment is not drafted until it is approved.
+	sut := teams.NewTeamsService(repoMq, nil, membersService(ctrl, &testLogger, 2), nil, nil, &testLogger)
+
+	// Act
+	payment, err := sut.CreateTransaction(context.Background(), 3, 2, memberPassword, "alice@example.com", 500, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, stored, payment)
+	assert.Equal(t, teams.PaymentStatusPending, payment.Status)
+	assert.Equal(t, 2, payment.RequiredApprovals)
+	assert.Equal(t, []int{2}, payment.ApprovedBy)
+	assert.Equal(t, []int{1}, payment.Approvers)
+}
+
+func TestCreateTransaction_NotEnoughApprovers_ReturnsError(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	setApprovalPolicy(t, 100, 3)
+
+	repoMq := mock.NewMockWalletsRepository(ctrl)
+	repoMq.EXPECT().GetMember(gomock.Any(), 3, 2).Return(spender(t), nil)
+	repoMq.EXPECT().GetWallet(gomock.Any(), 3).Return(&teams.Wallet{ID: 3, Paymail: "team@example.com"}, nil)
+	repoMq.EXPECT().GetMembers(gomock.Any(), 3).Return(teamMembers(), nil)
+
+	sut := teams.NewTeamsService(repoMq, nil, membersService(ctrl, &testLogger, 2), nil, nil, &testLogger)
+
+	// Act
+	payment, err := sut.CreateTransaction(context.Background(), 3, 2, memberPassword, "alice@example.com", 500, nil)
+
+	// Assert
+	require.ErrorIs(t, err, spverrors.ErrNotEnoughApprovers)
+	assert.Nil(t, payment)
+}
+
+func TestApprovePayment_LastApproval_SendsPayment(t *testing.T) {
+	testLogger := zerolog.Nop()
+	cases := []struct {
+		name           string
+		draftErr       error
+		recordErr      error
+		expectedStatus string
+		expectedError  error
+	}{
+		{
+			name:           "Recorded payment is approved",
+			expectedStatus: teams.PaymentStatusApproved,
+		},
+		{
+			name:           "Payment which cannot be recorded is failed",
+			recordErr:      errors.New("record error"),
+			expectedStatus: teams.PaymentStatusFailed,
+		},
+		{
+			name:           "Payment which cannot be drafted is failed",
+			draftErr:       errors.New("draft error"),
+			expectedStatus: teams.PaymentStatusFailed,
+			expectedError:  spverrors.ErrCreateTransaction,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repoMq := mock.NewMockWalletsRepository(ctrl)
+			clientFctrMq := mock.NewMockWalletClientFactory(ctrl)
+			userWalletClientMq := mock.NewMockUserWalletClient(ctrl)
+
+			repoMq.EXPECT().GetMember(gomock.Any(), 3, 1).Return(&teams.Member{
+				WalletID: 3,
+				UserID:   1,
+				Email:    "alice@example.com",
+				Role:     teams.MemberRoleOwner,
+				Xpriv:    encryptWithPassword(t, ownerPassword, walletXpriv),
+			}, nil)
+			repoMq.EXPECT().GetPendingPayment(gomock.Any(), 9).Return(pendingPayment(), nil)
+			repoMq.EXPECT().InsertPaymentApproval(gomock.Any(), 9, 1, gomock.Any()).Return([]int{2, 1}, nil)
+			repoMq.EXPECT().GetWallet(gomock.Any(), 3).Return(&teams.Wallet{ID: 3, Paymail: "team@example.com"}, nil)
+
+			finished := make(chan string, 1)
+			gomock.InOrder(
+				repoMq.EXPECT().UpdatePaymentStatus(gomock.Any(), 9, teams.PaymentStatusPending, teams.PaymentStatusSending).Return(true, nil),
+				repoMq.EXPECT().
+					UpdatePaymentStatus(gomock.Any(), 9, teams.PaymentStatusSending, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ int, _, status string) (bool, error) {
+						finished <- status
+						return true, nil
+					}),
+			)
+
+			clientFctrMq.EXPECT().CreateWithXpriv(walletXpriv).Return(userWalletClientMq, nil)
+			userWalletClientMq.EXPECT().
+				CreateAndFinalizeTransaction(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ any, metadata map[string]any) (*spvwallet.DraftTransaction, error) {
+					assert.Equal(t, "bob@example.com", metadata["initiator"])
+					if tc.draftErr != nil {
+						return nil, tc.draftErr
+					}
+					return &spvwallet.DraftTransaction{TxDraftID: "draft-id", TxHex: "draft-hex"}, nil
+				})
+			var recorded *teams.Transaction
+			if tc.draftErr == nil {
+				userWalletClientMq.EXPECT().
+					RecordTransaction("draft-hex", "draft-id", gomock.Any()).
+					Return(&models.Transaction{ID: "tx-id"}, tc.recordErr).
+					MinTimes(1)
+			}
+			if tc.draftErr == nil && tc.recordErr == nil {
+				repoMq.EXPECT().
+					InsertTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, transaction *teams.Transaction) error {
+						recorded = transaction
+						return nil
+					})
+			}
+
+			tService := transactions.NewTransactionService(nil, clientFctrMq, recorderMq(ctrl), &testLogger)
+			sut := teams.NewTeamsService(repoMq, nil, membersService(ctrl, &testLogger, 1), tService, nil, &testLogger)
+
+			// Act
+			events := make(chan notification.TransactionEvent, 1)
+			payment, err := sut.ApprovePayment(context.Background(), 3, 9, 1, ownerPassword, events)
+
+			// Assert
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, payment)
+				assert.Equal(t, tc.expectedStatus, <-finished)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, teams.PaymentStatusSending, payment.Status)
+			assert.Equal(t, []int{2, 1}, payment.ApprovedBy)
+
+			var event notification.TransactionEvent
+			select {
+			case event = <-events:
+			case <-time.After(10 * time.Second):
+				t.Fatal("timeout while waiting for transaction event")
+			}
+			assert.Equal(t, tc.expectedStatus, <-finished)
+			if tc.recordErr != nil {
+				assert.Nil(t, event.Transaction)
+				return
+			}
+			assert.Equal(t, "tx-id", event.Transaction.ID)
+			assert.Equal(t, 2, recorded.InitiatorID)
+			assert.Equal(t, "bob@example.com", recorded.InitiatorEmail)
+		})
+	}
+}
+
+func TestApprovePayment(t *testing.T) {
+	testLogger := zerolog.Nop()
+	cases := []struct {
+		name           string
+		userID         int
+		payment        func() *teams.PendingPayment
+		approvedBy     []int
+		expectSending  bool
+		expectedStatus string
+		expectedError  error
+	}{
+		{
+			name:   "Approval before the last one",
+			userID: 1,
+			payment: func() *teams.PendingPayment {
+				payment := pendingPayment()
+				payment.RequiredApprovals = 3
+				return payment
+			},
+			approvedBy:     []int{2, 1},
+			expectedStatus: teams.PaymentStatusPending,
+		},
+		{
+			name:   "Concurrent approval is counted",
+			userID: 1,
+			payment: func() *teams.PendingPayment {
+				payment := pendingPayment()
+				payment.RequiredApprovals = 3
+				return payment
+			},
+			approvedBy:    []int{2, 4, 1},
+			expectSending: true,
+			expectedError: spverrors.ErrPaymentNotPending,
+		},
+		{
+			name:          "Initiator approves again",
+			userID:        2,
+			payment:       pendingPayment,
+			expectedError: spverrors.ErrPaymentAlreadyApproved,
+		},
+		{
+			name:   "Expired payment",
+			userID: 1,
+			payment: func() *teams.PendingPayment {
+				payment := pendingPayment()
+				payment.ExpiresAt = time.Now().Add(-time.Minute)
+				return payment
+			},
+			expectedError: spverrors.ErrPaymentNotPending,
+		},
+		{
+			name:   "Payment of other wallet",
+			userID: 1,
+			payment: func() *teams.PendingPayment {
+				payment := pendingPayment()
+				payment.WalletID = 8
+				return payment
+			},
+			expectedError: spverrors.ErrPaymentNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repoMq := mock.NewMockWalletsRepository(ctrl)
+			repoMq.EXPECT().GetMember(gomock.Any(), 3, tc.userID).Return(&teams.Member{
+				WalletID: 3,
+				UserID:   tc.userID,
+				Role:     teams.MemberRoleOwner,
+				Xpriv:    encryptWithPassword(t, ownerPassword, walletXpriv),
+			}, nil)
+			repoMq.EXPECT().GetPendingPayment(gomock.Any(), 9).Return(tc.payment(), nil)
+			if tc.approvedBy != nil {
+				repoMq.EXPECT().InsertPaymentApproval(gomock.Any(), 9, tc.userID, gomock.Any()).Return(tc.approvedBy, nil)
+			}
+			if tc.expectSending {
+				// Concurrent approval which was counted first sends the payment.
+				repoMq.EXPECT().UpdatePaymentStatus(gomock.Any(), 9, teams.PaymentStatusPending, teams.PaymentStatusSending).Return(false, nil)
+			}
+
+			sut := teams.NewTeamsService(repoMq, nil, membersService(ctrl, &testLogger, tc.userID), nil, nil, &testLogger)
+
+			// Act
+			payment, err := sut.ApprovePayment(context.Background(), 3, 9, tc.userID, ownerPassword, nil)
+
+			// Assert
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, payment)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedStatus, payment.Status)
+			}
+		})
+	}
+}
+
+func setApprovalPolicy(t *testing.T, threshold uint64, requiredApprovals int) {
+	viper.Set(config.EnvTeamsApprovalThreshold, threshold)
+	viper.Set(config.EnvTeamsRequiredApprovals, requiredApprovals)
+	viper.Set(config.EnvTeamsApprovalTTL, time.Hour)
+	t.Cleanup(func() {
+		viper.Set(config.EnvTeamsApprovalThreshold, nil)
+		viper.Set(config.EnvTeamsRequiredApprovals, nil)
+		viper.Set(config.EnvTeamsApprovalTTL, nil)
+	})
+}
+
+func spender(t *testing.T) *teams.Member {
+	return &teams.Member{
+		WalletID: 3,
+		UserID:   2,
+		Email:    "bob@example.com",
+		Role:     teams.MemberRoleSpender,
+		Xpriv:    encryptWithPassword(t, memberPassword, walletXpriv),
+	}
+}
+
+func teamMembers() []*teams.Member {
+	return []*teams.Member{
+		{WalletID: 3, UserID: 1, Role: teams.MemberRoleOwner},
+		{WalletID: 3, UserID: 2, Role: teams.MemberRoleSpender},
+		{WalletID: 3, UserID: 4, Role: teams.MemberRoleViewer},
+	}
+}
+
+func pendingPayment() *teams.PendingPayment {
+	return &teams.PendingPayment{
+		ID:                9,
+		WalletID:          3,
+		InitiatorID:       2,
+		InitiatorEmail:    "bob@example.com",
+		Recipient:         "carol@example.com",
+		Satoshis:          500,
+		Status:            teams.PaymentStatusPending,
+		RequiredApprovals: 2,
+		ApprovedBy:        []int{2},
+		ExpiresAt:         time.Now().Add(time.Hour),
+	}
+}
@@ -0,0 +1,457 @@
+package teams_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/spv-wallet-go-client/commands"
+	"github.com/bsv-blockchain/spv-wallet/models"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/audit"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/teams"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/transactions"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/encryption"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/notification"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+	mock "github.com/bsv-blockchain/spv-wallet-web-backend/tests/mocks"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/spvwallet"
+)
+
+const (
+	ownerPassword  = "ownerP4$$word"
+	memberPassword = "memberP4$$word"
+	walletXpriv    = "team-wallet-xpriv"
+	ssoSpendingPIN = "482915"
+	ssoIssuer      = "https://idp.example.com"
+	ssoSubject     = "bob-subject"
+)
+
+func TestCreateWallet_PaymailNotRegistered_CompensatesWallet(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockWalletsRepository(ctrl)
+	usersRepoMq := mock.NewMockRepository(ctrl)
+	adminClientMq := mock.NewMockAdminWalletClient(ctrl)
+
+	owner := &users.User{ID: 1, Email: "alice@example.com", Role: users.RoleOwner, Xpriv: encryptWithPassword(t, ownerPassword, "alice-xpriv")}
+	usersRepoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(owner, nil).Times(2)
+	usersRepoMq.EXPECT().GetUserPaymail(gomock.Any(), "team@example.com").Return(nil, nil)
+	adminClientMq.EXPECT().IsPaymailAvailable("team", "example.com").Return(true, nil)
+
+	adminClientMq.EXPECT().RegisterXpub(gomock.Any()).Return("team-xpub", nil)
+	adminClientMq.EXPECT().
+		RegisterPaymail("team", "example.com", gomock.Any(), gomock.Any()).
+		Return("", errors.New("spv-wallet unavailable"))
+	adminClientMq.EXPECT().GetXpubPaymails(gomock.Any()).Return([]*users.WalletPaymail{{Address: "team@example.com"}}, nil)
+	adminClientMq.EXPECT().DeletePaymail("team@example.com").Return(nil)
+	repoMq.EXPECT().InsertWallet(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	usersService := users.NewUserService(usersRepoMq, adminClientMq, nil, nil, recorderMq(ctrl), &testLogger)
+	sut := teams.NewTeamsService(repoMq, usersRepoMq, usersService, nil, adminClientMq, &testLogger)
+
+	// Act
+	created, err := sut.CreateWallet(context.Background(), 1, ownerPassword, "Team", "team", "example.com")
+
+	// Assert
+	require.ErrorIs(t, err, spverrors.ErrRegisterPaymail)
+	assert.Nil(t, created)
+}
+
+func TestInviteMember_InvitationAcceptedWithMemberPassword(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	viper.Set(config.EnvTeamsInvitationTTL, time.Hour)
+	t.Cleanup(func() { viper.Set(config.EnvTeamsInvitationTTL, nil) })
+
+	repoMq := mock.NewMockWalletsRepository(ctrl)
+	usersRepoMq := mock.NewMockRepository(ctrl)
+
+	owner := &teams.Member{WalletID: 3, UserID: 1, Role: teams.MemberRoleOwner, Xpriv: encryptWithPassword(t, ownerPassword, walletXpriv)}
+	invited := &users.User{ID: 2, Email: "bob@example.com", Role: users.RoleOwner, Xpriv: encryptWithPassword(t, memberPassword, "bob-xpriv")}
+
+	repoMq.EXPECT().GetMember(gomock.Any(), 3, 1).Return(owner, nil)
+	repoMq.EXPECT().GetMember(gomock.Any(), 3, 2).Return(nil, nil).Times(2)
+	usersRepoMq.EXPECT().GetUserByEmail(gomock.Any(), "bob@example.com").Return(invited, nil)
+	usersRepoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(&users.User{ID: 1, Email: "alice@example.com", Role: users.RoleOwner}, nil)
+	usersRepoMq.EXPECT().GetUserByID(gomock.Any(), 2).Return(invited, nil).Times(3)
+
+	var stored *teams.Invitation
+	repoMq.EXPECT().
+		InsertInvitation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, invitation *teams.Invitation) error {
+			stored = invitation
+			stored.ID = 5
+			return nil
+		})
+	repoMq.EXPECT().
+		GetInvitation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, tokenHash string) (*teams.Invitation, error) {
+			assert.Equal(t, stored.TokenHash, tokenHash)
+			return stored, nil
+		})
+
+	var member *teams.Member
+	repoMq.EXPECT().
+		AcceptInvitation(gomock.Any(), gomock.Any(), 5).
+		DoAndReturn(func(_ context.Context, accepted *teams.Member, _ int) error {
+			member = accepted
+			return nil
+		})
+	repoMq.EXPECT().GetWallet(gomock.Any(), 3).Return(&teams.Wallet{ID: 3, Name: "Team"}, nil)
+
+	usersService := users.NewUserService(usersRepoMq, nil, nil, nil, recorderMq(ctrl), &testLogger)
+	sut := teams.NewTeamsService(repoMq, usersRepoMq, usersService, nil, nil, &testLogger)
+
+	// Act
+	invitation, err := sut.InviteMember(context.Background(), 3, 1, ownerPassword, " bob@example.com ", teams.MemberRoleSpender)
+	require.NoError(t, err)
+	wallet, err := sut.AcceptInvitation(context.Background(), 2, invitation.Token, memberPassword)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEqual(t, invitation.Token, stored.TokenHash)
+	assert.Equal(t, teams.MemberRoleSpender, wallet.Role)
+	assert.Equal(t, 3, member.WalletID)
+	assert.Equal(t, 2, member.UserID)
+	assert.Equal(t, teams.MemberRoleSpender, member.Role)
+	assert.Equal(t, walletXpriv, decryptWithPassword(t, memberPassword, member.Xpriv))
+}
+
+func TestAcceptInvitation_OtherUser_ReturnsError(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockWalletsRepository(ctrl)
+	usersRepoMq := mock.NewMockRepository(ctrl)
+
+	repoMq.EXPECT().GetInvitation(gomock.Any(), gomock.Any()).Return(&teams.Invitation{
+		ID:        5,
+		WalletID:  3,
+		Email:     "bob@example.com",
+		Role:      teams.MemberRoleSpender,
+		Xpriv:     encryptWithPassword(t, "token", walletXpriv),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, nil)
+	usersRepoMq.EXPECT().GetUserByID(gomock.Any(), 4).Return(&users.User{ID: 4, Email: "eve@example.com"}, nil)
+
+	usersService := users.NewUserService(usersRepoMq, nil, nil, nil, nil, &testLogger)
+	sut := teams.NewTeamsService(repoMq, usersRepoMq, usersService, nil, nil, &testLogger)
+
+	// Act
+	wallet, err := sut.AcceptInvitation(context.Background(), 4, "token", memberPassword)
+
+	// Assert
+	require.ErrorIs(t, err, spverrors.ErrInvalidInvitation)
+	assert.Nil(t, wallet)
+}
+
+func TestAcceptInvitation_SSOUser_EncryptsKeyWithSpendingPINKey(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	setSpendingPINLimits(t)
+
+	repoMq := mock.NewMockWalletsRepository(ctrl)
+	usersRepoMq := mock.NewMockRepository(ctrl)
+
+	user := &users.User{
+		ID:         2,
+		Email:      "bob@example.com",
+		Role:       users.RoleOwner,
+		SSOIssuer:  ssoIssuer,
+		SSOSubject: ssoSubject,
+		Xpriv:      encryptWithPassword(t, ssoPINKey(ssoSpendingPIN), "bob-xpriv"),
+	}
+	repoMq.EXPECT().GetInvitation(gomock.Any(), gomock.Any()).Return(&teams.Invitation{
+		ID:        5,
+		WalletID:  3,
+		Email:     "bob@example.com",
+		Role:      teams.MemberRoleSpender,
+		Xpriv:     encryptWithPassword(t, "token", walletXpriv),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, nil)
+	repoMq.EXPECT().GetMember(gomock.Any(), 3, 2).Return(nil, nil)
+	usersRepoMq.EXPECT().GetUserByID(gomock.Any(), 2).Return(user, nil).Times(3)
+	usersRepoMq.EXPECT().ReserveSpendingPINAttempt(gomock.Any(), 2, gomock.Any()).Return(1, true, nil)
+	usersRepoMq.EXPECT().ResetSpendingPINAttempts(gomock.Any(), 2).Return(nil)
+
+	var member *teams.Member
+	repoMq.EXPECT().
+		AcceptInvitation(gomock.Any(), gomock.Any(), 5).
+		DoAndReturn(func(_ context.Context, accepted *teams.Member, _ int) error {
+			member = accepted
+			return nil
+		})
+	repoMq.EXPECT().GetWallet(gomock.Any(), 3).Return(&teams.Wallet{ID: 3, Name: "Team"}, nil)
+
+	usersService := users.NewUserService(usersRepoMq, nil, nil, nil, recorderMq(ctrl), &testLogger)
+	sut := teams.NewTeamsService(repoMq, usersRepoMq, usersService, nil, nil, &testLogger)
+
+	// Act
+	_, err := sut.AcceptInvitation(context.Background(), 2, "token", ssoSpendingPIN)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, decryptWithPassword(t, ssoSpendingPIN, member.Xpriv))
+	assert.Equal(t, walletXpriv, decryptWithPassword(t, ssoPINKey(ssoSpendingPIN), member.Xpriv))
+}
+
+func TestCreateTransaction_SSOMemberInvalidPIN_LocksPIN(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	setSpendingPINLimits(t)
+
+	repoMq := mock.NewMockWalletsRepository(ctrl)
+	usersRepoMq := mock.NewMockRepository(ctrl)
+	recorderMq := mock.NewMockRecorder(ctrl)
+
+	repoMq.EXPECT().GetMember(gomock.Any(), 3, 2).Return(&teams.Member{
+		WalletID: 3,
+		UserID:   2,
+		Role:     teams.MemberRoleSpender,
+		Xpriv:    encryptWithPassword(t, ssoPINKey(ssoSpendingPIN), walletXpriv),
+	}, nil)
+	usersRepoMq.EXPECT().GetUserByID(gomock.Any(), 2).Return(&users.User{
+		ID:         2,
+		Email:      "bob@example.com",
+		SSOIssuer:  ssoIssuer,
+		SSOSubject: ssoSubject,
+	}, nil)
+	usersRepoMq.EXPECT().ReserveSpendingPINAttempt(gomock.Any(), 2, gomock.Any()).Return(3, true, nil)
+	usersRepoMq.EXPECT().LockSpendingPIN(gomock.Any(), 2, gomock.Any()).Return(nil)
+	recorderMq.EXPECT().
+		Record(gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, event *audit.Event) {
+			assert.Equal(t, audit.ActionKeyUnlock, event.Action)
+			assert.Equal(t, "bob@example.com", event.Actor)
+			assert.Equal(t, audit.ResultFailure, event.Result)
+		})
+
+	usersService := users.NewUserService(usersRepoMq, nil, nil, nil, recorderMq, &testLogger)
+	sut := teams.NewTeamsService(repoMq, usersRepoMq, usersService, nil, nil, &testLogger)
+
+	// Act
+	payment, err := sut.CreateTransaction(context.Background(), 3, 2, "000000", "alice@example.com", 500, nil)
+
+	// Assert
+	require.ErrorIs(t, err, spverrors.ErrSpendingPINLocked)
+	assert.Nil(t, payment)
+}
+
+func TestRemoveMember(t *testing.T) {
+	testLogger := zerolog.Nop()
+	members := []*teams.Member{
+		{WalletID: 3, UserID: 1, Role: teams.MemberRoleOwner},
+		{WalletID: 3, UserID: 2, Role: teams.MemberRoleSpender},
+		{WalletID: 3, UserID: 4, Role: teams.MemberRoleViewer},
+	}
+
+	cases := []struct {
+		name          string
+		userID        int
+		memberID      int
+		expectDelete  bool
+		expectedError error
+	}{
+		{
+			name:         "Owner removes spender",
+			userID:       1,
+			memberID:     2,
+			expectDelete: true,
+		},
+		{
+			name:         "Viewer leaves",
+			userID:       4,
+			memberID:     4,
+			expectDelete: true,
+		},
+		{
+			name:          "Spender removes viewer",
+			userID:        2,
+			memberID:      4,
+			expectedError: spverrors.ErrForbidden,
+		},
+		{
+			name:          "Last owner leaves",
+			userID:        1,
+			memberID:      1,
+			expectedError: spverrors.ErrLastTeamWalletOwner,
+		},
+		{
+			name:          "Owner removes not a member",
+			userID:        1,
+			memberID:      7,
+			expectedError: spverrors.ErrMemberNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repoMq := mock.NewMockWalletsRepository(ctrl)
+			for _, member := range members {
+				if member.UserID == tc.userID {
+					repoMq.EXPECT().GetMember(gomock.Any(), 3, tc.userID).Return(member, nil)
+				}
+			}
+			repoMq.EXPECT().GetMembers(gomock.Any(), 3).Return(members, nil).AnyTimes()
+			if tc.expectDelete {
+				repoMq.EXPECT().DeleteMember(gomock.Any(), 3, tc.memberID).Return(nil)
+			}
+
+			sut := teams.NewTeamsService(repoMq, nil, nil, nil, nil, &testLogger)
+
+			// Act
+			err := sut.RemoveMember(context.Background(), 3, tc.userID, tc.memberID)
+
+			// Assert
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateTransaction_RecordsInitiator(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockWalletsRepository(ctrl)
+	clientFctrMq := mock.NewMockWalletClientFactory(ctrl)
+	userWalletClientMq := mock.NewMockUserWalletClient(ctrl)
+
+	repoMq.EXPECT().GetMember(gomock.Any(), 3, 2).Return(&teams.Member{
+		WalletID: 3,
+		UserID:   2,
+		Email:    "bob@example.com",
+		Role:     teams.MemberRoleSpender,
+		Xpriv:    encryptWithPassword(t, memberPassword, walletXpriv),
+	}, nil)
+	repoMq.EXPECT().GetWallet(gomock.Any(), 3).Return(&teams.Wallet{ID: 3, Paymail: "team@example.com"}, nil)
+
+	clientFctrMq.EXPECT().CreateWithXpriv(walletXpriv).Return(userWalletClientMq, nil)
+	userWalletClientMq.EXPECT().
+		CreateAndFinalizeTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ []*commands.Recipients, metadata map[string]any) (users.DraftTransaction, error) {
+			assert.Equal(t, "bob@example.com", metadata["initiator"])
+			assert.Equal(t, "team@example.com", metadata["sender"])
+			return &spvwallet.DraftTransaction{}, nil
+		})
+	userWalletClientMq.EXPECT().
+		RecordTransaction(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&models.Transaction{ID: "tx-id"}, nil)
+
+	var recorded *teams.Transaction
+	repoMq.EXPECT().
+		InsertTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, transaction *teams.Transaction) error {
+			recorded = transaction
+			return nil
+		})
+
+	tService := transactions.NewTransactionService(nil, clientFctrMq, recorderMq(ctrl), &testLogger)
+	sut := teams.NewTeamsService(repoMq, nil, membersService(ctrl, &testLogger, 2), tService, nil, &testLogger)
+
+	// Act
+	events := make(chan notification.TransactionEvent, 1)
+	payment, err := sut.CreateTransaction(context.Background(), 3, 2, memberPassword, "alice@example.com", 500, events)
+	require.NoError(t, err)
+	event := <-events
+
+	// Assert
+	assert.Nil(t, payment)
+	assert.Equal(t, "tx-id", event.Transaction.ID)
+	assert.Equal(t, "tx-id", recorded.TransactionID)
+	assert.Equal(t, 2, recorded.InitiatorID)
+	assert.Equal(t, "bob@example.com", recorded.InitiatorEmail)
+	assert.Equal(t, uint64(500), recorded.Satoshis)
+}
+
+func TestCreateTransaction_ViewerMember_ReturnsError(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockWalletsRepository(ctrl)
+	repoMq.EXPECT().GetMember(gomock.Any(), 3, 4).Return(&teams.Member{WalletID: 3, UserID: 4, Role: teams.MemberRoleViewer}, nil)
+
+	sut := teams.NewTeamsService(repoMq, nil, nil, nil, nil, &testLogger)
+
+	// Act
+	payment, err := sut.CreateTransaction(context.Background(), 3, 4, memberPassword, "alice@example.com", 500, nil)
+
+	// Assert
+	require.ErrorIs(t, err, spverrors.ErrForbidden)
+	assert.Nil(t, payment)
+}
+
+func encryptWithPassword(t *testing.T, password, value string) string {
+	hashedPassword, err := encryption.Hash(password)
+	require.NoError(t, err)
+	encrypted, err := encryption.Encrypt(hashedPassword, value)
+	require.NoError(t, err)
+	return encrypted
+}
+
+func decryptWithPassword(t *testing.T, password, value string) string {
+	hashedPassword, err := encryption.Hash(password)
+	require.NoError(t, err)
+	return encryption.Decrypt(hashedPassword, value)
+}
+
+// membersService returns users service of the team wallet members, who unlock the wallet key with their password.
+func membersService(ctrl *gomock.Controller, testLogger *zerolog.Logger, userIDs ...int) *users.UserService {
+	usersRepoMq := mock.NewMockRepository(ctrl)
+	for _, userID := range userIDs {
+		usersRepoMq.EXPECT().GetUserByID(gomock.Any(), userID).Return(&users.User{ID: userID, Role: users.RoleOwner}, nil).AnyTimes()
+	}
+	return users.NewUserService(usersRepoMq, nil, nil, nil, recorderMq(ctrl), testLogger)
+}
+
+// ssoPINKey returns key derived from spending PIN of the member signing in with the identity provider.
+func ssoPINKey(pin string) string {
+	return encryption.PINKey(pin, "sso:"+ssoIssuer+":"+ssoSubject)
+}
+
+func setSpendingPINLimits(t *testing.T) {
+	viper.Set(config.EnvSpendingPINMaxAttempts, 3)
+	viper.Set(config.EnvSpendingPINLockout, 10*time.Minute)
+	t.Cleanup(func() {
+		viper.Set(config.EnvSpendingPINMaxAttempts, nil)
+		viper.Set(config.EnvSpendingPINLockout, nil)
+	})
+}
+
+// recorderMq returns audit recorder mock accepting any events.
+func recorderMq(ctrl *gomock.Controller) *mock.MockRecorder {
+	recorderMq := mock.NewMockRecorder(ctrl)
+	recorderMq.EXPECT().Record(gomock.Any(), gomock.Any()).AnyTimes()
+	return recorderMq
+}
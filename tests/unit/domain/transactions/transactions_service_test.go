@@ -1,15 +1,19 @@
 package transactions_test
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
+	"github.com/bsv-blockchain/spv-wallet/models"
 	"github.com/golang/mock/gomock"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/audit"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/transactions"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/notification"
@@ -22,41 +26,96 @@ import (
 
 func TestCreateTransaction(t *testing.T) {
 	testLogger := zerolog.Nop()
-	t.Run("Create transaction", func(t *testing.T) {
-		// Arrange
-		ctrl := gomock.NewController(t)
-		defer ctrl.Finish()
-
-		paymail := "paymail@example.com"
-		xpriv := gofakeit.HexUint256()
-		recipient := "recipient.paymail@example.com"
-		txValueInSatoshis := uint64(500)
-
-		tr := spvwallet.DraftTransaction{}
-
-		mockUserWalletClient := mock.NewMockUserWalletClient(ctrl)
-		mockUserWalletClient.EXPECT().
-			CreateAndFinalizeTransaction(gomock.Any(), gomock.Any()).
-			Return(&tr, nil)
-
-		mockUserWalletClient.EXPECT().
-			RecordTransaction(gomock.Any(), gomock.Any(), gomock.Any()).
-			AnyTimes()
-
-		clientFctrMq := mock.NewMockWalletClientFactory(ctrl)
-		clientFctrMq.EXPECT().
-			CreateWithXpriv(xpriv).
-			Return(mockUserWalletClient, nil)
-
-		sut := transactions.NewTransactionService(mock.NewMockAdminWalletClient(ctrl), clientFctrMq, &testLogger)
-
-		// Act
-		txs := make(chan notification.TransactionEvent, 1)
-		err := sut.CreateTransaction(paymail, xpriv, recipient, txValueInSatoshis, txs)
-		if err != nil {
-			t.Fatal(err)
-		}
-	})
+	cases := []struct {
+		name           string
+		recordErr      error
+		expectError    bool
+		expectedResult string
+	}{
+		{
+			name:           "Recorded transaction is notified",
+			expectedResult: audit.ResultSuccess,
+		},
+		{
+			name:           "Failed record is notified",
+			recordErr:      errors.New("broadcast failed"),
+			expectError:    true,
+			expectedResult: audit.ResultFailure,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			paymail := "paymail@example.com"
+			xpriv := gofakeit.HexUint256()
+			recipient := "recipient.paymail@example.com"
+			txValueInSatoshis := uint64(500)
+
+			tr := spvwallet.DraftTransaction{TxDraftID: "draft-id", TxHex: "draft-hex"}
+
+			mockUserWalletClient := mock.NewMockUserWalletClient(ctrl)
+			mockUserWalletClient.EXPECT().
+				CreateAndFinalizeTransaction(gomock.Any(), gomock.Any()).
+				Return(&tr, nil)
+
+			if tc.recordErr != nil {
+				mockUserWalletClient.EXPECT().
+					RecordTransaction("draft-hex", "draft-id", gomock.Any()).
+					Return(nil, tc.recordErr).
+					MinTimes(1)
+			} else {
+				mockUserWalletClient.EXPECT().
+					RecordTransaction("draft-hex", "draft-id", gomock.Any()).
+					Return(&models.Transaction{ID: "tx-id"}, nil)
+			}
+
+			clientFctrMq := mock.NewMockWalletClientFactory(ctrl)
+			clientFctrMq.EXPECT().
+				CreateWithXpriv(xpriv).
+				Return(mockUserWalletClient, nil)
+
+			recorded := make(chan *audit.Event, 1)
+			recorderMq := mock.NewMockRecorder(ctrl)
+			recorderMq.EXPECT().
+				Record(gomock.Any(), gomock.Any()).
+				Do(func(_ context.Context, event *audit.Event) { recorded <- event })
+
+			sut := transactions.NewTransactionService(mock.NewMockAdminWalletClient(ctrl), clientFctrMq, recorderMq, &testLogger)
+
+			// Act
+			txs := make(chan notification.TransactionEvent, 1)
+			err := sut.CreateTransaction(context.Background(), paymail, xpriv, recipient, txValueInSatoshis, txs)
+			require.NoError(t, err)
+
+			// Assert
+			select {
+			case event := <-txs:
+				if tc.expectError {
+					require.NotNil(t, event.Error)
+					assert.Nil(t, event.Transaction)
+				} else {
+					assert.Nil(t, event.Error)
+					require.NotNil(t, event.Transaction)
+					assert.Equal(t, "tx-id", event.Transaction.ID)
+				}
+			case <-time.After(10 * time.Second):
+				t.Fatal("transaction event was not sent")
+			}
+
+			event := <-recorded
+			assert.Equal(t, audit.ActionPayment, event.Action)
+			assert.Equal(t, paymail, event.Actor)
+			assert.Equal(t, recipient, event.Target)
+			assert.Equal(t, tc.expectedResult, event.Result)
+			if !tc.expectError {
+				assert.Contains(t, event.Details, "tx-id")
+			}
+		})
+	}
 }
 
 func TestGetTransaction_ReturnsTransactionDetails(t *testing.T) {
@@ -92,7 +151,7 @@ func TestGetTransaction_ReturnsTransactionDetails(t *testing.T) {
 				CreateWithAccessKey(accessKey).
 				Return(mockUserWalletClient, nil)
 
-			sut := transactions.NewTransactionService(mock.NewMockAdminWalletClient(ctrl), clientFctrMq, &testLogger)
+			sut := transactions.NewTransactionService(mock.NewMockAdminWalletClient(ctrl), clientFctrMq, nil, &testLogger)
 
 			// Act
 			result, err := sut.GetTransaction(accessKey, tc.transactionID, paymail)
@@ -141,7 +200,7 @@ func TestGetTransaction_ReturnsError(t *testing.T) {
 				CreateWithAccessKey(accessKey).
 				Return(mockUserWalletClient, nil)
 
-			sut := transactions.NewTransactionService(mock.NewMockAdminWalletClient(ctrl), clientFctrMq, &testLogger)
+			sut := transactions.NewTransactionService(mock.NewMockAdminWalletClient(ctrl), clientFctrMq, nil, &testLogger)
 
 			// Act
 			result, err := sut.GetTransaction(accessKey, tc.transactionID, paymail)
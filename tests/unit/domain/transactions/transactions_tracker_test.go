@@ -0,0 +1,152 @@
+package transactions_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/transactions"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/notification"
+	mock "github.com/bsv-blockchain/spv-wallet-web-backend/tests/mocks"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/spvwallet"
+)
+
+func TestCheckTrackedTransactions_NotifiesAboutStatusChanges(t *testing.T) {
+	testLogger := zerolog.Nop()
+
+	chainInfo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"blocks": 102}`))
+	}))
+	defer chainInfo.Close()
+	viper.Set(config.EnvEndpointsChainInfo, chainInfo.URL)
+	defer viper.Set(config.EnvEndpointsChainInfo, nil)
+	viper.Set(config.EnvTransactionsTrackingConfirmations, uint64(6))
+	defer viper.Set(config.EnvTransactionsTrackingConfirmations, nil)
+
+	cases := []struct {
+		name           string
+		tracked        *transactions.TrackedTransaction
+		current        *spvwallet.FullTransaction
+		expectedStatus string
+		expectDelete   bool
+	}{
+		{
+			name:           "Pending transaction is mined",
+			tracked:        &transactions.TrackedTransaction{TransactionID: "tx", UserID: 1, Status: transactions.TrackingStatusPending},
+			current:        &spvwallet.FullTransaction{ID: "tx", BlockHash: "block", BlockHeight: 100, Status: "MINED"},
+			expectedStatus: transactions.TrackingStatusMined,
+		},
+		{
+			name:           "Mined transaction is reorged",
+			tracked:        &transactions.TrackedTransaction{TransactionID: "tx", UserID: 1, Status: transactions.TrackingStatusMined, BlockHash: "block", BlockHeight: 100},
+			current:        &spvwallet.FullTransaction{ID: "tx", Status: "BROADCASTED"},
+			expectedStatus: transactions.TrackingStatusReorged,
+		},
+		{
+			name:           "Mined transaction is moved to another block",
+			tracked:        &transactions.TrackedTransaction{TransactionID: "tx", UserID: 1, Status: transactions.TrackingStatusMined, BlockHash: "block", BlockHeight: 100},
+			current:        &spvwallet.FullTransaction{ID: "tx", BlockHash: "other-block", BlockHeight: 101, Status: "MINED"},
+			expectedStatus: transactions.TrackingStatusReorged,
+		},
+		{
+			name:           "Pending transaction is rejected",
+			tracked:        &transactions.TrackedTransaction{TransactionID: "tx", UserID: 1, Status: transactions.TrackingStatusPending},
+			current:        &spvwallet.FullTransaction{ID: "tx", Status: "REVERTED"},
+			expectedStatus: transactions.TrackingStatusRejected,
+			expectDelete:   true,
+		},
+		{
+			name:           "Mined transaction reaches confirmation depth",
+			tracked:        &transactions.TrackedTransaction{TransactionID: "tx", UserID: 1, Status: transactions.TrackingStatusMined, BlockHash: "block", BlockHeight: 97},
+			current:        &spvwallet.FullTransaction{ID: "tx", BlockHash: "block", BlockHeight: 97, Status: "MINED"},
+			expectedStatus: transactions.TrackingStatusConfirmed,
+			expectDelete:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repoMq := mock.NewMockTrackingRepository(ctrl)
+			repoMq.EXPECT().
+				GetTrackedTransactions(gomock.Any()).
+				Return([]*transactions.TrackedTransaction{tc.tracked}, nil)
+			if tc.expectDelete {
+				repoMq.EXPECT().
+					DeleteTrackedTransaction(gomock.Any(), tc.tracked.TransactionID, tc.tracked.UserID).
+					Return(nil)
+			} else {
+				repoMq.EXPECT().
+					UpdateTrackedTransaction(gomock.Any(), gomock.Any()).
+					Return(nil)
+			}
+
+			adminWalletClientMq := mock.NewMockAdminWalletClient(ctrl)
+			adminWalletClientMq.EXPECT().
+				GetTransaction(tc.tracked.TransactionID).
+				Return(tc.current, nil)
+
+			sut := transactions.NewTracker(repoMq, adminWalletClientMq, &testLogger)
+
+			events := make([]notification.TransactionStatusChangedEvent, 0)
+			sut.OnStatusChanged(func(userID int, event notification.TransactionStatusChangedEvent) {
+				assert.Equal(t, tc.tracked.UserID, userID)
+				events = append(events, event)
+			})
+
+			// Act
+			sut.CheckTrackedTransactions(context.Background())
+
+			// Assert
+			assert.Len(t, events, 1)
+			assert.Equal(t, notification.TransactionStatusChangedEventType, events[0].EventType)
+			assert.Equal(t, tc.expectedStatus, events[0].Transaction.Status)
+			assert.Equal(t, tc.current.BlockHeight, events[0].Transaction.BlockHeight)
+		})
+	}
+}
+
+func TestCheckTrackedTransactions_SkipsUnchangedTransactions(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	viper.Set(config.EnvEndpointsChainInfo, "http://127.0.0.1:0")
+	defer viper.Set(config.EnvEndpointsChainInfo, nil)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tracked := &transactions.TrackedTransaction{TransactionID: "tx", UserID: 1, Status: transactions.TrackingStatusPending}
+
+	repoMq := mock.NewMockTrackingRepository(ctrl)
+	repoMq.EXPECT().
+		GetTrackedTransactions(gomock.Any()).
+		Return([]*transactions.TrackedTransaction{tracked}, nil)
+
+	adminWalletClientMq := mock.NewMockAdminWalletClient(ctrl)
+	adminWalletClientMq.EXPECT().
+		GetTransaction(tracked.TransactionID).
+		Return(&spvwallet.FullTransaction{ID: "tx", Status: "BROADCASTED"}, nil)
+
+	sut := transactions.NewTracker(repoMq, adminWalletClientMq, &testLogger)
+
+	notified := false
+	sut.OnStatusChanged(func(_ int, _ notification.TransactionStatusChangedEvent) {
+		notified = true
+	})
+
+	// Act
+	sut.CheckTrackedTransactions(context.Background())
+
+	// Assert
+	assert.False(t, notified)
+}
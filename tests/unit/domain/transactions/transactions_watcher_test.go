@@ -0,0 +1,144 @@
+package transactions_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/transactions"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	mock "github.com/bsv-blockchain/spv-wallet-web-backend/tests/mocks"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/spvwallet"
+)
+
+func TestWatchIncomingTransactions_NotifiesAboutNewIncomingTransactions(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	viper.Set(config.EnvTransactionsNotificationsInterval, 10*time.Millisecond)
+	defer viper.Set(config.EnvTransactionsNotificationsInterval, nil)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	paymail := "paymail@example.com"
+	accessKey := "access-key"
+	known := &spvwallet.Transaction{ID: "known", Direction: "incoming"}
+	outgoing := &spvwallet.Transaction{ID: "outgoing", Direction: "outgoing"}
+	incoming := &spvwallet.Transaction{ID: "incoming", Direction: "incoming", TotalValue: 500, Sender: "sender@example.com"}
+
+	mockUserWalletClient := mock.NewMockUserWalletClient(ctrl)
+	gomock.InOrder(
+		mockUserWalletClient.EXPECT().
+			GetTransactions(gomock.Any(), paymail).
+			Return([]users.Transaction{known}, nil),
+		mockUserWalletClient.EXPECT().
+			GetTransactions(gomock.Any(), paymail).
+			Return([]users.Transaction{incoming, outgoing, known}, nil).
+			AnyTimes(),
+	)
+
+	clientFctrMq := mock.NewMockWalletClientFactory(ctrl)
+	clientFctrMq.EXPECT().
+		CreateWithAccessKey(accessKey).
+		Return(mockUserWalletClient, nil)
+
+	sut := transactions.NewTransactionService(mock.NewMockAdminWalletClient(ctrl), clientFctrMq, nil, &testLogger)
+
+	// Act
+	ctx, cancel := context.WithCancel(context.Background())
+	txs := make(chan users.Transaction, 10)
+	done := make(chan struct{})
+	go func() {
+		sut.WatchIncomingTransactions(ctx, accessKey, paymail, func(tx users.Transaction) {
+			txs <- tx
+		})
+		close(done)
+	}()
+
+	var received users.Transaction
+	select {
+	case received = <-txs:
+	case <-time.After(time.Second):
+		t.Fatal("timeout while waiting for incoming transaction")
+	}
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	<-done
+
+	// Assert
+	assert.Equal(t, incoming.ID, received.GetTransactionID())
+	assert.Empty(t, txs)
+}
+
+func TestWatchIncomingTransactions_NotifiesOnlyTransactionsNewerThanSeen(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	viper.Set(config.EnvTransactionsNotificationsInterval, 10*time.Millisecond)
+	defer viper.Set(config.EnvTransactionsNotificationsInterval, nil)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	paymail := "paymail@example.com"
+	accessKey := "access-key"
+	seenAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	seen := &spvwallet.Transaction{ID: "seen", Direction: "incoming", CreatedAt: seenAt}
+	older := &spvwallet.Transaction{ID: "older", Direction: "incoming", CreatedAt: seenAt.Add(-time.Hour)}
+	sameTime := &spvwallet.Transaction{ID: "same-time", Direction: "incoming", CreatedAt: seenAt}
+	newer := &spvwallet.Transaction{ID: "newer", Direction: "incoming", CreatedAt: seenAt.Add(time.Minute)}
+
+	mockUserWalletClient := mock.NewMockUserWalletClient(ctrl)
+	gomock.InOrder(
+		mockUserWalletClient.EXPECT().
+			GetTransactions(gomock.Any(), paymail).
+			Return([]users.Transaction{seen}, nil),
+		mockUserWalletClient.EXPECT().
+			GetTransactions(gomock.Any(), paymail).
+			Return([]users.Transaction{sameTime, seen, older}, nil),
+		mockUserWalletClient.EXPECT().
+			GetTransactions(gomock.Any(), paymail).
+			Return([]users.Transaction{newer, sameTime, seen, older}, nil).
+			AnyTimes(),
+	)
+
+	clientFctrMq := mock.NewMockWalletClientFactory(ctrl)
+	clientFctrMq.EXPECT().
+		CreateWithAccessKey(accessKey).
+		Return(mockUserWalletClient, nil)
+
+	sut := transactions.NewTransactionService(mock.NewMockAdminWalletClient(ctrl), clientFctrMq, nil, &testLogger)
+
+	// Act
+	ctx, cancel := context.WithCancel(context.Background())
+	txs := make(chan users.Transaction, 10)
+	done := make(chan struct{})
+	go func() {
+		sut.WatchIncomingTransactions(ctx, accessKey, paymail, func(tx users.Transaction) {
+			txs <- tx
+		})
+		close(done)
+	}()
+
+	received := make([]string, 0)
+	for len(received) < 2 {
+		select {
+		case tx := <-txs:
+			received = append(received, tx.GetTransactionID())
+		case <-time.After(time.Second):
+			t.Fatal("timeout while waiting for incoming transactions")
+		}
+	}
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	<-done
+
+	// Assert
+	assert.Equal(t, []string{sameTime.ID, newer.ID}, received)
+	assert.Empty(t, txs)
+}
@@ -0,0 +1,212 @@
+package users_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/libsv/go-bk/bip32"
+	"github.com/libsv/go-bk/chaincfg"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+	mock "github.com/bsv-blockchain/spv-wallet-web-backend/tests/mocks"
+)
+
+const profilePassword = "strongP4$$word"
+
+func TestUpdateDisplayName_PropagatesToPaymails(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockRepository(ctrl)
+	adminClientMq := mock.NewMockAdminWalletClient(ctrl)
+
+	repoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(userWithXpriv(t), nil)
+	repoMq.EXPECT().GetUserProfile(gomock.Any(), 1).Return(nil, nil)
+	repoMq.EXPECT().UpsertUserProfile(gomock.Any(), gomock.Any())
+	repoMq.EXPECT().GetUserPaymails(gomock.Any(), 1).Return([]*users.UserPaymail{
+		{UserID: 1, Paymail: "homer@example.com", Primary: true},
+		{UserID: 1, Paymail: "donut@example.com"},
+	}, nil)
+	expectedProfile := &users.PaymailProfile{PublicName: "Homer Simpson"}
+	adminClientMq.EXPECT().UpdatePaymailProfile("homer@example.com", gomock.Any(), expectedProfile)
+	adminClientMq.EXPECT().UpdatePaymailProfile("donut@example.com", gomock.Any(), expectedProfile)
+
+	uService := users.NewUserService(repoMq, adminClientMq, nil, nil, recorderMq(ctrl), &testLogger)
+	sut := users.NewProfileService(repoMq, uService, adminClientMq, nil, &testLogger)
+
+	// Act
+	profile, err := sut.UpdateDisplayName(context.Background(), 1, profilePassword, "  Homer Simpson ")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Homer Simpson", profile.DisplayName)
+}
+
+func TestUpdateDisplayName_PaymailUpdateFails_RestoresPaymailsAndKeepsProfile(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockRepository(ctrl)
+	adminClientMq := mock.NewMockAdminWalletClient(ctrl)
+
+	repoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(userWithXpriv(t), nil)
+	repoMq.EXPECT().GetUserProfile(gomock.Any(), 1).Return(&users.Profile{UserID: 1, DisplayName: "Homer"}, nil)
+	repoMq.EXPECT().UpsertUserProfile(gomock.Any(), gomock.Any()).Times(0)
+	repoMq.EXPECT().GetUserPaymails(gomock.Any(), 1).Return([]*users.UserPaymail{
+		{UserID: 1, Paymail: "homer@example.com", Primary: true},
+		{UserID: 1, Paymail: "donut@example.com"},
+	}, nil)
+	expectedProfile := &users.PaymailProfile{PublicName: "Homer Simpson"}
+	previousProfile := &users.PaymailProfile{PublicName: "Homer"}
+	gomock.InOrder(
+		adminClientMq.EXPECT().UpdatePaymailProfile("homer@example.com", gomock.Any(), expectedProfile),
+		adminClientMq.EXPECT().UpdatePaymailProfile("donut@example.com", gomock.Any(), expectedProfile).Return(errors.New("spv-wallet unavailable")),
+		adminClientMq.EXPECT().UpdatePaymailProfile("homer@example.com", gomock.Any(), previousProfile),
+	)
+
+	uService := users.NewUserService(repoMq, adminClientMq, nil, nil, recorderMq(ctrl), &testLogger)
+	sut := users.NewProfileService(repoMq, uService, adminClientMq, nil, &testLogger)
+
+	// Act
+	profile, err := sut.UpdateDisplayName(context.Background(), 1, profilePassword, "Homer Simpson")
+
+	// Assert
+	require.ErrorIs(t, err, spverrors.ErrUpdatePaymailProfile)
+	assert.Nil(t, profile)
+}
+
+func TestUpdateDisplayName_InvalidName_ReturnsError(t *testing.T) {
+	testLogger := zerolog.Nop()
+	cases := []struct {
+		name        string
+		displayName string
+	}{
+		{
+			name:        "Empty name",
+			displayName: "   ",
+		},
+		{
+			name:        "Control characters",
+			displayName: "Homer\nSimpson",
+		},
+		{
+			name:        "Too long name",
+			displayName: string(make([]rune, 101)),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sut := users.NewProfileService(mock.NewMockRepository(ctrl), nil, mock.NewMockAdminWalletClient(ctrl), nil, &testLogger)
+
+			// Act
+			profile, err := sut.UpdateDisplayName(context.Background(), 1, profilePassword, tc.displayName)
+
+			// Assert
+			require.EqualError(t, err, spverrors.ErrInvalidDisplayName.Error())
+			assert.Nil(t, profile)
+		})
+	}
+}
+
+func TestUpdateAvatar_ReplacesPreviousAvatar(t *testing.T) {
+	// Arrange
+	viper.Set(config.EnvAvatarsMaxSize, 1024)
+	defer viper.Set(config.EnvAvatarsMaxSize, nil)
+	viper.Set(config.EnvAvatarsURL, "https://example.com/api/v1/avatars/")
+	defer viper.Set(config.EnvAvatarsURL, nil)
+
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockRepository(ctrl)
+	adminClientMq := mock.NewMockAdminWalletClient(ctrl)
+	storageMq := mock.NewMockAvatarStorage(ctrl)
+
+	repoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(userWithXpriv(t), nil)
+	repoMq.EXPECT().GetUserProfile(gomock.Any(), 1).Return(&users.Profile{UserID: 1, Avatar: "1-old.png"}, nil)
+	repoMq.EXPECT().UpsertUserProfile(gomock.Any(), gomock.Any())
+	repoMq.EXPECT().GetUserPaymails(gomock.Any(), 1).Return([]*users.UserPaymail{}, nil)
+	storageMq.EXPECT().Save(gomock.Any(), gomock.Any())
+	storageMq.EXPECT().Delete("1-old.png")
+
+	uService := users.NewUserService(repoMq, adminClientMq, nil, nil, recorderMq(ctrl), &testLogger)
+	sut := users.NewProfileService(repoMq, uService, adminClientMq, storageMq, &testLogger)
+
+	// Act
+	profile, err := sut.UpdateAvatar(context.Background(), 1, profilePassword, []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Regexp(t, `^1-[0-9a-f]{16}\.png$`, profile.Avatar)
+	assert.Equal(t, "https://example.com/api/v1/avatars/"+profile.Avatar, profile.AvatarURL)
+}
+
+func TestUpdateAvatar_InvalidAvatar_ReturnsError(t *testing.T) {
+	viper.Set(config.EnvAvatarsMaxSize, 16)
+	defer viper.Set(config.EnvAvatarsMaxSize, nil)
+
+	testLogger := zerolog.Nop()
+	cases := []struct {
+		name   string
+		avatar []byte
+	}{
+		{
+			name:   "Not an image",
+			avatar: []byte("<svg onload=alert(1)>"),
+		},
+		{
+			name:   "Too large image",
+			avatar: append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 16)...),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sut := users.NewProfileService(mock.NewMockRepository(ctrl), nil, mock.NewMockAdminWalletClient(ctrl), mock.NewMockAvatarStorage(ctrl), &testLogger)
+
+			// Act
+			profile, err := sut.UpdateAvatar(context.Background(), 1, profilePassword, tc.avatar)
+
+			// Assert
+			require.EqualError(t, err, spverrors.ErrInvalidAvatar.Error())
+			assert.Nil(t, profile)
+		})
+	}
+}
+
+// userWithXpriv returns user with xpriv encrypted by profilePassword.
+func userWithXpriv(t *testing.T) *users.User {
+	xpriv, err := bip32.NewMaster(make([]byte, 32), &chaincfg.MainNet)
+	require.NoError(t, err)
+
+	return &users.User{ID: 1, Xpriv: encryptWithPassword(t, profilePassword, xpriv.String())}
+}
+
+// recorderMq returns audit recorder mock accepting any events.
+func recorderMq(ctrl *gomock.Controller) *mock.MockRecorder {
+	recorderMq := mock.NewMockRecorder(ctrl)
+	recorderMq.EXPECT().Record(gomock.Any(), gomock.Any()).AnyTimes()
+	return recorderMq
+}
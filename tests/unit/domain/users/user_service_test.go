@@ -5,26 +5,37 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
 	mock "github.com/bsv-blockchain/spv-wallet-web-backend/tests/mocks"
 )
 
 func TestCreateNewUser_ReturnsUser(t *testing.T) {
+	viper.Set(config.EnvPaymailDomain, "example.com")
+	defer viper.Set(config.EnvPaymailDomain, nil)
+
 	testLogger := zerolog.Nop()
 	cases := []struct {
-		name         string
-		userEmail    string
-		userPswd     string
-		expectedUser *users.CreatedUser
+		name           string
+		userEmail      string
+		userPswd       string
+		alias          string
+		domain         string
+		expectedAlias  string
+		expectedDomain string
+		expectedUser   *users.CreatedUser
 	}{
 		{
-			name:      "Insert valid user",
-			userEmail: "homer.simpson@example.com",
-			userPswd:  "strongP4$$word",
+			name:           "Insert valid user",
+			userEmail:      "homer.simpson@example.com",
+			userPswd:       "strongP4$$word",
+			expectedAlias:  "homer.simpson",
+			expectedDomain: "example.com",
 			expectedUser: &users.CreatedUser{
 				User: &users.User{
 					Email:   "homer.simpson@example.com",
@@ -32,6 +43,21 @@ func TestCreateNewUser_ReturnsUser(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:           "Insert valid user with chosen alias and domain",
+			userEmail:      "homer.simpson@example.com",
+			userPswd:       "strongP4$$word",
+			alias:          " Homer ",
+			domain:         "Homer.Simpson.Space",
+			expectedAlias:  "homer",
+			expectedDomain: "homer.simpson.space",
+			expectedUser: &users.CreatedUser{
+				User: &users.User{
+					Email:   "homer.simpson@example.com",
+					Paymail: "homer@homer.simpson.space",
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -47,19 +73,31 @@ func TestCreateNewUser_ReturnsUser(t *testing.T) {
 				GetUserByEmail(gomock.Any(), tc.userEmail).
 				Return(nil, nil)
 
-			repoMq.EXPECT().InsertUser(gomock.Any(), gomock.Any())
+			repoMq.EXPECT().
+				GetUserPaymail(gomock.Any(), gomock.Any()).
+				Return(nil, nil)
+
+			repoMq.EXPECT().
+				GetPendingRegistration(gomock.Any(), tc.userEmail).
+				Return(nil, nil)
+			repoMq.EXPECT().InsertPendingRegistration(gomock.Any(), gomock.Any())
+			repoMq.EXPECT().UpdatePendingRegistration(gomock.Any(), gomock.Any()).Times(2)
+			repoMq.EXPECT().CompleteRegistration(gomock.Any(), gomock.Any(), gomock.Any())
 
+			mockAdminWalletClient.EXPECT().
+				IsPaymailAvailable(tc.expectedAlias, tc.expectedDomain).
+				Return(true, nil)
 			mockAdminWalletClient.EXPECT().
 				RegisterXpub(gomock.Any()).
 				Return(gomock.Any().String(), nil)
 			mockAdminWalletClient.EXPECT().
-				RegisterPaymail(gomock.Any(), gomock.Any()).
+				RegisterPaymail(tc.expectedAlias, tc.expectedDomain, gomock.Any(), nil).
 				Return(tc.expectedUser.User.Paymail, nil)
 
-			sut := users.NewUserService(repoMq, mockAdminWalletClient, nil, nil, &testLogger)
+			sut := users.NewUserService(repoMq, mockAdminWalletClient, nil, nil, nil, &testLogger)
 
 			// Act
-			result, err := sut.CreateNewUser(tc.userEmail, tc.userPswd)
+			result, err := sut.CreateNewUser(tc.userEmail, tc.userPswd, tc.alias, tc.domain)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -112,10 +150,10 @@ func TestCreateNewUser_InvalidData_ReturnsError(t *testing.T) {
 				Return(&users.User{}, nil).
 				AnyTimes()
 
-			sut := users.NewUserService(repoMq, mockAdminWalletClient, nil, nil, &testLogger)
+			sut := users.NewUserService(repoMq, mockAdminWalletClient, nil, nil, nil, &testLogger)
 
 			// Act
-			result, err := sut.CreateNewUser(tc.userEmail, tc.userPswd)
+			result, err := sut.CreateNewUser(tc.userEmail, tc.userPswd, "", "")
 
 			// Assert
 			require.EqualError(t, err, tc.expectedErr.Error())
@@ -124,6 +162,124 @@ func TestCreateNewUser_InvalidData_ReturnsError(t *testing.T) {
 	}
 }
 
+func TestCreateNewUser_UnavailableAlias_ReturnsError(t *testing.T) {
+	testLogger := zerolog.Nop()
+	cases := []struct {
+		name          string
+		alias         string
+		takenLocally  bool
+		takenInWallet bool
+		expectedErr   error
+	}{
+		{
+			name:        "Invalid alias",
+			alias:       "homer@simpson",
+			expectedErr: spverrors.ErrInvalidPaymailAlias,
+		},
+		{
+			name:         "Alias taken by other user",
+			alias:        "homer",
+			takenLocally: true,
+			expectedErr:  spverrors.ErrPaymailAliasTaken,
+		},
+		{
+			name:          "Alias taken in SPV Wallet",
+			alias:         "homer",
+			takenInWallet: true,
+			expectedErr:   spverrors.ErrPaymailAliasTaken,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repoMq := mock.NewMockRepository(ctrl)
+			mockAdminWalletClient := mock.NewMockAdminWalletClient(ctrl)
+
+			repoMq.EXPECT().
+				GetUserByEmail(gomock.Any(), gomock.Any()).
+				Return(nil, nil)
+			repoMq.EXPECT().
+				GetPendingRegistration(gomock.Any(), gomock.Any()).
+				Return(nil, nil)
+
+			var existing *users.UserPaymail
+			if tc.takenLocally {
+				existing = &users.UserPaymail{UserID: 2, Paymail: tc.alias + "@example.com"}
+			}
+			repoMq.EXPECT().
+				GetUserPaymail(gomock.Any(), gomock.Any()).
+				Return(existing, nil).
+				AnyTimes()
+			mockAdminWalletClient.EXPECT().
+				IsPaymailAvailable(tc.alias, "example.com").
+				Return(!tc.takenInWallet, nil).
+				AnyTimes()
+
+			sut := users.NewUserService(repoMq, mockAdminWalletClient, nil, nil, nil, &testLogger)
+
+			// Act
+			result, err := sut.CreateNewUser("homer.simpson@example.com", "strongP4$$word", tc.alias, "example.com")
+
+			// Assert
+			require.EqualError(t, err, tc.expectedErr.Error())
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func TestSetPrimaryPaymail(t *testing.T) {
+	testLogger := zerolog.Nop()
+	cases := []struct {
+		name        string
+		owner       int
+		expectedErr error
+	}{
+		{
+			name:  "Paymail owned by the user",
+			owner: 1,
+		},
+		{
+			name:        "Paymail owned by other user",
+			owner:       2,
+			expectedErr: spverrors.ErrPaymailNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repoMq := mock.NewMockRepository(ctrl)
+			repoMq.EXPECT().
+				GetUserPaymail(gomock.Any(), "homer@example.com").
+				Return(&users.UserPaymail{UserID: tc.owner, Paymail: "homer@example.com"}, nil)
+			if tc.expectedErr == nil {
+				repoMq.EXPECT().SetPrimaryPaymail(gomock.Any(), 1, "homer@example.com")
+			}
+
+			sut := users.NewUserService(repoMq, nil, nil, nil, nil, &testLogger)
+
+			// Act
+			result, err := sut.SetPrimaryPaymail(1, " Homer@example.com")
+
+			// Assert
+			if tc.expectedErr != nil {
+				require.EqualError(t, err, tc.expectedErr.Error())
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, result.Primary)
+		})
+	}
+}
+
 func assertNewUser(t *testing.T, expectedUser, newUser *users.CreatedUser) {
 	assert.Equal(t, expectedUser.User.Email, newUser.User.Email)
 	assert.Equal(t, expectedUser.User.Paymail, newUser.User.Paymail)
@@ -0,0 +1,131 @@
+package users_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+	mock "github.com/bsv-blockchain/spv-wallet-web-backend/tests/mocks"
+)
+
+const sweepAddress = "marge@example.com"
+
+func TestDeleteAccount_SweepsFundsAndErasesUser(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockRepository(ctrl)
+	adminClientMq := mock.NewMockAdminWalletClient(ctrl)
+	factoryMq := mock.NewMockWalletClientFactory(ctrl)
+	userClientMq := mock.NewMockUserWalletClient(ctrl)
+	xpubMq := mock.NewMockPubKey(ctrl)
+	transactionMq := mock.NewMockTransaction(ctrl)
+
+	user := userWithXpriv(t)
+	user.Paymail = "homer@example.com"
+	repoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(user, nil).Times(2)
+	factoryMq.EXPECT().CreateWithXpriv(gomock.Any()).Return(userClientMq, nil)
+	userClientMq.EXPECT().GetXPub().Return(xpubMq, nil)
+	xpubMq.EXPECT().GetCurrentBalance().Return(uint64(1000))
+	userClientMq.EXPECT().SendAll(sweepAddress, "homer@example.com").Return(transactionMq, nil)
+	transactionMq.EXPECT().GetTransactionID().Return("tx-id")
+	repoMq.EXPECT().GetUserPaymails(gomock.Any(), 1).Return([]*users.UserPaymail{
+		{UserID: 1, Paymail: "homer@example.com", Primary: true},
+		{UserID: 1, Paymail: "donut@example.com"},
+	}, nil)
+	adminClientMq.EXPECT().DeletePaymail("homer@example.com")
+	adminClientMq.EXPECT().DeletePaymail("donut@example.com")
+	userClientMq.EXPECT().RevokeAllAccessKeys()
+	repoMq.EXPECT().DeleteUser(gomock.Any(), 1)
+
+	sut := users.NewUserService(repoMq, adminClientMq, factoryMq, nil, recorderMq(ctrl), &testLogger)
+	var notified []int
+	sut.SubscribeAccountDeletion(func(userID int) {
+		notified = append(notified, userID)
+	})
+
+	// Act
+	err := sut.DeleteAccount(context.Background(), 1, profilePassword, sweepAddress)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, notified)
+}
+
+func TestDeleteAccount_FundsNotSwept_KeepsAccount(t *testing.T) {
+	testLogger := zerolog.Nop()
+	cases := []struct {
+		name        string
+		sweepTo     string
+		arrange     func(userClientMq *mock.MockUserWalletClient)
+		expectedErr error
+	}{
+		{
+			name:        "No sweep address",
+			sweepTo:     "",
+			arrange:     func(_ *mock.MockUserWalletClient) {},
+			expectedErr: spverrors.ErrSweepAddressRequired,
+		},
+		{
+			name:    "Sending funds fails",
+			sweepTo: sweepAddress,
+			arrange: func(userClientMq *mock.MockUserWalletClient) {
+				userClientMq.EXPECT().SendAll(sweepAddress, gomock.Any()).Return(nil, errWalletUnavailable)
+			},
+			expectedErr: spverrors.ErrSweepFunds,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repoMq := mock.NewMockRepository(ctrl)
+			factoryMq := mock.NewMockWalletClientFactory(ctrl)
+			userClientMq := mock.NewMockUserWalletClient(ctrl)
+			xpubMq := mock.NewMockPubKey(ctrl)
+
+			repoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(userWithXpriv(t), nil).Times(2)
+			factoryMq.EXPECT().CreateWithXpriv(gomock.Any()).Return(userClientMq, nil)
+			userClientMq.EXPECT().GetXPub().Return(xpubMq, nil)
+			xpubMq.EXPECT().GetCurrentBalance().Return(uint64(1000))
+			tc.arrange(userClientMq)
+
+			sut := users.NewUserService(repoMq, nil, factoryMq, nil, recorderMq(ctrl), &testLogger)
+
+			// Act
+			err := sut.DeleteAccount(context.Background(), 1, profilePassword, tc.sweepTo)
+
+			// Assert
+			require.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestDeleteAccount_InvalidPassword_ReturnsError(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockRepository(ctrl)
+	repoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(userWithXpriv(t), nil)
+
+	sut := users.NewUserService(repoMq, nil, nil, nil, recorderMq(ctrl), &testLogger)
+
+	// Act
+	err := sut.DeleteAccount(context.Background(), 1, "wrong password", sweepAddress)
+
+	// Assert
+	require.ErrorIs(t, err, spverrors.ErrInvalidCredentials)
+}
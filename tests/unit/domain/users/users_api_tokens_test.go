@@ -0,0 +1,305 @@
+package users_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/encryption"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+	mock "github.com/bsv-blockchain/spv-wallet-web-backend/tests/mocks"
+)
+
+func TestCreateAPIToken_TokenAuthorizesWithKeys(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	setAPITokensTTL(t)
+
+	user := userWithXpriv(t)
+	user.Paymail = "homer@example.com"
+	user.Role = users.RoleOwner
+
+	repoMq := mock.NewMockRepository(ctrl)
+	userClientMq := mock.NewMockUserWalletClient(ctrl)
+	factoryMq := mock.NewMockWalletClientFactory(ctrl)
+	accessKeyMq := mock.NewMockAccKey(ctrl)
+
+	repoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(user, nil).Times(2)
+	factoryMq.EXPECT().CreateWithXpriv(gomock.Any()).Return(userClientMq, nil)
+	userClientMq.EXPECT().CreateAccessKey().Return(accessKeyMq, nil)
+	accessKeyMq.EXPECT().GetAccessKey().Return("token-access-key").AnyTimes()
+	accessKeyMq.EXPECT().GetAccessKeyID().Return("token-access-key-id").AnyTimes()
+
+	var stored *users.APIToken
+	repoMq.EXPECT().
+		InsertAPIToken(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, token *users.APIToken) error {
+			stored = token
+			stored.ID = 7
+			return nil
+		})
+	repoMq.EXPECT().
+		GetAPIToken(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, tokenHash string) (*users.APIToken, error) {
+			assert.Equal(t, stored.TokenHash, tokenHash)
+			return stored, nil
+		})
+	repoMq.EXPECT().UpdateAPITokenLastUsed(gomock.Any(), 7, gomock.Any()).Return(nil)
+
+	sut := users.NewUserService(repoMq, nil, factoryMq, nil, recorderMq(ctrl), &testLogger)
+
+	// Act
+	scopes := []string{users.APITokenScopeTransactionsSend, users.APITokenScopeBalanceRead, users.APITokenScopeBalanceRead}
+	created, err := sut.CreateAPIToken(context.Background(), 1, profilePassword, " deploy script ", scopes, 1000, time.Time{})
+	require.NoError(t, err)
+	authorized, err := sut.AuthorizeAPIToken(context.Background(), created.Token)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Token, "spvw_"))
+	assert.NotContains(t, stored.TokenHash, created.Token)
+	assert.Equal(t, "deploy script", stored.Name)
+	assert.Equal(t, []string{users.APITokenScopeBalanceRead, users.APITokenScopeTransactionsSend}, stored.Scopes)
+	assert.Equal(t, uint64(1000), stored.SendLimit)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), stored.ExpiresAt, time.Minute)
+	assert.NotEmpty(t, stored.Xpriv)
+
+	assert.Equal(t, 7, authorized.Token.ID)
+	assert.Equal(t, user, authorized.User)
+	assert.Equal(t, "token-access-key", authorized.AccessKey)
+
+	hashedPassword, err := encryption.Hash(profilePassword)
+	require.NoError(t, err)
+	assert.Equal(t, encryption.Decrypt(hashedPassword, user.Xpriv), authorized.Xpriv)
+}
+
+func TestCreateAPIToken_ContactsScope_StoresNoXpriv(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	setAPITokensTTL(t)
+
+	user := userWithXpriv(t)
+
+	repoMq := mock.NewMockRepository(ctrl)
+	userClientMq := mock.NewMockUserWalletClient(ctrl)
+	factoryMq := mock.NewMockWalletClientFactory(ctrl)
+	accessKeyMq := mock.NewMockAccKey(ctrl)
+
+	repoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(user, nil)
+	factoryMq.EXPECT().CreateWithXpriv(gomock.Any()).Return(userClientMq, nil)
+	userClientMq.EXPECT().CreateAccessKey().Return(accessKeyMq, nil)
+	accessKeyMq.EXPECT().GetAccessKey().Return("token-access-key").AnyTimes()
+	accessKeyMq.EXPECT().GetAccessKeyID().Return("token-access-key-id").AnyTimes()
+
+	var stored *users.APIToken
+	repoMq.EXPECT().
+		InsertAPIToken(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, token *users.APIToken) error {
+			stored = token
+			return nil
+		})
+
+	sut := users.NewUserService(repoMq, nil, factoryMq, nil, recorderMq(ctrl), &testLogger)
+
+	// Act
+	scopes := []string{users.APITokenScopeContactsManage, users.APITokenScopeTransactionsRead}
+	_, err := sut.CreateAPIToken(context.Background(), 1, profilePassword, "contacts", scopes, 0, time.Time{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEmpty(t, stored.AccessKey)
+	assert.Empty(t, stored.Xpriv)
+}
+
+func TestCreateAPIToken_InvalidRequest_ReturnsError(t *testing.T) {
+	testLogger := zerolog.Nop()
+	cases := []struct {
+		name          string
+		tokenName     string
+		scopes        []string
+		sendLimit     uint64
+		expiresAt     time.Time
+		expectedError error
+	}{
+		{
+			name:          "Empty name",
+			tokenName:     "  ",
+			scopes:        []string{users.APITokenScopeBalanceRead},
+			expectedError: spverrors.ErrInvalidAPITokenName,
+		},
+		{
+			name:          "Too long name",
+			tokenName:     strings.Repeat("a", 65),
+			scopes:        []string{users.APITokenScopeBalanceRead},
+			expectedError: spverrors.ErrInvalidAPITokenName,
+		},
+		{
+			name:          "No scopes",
+			tokenName:     "script",
+			expectedError: spverrors.ErrInvalidAPITokenScopes,
+		},
+		{
+			name:          "Unknown scope",
+			tokenName:     "script",
+			scopes:        []string{users.APITokenScopeBalanceRead, "wallet:delete"},
+			expectedError: spverrors.ErrInvalidAPITokenScopes,
+		},
+		{
+			name:          "Send scope without limit",
+			tokenName:     "script",
+			scopes:        []string{users.APITokenScopeTransactionsSend},
+			expectedError: spverrors.ErrInvalidAPITokenSendLimit,
+		},
+		{
+			name:          "Limit without send scope",
+			tokenName:     "script",
+			scopes:        []string{users.APITokenScopeTransactionsRead},
+			sendLimit:     1000,
+			expectedError: spverrors.ErrInvalidAPITokenSendLimit,
+		},
+		{
+			name:          "Expiry in the past",
+			tokenName:     "script",
+			scopes:        []string{users.APITokenScopeBalanceRead},
+			expiresAt:     time.Now().Add(-time.Minute),
+			expectedError: spverrors.ErrInvalidAPITokenExpiry,
+		},
+		{
+			name:          "Expiry after max TTL",
+			tokenName:     "script",
+			scopes:        []string{users.APITokenScopeBalanceRead},
+			expiresAt:     time.Now().Add(48 * time.Hour),
+			expectedError: spverrors.ErrInvalidAPITokenExpiry,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			setAPITokensTTL(t)
+
+			repoMq := mock.NewMockRepository(ctrl)
+			sut := users.NewUserService(repoMq, nil, nil, nil, recorderMq(ctrl), &testLogger)
+
+			// Act
+			created, err := sut.CreateAPIToken(context.Background(), 1, profilePassword, tc.tokenName, tc.scopes, tc.sendLimit, tc.expiresAt)
+
+			// Assert
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Nil(t, created)
+		})
+	}
+}
+
+func TestAuthorizeAPIToken_InvalidToken_ReturnsError(t *testing.T) {
+	testLogger := zerolog.Nop()
+	disabledAt := time.Now()
+	cases := []struct {
+		name          string
+		token         *users.APIToken
+		user          *users.User
+		expectedError error
+	}{
+		{
+			name:          "Unknown token",
+			expectedError: spverrors.ErrInvalidAPIToken,
+		},
+		{
+			name:          "Expired token",
+			token:         &users.APIToken{ID: 7, UserID: 1, ExpiresAt: time.Now().Add(-time.Minute)},
+			expectedError: spverrors.ErrInvalidAPIToken,
+		},
+		{
+			name:          "Disabled user",
+			token:         &users.APIToken{ID: 7, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)},
+			user:          &users.User{ID: 1, DisabledAt: &disabledAt},
+			expectedError: spverrors.ErrAccountDisabled,
+		},
+		{
+			name:          "Access key not encrypted with the token",
+			token:         &users.APIToken{ID: 7, UserID: 1, AccessKey: encryptWithPassword(t, profilePassword, "access-key"), ExpiresAt: time.Now().Add(time.Hour)},
+			user:          &users.User{ID: 1},
+			expectedError: spverrors.ErrInvalidAPIToken,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repoMq := mock.NewMockRepository(ctrl)
+			repoMq.EXPECT().GetAPIToken(gomock.Any(), gomock.Any()).Return(tc.token, nil)
+			if tc.user != nil {
+				repoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(tc.user, nil)
+			}
+
+			sut := users.NewUserService(repoMq, nil, nil, nil, recorderMq(ctrl), &testLogger)
+
+			// Act
+			authorized, err := sut.AuthorizeAPIToken(context.Background(), "spvw_token")
+
+			// Assert
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Nil(t, authorized)
+		})
+	}
+}
+
+func TestSpendAPIToken_LimitExceeded_ReturnsError(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockRepository(ctrl)
+	repoMq.EXPECT().SpendAPIToken(gomock.Any(), 7, uint64(500)).Return(false, nil)
+
+	sut := users.NewUserService(repoMq, nil, nil, nil, recorderMq(ctrl), &testLogger)
+
+	// Act
+	err := sut.SpendAPIToken(context.Background(), 7, 500)
+
+	// Assert
+	require.ErrorIs(t, err, spverrors.ErrAPITokenSendLimitExceeded)
+}
+
+func TestRefundAPIToken_RefundsToRepository(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockRepository(ctrl)
+	repoMq.EXPECT().RefundAPIToken(gomock.Any(), 7, uint64(500)).Return(nil)
+
+	sut := users.NewUserService(repoMq, nil, nil, nil, recorderMq(ctrl), &testLogger)
+
+	// Act
+	sut.RefundAPIToken(context.Background(), 7, 500)
+}
+
+func setAPITokensTTL(t *testing.T) {
+	viper.Set(config.EnvAPITokensDefaultTTL, time.Hour)
+	viper.Set(config.EnvAPITokensMaxTTL, 24*time.Hour)
+	t.Cleanup(func() {
+		viper.Set(config.EnvAPITokensDefaultTTL, nil)
+		viper.Set(config.EnvAPITokensMaxTTL, nil)
+	})
+}
@@ -0,0 +1,318 @@
+package users_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/libsv/go-bk/bip32"
+	"github.com/libsv/go-bk/chaincfg"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/encryption"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+	mock "github.com/bsv-blockchain/spv-wallet-web-backend/tests/mocks"
+)
+
+const (
+	registrationEmail    = "homer.simpson@example.com"
+	registrationPassword = "strongP4$$word"
+	registrationMnemonic = "donut duff beer couch nuclear plant"
+	registrationPaymail  = "homer@example.com"
+)
+
+var errWalletUnavailable = errors.New("spv wallet unavailable")
+
+func TestCreateNewUser_FailedStep_KeepsRegistrationForResume(t *testing.T) {
+	setRegistrationConfig(t)
+	testLogger := zerolog.Nop()
+	cases := []struct {
+		name         string
+		arrange      func(repoMq *mock.MockRepository, adminClientMq *mock.MockAdminWalletClient)
+		expectedStep string
+		expectedErr  error
+	}{
+		{
+			name: "xPub registration fails",
+			arrange: func(_ *mock.MockRepository, adminClientMq *mock.MockAdminWalletClient) {
+				adminClientMq.EXPECT().RegisterXpub(gomock.Any()).Return("", errWalletUnavailable)
+			},
+			expectedStep: users.RegistrationStepCreated,
+			expectedErr:  spverrors.ErrRegisterXPub,
+		},
+		{
+			name: "Paymail registration fails",
+			arrange: func(_ *mock.MockRepository, adminClientMq *mock.MockAdminWalletClient) {
+				adminClientMq.EXPECT().RegisterXpub(gomock.Any()).Return("xpub", nil)
+				adminClientMq.EXPECT().RegisterPaymail("homer", "example.com", gomock.Any(), nil).Return("", errWalletUnavailable)
+				adminClientMq.EXPECT().IsPaymailAvailable("homer", "example.com").Return(true, nil)
+			},
+			expectedStep: users.RegistrationStepXpubRegistered,
+			expectedErr:  spverrors.ErrRegisterPaymail,
+		},
+		{
+			name: "User insert fails",
+			arrange: func(repoMq *mock.MockRepository, adminClientMq *mock.MockAdminWalletClient) {
+				adminClientMq.EXPECT().RegisterXpub(gomock.Any()).Return("xpub", nil)
+				adminClientMq.EXPECT().RegisterPaymail("homer", "example.com", gomock.Any(), nil).Return(registrationPaymail, nil)
+				repoMq.EXPECT().CompleteRegistration(gomock.Any(), gomock.Any(), 7).Return(errWalletUnavailable)
+			},
+			expectedStep: users.RegistrationStepPaymailRegistered,
+			expectedErr:  spverrors.ErrInsertUser,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repoMq := mock.NewMockRepository(ctrl)
+			adminClientMq := mock.NewMockAdminWalletClient(ctrl)
+
+			registration := pendingRegistration(t, users.RegistrationStepCreated, 0)
+			expectPendingRegistration(repoMq, registration)
+
+			var saved users.PendingRegistration
+			repoMq.EXPECT().
+				UpdatePendingRegistration(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ any, r *users.PendingRegistration) error {
+					saved = *r
+					return nil
+				}).
+				AnyTimes()
+			tc.arrange(repoMq, adminClientMq)
+
+			sut := users.NewUserService(repoMq, adminClientMq, nil, nil, nil, &testLogger)
+
+			// Act
+			result, err := sut.CreateNewUser(registrationEmail, registrationPassword, "", "")
+
+			// Assert
+			require.EqualError(t, err, tc.expectedErr.Error())
+			assert.Nil(t, result)
+			assert.Equal(t, tc.expectedStep, saved.Step)
+			assert.Equal(t, 1, saved.Attempts)
+			assert.Equal(t, errWalletUnavailable.Error(), saved.LastError)
+		})
+	}
+}
+
+func TestCreateNewUser_LastAttemptFails_CompensatesRegistration(t *testing.T) {
+	setRegistrationConfig(t)
+	testLogger := zerolog.Nop()
+	cases := []struct {
+		name        string
+		step        string
+		arrange     func(repoMq *mock.MockRepository, adminClientMq *mock.MockAdminWalletClient)
+		expectedErr error
+	}{
+		{
+			name: "xPub registration fails",
+			step: users.RegistrationStepCreated,
+			arrange: func(_ *mock.MockRepository, adminClientMq *mock.MockAdminWalletClient) {
+				adminClientMq.EXPECT().RegisterXpub(gomock.Any()).Return("", errWalletUnavailable)
+			},
+			expectedErr: spverrors.ErrRegisterXPub,
+		},
+		{
+			name: "Paymail registration fails",
+			step: users.RegistrationStepXpubRegistered,
+			arrange: func(_ *mock.MockRepository, adminClientMq *mock.MockAdminWalletClient) {
+				adminClientMq.EXPECT().RegisterPaymail("homer", "example.com", gomock.Any(), nil).Return("", errWalletUnavailable)
+				adminClientMq.EXPECT().IsPaymailAvailable("homer", "example.com").Return(true, nil)
+			},
+			expectedErr: spverrors.ErrRegisterPaymail,
+		},
+		{
+			name: "User insert fails",
+			step: users.RegistrationStepPaymailRegistered,
+			arrange: func(repoMq *mock.MockRepository, adminClientMq *mock.MockAdminWalletClient) {
+				repoMq.EXPECT().CompleteRegistration(gomock.Any(), gomock.Any(), 7).Return(errWalletUnavailable)
+				adminClientMq.EXPECT().DeletePaymail(registrationPaymail)
+			},
+			expectedErr: spverrors.ErrInsertUser,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repoMq := mock.NewMockRepository(ctrl)
+			adminClientMq := mock.NewMockAdminWalletClient(ctrl)
+
+			expectPendingRegistration(repoMq, pendingRegistration(t, tc.step, 2))
+			repoMq.EXPECT().UpdatePendingRegistration(gomock.Any(), gomock.Any())
+			repoMq.EXPECT().DeletePendingRegistration(gomock.Any(), 7)
+			tc.arrange(repoMq, adminClientMq)
+
+			sut := users.NewUserService(repoMq, adminClientMq, nil, nil, nil, &testLogger)
+
+			// Act
+			result, err := sut.CreateNewUser(registrationEmail, registrationPassword, "", "")
+
+			// Assert
+			require.EqualError(t, err, tc.expectedErr.Error())
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func TestCreateNewUser_PaymailTakenDuringResume_CompensatesRegistration(t *testing.T) {
+	// Arrange
+	setRegistrationConfig(t)
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockRepository(ctrl)
+	adminClientMq := mock.NewMockAdminWalletClient(ctrl)
+
+	expectPendingRegistration(repoMq, pendingRegistration(t, users.RegistrationStepXpubRegistered, 0))
+	adminClientMq.EXPECT().RegisterPaymail("homer", "example.com", gomock.Any(), nil).Return("", errWalletUnavailable)
+	adminClientMq.EXPECT().IsPaymailAvailable("homer", "example.com").Return(false, nil)
+	repoMq.EXPECT().DeletePendingRegistration(gomock.Any(), 7)
+
+	sut := users.NewUserService(repoMq, adminClientMq, nil, nil, nil, &testLogger)
+
+	// Act
+	result, err := sut.CreateNewUser(registrationEmail, registrationPassword, "", "")
+
+	// Assert
+	require.EqualError(t, err, spverrors.ErrPaymailAliasTaken.Error())
+	assert.Nil(t, result)
+}
+
+func TestCreateNewUser_ResumesPendingRegistration(t *testing.T) {
+	// Arrange
+	setRegistrationConfig(t)
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockRepository(ctrl)
+	adminClientMq := mock.NewMockAdminWalletClient(ctrl)
+
+	registration := pendingRegistration(t, users.RegistrationStepXpubRegistered, 1)
+	expectPendingRegistration(repoMq, registration)
+	adminClientMq.EXPECT().RegisterPaymail("homer", "example.com", gomock.Any(), nil).Return(registrationPaymail, nil)
+	repoMq.EXPECT().UpdatePendingRegistration(gomock.Any(), gomock.Any())
+	repoMq.EXPECT().CompleteRegistration(gomock.Any(), gomock.Any(), 7)
+
+	sut := users.NewUserService(repoMq, adminClientMq, nil, nil, nil, &testLogger)
+
+	// Act
+	result, err := sut.CreateNewUser(registrationEmail, registrationPassword, "other", "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, registrationMnemonic, result.Mnemonic)
+	assert.Equal(t, registrationPaymail, result.User.Paymail)
+	assert.Equal(t, registration.Xpriv, result.User.Xpriv)
+	assert.Equal(t, registration.XpubID, result.User.XpubID)
+}
+
+func TestCreateNewUser_PendingRegistrationWithOtherPassword_ReturnsError(t *testing.T) {
+	// Arrange
+	setRegistrationConfig(t)
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockRepository(ctrl)
+	expectPendingRegistration(repoMq, pendingRegistration(t, users.RegistrationStepXpubRegistered, 0))
+
+	sut := users.NewUserService(repoMq, mock.NewMockAdminWalletClient(ctrl), nil, nil, nil, &testLogger)
+
+	// Act
+	result, err := sut.CreateNewUser(registrationEmail, "otherP4$$word", "", "")
+
+	// Assert
+	require.EqualError(t, err, spverrors.ErrRegistrationInProgress.Error())
+	assert.Nil(t, result)
+}
+
+func TestCleanupPendingRegistrations_CompensatesExpiredRegistrations(t *testing.T) {
+	// Arrange
+	setRegistrationConfig(t)
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockRepository(ctrl)
+	adminClientMq := mock.NewMockAdminWalletClient(ctrl)
+
+	withPaymail := pendingRegistration(t, users.RegistrationStepPaymailRegistered, 1)
+	withoutPaymail := pendingRegistration(t, users.RegistrationStepCreated, 0)
+	withoutPaymail.ID = 8
+	repoMq.EXPECT().
+		GetPendingRegistrationsBefore(gomock.Any(), gomock.Any()).
+		Return([]*users.PendingRegistration{withPaymail, withoutPaymail}, nil)
+	adminClientMq.EXPECT().DeletePaymail(registrationPaymail)
+	repoMq.EXPECT().DeletePendingRegistration(gomock.Any(), 7)
+	repoMq.EXPECT().DeletePendingRegistration(gomock.Any(), 8)
+
+	sut := users.NewUserService(repoMq, adminClientMq, nil, nil, nil, &testLogger)
+
+	// Act & Assert
+	sut.CleanupPendingRegistrations(t.Context())
+}
+
+func setRegistrationConfig(t *testing.T) {
+	viper.Set(config.EnvRegistrationMaxAttempts, 3)
+	viper.Set(config.EnvRegistrationPendingTTL, time.Hour)
+	t.Cleanup(func() {
+		viper.Set(config.EnvRegistrationMaxAttempts, nil)
+		viper.Set(config.EnvRegistrationPendingTTL, nil)
+	})
+}
+
+func expectPendingRegistration(repoMq *mock.MockRepository, registration *users.PendingRegistration) {
+	repoMq.EXPECT().
+		GetUserByEmail(gomock.Any(), registrationEmail).
+		Return(nil, nil)
+	repoMq.EXPECT().
+		GetPendingRegistration(gomock.Any(), registrationEmail).
+		Return(registration, nil)
+}
+
+// pendingRegistration returns registration of the user with keys encrypted by registrationPassword.
+func pendingRegistration(t *testing.T, step string, attempts int) *users.PendingRegistration {
+	xpriv, err := bip32.NewMaster(make([]byte, 32), &chaincfg.MainNet)
+	require.NoError(t, err)
+
+	registration := &users.PendingRegistration{
+		ID:        7,
+		Email:     registrationEmail,
+		Xpriv:     encryptWithPassword(t, registrationPassword, xpriv.String()),
+		Mnemonic:  encryptWithPassword(t, registrationPassword, registrationMnemonic),
+		Alias:     "homer",
+		Domain:    "example.com",
+		XpubID:    "xpub-id",
+		Step:      step,
+		Attempts:  attempts,
+		CreatedAt: time.Now(),
+	}
+	if step == users.RegistrationStepPaymailRegistered {
+		registration.Paymail = registrationPaymail
+	}
+	return registration
+}
+
+func encryptWithPassword(t *testing.T, password, value string) string {
+	hashedPassword, err := encryption.Hash(password)
+	require.NoError(t, err)
+	encrypted, err := encryption.Encrypt(hashedPassword, value)
+	require.NoError(t, err)
+	return encrypted
+}
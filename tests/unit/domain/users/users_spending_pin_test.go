@@ -0,0 +1,223 @@
+package users_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/encryption"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+	mock "github.com/bsv-blockchain/spv-wallet-web-backend/tests/mocks"
+)
+
+const spendingPIN = "482913"
+
+func TestSetSpendingPIN_PINUnlocksXpriv(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := userWithXpriv(t)
+	repoMq := mock.NewMockRepository(ctrl)
+	repoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(user, nil).Times(3)
+
+	var stored *users.SpendingPIN
+	repoMq.EXPECT().
+		UpsertSpendingPIN(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, pin *users.SpendingPIN) error {
+			stored = pin
+			return nil
+		})
+	repoMq.EXPECT().
+		GetSpendingPIN(gomock.Any(), 1).
+		DoAndReturn(func(context.Context, int) (*users.SpendingPIN, error) {
+			return stored, nil
+		})
+	repoMq.EXPECT().ReserveSpendingPINAttempt(gomock.Any(), 1, gomock.Any()).Return(1, true, nil)
+	repoMq.EXPECT().ResetSpendingPINAttempts(gomock.Any(), 1).Return(nil)
+
+	sut := users.NewUserService(repoMq, nil, nil, nil, recorderMq(ctrl), &testLogger)
+
+	// Act
+	err := sut.SetSpendingPIN(context.Background(), 1, profilePassword, spendingPIN)
+	require.NoError(t, err)
+	xpriv, err := sut.UnlockWithSpendingPIN(context.Background(), 1, spendingPIN)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, decryptWithPassword(t, profilePassword, user.Xpriv), xpriv)
+	assert.Equal(t, 1, stored.UserID)
+	assert.NotEqual(t, user.Xpriv, stored.Xpriv)
+}
+
+func TestSetSpendingPIN_InvalidRequest_ReturnsError(t *testing.T) {
+	testLogger := zerolog.Nop()
+	cases := []struct {
+		name          string
+		user          *users.User
+		password      string
+		pin           string
+		expectedError error
+	}{
+		{
+			name:          "Too short PIN",
+			pin:           "12345",
+			password:      profilePassword,
+			expectedError: spverrors.ErrInvalidSpendingPIN,
+		},
+		{
+			name:          "Not numeric PIN",
+			pin:           "12345a",
+			password:      profilePassword,
+			expectedError: spverrors.ErrInvalidSpendingPIN,
+		},
+		{
+			name:          "Invalid password",
+			user:          &users.User{ID: 1, Xpriv: encryptWithPassword(t, profilePassword, "xpriv")},
+			pin:           spendingPIN,
+			password:      "invalid",
+			expectedError: spverrors.ErrInvalidCredentials,
+		},
+		{
+			name:          "User of identity provider",
+			user:          &users.User{ID: 1, SSOSubject: ssoIdentitySubject},
+			pin:           spendingPIN,
+			password:      profilePassword,
+			expectedError: spverrors.ErrSpendingPINManagedBySSO,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repoMq := mock.NewMockRepository(ctrl)
+			if tc.user != nil {
+				repoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(tc.user, nil).AnyTimes()
+			}
+
+			sut := users.NewUserService(repoMq, nil, nil, nil, recorderMq(ctrl), &testLogger)
+
+			// Act
+			err := sut.SetSpendingPIN(context.Background(), 1, tc.password, tc.pin)
+
+			// Assert
+			require.ErrorIs(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestUnlockWithSpendingPIN_AttemptLimiter(t *testing.T) {
+	testLogger := zerolog.Nop()
+	cases := []struct {
+		name          string
+		pin           string
+		attempt       int
+		locked        bool
+		expectLock    bool
+		expectedError error
+	}{
+		{
+			name:          "Invalid PIN is counted",
+			pin:           "000000",
+			attempt:       1,
+			expectedError: spverrors.ErrInvalidCredentials,
+		},
+		{
+			name:          "Last invalid attempt locks PIN",
+			pin:           "000000",
+			attempt:       3,
+			expectLock:    true,
+			expectedError: spverrors.ErrSpendingPINLocked,
+		},
+		{
+			name:          "Locked PIN is not tried",
+			pin:           spendingPIN,
+			locked:        true,
+			expectedError: spverrors.ErrSpendingPINLocked,
+		},
+		{
+			name:          "Concurrent attempt over the limit is not tried",
+			pin:           spendingPIN,
+			attempt:       4,
+			expectLock:    true,
+			expectedError: spverrors.ErrSpendingPINLocked,
+		},
+		{
+			name:    "Valid PIN resets attempts",
+			pin:     spendingPIN,
+			attempt: 3,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			setSpendingPINLimits(t)
+
+			repoMq := mock.NewMockRepository(ctrl)
+			repoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(&users.User{ID: 1}, nil)
+			repoMq.EXPECT().GetSpendingPIN(gomock.Any(), 1).Return(&users.SpendingPIN{
+				UserID: 1,
+				Xpriv:  encryptWithPassword(t, encryption.PINKey(spendingPIN, "user:1"), "xpriv"),
+			}, nil)
+			repoMq.EXPECT().ReserveSpendingPINAttempt(gomock.Any(), 1, gomock.Any()).Return(tc.attempt, !tc.locked, nil)
+			if tc.expectLock {
+				repoMq.EXPECT().
+					LockSpendingPIN(gomock.Any(), 1, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ int, until time.Time) error {
+						assert.WithinDuration(t, time.Now().Add(10*time.Minute), until, time.Minute)
+						return nil
+					})
+			}
+			if tc.expectedError == nil {
+				repoMq.EXPECT().ResetSpendingPINAttempts(gomock.Any(), 1).Return(nil)
+			}
+
+			sut := users.NewUserService(repoMq, nil, nil, nil, recorderMq(ctrl), &testLogger)
+
+			// Act
+			xpriv, err := sut.UnlockWithSpendingPIN(context.Background(), 1, tc.pin)
+
+			// Assert
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				assert.Empty(t, xpriv)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "xpriv", xpriv)
+		})
+	}
+}
+
+func TestUnlockWithSpendingPIN_NotSet_ReturnsError(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockRepository(ctrl)
+	repoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(userWithXpriv(t), nil)
+	repoMq.EXPECT().GetSpendingPIN(gomock.Any(), 1).Return(nil, nil)
+
+	sut := users.NewUserService(repoMq, nil, nil, nil, recorderMq(ctrl), &testLogger)
+
+	// Act
+	xpriv, err := sut.UnlockWithSpendingPIN(context.Background(), 1, spendingPIN)
+
+	// Assert
+	require.ErrorIs(t, err, spverrors.ErrSpendingPINNotSet)
+	assert.Empty(t, xpriv)
+}
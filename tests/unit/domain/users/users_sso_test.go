@@ -0,0 +1,298 @@
+package users_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/encryption"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+	mock "github.com/bsv-blockchain/spv-wallet-web-backend/tests/mocks"
+)
+
+const (
+	ssoSpendingPIN      = "135790"
+	ssoAccessKeySecret  = "access-key-secret"
+	ssoIdentityIssuer   = "https://idp.example.com"
+	ssoIdentitySubject  = "subject-1"
+	ssoAccessKeyID      = "sso-access-key-id"
+	ssoAccessKeyPrivate = "sso-access-key"
+)
+
+func TestRegisterSSOUser_MapsUserToIdentity(t *testing.T) {
+	// Arrange
+	setRegistrationConfig(t)
+	setSSOAccessKeySecret(t)
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMq := mock.NewMockRepository(ctrl)
+	factoryMq := mock.NewMockWalletClientFactory(ctrl)
+	userClientMq := mock.NewMockUserWalletClient(ctrl)
+	accessKeyMq := mock.NewMockAccKey(ctrl)
+
+	registration := pendingRegistration(t, users.RegistrationStepPaymailRegistered, 0)
+	xpriv := decryptWithPassword(t, registrationPassword, registration.Xpriv)
+	registration.Xpriv = encryptWithPassword(t, ssoPINKey(ssoSpendingPIN), xpriv)
+	registration.Mnemonic = encryptWithPassword(t, ssoPINKey(ssoSpendingPIN), registrationMnemonic)
+	expectPendingRegistration(repoMq, registration)
+
+	factoryMq.EXPECT().CreateWithXpriv(xpriv).Return(userClientMq, nil)
+	userClientMq.EXPECT().CreateAccessKey().Return(accessKeyMq, nil)
+	accessKeyMq.EXPECT().GetAccessKey().Return(ssoAccessKeyPrivate).AnyTimes()
+	accessKeyMq.EXPECT().GetAccessKeyID().Return(ssoAccessKeyID).AnyTimes()
+
+	var stored *users.User
+	repoMq.EXPECT().
+		CompleteRegistration(gomock.Any(), gomock.Any(), 7).
+		DoAndReturn(func(_ context.Context, user *users.User, _ int) error {
+			stored = user
+			return nil
+		})
+
+	sut := users.NewUserService(repoMq, mock.NewMockAdminWalletClient(ctrl), factoryMq, nil, recorderMq(ctrl), &testLogger)
+
+	// Act
+	result, err := sut.RegisterSSOUser(ssoIdentity(), ssoSpendingPIN, "", "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, registrationMnemonic, result.Mnemonic)
+	assert.Equal(t, ssoIdentityIssuer, stored.SSOIssuer)
+	assert.Equal(t, ssoIdentitySubject, stored.SSOSubject)
+	assert.Equal(t, ssoAccessKeyID, stored.AccessKeyID)
+	assert.Equal(t, ssoAccessKeyPrivate, decryptWithPassword(t, ssoAccessKeySecret, stored.AccessKey))
+	assert.Equal(t, xpriv, decryptWithPassword(t, ssoPINKey(ssoSpendingPIN), stored.Xpriv))
+	assert.Empty(t, decryptWithPassword(t, ssoSpendingPIN, stored.Xpriv))
+}
+
+func TestRegisterSSOUser_InvalidSpendingPIN_ReturnsError(t *testing.T) {
+	testLogger := zerolog.Nop()
+	cases := []struct {
+		name string
+		pin  string
+	}{
+		{name: "Too short", pin: "12345"},
+		{name: "Too long", pin: "1234567890123"},
+		{name: "Not digits", pin: "12345a"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			sut := users.NewUserService(mock.NewMockRepository(ctrl), nil, nil, nil, recorderMq(ctrl), &testLogger)
+
+			// Act
+			result, err := sut.RegisterSSOUser(ssoIdentity(), tc.pin, "", "")
+
+			// Assert
+			require.ErrorIs(t, err, spverrors.ErrInvalidSpendingPIN)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func TestSignInSSOUser_NotAllowed_ReturnsError(t *testing.T) {
+	testLogger := zerolog.Nop()
+	disabledAt := time.Now()
+	cases := []struct {
+		name          string
+		user          *users.User
+		expectedError error
+	}{
+		{
+			name:          "Identity not registered",
+			expectedError: spverrors.ErrSSORegistrationRequired,
+		},
+		{
+			name:          "Disabled user",
+			user:          &users.User{ID: 1, SSOIssuer: ssoIdentityIssuer, SSOSubject: ssoIdentitySubject, DisabledAt: &disabledAt},
+			expectedError: spverrors.ErrAccountDisabled,
+		},
+		{
+			name:          "Access key not encrypted with the server secret",
+			user:          &users.User{ID: 1, SSOIssuer: ssoIdentityIssuer, SSOSubject: ssoIdentitySubject, AccessKey: encryptWithPassword(t, "other-secret", ssoAccessKeyPrivate)},
+			expectedError: spverrors.ErrSSOAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			setSSOAccessKeySecret(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repoMq := mock.NewMockRepository(ctrl)
+			repoMq.EXPECT().GetUserBySSOIdentity(gomock.Any(), ssoIdentityIssuer, ssoIdentitySubject).Return(tc.user, nil)
+
+			sut := users.NewUserService(repoMq, nil, nil, nil, recorderMq(ctrl), &testLogger)
+
+			// Act
+			signInUser, err := sut.SignInSSOUser(context.Background(), ssoIdentity())
+
+			// Assert
+			require.ErrorIs(t, err, tc.expectedError)
+			assert.Nil(t, signInUser)
+		})
+	}
+}
+
+func TestSignInUser_SSOUser_ReturnsInvalidCredentials(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := userWithXpriv(t)
+	user.Role = users.RoleOwner
+	user.SSOIssuer = ssoIdentityIssuer
+	user.SSOSubject = ssoIdentitySubject
+
+	repoMq := mock.NewMockRepository(ctrl)
+	repoMq.EXPECT().GetUserByEmail(gomock.Any(), registrationEmail).Return(user, nil)
+
+	sut := users.NewUserService(repoMq, nil, nil, nil, recorderMq(ctrl), &testLogger)
+
+	// Act
+	signInUser, err := sut.SignInUser(context.Background(), registrationEmail, profilePassword)
+
+	// Assert
+	require.ErrorIs(t, err, spverrors.ErrInvalidCredentials)
+	assert.Nil(t, signInUser)
+}
+
+func TestGetUserXpriv_SSOUser_AttemptLimiter(t *testing.T) {
+	testLogger := zerolog.Nop()
+	cases := []struct {
+		name          string
+		pin           string
+		attempt       int
+		locked        bool
+		expectLock    bool
+		expectedError error
+	}{
+		{
+			name:          "Invalid PIN is counted",
+			pin:           "000000",
+			attempt:       1,
+			expectedError: spverrors.ErrInvalidCredentials,
+		},
+		{
+			name:          "Last invalid attempt locks PIN",
+			pin:           "000000",
+			attempt:       3,
+			expectLock:    true,
+			expectedError: spverrors.ErrSpendingPINLocked,
+		},
+		{
+			name:          "Locked PIN is not tried",
+			pin:           ssoSpendingPIN,
+			locked:        true,
+			expectedError: spverrors.ErrSpendingPINLocked,
+		},
+		{
+			name:          "Concurrent attempt over the limit is not tried",
+			pin:           ssoSpendingPIN,
+			attempt:       4,
+			expectLock:    true,
+			expectedError: spverrors.ErrSpendingPINLocked,
+		},
+		{
+			name:    "Valid PIN resets attempts",
+			pin:     ssoSpendingPIN,
+			attempt: 3,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			setSpendingPINLimits(t)
+
+			user := &users.User{
+				ID:         1,
+				SSOIssuer:  ssoIdentityIssuer,
+				SSOSubject: ssoIdentitySubject,
+				Xpriv:      encryptWithPassword(t, ssoPINKey(ssoSpendingPIN), "xpriv"),
+			}
+			repoMq := mock.NewMockRepository(ctrl)
+			repoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(user, nil)
+			repoMq.EXPECT().ReserveSpendingPINAttempt(gomock.Any(), 1, gomock.Any()).Return(tc.attempt, !tc.locked, nil)
+			if tc.expectLock {
+				repoMq.EXPECT().
+					LockSpendingPIN(gomock.Any(), 1, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ int, until time.Time) error {
+						assert.WithinDuration(t, time.Now().Add(10*time.Minute), until, time.Minute)
+						return nil
+					})
+			}
+			if tc.expectedError == nil {
+				repoMq.EXPECT().ResetSpendingPINAttempts(gomock.Any(), 1).Return(nil)
+			}
+
+			sut := users.NewUserService(repoMq, nil, nil, nil, recorderMq(ctrl), &testLogger)
+
+			// Act
+			xpriv, err := sut.GetUserXpriv(context.Background(), 1, tc.pin)
+
+			// Assert
+			if tc.expectedError != nil {
+				require.ErrorIs(t, err, tc.expectedError)
+				assert.Empty(t, xpriv)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "xpriv", xpriv)
+		})
+	}
+}
+
+func ssoIdentity() *users.SSOIdentity {
+	return &users.SSOIdentity{
+		Issuer:  ssoIdentityIssuer,
+		Subject: ssoIdentitySubject,
+		Email:   registrationEmail,
+	}
+}
+
+func setSSOAccessKeySecret(t *testing.T) {
+	viper.Set(config.EnvSSOAccessKeySecret, ssoAccessKeySecret)
+	t.Cleanup(func() {
+		viper.Set(config.EnvSSOAccessKeySecret, nil)
+	})
+}
+
+// ssoPINKey returns key derived from spending PIN of the user of the identity provider.
+func ssoPINKey(pin string) string {
+	return encryption.PINKey(pin, "sso:"+ssoIdentityIssuer+":"+ssoIdentitySubject)
+}
+
+func setSpendingPINLimits(t *testing.T) {
+	viper.Set(config.EnvSpendingPINMaxAttempts, 3)
+	viper.Set(config.EnvSpendingPINLockout, 10*time.Minute)
+	t.Cleanup(func() {
+		viper.Set(config.EnvSpendingPINMaxAttempts, nil)
+		viper.Set(config.EnvSpendingPINLockout, nil)
+	})
+}
+
+func decryptWithPassword(t *testing.T, password, value string) string {
+	hashedPassword, err := encryption.Hash(password)
+	require.NoError(t, err)
+	return encryption.Decrypt(hashedPassword, value)
+}
@@ -0,0 +1,146 @@
+package users_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/encryption"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+	mock "github.com/bsv-blockchain/spv-wallet-web-backend/tests/mocks"
+)
+
+const viewerPassword = "viewerP4$$word"
+
+func TestInviteViewer_InvitationAcceptedWithToken(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	viper.Set(config.EnvViewersInvitationTTL, time.Hour)
+	t.Cleanup(func() { viper.Set(config.EnvViewersInvitationTTL, nil) })
+
+	repoMq := mock.NewMockRepository(ctrl)
+	userClientMq := mock.NewMockUserWalletClient(ctrl)
+	factoryMq := mock.NewMockWalletClientFactory(ctrl)
+	accessKeyMq := mock.NewMockAccKey(ctrl)
+
+	repoMq.EXPECT().GetUserByEmail(gomock.Any(), "accountant@example.com").Return(nil, nil).Times(2)
+	repoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(userWithXpriv(t), nil)
+	factoryMq.EXPECT().CreateWithXpriv(gomock.Any()).Return(userClientMq, nil)
+	userClientMq.EXPECT().CreateAccessKey().Return(accessKeyMq, nil)
+	accessKeyMq.EXPECT().GetAccessKey().Return("viewer-access-key").AnyTimes()
+	accessKeyMq.EXPECT().GetAccessKeyID().Return("viewer-access-key-id").AnyTimes()
+
+	var stored *users.ViewerInvitation
+	repoMq.EXPECT().
+		InsertViewerInvitation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, invitation *users.ViewerInvitation) error {
+			stored = invitation
+			stored.ID = 5
+			return nil
+		})
+	repoMq.EXPECT().
+		GetViewerInvitation(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, tokenHash string) (*users.ViewerInvitation, error) {
+			assert.Equal(t, stored.TokenHash, tokenHash)
+			return stored, nil
+		})
+
+	var viewer *users.User
+	repoMq.EXPECT().
+		AcceptViewerInvitation(gomock.Any(), gomock.Any(), 5).
+		DoAndReturn(func(_ context.Context, user *users.User, _ int) error {
+			viewer = user
+			return nil
+		})
+
+	sut := users.NewUserService(repoMq, nil, factoryMq, nil, recorderMq(ctrl), &testLogger)
+
+	// Act
+	invitation, err := sut.InviteViewer(context.Background(), 1, profilePassword, " accountant@example.com ")
+	require.NoError(t, err)
+	_, err = sut.AcceptViewerInvitation(context.Background(), invitation.Token, viewerPassword)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEqual(t, invitation.Token, stored.TokenHash)
+	assert.Equal(t, "accountant@example.com", viewer.Email)
+	assert.Equal(t, users.RoleViewer, viewer.Role)
+	assert.Equal(t, 1, *viewer.OwnerID)
+	assert.Equal(t, "viewer-access-key-id", viewer.AccessKeyID)
+	assert.Empty(t, viewer.Xpriv)
+
+	hashedPassword, err := encryption.Hash(viewerPassword)
+	require.NoError(t, err)
+	assert.Equal(t, "viewer-access-key", encryption.Decrypt(hashedPassword, viewer.AccessKey))
+}
+
+func TestAcceptViewerInvitation_InvalidInvitation_ReturnsError(t *testing.T) {
+	testLogger := zerolog.Nop()
+	cases := []struct {
+		name       string
+		invitation *users.ViewerInvitation
+	}{
+		{
+			name: "Unknown token",
+		},
+		{
+			name: "Expired invitation",
+			invitation: &users.ViewerInvitation{
+				ID:        5,
+				OwnerID:   1,
+				Email:     "accountant@example.com",
+				AccessKey: encryptWithPassword(t, "token", "viewer-access-key"),
+				ExpiresAt: time.Now().Add(-time.Minute),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repoMq := mock.NewMockRepository(ctrl)
+			repoMq.EXPECT().GetViewerInvitation(gomock.Any(), gomock.Any()).Return(tc.invitation, nil)
+
+			sut := users.NewUserService(repoMq, nil, nil, nil, nil, &testLogger)
+
+			// Act
+			viewer, err := sut.AcceptViewerInvitation(context.Background(), "token", viewerPassword)
+
+			// Assert
+			require.ErrorIs(t, err, spverrors.ErrInvalidInvitation)
+			assert.Nil(t, viewer)
+		})
+	}
+}
+
+func TestRemoveViewer_ViewerOfOtherOwner_ReturnsError(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	otherOwnerID := 2
+	repoMq := mock.NewMockRepository(ctrl)
+	repoMq.EXPECT().GetUserByID(gomock.Any(), 7).Return(&users.User{ID: 7, Role: users.RoleViewer, OwnerID: &otherOwnerID}, nil)
+
+	sut := users.NewUserService(repoMq, nil, nil, nil, nil, &testLogger)
+
+	// Act
+	err := sut.RemoveViewer(context.Background(), 1, 7, profilePassword)
+
+	// Assert
+	require.ErrorIs(t, err, spverrors.ErrViewerNotFound)
+}
@@ -0,0 +1,31 @@
+package encryption_test
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/encryption"
+)
+
+// TestPINKey tests if key derived from the PIN depends on the PIN, the salt and the pepper.
+func TestPINKey(t *testing.T) {
+	viper.Set(config.EnvSpendingPINPepper, "pepper")
+	t.Cleanup(func() {
+		viper.Set(config.EnvSpendingPINPepper, nil)
+	})
+
+	// Act
+	key := encryption.PINKey("123456", "user:1")
+
+	// Assert
+	assert.Len(t, key, 64)
+	assert.Equal(t, key, encryption.PINKey("123456", "user:1"))
+	assert.NotEqual(t, key, encryption.PINKey("123457", "user:1"))
+	assert.NotEqual(t, key, encryption.PINKey("123456", "user:2"))
+
+	viper.Set(config.EnvSpendingPINPepper, "other-pepper")
+	assert.NotEqual(t, key, encryption.PINKey("123456", "user:1"))
+}
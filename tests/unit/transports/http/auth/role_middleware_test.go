@@ -0,0 +1,80 @@
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/auth"
+)
+
+func TestRoleMiddleware(t *testing.T) {
+	cases := []struct {
+		name           string
+		role           string
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{
+			name:           "Owner creates transaction",
+			role:           users.RoleOwner,
+			method:         http.MethodPost,
+			path:           "/api/v1/transaction",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Viewer gets transaction",
+			role:           users.RoleViewer,
+			method:         http.MethodGet,
+			path:           "/api/v1/transaction/tx-id",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Viewer creates transaction",
+			role:           users.RoleViewer,
+			method:         http.MethodPost,
+			path:           "/api/v1/transaction",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Unknown role creates transaction",
+			role:           "accountant",
+			method:         http.MethodPost,
+			path:           "/api/v1/transaction",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			testLogger := zerolog.Nop()
+			gin.SetMode(gin.TestMode)
+
+			sut := auth.NewRoleMiddleware([]auth.Endpoint{
+				{Method: http.MethodGet, Path: "/api/v1/transaction/:id"},
+			}, &testLogger)
+
+			engine := gin.New()
+			api := engine.Group("/api/v1", func(c *gin.Context) { c.Set(auth.SessionUserRole, tc.role) }, sut.ApplyToAPI)
+			api.POST("/transaction", func(c *gin.Context) { c.Status(http.StatusOK) })
+			api.GET("/transaction/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequestWithContext(context.Background(), tc.method, tc.path, nil)
+
+			// Act
+			engine.ServeHTTP(recorder, request)
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/auth"
+)
+
+func TestScopeMiddleware(t *testing.T) {
+	cases := []struct {
+		name           string
+		tokenID        int
+		scopes         []string
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{
+			name:           "Session creates transaction",
+			method:         http.MethodPost,
+			path:           "/api/v1/transaction",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Token with send scope creates transaction",
+			tokenID:        7,
+			scopes:         []string{users.APITokenScopeBalanceRead, users.APITokenScopeTransactionsSend},
+			method:         http.MethodPost,
+			path:           "/api/v1/transaction",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Token with read scope creates transaction",
+			tokenID:        7,
+			scopes:         []string{users.APITokenScopeTransactionsRead},
+			method:         http.MethodPost,
+			path:           "/api/v1/transaction",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Token calls endpoint of no scope",
+			tokenID:        7,
+			scopes:         []string{users.APITokenScopeTransactionsSend},
+			method:         http.MethodPost,
+			path:           "/api/v1/sign-out",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			testLogger := zerolog.Nop()
+			gin.SetMode(gin.TestMode)
+
+			sut := auth.NewScopeMiddleware(map[string][]auth.Endpoint{
+				users.APITokenScopeTransactionsSend: {{Method: http.MethodPost, Path: "/api/v1/transaction"}},
+			}, &testLogger)
+
+			engine := gin.New()
+			api := engine.Group("/api/v1", func(c *gin.Context) {
+				if tc.tokenID != 0 {
+					c.Set(auth.APITokenID, tc.tokenID)
+					c.Set(auth.APITokenScopes, tc.scopes)
+				}
+			}, sut.ApplyToAPI)
+			api.POST("/transaction", func(c *gin.Context) { c.Status(http.StatusOK) })
+			api.POST("/sign-out", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequestWithContext(context.Background(), tc.method, tc.path, nil)
+
+			// Act
+			engine.ServeHTTP(recorder, request)
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, recorder.Code)
+		})
+	}
+}
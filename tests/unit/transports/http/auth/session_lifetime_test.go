@@ -0,0 +1,213 @@
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/memstore"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	mock "github.com/bsv-blockchain/spv-wallet-web-backend/tests/mocks"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/auth"
+)
+
+func TestSessionValidator_SessionLifetime(t *testing.T) {
+	testLogger := zerolog.Nop()
+	now := time.Now().UTC()
+	cases := []struct {
+		name          string
+		signedInAt    time.Time
+		lastActiveAt  time.Time
+		rememberMe    bool
+		expectExpired bool
+	}{
+		{
+			name:         "Active session",
+			signedInAt:   now.Add(-2 * time.Hour),
+			lastActiveAt: now.Add(-10 * time.Minute),
+		},
+		{
+			name:          "Inactive session",
+			signedInAt:    now.Add(-2 * time.Hour),
+			lastActiveAt:  now.Add(-31 * time.Minute),
+			expectExpired: true,
+		},
+		{
+			name:          "Session after absolute lifetime",
+			signedInAt:    now.Add(-13 * time.Hour),
+			lastActiveAt:  now.Add(-time.Minute),
+			expectExpired: true,
+		},
+		{
+			name:         "Inactive session of remembered user",
+			signedInAt:   now.Add(-3 * 24 * time.Hour),
+			lastActiveAt: now.Add(-2 * 24 * time.Hour),
+			rememberMe:   true,
+		},
+		{
+			name:          "Remembered session after absolute lifetime",
+			signedInAt:    now.Add(-31 * 24 * time.Hour),
+			lastActiveAt:  now.Add(-time.Hour),
+			rememberMe:    true,
+			expectExpired: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			setSessionTimeouts(t)
+
+			store := memstore.NewStore([]byte("secret"))
+			request := sessionRequest(t, store, tc.signedInAt, tc.lastActiveAt, tc.rememberMe)
+
+			factoryMq := mock.NewMockWalletClientFactory(ctrl)
+			factoryMq.EXPECT().CreateAdminClient().Return(mock.NewMockAdminWalletClient(ctrl), nil)
+			repoMq := mock.NewMockRepository(ctrl)
+			if !tc.expectExpired {
+				userClientMq := mock.NewMockUserWalletClient(ctrl)
+				userClientMq.EXPECT().GetAccessKey("access-key-id").Return(mock.NewMockAccKey(ctrl), nil)
+				factoryMq.EXPECT().CreateWithAccessKey("access-key").Return(userClientMq, nil)
+				repoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(&users.User{ID: 1}, nil)
+			}
+
+			sut := auth.NewSessionValidator(store, servicesWith(factoryMq, repoMq), &testLogger)
+
+			// Act
+			err := sut.Validate(request, "1")
+
+			// Assert
+			if tc.expectExpired {
+				require.ErrorIs(t, err, auth.ErrSessionRevoked)
+				require.ErrorIs(t, err, auth.ErrSessionExpired)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestApplyToAPI_RenewsSession(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	setSessionTimeouts(t)
+
+	now := time.Now().UTC()
+	store := memstore.NewStore([]byte("secret"))
+	request := sessionRequest(t, store, now.Add(-time.Hour), now.Add(-20*time.Minute), false)
+
+	userClientMq := mock.NewMockUserWalletClient(ctrl)
+	userClientMq.EXPECT().GetAccessKey("access-key-id").Return(mock.NewMockAccKey(ctrl), nil)
+	factoryMq := mock.NewMockWalletClientFactory(ctrl)
+	factoryMq.EXPECT().CreateAdminClient().Return(mock.NewMockAdminWalletClient(ctrl), nil)
+	factoryMq.EXPECT().CreateWithAccessKey("access-key").Return(userClientMq, nil)
+	repoMq := mock.NewMockRepository(ctrl)
+	repoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(&users.User{ID: 1}, nil)
+
+	sut := auth.NewAuthMiddleware(servicesWith(factoryMq, repoMq), &testLogger)
+	engine := gin.New()
+	engine.Use(sessions.Sessions("Authorization", store))
+	engine.GET("/api/v1/user", sut.ApplyToAPI, func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	// Act
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, request)
+
+	// Assert
+	require.Equal(t, http.StatusOK, recorder.Code)
+	cookies := recorder.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, 30*60, cookies[0].MaxAge)
+
+	renewed, err := store.Get(cookieRequest(cookies), "Authorization")
+	require.NoError(t, err)
+	lastActiveAt, ok := renewed.Values[auth.SessionLastActiveAt].(int64)
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now(), time.UnixMicro(lastActiveAt), time.Minute)
+}
+
+func TestApplyToAPI_ExpiredSession_ReturnsUnauthorized(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	setSessionTimeouts(t)
+
+	now := time.Now().UTC()
+	store := memstore.NewStore([]byte("secret"))
+	request := sessionRequest(t, store, now.Add(-time.Hour), now.Add(-31*time.Minute), false)
+
+	factoryMq := mock.NewMockWalletClientFactory(ctrl)
+	factoryMq.EXPECT().CreateAdminClient().Return(mock.NewMockAdminWalletClient(ctrl), nil)
+
+	sut := auth.NewAuthMiddleware(servicesWith(factoryMq, mock.NewMockRepository(ctrl)), &testLogger)
+	engine := gin.New()
+	engine.Use(sessions.Sessions("Authorization", store))
+	engine.GET("/api/v1/user", sut.ApplyToAPI, func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	// Act
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, request)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
+
+// sessionRequest returns request with cookie of session in which user signed in and was last active at given times.
+func sessionRequest(t *testing.T, store sessions.Store, signedInAt, lastActiveAt time.Time, rememberMe bool) *http.Request {
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/sign-in", nil)
+	sessions.Sessions("Authorization", store)(ctx)
+
+	session := sessions.Default(ctx)
+	session.Set(auth.SessionAccessKeyID, "access-key-id")
+	session.Set(auth.SessionAccessKey, "access-key")
+	session.Set(auth.SessionUserID, 1)
+	session.Set(auth.SessionUserPaymail, "homer@example.com")
+	session.Set(auth.SessionSignedInAt, signedInAt.UnixMicro())
+	session.Set(auth.SessionLastActiveAt, lastActiveAt.UnixMicro())
+	session.Set(auth.SessionRememberMe, rememberMe)
+	require.NoError(t, session.Save())
+
+	return cookieRequest(recorder.Result().Cookies())
+}
+
+// cookieRequest returns request to the API with given cookies.
+func cookieRequest(cookies []*http.Cookie) *http.Request {
+	request := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/api/v1/user", nil)
+	for _, cookie := range cookies {
+		request.AddCookie(cookie)
+	}
+	return request
+}
+
+func setSessionTimeouts(t *testing.T) {
+	viper.Set(config.EnvHTTPServerSessionIdleTimeout, 30*time.Minute)
+	viper.Set(config.EnvHTTPServerSessionAbsoluteTimeout, 12*time.Hour)
+	viper.Set(config.EnvHTTPServerSessionRememberMeIdleTimeout, 7*24*time.Hour)
+	viper.Set(config.EnvHTTPServerSessionRememberMeAbsoluteTimeout, 30*24*time.Hour)
+	t.Cleanup(func() {
+		viper.Set(config.EnvHTTPServerSessionIdleTimeout, nil)
+		viper.Set(config.EnvHTTPServerSessionAbsoluteTimeout, nil)
+		viper.Set(config.EnvHTTPServerSessionRememberMeIdleTimeout, nil)
+		viper.Set(config.EnvHTTPServerSessionRememberMeAbsoluteTimeout, nil)
+	})
+}
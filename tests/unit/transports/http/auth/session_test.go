@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/gin-contrib/sessions"
@@ -38,6 +39,7 @@ func TestTerminateSession(t *testing.T) {
 
 func TestUpdateSession(t *testing.T) {
 	// Arrange
+	setSessionTimeouts(t)
 	ctx := setupTest()
 
 	user := users.AuthenticatedUser{
@@ -49,11 +51,12 @@ func TestUpdateSession(t *testing.T) {
 			ID:      gofakeit.IntRange(0, 1000),
 			Paymail: gofakeit.HexUint256(),
 		},
-		Xpriv: "xprivtest",
+		Xpriv:      "xprivtest",
+		SignedInAt: time.Now().UTC(),
 	}
 
 	// Act
-	_ = auth.UpdateSession(ctx, &user)
+	_ = auth.UpdateSession(ctx, &user, true)
 
 	// Assert
 	session := sessions.Default(ctx)
@@ -63,6 +66,8 @@ func TestUpdateSession(t *testing.T) {
 	assert.Equal(t, user.User.ID, session.Get(auth.SessionUserID))
 	assert.Equal(t, user.User.Paymail, session.Get(auth.SessionUserPaymail))
 	assert.Equal(t, user.Xpriv, session.Get(auth.SessionXPriv))
+	assert.Equal(t, user.SignedInAt.UnixMicro(), session.Get(auth.SessionLastActiveAt))
+	assert.Equal(t, true, session.Get(auth.SessionRememberMe))
 }
 
 func setupTest() (ctx *gin.Context) {
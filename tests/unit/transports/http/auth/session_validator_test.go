@@ -0,0 +1,141 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/memstore"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	mock "github.com/bsv-blockchain/spv-wallet-web-backend/tests/mocks"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/auth"
+)
+
+func TestSessionValidator_ValidSession(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	setSessionTimeouts(t)
+
+	store := memstore.NewStore([]byte("secret"))
+	request := signedInRequest(t, store, 1)
+
+	userClientMq := mock.NewMockUserWalletClient(ctrl)
+	userClientMq.EXPECT().GetAccessKey("access-key-id").Return(mock.NewMockAccKey(ctrl), nil)
+	factoryMq := mock.NewMockWalletClientFactory(ctrl)
+	factoryMq.EXPECT().CreateAdminClient().Return(mock.NewMockAdminWalletClient(ctrl), nil)
+	factoryMq.EXPECT().CreateWithAccessKey("access-key").Return(userClientMq, nil)
+	repoMq := mock.NewMockRepository(ctrl)
+	repoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(&users.User{ID: 1}, nil)
+
+	sut := auth.NewSessionValidator(store, servicesWith(factoryMq, repoMq), &testLogger)
+
+	// Act
+	err := sut.Validate(request, "1")
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestSessionValidator_RevokedSession(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	setSessionTimeouts(t)
+
+	store := memstore.NewStore([]byte("secret"))
+
+	factoryMq := mock.NewMockWalletClientFactory(ctrl)
+	factoryMq.EXPECT().CreateAdminClient().Return(mock.NewMockAdminWalletClient(ctrl), nil)
+	repoMq := mock.NewMockRepository(ctrl)
+	sut := auth.NewSessionValidator(store, servicesWith(factoryMq, repoMq), &testLogger)
+
+	// Act & Assert
+	t.Run("Session of other user", func(t *testing.T) {
+		err := sut.Validate(signedInRequest(t, store, 2), "1")
+		assert.ErrorIs(t, err, auth.ErrSessionRevoked)
+	})
+
+	t.Run("Request without session", func(t *testing.T) {
+		request := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/api/websocket", nil)
+		err := sut.Validate(request, "1")
+		assert.ErrorIs(t, err, auth.ErrSessionRevoked)
+	})
+
+	t.Run("Revoked access key", func(t *testing.T) {
+		userClientMq := mock.NewMockUserWalletClient(ctrl)
+		userClientMq.EXPECT().GetAccessKey("access-key-id").Return(nil, errors.New("access key revoked"))
+		factoryMq.EXPECT().CreateWithAccessKey("access-key").Return(userClientMq, nil)
+
+		err := sut.Validate(signedInRequest(t, store, 1), "1")
+		assert.ErrorIs(t, err, auth.ErrSessionRevoked)
+	})
+
+	t.Run("Signed out by admin", func(t *testing.T) {
+		request := signedInRequest(t, store, 1)
+		revokedAt := time.Now().UTC().Add(time.Second)
+		userClientMq := mock.NewMockUserWalletClient(ctrl)
+		userClientMq.EXPECT().GetAccessKey("access-key-id").Return(mock.NewMockAccKey(ctrl), nil)
+		factoryMq.EXPECT().CreateWithAccessKey("access-key").Return(userClientMq, nil)
+		repoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(&users.User{ID: 1, SessionsRevokedAt: &revokedAt}, nil)
+
+		err := sut.Validate(request, "1")
+		assert.ErrorIs(t, err, auth.ErrSessionRevoked)
+	})
+
+	t.Run("Disabled user", func(t *testing.T) {
+		disabledAt := time.Now().UTC()
+		userClientMq := mock.NewMockUserWalletClient(ctrl)
+		userClientMq.EXPECT().GetAccessKey("access-key-id").Return(mock.NewMockAccKey(ctrl), nil)
+		factoryMq.EXPECT().CreateWithAccessKey("access-key").Return(userClientMq, nil)
+		repoMq.EXPECT().GetUserByID(gomock.Any(), 1).Return(&users.User{ID: 1, DisabledAt: &disabledAt}, nil)
+
+		err := sut.Validate(signedInRequest(t, store, 1), "1")
+		assert.ErrorIs(t, err, auth.ErrSessionRevoked)
+	})
+}
+
+// servicesWith returns services using given wallet client factory and users repository.
+func servicesWith(factory users.WalletClientFactory, repo users.Repository) *domain.Services {
+	testLogger := zerolog.Nop()
+	return &domain.Services{
+		WalletClientFactory: factory,
+		UsersService:        users.NewUserService(repo, nil, factory, nil, nil, &testLogger),
+	}
+}
+
+// signedInRequest returns request with cookie of session in which user signed in.
+func signedInRequest(t *testing.T, store sessions.Store, userID int) *http.Request {
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/api/v1/sign-in", nil)
+	sessions.Sessions("Authorization", store)(ctx)
+
+	session := sessions.Default(ctx)
+	session.Set(auth.SessionAccessKeyID, "access-key-id")
+	session.Set(auth.SessionAccessKey, "access-key")
+	session.Set(auth.SessionUserID, userID)
+	session.Set(auth.SessionSignedInAt, time.Now().UTC().UnixMicro())
+	require.NoError(t, session.Save())
+
+	request := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/api/websocket", nil)
+	for _, cookie := range recorder.Result().Cookies() {
+		request.AddCookie(cookie)
+	}
+	return request
+}
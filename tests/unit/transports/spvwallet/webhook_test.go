@@ -0,0 +1,99 @@
+package spvwallet_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bsv-blockchain/spv-wallet-go-client/commands"
+	"github.com/bsv-blockchain/spv-wallet-go-client/notifications"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/spvwallet"
+)
+
+func TestWebhookSubscription_Run_RetriesUntilSubscribed(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	viper.Set(config.EnvWebhookSubscribeTimeout, time.Second)
+	viper.Set(config.EnvWebhookSubscribeRetryInterval, time.Millisecond)
+	t.Cleanup(func() {
+		viper.Set(config.EnvWebhookSubscribeTimeout, nil)
+		viper.Set(config.EnvWebhookSubscribeRetryInterval, nil)
+	})
+
+	subscriber := &failingSubscriber{failures: 2}
+	webhook := notifications.NewWebhook(subscriber, "https://backend.example.com/api/v1/webhook")
+	sut := spvwallet.NewWebhookSubscription(webhook, &testLogger)
+
+	// Act
+	done := make(chan struct{})
+	go func() {
+		sut.Run(context.Background())
+		close(done)
+	}()
+
+	// Assert
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timeout while waiting for webhook subscription")
+	}
+	assert.Equal(t, 3, subscriber.attempts)
+}
+
+func TestWebhookSubscription_Run_StopsWhenContextCanceled(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	viper.Set(config.EnvWebhookSubscribeTimeout, time.Second)
+	viper.Set(config.EnvWebhookSubscribeRetryInterval, time.Hour)
+	t.Cleanup(func() {
+		viper.Set(config.EnvWebhookSubscribeTimeout, nil)
+		viper.Set(config.EnvWebhookSubscribeRetryInterval, nil)
+	})
+
+	webhook := notifications.NewWebhook(&failingSubscriber{failures: 1}, "https://backend.example.com/api/v1/webhook")
+	sut := spvwallet.NewWebhookSubscription(webhook, &testLogger)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Act
+	done := make(chan struct{})
+	go func() {
+		sut.Run(ctx)
+		close(done)
+	}()
+	cancel()
+
+	// Assert
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("subscription did not stop when context was canceled")
+	}
+}
+
+// failingSubscriber fails the given number of subscriptions before it accepts the webhook.
+type failingSubscriber struct {
+	failures int
+	attempts int
+}
+
+func (s *failingSubscriber) AdminSubscribeWebhook(_ context.Context, _ *commands.CreateWebhookSubscription) error {
+	s.attempts++
+	if s.attempts <= s.failures {
+		return errors.New("spv-wallet unavailable")
+	}
+	return nil
+}
+
+func (s *failingSubscriber) AdminUnsubscribeWebhook(_ context.Context, _ *commands.CancelWebhookSubscription) error {
+	return nil
+}
+
+func (s *failingSubscriber) AdminGetAllWebhooks(_ context.Context) ([]*notifications.Webhook, error) {
+	return nil, nil
+}
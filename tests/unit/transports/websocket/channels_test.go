@@ -0,0 +1,37 @@
+package websocket_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/websocket"
+)
+
+// TestCanSubscribe tests if user can join only own and public channels.
+func TestCanSubscribe(t *testing.T) {
+	cases := []struct {
+		name    string
+		channel string
+		allowed bool
+	}{
+		{name: "Own transactions channel", channel: "user:1:transactions", allowed: true},
+		{name: "Own contacts channel", channel: "user:1:contacts", allowed: true},
+		{name: "Rates channel", channel: websocket.RatesChannel, allowed: true},
+		{name: "Broadcast channel", channel: websocket.BroadcastChannel, allowed: true},
+		{name: "Other user's channel", channel: "user:2:transactions", allowed: false},
+		{name: "Other user's channel with same prefix", channel: "user:11:transactions", allowed: false},
+		{name: "Unknown channel", channel: "admin", allowed: false},
+		{name: "Own channel without category", channel: "user:1", allowed: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			allowed := websocket.CanSubscribe("1", tc.channel)
+
+			// Assert
+			assert.Equal(t, tc.allowed, allowed)
+		})
+	}
+}
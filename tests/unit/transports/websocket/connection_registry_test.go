@@ -0,0 +1,127 @@
+package websocket_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/websocket"
+)
+
+func TestConnectionRegistry_StartsAndStopsWatchersOncePerUser(t *testing.T) {
+	// Arrange
+	sut := websocket.NewConnectionRegistry()
+	var started, stopped int
+	startWatchers := func() context.CancelFunc {
+		started++
+		return func() { stopped++ }
+	}
+
+	// Act & Assert
+	sut.Add(websocket.ConnectionInfo{ClientID: "client-1", UserID: "1", UserAgent: "firefox"}, startWatchers)
+	sut.Add(websocket.ConnectionInfo{ClientID: "client-2", UserID: "1", UserAgent: "chrome"}, startWatchers)
+	assert.Equal(t, 1, started)
+	assert.Equal(t, 2, sut.Count())
+
+	sut.Remove("client-1", "1")
+	assert.Equal(t, 0, stopped)
+	assert.True(t, sut.IsConnected("1"))
+
+	sut.Remove("client-2", "1")
+	assert.Equal(t, 1, stopped)
+	assert.False(t, sut.IsConnected("1"))
+	assert.Equal(t, 0, sut.Count())
+}
+
+func TestConnectionRegistry_KeepsConnectionMetadata(t *testing.T) {
+	// Arrange
+	sut := websocket.NewConnectionRegistry()
+	sut.Add(websocket.ConnectionInfo{ClientID: "client-1", UserID: "1", UserAgent: "firefox", SessionID: "session"}, nil)
+	connected := sut.Connections()[0]
+
+	// Act
+	sut.Touch("client-1", "1")
+
+	// Assert
+	connections := sut.Connections()
+	assert.Len(t, connections, 1)
+	assert.Equal(t, "client-1", connections[0].ClientID)
+	assert.Equal(t, "1", connections[0].UserID)
+	assert.Equal(t, "firefox", connections[0].UserAgent)
+	assert.Equal(t, "session", connections[0].SessionID)
+	assert.Equal(t, connected.ConnectedAt, connections[0].ConnectedAt)
+	assert.False(t, connections[0].LastActivity.Before(connected.LastActivity))
+}
+
+func TestConnectionRegistry_ReturnsConnectionsForWatchers(t *testing.T) {
+	// Arrange
+	sut := websocket.NewConnectionRegistry()
+	latestOnStart := make(chan websocket.ConnectionInfo, 1)
+	startWatchers := func() context.CancelFunc {
+		// Watchers run in background, as the registry is locked while they are started.
+		go func() {
+			conn, _ := sut.LatestConnection("1")
+			latestOnStart <- conn
+		}()
+		return func() {}
+	}
+
+	// Act & Assert
+	sut.Add(websocket.ConnectionInfo{ClientID: "client-1", UserID: "1", AccessKey: "access-key-1"}, startWatchers)
+	assert.Equal(t, "access-key-1", (<-latestOnStart).AccessKey)
+
+	time.Sleep(time.Millisecond)
+	sut.Add(websocket.ConnectionInfo{ClientID: "client-2", UserID: "1", AccessKey: "access-key-2"}, startWatchers)
+	latest, ok := sut.LatestConnection("1")
+	assert.True(t, ok)
+	assert.Equal(t, "client-2", latest.ClientID)
+
+	sut.Remove("client-2", "1")
+	_, ok = sut.UserConnection("1", "client-2")
+	assert.False(t, ok)
+	conn, ok := sut.UserConnection("1", "client-1")
+	assert.True(t, ok)
+	assert.Equal(t, "access-key-1", conn.AccessKey)
+
+	sut.Remove("client-1", "1")
+	_, ok = sut.LatestConnection("1")
+	assert.False(t, ok)
+}
+
+func TestConnectionRegistry_ConcurrentAccess(t *testing.T) {
+	// Arrange
+	sut := websocket.NewConnectionRegistry()
+	var running atomic.Int64
+	startWatchers := func() context.CancelFunc {
+		running.Add(1)
+		return func() { running.Add(-1) }
+	}
+
+	// Act
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		userID := fmt.Sprint(i % 5)
+		clientID := fmt.Sprint("client-", i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sut.Add(websocket.ConnectionInfo{ClientID: clientID, UserID: userID}, startWatchers)
+			sut.Touch(clientID, userID)
+			_ = sut.UserIDs()
+			_ = sut.Connections()
+			_ = sut.Count()
+			sut.Remove(clientID, userID)
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, 0, sut.Count())
+	assert.Empty(t, sut.UserIDs())
+	assert.Equal(t, int64(0), running.Load())
+}
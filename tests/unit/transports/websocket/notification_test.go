@@ -0,0 +1,108 @@
+package websocket_test
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/centrifugal/centrifuge"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/events"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/rates"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/transactions"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/notification"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/websocket"
+)
+
+func TestNotify_StoresEventsOfDisconnectedUserInHistory(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	viper.Set(config.EnvWebsocketHistoryMax, 2)
+	defer viper.Set(config.EnvWebsocketHistoryMax, nil)
+	viper.Set(config.EnvWebsocketHistoryTTL, 1)
+	defer viper.Set(config.EnvWebsocketHistoryTTL, nil)
+
+	sut := startServer(t, &testLogger)
+	defer sut.Shutdown() //nolint:errcheck // test cleanup
+
+	// Act
+	for _, message := range []string{"first", "second", "third"} {
+		sut.GetSocket(websocket.UserTransactionsChannel("1")).Notify(notification.PrepareWalletMessageEvent(message))
+	}
+
+	// Assert
+	history, err := sut.GetNode().History("user:1:transactions", centrifuge.WithLimit(centrifuge.NoLimit))
+	require.NoError(t, err)
+	require.Len(t, history.Publications, 2)
+	assert.Contains(t, string(history.Publications[0].Data), "second")
+	assert.Contains(t, string(history.Publications[1].Data), "third")
+	assert.Equal(t, uint64(3), history.Offset)
+}
+
+func TestNotify_ConcurrentNotifies(t *testing.T) {
+	// Arrange
+	testLogger := zerolog.Nop()
+	viper.Set(config.EnvWebsocketHistoryMax, 10)
+	defer viper.Set(config.EnvWebsocketHistoryMax, nil)
+	viper.Set(config.EnvWebsocketHistoryTTL, 1)
+	defer viper.Set(config.EnvWebsocketHistoryTTL, nil)
+
+	sut := startServer(t, &testLogger)
+	defer sut.Shutdown() //nolint:errcheck // test cleanup
+
+	// Act
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sut.GetSocket(websocket.UserTransactionsChannel("1")).Notify(notification.PrepareWalletMessageEvent("message"))
+			_ = sut.GetConnections()
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	history, err := sut.GetNode().History("user:1:transactions", centrifuge.WithLimit(centrifuge.NoLimit))
+	require.NoError(t, err)
+	assert.Len(t, history.Publications, 10)
+	assert.Equal(t, uint64(20), history.Offset)
+}
+
+func TestNotify_LogsOnlyEventTypeAndChannel(t *testing.T) {
+	// Arrange
+	var logs bytes.Buffer
+	testLogger := zerolog.New(&logs).Level(zerolog.DebugLevel)
+	viper.Set(config.EnvWebsocketHistoryMax, 10)
+	defer viper.Set(config.EnvWebsocketHistoryMax, nil)
+	viper.Set(config.EnvWebsocketHistoryTTL, 1)
+	defer viper.Set(config.EnvWebsocketHistoryTTL, nil)
+
+	sut := startServer(t, &testLogger)
+	defer sut.Shutdown() //nolint:errcheck // test cleanup
+
+	// Act
+	sut.GetSocket(websocket.UserTransactionsChannel("1")).Notify(notification.PrepareWalletMessageEvent("private message"))
+
+	// Assert
+	assert.NotContains(t, logs.String(), "private message")
+	assert.Contains(t, logs.String(), `"level":"debug","service":"websocket","message":"Event wallet_message published to websocket channel user:1:transactions"`)
+}
+
+func startServer(t *testing.T, log *zerolog.Logger) websocket.Server {
+	services := &domain.Services{
+		TransactionTracker: transactions.NewTracker(nil, nil, log),
+		EventsService:      events.NewEventsService(nil, log),
+		RatesService:       new(rates.Service),
+	}
+	server, err := websocket.NewServer(log, services, nil)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	return server
+}
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+)
+
+// AdminMiddleware middleware that is checking the admin token passed as a bearer token.
+type AdminMiddleware struct {
+	token string
+	log   *zerolog.Logger
+}
+
+// NewAdminMiddleware create middleware that is checking the admin token.
+func NewAdminMiddleware(logger *zerolog.Logger) *AdminMiddleware {
+	log := logger.With().Str("service", "admin-middleware").Logger()
+	return &AdminMiddleware{
+		token: viper.GetString(config.EnvHTTPServerAdminToken),
+		log:   &log,
+	}
+}
+
+// ApplyToAPI is a middleware which aborts requests without valid admin token.
+// When admin token is not configured, all requests are rejected.
+func (h *AdminMiddleware) ApplyToAPI(c *gin.Context) {
+	token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if h.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+		spverrors.AbortWithErrorResponse(c, spverrors.ErrUnauthorized, h.log)
+		return
+	}
+	c.Next()
+}
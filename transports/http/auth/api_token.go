@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Variables set for requests authorized by API token, in addition to session variables.
+const (
+	APITokenID     = "apiTokenId"
+	APITokenScopes = "apiTokenScopes"
+)
+
+// bearerPrefix is a prefix of the Authorization header carrying API token.
+const bearerPrefix = "Bearer "
+
+// bearerToken returns API token from the Authorization header of the request.
+func bearerToken(c *gin.Context) (string, bool) {
+	header := c.GetHeader("Authorization")
+	if len(header) <= len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return "", false
+	}
+	return strings.TrimSpace(header[len(bearerPrefix):]), true
+}
+
+// IsAPITokenRequest checks if the request is authorized by API token instead of the session.
+func IsAPITokenRequest(c *gin.Context) bool {
+	return c.GetInt(APITokenID) != 0
+}
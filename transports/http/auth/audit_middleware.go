@@ -0,0 +1,19 @@
+package auth
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/audit"
+)
+
+// AuditOriginMiddleware adds IP and user agent of the request to context, so services can record them in the audit log.
+func AuditOriginMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := audit.WithOrigin(c.Request.Context(), &audit.Origin{
+			IP:        c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/audit"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
 )
@@ -40,7 +41,14 @@ func NewAuthMiddleware(s *domain.Services, logger *zerolog.Logger) *Middleware {
 }
 
 // ApplyToAPI is a middleware which checks if the validity of variables in session.
+// Session which did not expire is renewed by the request, so it expires after inactivity of the user.
+// Requests with API token in the Authorization header are authorized by the token instead of the session.
 func (h *Middleware) ApplyToAPI(c *gin.Context) {
+	if token, ok := bearerToken(c); ok {
+		h.applyAPIToken(c, token)
+		return
+	}
+
 	session := sessions.Default(c)
 
 	accessKeyID, accessKey, userID, paymail, xPriv, err := h.authorizeSession(session)
@@ -49,11 +57,41 @@ func (h *Middleware) ApplyToAPI(c *gin.Context) {
 		return
 	}
 
+	if err = renewSession(session); err != nil {
+		h.log.Warn().Msgf("Session wasn't renewed: %s", err)
+	}
+
 	c.Set(SessionAccessKeyID, accessKeyID)
 	c.Set(SessionAccessKey, accessKey)
 	c.Set(SessionUserID, userID)
 	c.Set(SessionUserPaymail, paymail)
 	c.Set(SessionXPriv, xPriv)
+	c.Set(SessionUserRole, sessionUserRole(session.Get(SessionUserRole)))
+	c.Request = c.Request.WithContext(audit.WithUser(c.Request.Context(), userID.(int)))
+}
+
+// applyAPIToken sets the same variables as the session for the user of API token, with scopes of the token.
+func (h *Middleware) applyAPIToken(c *gin.Context, token string) {
+	authorized, err := h.services.UsersService.AuthorizeAPIToken(c.Request.Context(), token)
+	if err != nil {
+		spverrors.AbortWithErrorResponse(c, spverrors.ErrUnauthorized, h.log)
+		return
+	}
+
+	if err = h.checkAccessKey(authorized.AccessKey, authorized.Token.AccessKeyID); err != nil {
+		spverrors.AbortWithErrorResponse(c, spverrors.ErrUnauthorized, h.log)
+		return
+	}
+
+	c.Set(SessionAccessKeyID, authorized.Token.AccessKeyID)
+	c.Set(SessionAccessKey, authorized.AccessKey)
+	c.Set(SessionUserID, authorized.User.ID)
+	c.Set(SessionUserPaymail, authorized.User.Paymail)
+	c.Set(SessionXPriv, authorized.Xpriv)
+	c.Set(SessionUserRole, authorized.User.Role)
+	c.Set(APITokenID, authorized.Token.ID)
+	c.Set(APITokenScopes, authorized.Token.Scopes)
+	c.Request = c.Request.WithContext(audit.WithUser(c.Request.Context(), authorized.User.ID))
 }
 
 func (h *Middleware) authorizeSession(s sessions.Session) (accessKeyID, accessKey, userID, paymail, xPriv interface{}, err error) {
@@ -70,11 +108,21 @@ func (h *Middleware) authorizeSession(s sessions.Session) (accessKeyID, accessKe
 		return nil, nil, nil, nil, nil, ErrUnauthorized
 	}
 
+	err = checkSessionExpiry(s)
+	if err != nil {
+		return nil, nil, nil, nil, nil, fmt.Errorf("%w: %w", ErrUnauthorized, err)
+	}
+
 	err = h.checkAccessKey(accessKey.(string), accessKeyID.(string))
 	if err != nil {
 		return nil, nil, nil, nil, nil, fmt.Errorf("%w: %w", ErrUnauthorized, err)
 	}
 
+	err = h.checkUser(userID, s.Get(SessionSignedInAt))
+	if err != nil {
+		return nil, nil, nil, nil, nil, fmt.Errorf("%w: %w", ErrUnauthorized, err)
+	}
+
 	return accessKeyID, accessKey, userID, paymail, xPriv, err
 }
 
@@ -82,6 +130,17 @@ func isNilOrEmpty(s interface{}) bool {
 	return s == nil || s == ""
 }
 
+// checkUser checks if user of the session is not disabled and the session was not revoked.
+//
+//nolint:wrapcheck // error wrapped higher
+func (h *Middleware) checkUser(userID, signedInAt interface{}) error {
+	id, ok := userID.(int)
+	if !ok {
+		return ErrUnauthorized
+	}
+	return h.services.UsersService.AuthorizeSession(id, sessionSignedInAt(signedInAt))
+}
+
 //nolint:wrapcheck // error wrapped higher
 func (h *Middleware) checkAccessKey(accessKey, accessKeyID string) error {
 	userWalletClient, err := h.walletClientFactory.CreateWithAccessKey(accessKey)
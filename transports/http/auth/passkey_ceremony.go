@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+)
+
+// Passkey ceremonies which state is kept in the session between their begin and finish.
+const (
+	PasskeyRegistration = "passkeyRegistration"
+	PasskeySignIn       = "passkeySignIn"
+	PasskeyConfirmation = "passkeyConfirmation"
+)
+
+// SavePasskeyCeremony keeps state of the passkey ceremony in current (default) session.
+func SavePasskeyCeremony(c *gin.Context, ceremony, state string) error {
+	session := sessions.Default(c)
+	session.Set(ceremony, state)
+	err := session.Save()
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	return nil
+}
+
+// TakePasskeyCeremony returns state of the passkey ceremony and removes it from current (default) session,
+// so every ceremony can be finished only once.
+func TakePasskeyCeremony(c *gin.Context, ceremony string) string {
+	session := sessions.Default(c)
+	state, _ := session.Get(ceremony).(string)
+	if state == "" {
+		return ""
+	}
+	session.Delete(ceremony)
+	_ = session.Save()
+	return state
+}
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+)
+
+// Endpoint identifies API endpoint by HTTP method and full path as registered in the router.
+type Endpoint struct {
+	Method string
+	Path   string
+}
+
+// RoleMiddleware middleware that is checking if role of the signed in user allows to call the endpoint.
+type RoleMiddleware struct {
+	viewerEndpoints map[Endpoint]bool
+	log             *zerolog.Logger
+}
+
+// NewRoleMiddleware create middleware allowing owners to call all endpoints and viewers only the given ones.
+// It has to be applied after the auth middleware, which sets role of the session user.
+func NewRoleMiddleware(viewerEndpoints []Endpoint, logger *zerolog.Logger) *RoleMiddleware {
+	log := logger.With().Str("service", "role-middleware").Logger()
+	endpoints := make(map[Endpoint]bool, len(viewerEndpoints))
+	for _, endpoint := range viewerEndpoints {
+		endpoints[endpoint] = true
+	}
+	return &RoleMiddleware{
+		viewerEndpoints: endpoints,
+		log:             &log,
+	}
+}
+
+// ApplyToAPI is a middleware which aborts requests of viewers to endpoints not allowed for them.
+func (h *RoleMiddleware) ApplyToAPI(c *gin.Context) {
+	if c.GetString(SessionUserRole) == users.RoleOwner {
+		return
+	}
+	if !h.viewerEndpoints[Endpoint{Method: c.Request.Method, Path: c.FullPath()}] {
+		spverrors.AbortWithErrorResponse(c, spverrors.ErrForbidden, h.log)
+	}
+}
@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+)
+
+// ScopeMiddleware middleware that is checking if scopes of API token allow to call the endpoint.
+type ScopeMiddleware struct {
+	scopeEndpoints map[Endpoint][]string
+	log            *zerolog.Logger
+}
+
+// NewScopeMiddleware create middleware allowing requests authorized by API token to call only endpoints of its scopes.
+// Requests authorized by the session are not restricted. It has to be applied after the auth middleware.
+func NewScopeMiddleware(scopeEndpoints map[string][]Endpoint, logger *zerolog.Logger) *ScopeMiddleware {
+	log := logger.With().Str("service", "scope-middleware").Logger()
+	endpoints := make(map[Endpoint][]string)
+	for scope, scopeEndpoints := range scopeEndpoints {
+		for _, endpoint := range scopeEndpoints {
+			endpoints[endpoint] = append(endpoints[endpoint], scope)
+		}
+	}
+	return &ScopeMiddleware{
+		scopeEndpoints: endpoints,
+		log:            &log,
+	}
+}
+
+// ApplyToAPI is a middleware which aborts requests authorized by API token to endpoints not allowed by its scopes.
+func (h *ScopeMiddleware) ApplyToAPI(c *gin.Context) {
+	if !IsAPITokenRequest(c) {
+		return
+	}
+	tokenScopes := c.GetStringSlice(APITokenScopes)
+	for _, scope := range h.scopeEndpoints[Endpoint{Method: c.Request.Method, Path: c.FullPath()}] {
+		if slices.Contains(tokenScopes, scope) {
+			return
+		}
+	}
+	spverrors.AbortWithErrorResponse(c, spverrors.ErrAPITokenScope, h.log)
+}
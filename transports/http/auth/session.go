@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"time"
+
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
@@ -9,13 +11,19 @@ import (
 )
 
 // UpdateSession updates session with accessKeyId and userId.
-func UpdateSession(c *gin.Context, authUser *users.AuthenticatedUser) error {
+// Session of the user who chose to be remembered has longer idle and absolute lifetime.
+func UpdateSession(c *gin.Context, authUser *users.AuthenticatedUser, rememberMe bool) error {
 	session := sessions.Default(c)
 	session.Set(SessionAccessKeyID, authUser.AccessKey.ID)
 	session.Set(SessionAccessKey, authUser.AccessKey.Key)
 	session.Set(SessionUserID, authUser.User.ID)
 	session.Set(SessionUserPaymail, authUser.User.Paymail)
 	session.Set(SessionXPriv, authUser.Xpriv)
+	session.Set(SessionSignedInAt, authUser.SignedInAt.UnixMicro())
+	session.Set(SessionUserRole, authUser.User.Role)
+	session.Set(SessionLastActiveAt, authUser.SignedInAt.UnixMicro())
+	session.Set(SessionRememberMe, rememberMe)
+	session.Options(sessionOptions(time.Until(sessionExpiresAt(session))))
 	err := session.Save()
 	if err != nil {
 		return errors.Wrap(err, "internal error")
@@ -24,6 +32,52 @@ func UpdateSession(c *gin.Context, authUser *users.AuthenticatedUser) error {
 	return nil
 }
 
+// UpdateSessionPaymail updates paymail used as sender in current (default) session.
+func UpdateSessionPaymail(c *gin.Context, paymail string) error {
+	session := sessions.Default(c)
+	session.Set(SessionUserPaymail, paymail)
+	err := session.Save()
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	c.Set(SessionUserPaymail, paymail)
+	return nil
+}
+
+// UpdateSessionXPriv updates xpriv unlocked in current (default) session.
+func UpdateSessionXPriv(c *gin.Context, xPriv string) error {
+	session := sessions.Default(c)
+	session.Set(SessionXPriv, xPriv)
+	err := session.Save()
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	c.Set(SessionXPriv, xPriv)
+	return nil
+}
+
+// SessionID returns identifier of current (default) session.
+func SessionID(c *gin.Context) string {
+	return sessions.Default(c).ID()
+}
+
+// sessionSignedInAt returns time when user of the session signed in, sessions created before
+// the sign in time was stored are treated as started at the beginning of unix time.
+func sessionSignedInAt(value interface{}) time.Time {
+	signedInAt, _ := value.(int64)
+	return time.UnixMicro(signedInAt).UTC()
+}
+
+// sessionUserRole returns role of the user of the session, sessions created before
+// roles were stored belong to owners, as there were no other users then.
+func sessionUserRole(value interface{}) string {
+	role, _ := value.(string)
+	if role == "" {
+		return users.RoleOwner
+	}
+	return role
+}
+
 // TerminateSession terminates current (default) session.
 func TerminateSession(c *gin.Context) error {
 	session := sessions.Default(c)
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+)
+
+const deleteExpiredSessions = `
+	DELETE FROM http_sessions
+	WHERE expires_on < $1
+	`
+
+// SessionCleaner deletes expired sessions from the database, as the store only rejects them on load.
+type SessionCleaner struct {
+	db  *sql.DB
+	log *zerolog.Logger
+}
+
+// NewSessionCleaner creates cleaner of sessions kept in database.
+func NewSessionCleaner(db *sql.DB, logger *zerolog.Logger) *SessionCleaner {
+	log := logger.With().Str("service", "session-cleaner").Logger()
+	return &SessionCleaner{
+		db:  db,
+		log: &log,
+	}
+}
+
+// Run periodically deletes expired sessions until the context is canceled.
+func (c *SessionCleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(viper.GetDuration(config.EnvHTTPServerSessionCleanupInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Cleanup(ctx)
+		}
+	}
+}
+
+// Cleanup deletes sessions which expired.
+func (c *SessionCleaner) Cleanup(ctx context.Context) {
+	result, err := c.db.ExecContext(ctx, deleteExpiredSessions, time.Now().UTC())
+	if err != nil {
+		c.log.Error().Msgf("Error while deleting expired sessions: %v", err.Error())
+		return
+	}
+
+	if deleted, err := result.RowsAffected(); err == nil && deleted > 0 {
+		c.log.Debug().Msgf("Deleted %d expired sessions", deleted)
+	}
+}
@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+)
+
+// ErrSessionExpired is thrown if session of the user expired due to inactivity or its absolute lifetime.
+var ErrSessionExpired = errors.New("session expired")
+
+// sessionRenewalInterval limits how often activity of the user is stored in the session,
+// so not every request to the API writes the session to the database.
+const sessionRenewalInterval = time.Minute
+
+// sessionValues gives access to values of the session regardless of the session implementation.
+type sessionValues interface {
+	Get(key interface{}) interface{}
+}
+
+// storedValues are values of the session loaded directly from the store.
+type storedValues map[interface{}]interface{}
+
+// Get returns value of the session stored under the key.
+func (v storedValues) Get(key interface{}) interface{} {
+	return v[key]
+}
+
+// sessionLifetime defines how long session lives without activity and since the sign in.
+type sessionLifetime struct {
+	idle     time.Duration
+	absolute time.Duration
+}
+
+// lifetimeOf returns lifetime of the session, user who chose to be remembered has longer sessions.
+func lifetimeOf(rememberMe bool) sessionLifetime {
+	if rememberMe {
+		return sessionLifetime{
+			idle:     viper.GetDuration(config.EnvHTTPServerSessionRememberMeIdleTimeout),
+			absolute: viper.GetDuration(config.EnvHTTPServerSessionRememberMeAbsoluteTimeout),
+		}
+	}
+	return sessionLifetime{
+		idle:     viper.GetDuration(config.EnvHTTPServerSessionIdleTimeout),
+		absolute: viper.GetDuration(config.EnvHTTPServerSessionAbsoluteTimeout),
+	}
+}
+
+// expiresAt returns time when session expires, which is the earlier of the idle and the absolute deadline.
+func (l sessionLifetime) expiresAt(signedInAt, lastActiveAt time.Time) time.Time {
+	idleDeadline := lastActiveAt.Add(l.idle)
+	absoluteDeadline := signedInAt.Add(l.absolute)
+	if idleDeadline.Before(absoluteDeadline) {
+		return idleDeadline
+	}
+	return absoluteDeadline
+}
+
+// sessionExpiresAt returns time when session of signed-in user expires.
+// Sessions created before the activity was stored are treated as last active when the user signed in.
+func sessionExpiresAt(s sessionValues) time.Time {
+	signedInAt := sessionSignedInAt(s.Get(SessionSignedInAt))
+	lastActiveAt := signedInAt
+	if value, ok := s.Get(SessionLastActiveAt).(int64); ok {
+		lastActiveAt = time.UnixMicro(value).UTC()
+	}
+	return lifetimeOf(sessionRememberMe(s.Get(SessionRememberMe))).expiresAt(signedInAt, lastActiveAt)
+}
+
+// sessionRememberMe returns whether user of the session chose to be remembered.
+func sessionRememberMe(value interface{}) bool {
+	rememberMe, _ := value.(bool)
+	return rememberMe
+}
+
+// checkSessionExpiry checks if session of signed-in user did not expire.
+func checkSessionExpiry(s sessionValues) error {
+	if !time.Now().Before(sessionExpiresAt(s)) {
+		return ErrSessionExpired
+	}
+	return nil
+}
+
+// renewSession stores activity of the user in the session, which postpones its idle deadline.
+// Session is saved at most once per renewal interval, with cookie expiring together with the session.
+func renewSession(s sessions.Session) error {
+	now := time.Now().UTC()
+	if lastActiveAt, ok := s.Get(SessionLastActiveAt).(int64); ok && now.Sub(time.UnixMicro(lastActiveAt)) < sessionRenewalInterval {
+		return nil
+	}
+
+	s.Set(SessionLastActiveAt, now.UnixMicro())
+	s.Options(sessionOptions(time.Until(sessionExpiresAt(s))))
+	return s.Save() //nolint:wrapcheck // error wrapped higher
+}
+
+// applySessionLifetime makes cookie and stored session of the request expire together with the session,
+// sessions without signed-in user, e.g. during passkey ceremonies, live as long as the idle timeout.
+func applySessionLifetime(c *gin.Context) {
+	session := sessions.Default(c)
+	if session.Get(SessionSignedInAt) == nil {
+		session.Options(sessionOptions(viper.GetDuration(config.EnvHTTPServerSessionIdleTimeout)))
+	} else {
+		session.Options(sessionOptions(time.Until(sessionExpiresAt(session))))
+	}
+	c.Next()
+}
+
+// sessionOptions returns options of the session cookie expiring after maxAge,
+// cookie of already expired session is removed.
+func sessionOptions(maxAge time.Duration) sessions.Options {
+	seconds := int(math.Ceil(maxAge.Seconds()))
+	if seconds <= 0 {
+		seconds = -1
+	}
+
+	// If we're running on localhost, we need to set domain to empty string.
+	domain := viper.GetString(config.EnvHTTPServerCookieDomain)
+	if domain == "localhost" {
+		domain = ""
+	}
+
+	return sessions.Options{
+		MaxAge:   seconds,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   viper.GetBool(config.EnvHTTPServerCookieSecure),
+		SameSite: cookieSameSite(viper.GetString(config.EnvHTTPServerCookieSameSite)),
+		Domain:   domain,
+	}
+}
+
+// cookieSameSite returns same site mode of the session cookie, strict unless configured otherwise.
+func cookieSameSite(value string) http.SameSite {
+	switch strings.ToLower(value) {
+	case "lax":
+		return http.SameSiteLaxMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteStrictMode
+	}
+}
+
+// storeMaxAge returns default lifetime of the sessions in the store.
+// The store rejects sessions not saved for longer than its default lifetime, so it has to cover
+// the longest inactivity of the user, while each session expires on its own deadline set per request.
+func storeMaxAge() time.Duration {
+	return max(lifetimeOf(false).idle, lifetimeOf(true).idle)
+}
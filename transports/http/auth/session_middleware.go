@@ -2,7 +2,6 @@ package auth
 
 import (
 	"database/sql"
-	"net/http"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-contrib/sessions/postgres"
@@ -15,40 +14,37 @@ import (
 
 // Session variables.
 const (
-	SessionAccessKeyID = "accessKeyId"
-	SessionAccessKey   = "accessKey"
-	SessionUserID      = "userId"
-	SessionUserPaymail = "paymail"
-	SessionXPriv       = "xPriv"
+	SessionAccessKeyID  = "accessKeyId"
+	SessionAccessKey    = "accessKey"
+	SessionUserID       = "userId"
+	SessionUserPaymail  = "paymail"
+	SessionXPriv        = "xPriv"
+	SessionSignedInAt   = "signedInAt"
+	SessionUserRole     = "role"
+	SessionLastActiveAt = "lastActiveAt"
+	SessionRememberMe   = "rememberMe"
 )
 
+// sessionName is a name of the session cookie.
+const sessionName = "Authorization"
+
 // NewSessionMiddleware create Session middleware that is retrieving auth token from cookie.
+// Cookie and stored session of each request expire together with the session.
 func NewSessionMiddleware(db *sql.DB, engine *gin.Engine) router.APIMiddlewareFunc {
+	store := NewSessionStore(db)
+	engine.Use(sessions.Sessions(sessionName, store), applySessionLifetime)
+
+	return router.APIMiddlewareFunc(sessions.Sessions(sessionName, store))
+}
+
+// NewSessionStore creates store keeping sessions in database.
+func NewSessionStore(db *sql.DB) postgres.Store {
 	secret := viper.GetString(config.EnvHTTPServerSessionSecret)
 	store, err := postgres.NewStore(db, []byte(secret))
 	if err != nil {
 		panic(err)
 	}
 
-	// If we're running on localhost, we need to set domain to empty string.
-	domain := viper.GetString(config.EnvHTTPServerCookieDomain)
-	if domain == "localhost" {
-		domain = ""
-	}
-
-	secure := viper.GetBool(config.EnvHTTPServerCookieSecure)
-
-	options := sessions.Options{
-		MaxAge:   1800,
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   secure,
-		SameSite: http.SameSiteStrictMode,
-		Domain:   domain,
-	}
-
-	store.Options(options)
-	engine.Use(sessions.Sessions("Authorization", store))
-
-	return router.APIMiddlewareFunc(sessions.Sessions("Authorization", store))
+	store.Options(sessionOptions(storeMaxAge()))
+	return store
 }
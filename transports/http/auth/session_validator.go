@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/rs/zerolog"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain"
+)
+
+// ErrSessionRevoked is thrown if session of long living connection expired or user signed out.
+var ErrSessionRevoked = errors.New("session expired or revoked")
+
+// SessionValidator re-validates sessions of long living connections, e.g. websocket connections.
+type SessionValidator struct {
+	store sessions.Store
+	auth  *Middleware
+}
+
+// NewSessionValidator creates validator of sessions kept in the store.
+func NewSessionValidator(store sessions.Store, s *domain.Services, log *zerolog.Logger) *SessionValidator {
+	return &SessionValidator{
+		store: store,
+		auth:  NewAuthMiddleware(s, log),
+	}
+}
+
+// Validate checks if session of the request still belongs to the user and did not expire, its access key is still valid
+// and the user was neither disabled nor signed out by an admin.
+// Session is loaded directly from the store, because session cached for the request is not updated on sign-out.
+func (v *SessionValidator) Validate(r *http.Request, userID string) error {
+	session, err := v.store.New(r, sessionName)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSessionRevoked, err)
+	}
+	if session.IsNew {
+		return ErrSessionRevoked
+	}
+
+	sessionUserID, ok := session.Values[SessionUserID].(int)
+	if !ok || strconv.Itoa(sessionUserID) != userID {
+		return ErrSessionRevoked
+	}
+
+	if err = checkSessionExpiry(storedValues(session.Values)); err != nil {
+		return fmt.Errorf("%w: %w", ErrSessionRevoked, err)
+	}
+
+	accessKey, _ := session.Values[SessionAccessKey].(string)
+	accessKeyID, _ := session.Values[SessionAccessKeyID].(string)
+	if accessKey == "" || accessKeyID == "" {
+		return ErrSessionRevoked
+	}
+
+	if err = v.auth.checkAccessKey(accessKey, accessKeyID); err != nil {
+		return fmt.Errorf("%w: %w", ErrSessionRevoked, err)
+	}
+	if err = v.auth.checkUser(sessionUserID, session.Values[SessionSignedInAt]); err != nil {
+		return fmt.Errorf("%w: %w", ErrSessionRevoked, err)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package access
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
@@ -11,18 +12,21 @@ import (
 	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/auth"
 	router "github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/routes"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/websocket"
 )
 
 type handler struct {
 	service *users.UserService
 	log     *zerolog.Logger
+	ws      websocket.Server
 }
 
 // NewHandler creates new endpoint handler.
-func NewHandler(s *domain.Services, log *zerolog.Logger) (router.RootEndpoints, router.APIEndpoints) {
+func NewHandler(s *domain.Services, log *zerolog.Logger, ws websocket.Server) (router.RootEndpoints, router.APIEndpoints) {
 	h := &handler{
 		service: s.UsersService,
 		log:     log,
+		ws:      ws,
 	}
 
 	prefix := "/api/v1"
@@ -56,13 +60,13 @@ func (h *handler) signIn(c *gin.Context) {
 		return
 	}
 
-	signInUser, err := h.service.SignInUser(reqUser.Email, reqUser.Password)
+	signInUser, err := h.service.SignInUser(c.Request.Context(), reqUser.Email, reqUser.Password)
 	if err != nil {
 		spverrors.ErrorResponse(c, err, h.log)
 		return
 	}
 
-	err = auth.UpdateSession(c, signInUser)
+	err = auth.UpdateSession(c, signInUser, reqUser.RememberMe)
 	if err != nil {
 		h.log.Error().Msgf("Sign-in error. Session wasn't saved: %s", err)
 		spverrors.ErrorResponse(c, spverrors.ErrSessionUpdate, h.log)
@@ -86,7 +90,10 @@ func (h *handler) signIn(c *gin.Context) {
 //	@Router /api/v1/sign-out [post]
 func (h *handler) signOut(c *gin.Context) {
 	// Right now we cannot revoke access key without authentication with XPriv
-	// All we can do is to terminate session
+	// All we can do is to terminate session and close websocket connections opened within it
+
+	userID := c.GetInt(auth.SessionUserID)
+	sessionID := auth.SessionID(c)
 
 	err := auth.TerminateSession(c)
 	if err != nil {
@@ -95,5 +102,7 @@ func (h *handler) signOut(c *gin.Context) {
 		return
 	}
 
+	h.ws.DisconnectSession(strconv.Itoa(userID), sessionID)
+
 	c.Status(http.StatusOK)
 }
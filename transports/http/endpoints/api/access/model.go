@@ -4,8 +4,9 @@ import "github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
 
 // SignInUser is a struct that contains user sign in data.
 type SignInUser struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email      string `json:"email"`
+	Password   string `json:"password"`
+	RememberMe bool   `json:"rememberMe"`
 }
 
 // SignInResponse is a struct that represents struct sended after user sign in.
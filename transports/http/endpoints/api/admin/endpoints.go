@@ -0,0 +1,322 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/admin"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/audit"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/auth"
+	router "github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/routes"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/websocket"
+)
+
+// actorHeader is a header with name of the operator using the admin token, recorded in the audit trail.
+const actorHeader = "X-Admin-Actor"
+
+// defaultActor is recorded in the audit trail when the operator is not named.
+const defaultActor = "admin"
+
+type handler struct {
+	service      *admin.Service
+	auditService *audit.Service
+	ws           websocket.Server
+	log          *zerolog.Logger
+}
+
+// NewHandler creates new endpoint handler.
+func NewHandler(s *domain.Services, log *zerolog.Logger, ws websocket.Server) router.RootEndpoints {
+	return &handler{
+		service:      s.AdminService,
+		auditService: s.AuditService,
+		ws:           ws,
+		log:          log,
+	}
+}
+
+// RegisterEndpoints registers endpoints in root context of application, authorized by admin token.
+func (h *handler) RegisterEndpoints(router *gin.RouterGroup) {
+	admin := router.Group("/api/v1/admin", auth.NewAdminMiddleware(h.log).ApplyToAPI)
+	{
+		admin.GET("/websocket/connections", h.getWebsocketConnections)
+		admin.GET("/users", h.searchUsers)
+		admin.GET("/users/:id", h.getUser)
+		admin.POST("/users/:id/disable", h.disableUser)
+		admin.POST("/users/:id/enable", h.enableUser)
+		admin.POST("/users/:id/sign-out", h.signOutUser)
+		admin.GET("/actions", h.getActions)
+		admin.GET("/audit-events", h.getAuditEvents)
+	}
+}
+
+// Get live websocket connections.
+//
+//	@Summary Get live websocket connections of this instance
+//	@Tags admin
+//	@Produce json
+//	@Success 200 {object} WebsocketConnections
+//	@Router /api/v1/admin/websocket/connections [get]
+//	@Param Authorization header string true "Bearer admin token"
+func (h *handler) getWebsocketConnections(c *gin.Context) {
+	connections := h.ws.GetConnections()
+	c.JSON(http.StatusOK, WebsocketConnections{
+		Count:       len(connections),
+		Connections: connections,
+	})
+}
+
+// Search users.
+//
+//	@Summary Search users by email or paymail
+//	@Tags admin
+//	@Produce json
+//	@Success 200 {object} admin.UsersPage
+//	@Router /api/v1/admin/users [get]
+//	@Param Authorization header string true "Bearer admin token"
+//	@Param X-Admin-Actor header string false "Name of the operator recorded in the audit trail"
+//	@Param query query string false "Part of email or paymail, all users are returned when empty"
+//	@Param page query int false "Page number, starting from 1"
+//	@Param pageSize query int false "Page size, max 100"
+func (h *handler) searchUsers(c *gin.Context) {
+	page, pageSize := pagination(c)
+	users, err := h.service.SearchUsers(actor(c), c.Query("query"), page, pageSize)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+// Get user.
+//
+//	@Summary Get user with paymails and balance
+//	@Tags admin
+//	@Produce json
+//	@Success 200 {object} admin.UserDetails
+//	@Router /api/v1/admin/users/{id} [get]
+//	@Param Authorization header string true "Bearer admin token"
+//	@Param X-Admin-Actor header string false "Name of the operator recorded in the audit trail"
+//	@Param id path int true "User id"
+func (h *handler) getUser(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.service.GetUser(actor(c), userID)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// Disable user.
+//
+//	@Summary Disable user account and close its websocket connections
+//	@Tags admin
+//	@Success 200
+//	@Router /api/v1/admin/users/{id}/disable [post]
+//	@Param Authorization header string true "Bearer admin token"
+//	@Param X-Admin-Actor header string false "Name of the operator recorded in the audit trail"
+//	@Param id path int true "User id"
+func (h *handler) disableUser(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DisableUser(actor(c), userID); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+	h.ws.DisconnectUser(strconv.Itoa(userID))
+
+	c.Status(http.StatusOK)
+}
+
+// Enable user.
+//
+//	@Summary Enable disabled user account
+//	@Tags admin
+//	@Success 200
+//	@Router /api/v1/admin/users/{id}/enable [post]
+//	@Param Authorization header string true "Bearer admin token"
+//	@Param X-Admin-Actor header string false "Name of the operator recorded in the audit trail"
+//	@Param id path int true "User id"
+func (h *handler) enableUser(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.EnableUser(actor(c), userID); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// Sign out user.
+//
+//	@Summary Revoke all sessions of the user and close its websocket connections
+//	@Tags admin
+//	@Success 200
+//	@Router /api/v1/admin/users/{id}/sign-out [post]
+//	@Param Authorization header string true "Bearer admin token"
+//	@Param X-Admin-Actor header string false "Name of the operator recorded in the audit trail"
+//	@Param id path int true "User id"
+func (h *handler) signOutUser(c *gin.Context) {
+	userID, ok := h.userID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.SignOutUser(actor(c), userID); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+	h.ws.DisconnectUser(strconv.Itoa(userID))
+
+	c.Status(http.StatusOK)
+}
+
+// Get admin actions.
+//
+//	@Summary Get audit trail of admin actions, the newest first
+//	@Tags admin
+//	@Produce json
+//	@Success 200 {object} admin.ActionsPage
+//	@Router /api/v1/admin/actions [get]
+//	@Param Authorization header string true "Bearer admin token"
+//	@Param actor query string false "Name of the operator"
+//	@Param action query string false "Action, e.g. disable_user"
+//	@Param userId query int false "Id of the target user"
+//	@Param page query int false "Page number, starting from 1"
+//	@Param pageSize query int false "Page size, max 100"
+func (h *handler) getActions(c *gin.Context) {
+	filter := &admin.ActionsFilter{
+		Actor:  c.Query("actor"),
+		Action: c.Query("action"),
+	}
+	if userID := c.Query("userId"); userID != "" {
+		id, err := strconv.Atoi(userID)
+		if err != nil {
+			spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+			return
+		}
+		filter.TargetUserID = &id
+	}
+
+	page, pageSize := pagination(c)
+	actions, err := h.service.GetActions(filter, page, pageSize)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, actions)
+}
+
+// Get audit events.
+//
+//	@Summary Get security-relevant events of all users, the newest first
+//	@Tags admin
+//	@Produce json
+//	@Success 200 {object} audit.EventsPage
+//	@Router /api/v1/admin/audit-events [get]
+//	@Param Authorization header string true "Bearer admin token"
+//	@Param userId query int false "Id of the user"
+//	@Param action query string false "Action, e.g. sign_in"
+//	@Param result query string false "Result, success or failure"
+//	@Param from query string false "Only events recorded at or after this time, RFC3339"
+//	@Param to query string false "Only events recorded before this time, RFC3339"
+//	@Param page query int false "Page number, starting from 1"
+//	@Param pageSize query int false "Page size, max 100"
+func (h *handler) getAuditEvents(c *gin.Context) {
+	filter := &audit.EventsFilter{
+		Action: c.Query("action"),
+		Result: c.Query("result"),
+	}
+	if userID := c.Query("userId"); userID != "" {
+		id, err := strconv.Atoi(userID)
+		if err != nil {
+			spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+			return
+		}
+		filter.UserID = &id
+	}
+
+	var err error
+	if filter.From, err = queryTime(c, "from"); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+	if filter.To, err = queryTime(c, "to"); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	page, pageSize := pagination(c)
+	events, err := h.auditService.GetEvents(filter, page, pageSize)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// userID returns id of the user from path, error response is sent when it is invalid.
+func (h *handler) userID(c *gin.Context) (int, bool) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrUserNotFound, h.log)
+		return 0, false
+	}
+	return userID, true
+}
+
+// actor returns operator performing the request.
+func actor(c *gin.Context) *admin.Actor {
+	name := strings.TrimSpace(c.GetHeader(actorHeader))
+	if name == "" {
+		name = defaultActor
+	}
+	return &admin.Actor{
+		Name:      name,
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
+
+// pagination returns page and page size from query, invalid values are replaced with defaults by the service.
+func pagination(c *gin.Context) (int, int) {
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("pageSize"))
+	return page, pageSize
+}
+
+// queryTime returns time from RFC3339 query param, nil is returned when the param is empty.
+func queryTime(c *gin.Context, param string) (*time.Time, error) {
+	value := c.Query(param)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // error is replaced with bind error by the caller
+	}
+	t = t.UTC()
+	return &t, nil
+}
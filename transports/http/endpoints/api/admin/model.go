@@ -0,0 +1,9 @@
+package admin
+
+import "github.com/bsv-blockchain/spv-wallet-web-backend/transports/websocket"
+
+// WebsocketConnections represents live websocket connections of the instance.
+type WebsocketConnections struct {
+	Count       int                        `json:"count"`
+	Connections []websocket.ConnectionInfo `json:"connections"`
+}
@@ -85,7 +85,7 @@ func (h *handler) upsertContact(c *gin.Context) {
 		return
 	}
 
-	_, err := h.cService.UpsertContact(c.Request.Context(), c.GetString(auth.SessionXPriv), paymail, req.FullName, c.GetString(auth.SessionUserPaymail), req.Metadata)
+	_, err := h.cService.UpsertContact(c.Request.Context(), c.GetString(auth.SessionAccessKey), paymail, req.FullName, c.GetString(auth.SessionUserPaymail), req.Metadata)
 	if err != nil {
 		spverrors.ErrorResponse(c, err, h.log)
 		return
@@ -2,6 +2,7 @@ package cors
 
 import (
 	"net/http"
+	"slices"
 
 	"github.com/gin-gonic/gin"
 	"github.com/spf13/viper"
@@ -14,14 +15,11 @@ func Middleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		origin := c.Request.Header.Get("Origin")
 
-		for _, allowedOrigin := range viper.GetStringSlice(config.EnvHTTPServerCorsAllowedDomains) {
-			if allowedOrigin == origin {
-				c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
-				c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, PATCH, OPTIONS")
-				c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Cache-Control")
-				c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-				break
-			}
+		if IsOriginAllowed(origin) {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+			c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, PATCH, OPTIONS")
+			c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Cache-Control")
+			c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 		}
 
 		if c.Request.Method == "OPTIONS" {
@@ -32,3 +30,8 @@ func Middleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// IsOriginAllowed checks if origin is one of configured allowed domains.
+func IsOriginAllowed(origin string) bool {
+	return slices.Contains(viper.GetStringSlice(config.EnvHTTPServerCorsAllowedDomains), origin)
+}
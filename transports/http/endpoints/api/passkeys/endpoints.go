@@ -0,0 +1,234 @@
+package passkeys
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/passkeys"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/auth"
+	router "github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/routes"
+)
+
+type handler struct {
+	service *passkeys.Service
+	log     *zerolog.Logger
+}
+
+// NewHandler creates new endpoint handler.
+func NewHandler(s *domain.Services, log *zerolog.Logger) (router.RootEndpoints, router.APIEndpoints) {
+	h := &handler{
+		service: s.PasskeysService,
+		log:     log,
+	}
+
+	prefix := "/api/v1"
+
+	// Register root endpoints, the user is authorized by the passkey.
+	rootEndpoints := router.RootEndpointsFunc(func(router *gin.RouterGroup) {
+		router.POST(prefix+"/sign-in/passkey/begin", h.beginSignIn)
+		router.POST(prefix+"/sign-in/passkey/finish", h.finishSignIn)
+	})
+
+	// Register api endpoints which are authorized by session token.
+	apiEndpoints := router.APIEndpointsFunc(func(router *gin.RouterGroup) {
+		router.GET("/user/passkeys", h.getPasskeys)
+		router.POST("/user/passkeys/registration/begin", h.beginRegistration)
+		router.POST("/user/passkeys/registration/finish", h.finishRegistration)
+		router.DELETE("/user/passkeys/:id", h.deletePasskey)
+		router.POST("/transaction/confirmation/begin", h.beginConfirmation)
+	})
+
+	return rootEndpoints, apiEndpoints
+}
+
+// Get passkeys of the user.
+//
+//	@Summary Get passkeys
+//	@Tags user
+//	@Produce json
+//	@Success 200 {array} passkeys.Passkey
+//	@Router /api/v1/user/passkeys [get]
+func (h *handler) getPasskeys(c *gin.Context) {
+	userPasskeys, err := h.service.GetPasskeys(c.Request.Context(), c.GetInt(auth.SessionUserID))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, userPasskeys)
+}
+
+// Begin registration of the passkey.
+// @Description Returns options for navigator.credentials.create(), the registration has to be finished in the same session.
+//
+//	@Summary Begin passkey registration
+//	@Tags user
+//	@Produce json
+//	@Success 200 {object} object
+//	@Router /api/v1/user/passkeys/registration/begin [post]
+func (h *handler) beginRegistration(c *gin.Context) {
+	options, ceremony, err := h.service.BeginRegistration(c.Request.Context(), c.GetInt(auth.SessionUserID))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	if !h.saveCeremony(c, auth.PasskeyRegistration, ceremony) {
+		return
+	}
+
+	c.JSON(http.StatusOK, options)
+}
+
+// Finish registration of the passkey.
+// @Description Password decrypts the xpriv which is then encrypted with the output of the passkey PRF extension.
+//
+//	@Summary Finish passkey registration
+//	@Tags user
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} passkeys.Passkey
+//	@Router /api/v1/user/passkeys/registration/finish [post]
+//	@Param data body RegisterPasskey true "Passkey registration data"
+func (h *handler) finishRegistration(c *gin.Context) {
+	var reqPasskey RegisterPasskey
+	if err := c.Bind(&reqPasskey); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	ceremony := auth.TakePasskeyCeremony(c, auth.PasskeyRegistration)
+	passkey, err := h.service.FinishRegistration(c.Request.Context(), c.GetInt(auth.SessionUserID), reqPasskey.Password, reqPasskey.Name, ceremony, reqPasskey.Credential)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, passkey)
+}
+
+// Delete passkey of the user.
+//
+//	@Summary Delete passkey
+//	@Tags user
+//	@Accept json
+//	@Success 200
+//	@Router /api/v1/user/passkeys/{id} [delete]
+//	@Param id path int true "Passkey ID"
+//	@Param data body DeletePasskey true "User password"
+func (h *handler) deletePasskey(c *gin.Context) {
+	passkeyID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrPasskeyNotFound, h.log)
+		return
+	}
+
+	var reqDelete DeletePasskey
+	if err = c.Bind(&reqDelete); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	if err = h.service.DeletePasskey(c.Request.Context(), c.GetInt(auth.SessionUserID), passkeyID, reqDelete.Password); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// Begin sign in with passkey.
+// @Description Returns options for navigator.credentials.get(), the sign in has to be finished in the same session.
+//
+//	@Summary Begin passkey sign in
+//	@Tags user
+//	@Produce json
+//	@Success 200 {object} object
+//	@Router /api/v1/sign-in/passkey/begin [post]
+func (h *handler) beginSignIn(c *gin.Context) {
+	options, ceremony, err := h.service.BeginSignIn()
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	if !h.saveCeremony(c, auth.PasskeySignIn, ceremony) {
+		return
+	}
+
+	c.JSON(http.StatusOK, options)
+}
+
+// Finish sign in with passkey.
+//
+//	@Summary Finish passkey sign in
+//	@Tags user
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} SignInResponse
+//	@Router /api/v1/sign-in/passkey/finish [post]
+//	@Param data body SignInPasskey true "Passkey assertion"
+func (h *handler) finishSignIn(c *gin.Context) {
+	var reqPasskey SignInPasskey
+	if err := c.Bind(&reqPasskey); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	ceremony := auth.TakePasskeyCeremony(c, auth.PasskeySignIn)
+	signInUser, err := h.service.FinishSignIn(c.Request.Context(), ceremony, reqPasskey.Credential)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	err = auth.UpdateSession(c, signInUser, reqPasskey.RememberMe)
+	if err != nil {
+		h.log.Error().Msgf("Sign-in error. Session wasn't saved: %s", err)
+		spverrors.ErrorResponse(c, spverrors.ErrSessionUpdate, h.log)
+		return
+	}
+
+	response := SignInResponse{
+		Paymail: signInUser.User.Paymail,
+		Balance: signInUser.Balance,
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// Begin confirmation of the transaction with passkey.
+// @Description Returns options for navigator.credentials.get(), the assertion is sent as passkey of the created transaction.
+//
+//	@Summary Begin transaction confirmation with passkey
+//	@Tags transaction
+//	@Produce json
+//	@Success 200 {object} object
+//	@Router /api/v1/transaction/confirmation/begin [post]
+func (h *handler) beginConfirmation(c *gin.Context) {
+	options, ceremony, err := h.service.BeginConfirmation(c.Request.Context(), c.GetInt(auth.SessionUserID))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	if !h.saveCeremony(c, auth.PasskeyConfirmation, ceremony) {
+		return
+	}
+
+	c.JSON(http.StatusOK, options)
+}
+
+// saveCeremony keeps state of the ceremony in the session, it reports whether it succeeded.
+func (h *handler) saveCeremony(c *gin.Context, key, ceremony string) bool {
+	if err := auth.SavePasskeyCeremony(c, key, ceremony); err != nil {
+		h.log.Error().Msgf("Passkey error. Ceremony wasn't saved: %s", err)
+		spverrors.ErrorResponse(c, spverrors.ErrSessionUpdate, h.log)
+		return false
+	}
+	return true
+}
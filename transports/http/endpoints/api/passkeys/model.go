@@ -0,0 +1,31 @@
+package passkeys
+
+import (
+	"encoding/json"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+)
+
+// RegisterPasskey is a struct that contains data required to finish registration of the passkey.
+type RegisterPasskey struct {
+	Name       string          `json:"name"`
+	Password   string          `json:"password"`
+	Credential json.RawMessage `json:"credential" swaggertype:"object"`
+}
+
+// DeletePasskey is a struct that contains data required to delete passkey of the user.
+type DeletePasskey struct {
+	Password string `json:"password"`
+}
+
+// SignInPasskey is a struct that contains assertion of the passkey used to sign in.
+type SignInPasskey struct {
+	Credential json.RawMessage `json:"credential" swaggertype:"object"`
+	RememberMe bool            `json:"rememberMe"`
+}
+
+// SignInResponse is a struct that represents struct sended after user sign in.
+type SignInResponse struct {
+	Paymail string        `json:"paymail"`
+	Balance users.Balance `json:"balance"`
+}
@@ -0,0 +1,55 @@
+package paymails
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/paymail"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+	router "github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/routes"
+)
+
+// Resolution is used for swagger generation
+type Resolution = paymail.Resolution
+
+type handler struct {
+	resolver *paymail.Resolver
+	log      *zerolog.Logger
+}
+
+// NewHandler creates new endpoint handler.
+func NewHandler(s *domain.Services, log *zerolog.Logger) router.APIEndpoints {
+	return &handler{
+		resolver: s.PaymailResolver,
+		log:      log,
+	}
+}
+
+// RegisterAPIEndpoints registers routes that are part of service API.
+func (h *handler) RegisterAPIEndpoints(router *gin.RouterGroup) {
+	user := router.Group("/paymail")
+	{
+		user.GET("/resolve", h.resolvePaymail)
+	}
+}
+
+// Resolve paymail address.
+//
+//	@Summary Resolve paymail address with capability discovery.
+//	@Tags paymail
+//	@Produce json
+//	@Success 200 {object} Resolution
+//	@Router /api/v1/paymail/resolve [get]
+//	@Param address query string true "Paymail address"
+func (h *handler) resolvePaymail(c *gin.Context) {
+	resolution, err := h.resolver.Resolve(c.Request.Context(), c.Query("address"))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, resolution)
+}
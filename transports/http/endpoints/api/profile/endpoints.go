@@ -0,0 +1,149 @@
+package profile
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/auth"
+	router "github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/routes"
+)
+
+type handler struct {
+	service *users.ProfileService
+	log     *zerolog.Logger
+}
+
+// NewHandler creates new endpoint handler.
+func NewHandler(s *domain.Services, log *zerolog.Logger) (router.RootEndpoints, router.APIEndpoints) {
+	h := &handler{
+		service: s.ProfileService,
+		log:     log,
+	}
+
+	prefix := "/api/v1"
+
+	// Avatars are public, they are presented on paymails to counterparties.
+	rootEndpoints := router.RootEndpointsFunc(func(router *gin.RouterGroup) {
+		router.GET(prefix+"/avatars/:name", h.getAvatar)
+	})
+
+	// Register api endpoints which are athorized by session token.
+	apiEndpoints := router.APIEndpointsFunc(func(router *gin.RouterGroup) {
+		router.GET("/user/profile", h.getProfile)
+		router.PUT("/user/profile", h.updateProfile)
+		router.PUT("/user/profile/avatar", h.updateAvatar)
+	})
+
+	return rootEndpoints, apiEndpoints
+}
+
+// getProfile returns public profile of the user.
+//
+//	@Summary Get user profile
+//	@Tags user
+//	@Produce json
+//	@Success 200 {object} Profile
+//	@Router /user/profile [get]
+func (h *handler) getProfile(c *gin.Context) {
+	profile, err := h.service.GetProfile(c.GetInt(auth.SessionUserID))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// updateProfile sets public display name of the user presented on all user paymails.
+//
+//	@Summary Update user display name
+//	@Tags user
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} Profile
+//	@Router /user/profile [put]
+//	@Param data body UpdateProfile true "Display name and user password"
+func (h *handler) updateProfile(c *gin.Context) {
+	var reqProfile UpdateProfile
+	if err := c.Bind(&reqProfile); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	profile, err := h.service.UpdateDisplayName(c.Request.Context(), c.GetInt(auth.SessionUserID), reqProfile.Password, reqProfile.DisplayName)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// updateAvatar uploads avatar of the user presented on all user paymails.
+//
+//	@Summary Upload user avatar
+//	@Tags user
+//	@Accept multipart/form-data
+//	@Produce json
+//	@Success 200 {object} Profile
+//	@Router /user/profile/avatar [put]
+//	@Param avatar formData file true "PNG, JPEG, GIF or WEBP image"
+//	@Param password formData string true "User password"
+func (h *handler) updateAvatar(c *gin.Context) {
+	fileHeader, err := c.FormFile("avatar")
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+	defer file.Close() //nolint:errcheck // best effort cleanup
+
+	// Read one byte over the limit, so too large avatars are rejected by the service.
+	avatar, err := io.ReadAll(io.LimitReader(file, viper.GetInt64(config.EnvAvatarsMaxSize)+1))
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	profile, err := h.service.UpdateAvatar(c.Request.Context(), c.GetInt(auth.SessionUserID), c.PostForm("password"), avatar)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// getAvatar serves stored avatar of the user.
+//
+//	@Summary Get avatar
+//	@Tags user
+//	@Produce image/png,image/jpeg,image/gif,image/webp
+//	@Success 200 {file} binary
+//	@Router /api/v1/avatars/{name} [get]
+//	@Param name path string true "Avatar name"
+func (h *handler) getAvatar(c *gin.Context) {
+	path, err := h.service.AvatarPath(c.Param("name"))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	// Avatar names are unique, new upload gets a new name.
+	c.Header("Cache-Control", "public, max-age=86400, immutable")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.File(path)
+}
@@ -0,0 +1,12 @@
+package profile
+
+import "github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+
+// Profile is used for swagger generation
+type Profile = users.Profile
+
+// UpdateProfile is a struct that contains new display name of the user.
+type UpdateProfile struct {
+	DisplayName string `json:"displayName"`
+	Password    string `json:"password"`
+}
@@ -0,0 +1,221 @@
+package sso
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/bsv-blockchain/spv-wallet/models"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain"
+	domainconfig "github.com/bsv-blockchain/spv-wallet-web-backend/domain/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/sso"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/auth"
+	router "github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/routes"
+)
+
+// Results of the sign in passed to the frontend.
+const (
+	resultSignedIn             = "signed-in"
+	resultRegistrationRequired = "registration-required"
+	resultError                = "error"
+)
+
+type handler struct {
+	service      *sso.Service
+	usersService *users.UserService
+	config       *domainconfig.Service
+	log          *zerolog.Logger
+}
+
+// NewHandler creates new endpoint handler.
+func NewHandler(s *domain.Services, log *zerolog.Logger) router.RootEndpoints {
+	h := &handler{
+		service:      s.SSOService,
+		usersService: s.UsersService,
+		config:       s.ConfigService,
+		log:          log,
+	}
+
+	prefix := "/api/v1"
+
+	// Register root endpoints, the user is authorized by the identity provider.
+	return router.RootEndpointsFunc(func(router *gin.RouterGroup) {
+		router.GET(prefix+"/sso/login", h.login)
+		router.GET(prefix+"/sso/callback", h.callback)
+		router.POST(prefix+"/sso/register", h.register)
+	})
+}
+
+// login redirects the user to the identity provider.
+//
+//	@Summary Sign in with identity provider
+//	@Tags sso
+//	@Success 302
+//	@Router /api/v1/sso/login [get]
+//	@Param rememberMe query bool false "Keep the user signed in longer"
+func (h *handler) login(c *gin.Context) {
+	authorization, err := h.service.StartAuthorization(c.Request.Context())
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	if err = setFlow(c, &flow{Authorization: authorization, RememberMe: c.Query("rememberMe") == "true"}); err != nil {
+		h.log.Error().Msgf("SSO error. Flow wasn't saved: %s", err)
+		spverrors.ErrorResponse(c, spverrors.ErrSessionUpdate, h.log)
+		return
+	}
+
+	c.Redirect(http.StatusFound, authorization.URL)
+}
+
+// callback completes the sign in with the identity provider and redirects the user to the frontend.
+// @Description User mapped to the identity is signed in. Otherwise verified identity is kept until the user registers with spending PIN.
+//
+//	@Summary Identity provider callback
+//	@Tags sso
+//	@Success 302
+//	@Router /api/v1/sso/callback [get]
+//	@Param state query string true "State of the authorization"
+//	@Param code query string true "Authorization code"
+func (h *handler) callback(c *gin.Context) {
+	current := getFlow(c)
+
+	if providerError := c.Query("error"); providerError != "" {
+		h.log.Warn().Msgf("Identity provider returned error: %s", providerError)
+		clearFlow(c)
+		h.redirectToFrontend(c, resultError, spverrors.ErrSSOAuthorization.Code)
+		return
+	}
+
+	var authorization *sso.Authorization
+	var rememberMe bool
+	if current != nil {
+		authorization = current.Authorization
+		rememberMe = current.RememberMe
+	}
+	identity, err := h.service.CompleteAuthorization(c.Request.Context(), authorization, c.Query("state"), c.Query("code"))
+	if err != nil {
+		clearFlow(c)
+		h.redirectToFrontend(c, resultError, errorCode(err))
+		return
+	}
+
+	signInUser, err := h.usersService.SignInSSOUser(c.Request.Context(), identity)
+	if errors.Is(err, spverrors.ErrSSORegistrationRequired) {
+		if err = setFlow(c, &flow{Identity: identity, RememberMe: rememberMe}); err != nil {
+			h.log.Error().Msgf("SSO error. Flow wasn't saved: %s", err)
+			h.redirectToFrontend(c, resultError, spverrors.ErrSessionUpdate.Code)
+			return
+		}
+		h.redirectToFrontend(c, resultRegistrationRequired, "")
+		return
+	}
+	clearFlow(c)
+	if err != nil {
+		h.redirectToFrontend(c, resultError, errorCode(err))
+		return
+	}
+
+	if err = auth.UpdateSession(c, signInUser, rememberMe); err != nil {
+		h.log.Error().Msgf("Sign-in error. Session wasn't saved: %s", err)
+		h.redirectToFrontend(c, resultError, spverrors.ErrSessionUpdate.Code)
+		return
+	}
+
+	h.redirectToFrontend(c, resultSignedIn, "")
+}
+
+// register creates the user mapped to the identity verified in the callback and signs the user in.
+// @Description Spending PIN encrypts the keys of the user, it is required to send transactions instead of password.
+//
+//	@Summary Register user signed in with identity provider
+//	@Tags sso
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} RegisterResponse
+//	@Router /api/v1/sso/register [post]
+//	@Param data body RegisterSSOUser true "Spending PIN and paymail of the user"
+func (h *handler) register(c *gin.Context) {
+	current := getFlow(c)
+	if current == nil || current.Identity == nil {
+		spverrors.ErrorResponse(c, spverrors.ErrSSOAuthorization, h.log)
+		return
+	}
+
+	var reqUser RegisterSSOUser
+	if err := c.Bind(&reqUser); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	if reqUser.PIN != reqUser.PINConfirmation {
+		spverrors.ErrorResponse(c, spverrors.ErrSpendingPINMismatch, h.log)
+		return
+	}
+
+	domain, err := h.config.RegistrationDomain(reqUser.Domain)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	newUser, err := h.usersService.RegisterSSOUser(current.Identity, reqUser.PIN, reqUser.Alias, domain)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+	clearFlow(c)
+
+	signInUser, err := h.usersService.SignInSSOUser(c.Request.Context(), current.Identity)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	if err = auth.UpdateSession(c, signInUser, current.RememberMe); err != nil {
+		h.log.Error().Msgf("Sign-in error. Session wasn't saved: %s", err)
+		spverrors.ErrorResponse(c, spverrors.ErrSessionUpdate, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, RegisterResponse{
+		Mnemonic: newUser.Mnemonic,
+		Paymail:  newUser.User.Paymail,
+	})
+}
+
+// redirectToFrontend redirects the user to the frontend page with the result of the sign in.
+func (h *handler) redirectToFrontend(c *gin.Context, result, code string) {
+	target, err := url.Parse(viper.GetString(config.EnvSSOFrontendURL))
+	if err != nil {
+		h.log.Error().Msgf("Invalid frontend url of SSO: %s", err)
+		spverrors.ErrorResponse(c, spverrors.ErrSSOProvider, h.log)
+		return
+	}
+
+	query := target.Query()
+	query.Set("result", result)
+	if code != "" {
+		query.Set("code", code)
+	}
+	target.RawQuery = query.Encode()
+
+	c.Redirect(http.StatusFound, target.String())
+}
+
+// errorCode returns code of the error passed to the frontend.
+func errorCode(err error) string {
+	var spvError models.SPVError
+	if errors.As(err, &spvError) {
+		return spvError.Code
+	}
+	return spverrors.ErrSSOAuthorization.Code
+}
@@ -0,0 +1,75 @@
+package sso
+
+import (
+	"crypto/sha256"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/securecookie"
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/sso"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+)
+
+// flowCookieName is a name of the cookie keeping state of the sign in with the identity provider.
+const flowCookieName = "sso_flow"
+
+// flowCookiePath limits the cookie to the sso endpoints.
+const flowCookiePath = "/api/v1/sso"
+
+// flow is the state of the sign in with the identity provider. It is kept in the cookie encrypted with the session
+// secret, which is sent on the redirect from the identity provider unlike the session cookie, which is same-site strict.
+type flow struct {
+	Authorization *sso.Authorization
+	Identity      *users.SSOIdentity // verified identity of the user who has to register
+	RememberMe    bool               // user chose to be kept signed in longer
+}
+
+// flowCodec returns codec encrypting and authenticating the flow cookie with keys derived from the session secret.
+func flowCodec() *securecookie.SecureCookie {
+	secret := viper.GetString(config.EnvHTTPServerSessionSecret)
+	hashKey := sha256.Sum256([]byte("sso-flow-hash:" + secret))
+	blockKey := sha256.Sum256([]byte("sso-flow-block:" + secret))
+	return securecookie.New(hashKey[:], blockKey[:]).MaxAge(int(viper.GetDuration(config.EnvSSOFlowTTL).Seconds()))
+}
+
+// setFlow stores the flow in the cookie.
+func setFlow(c *gin.Context, value *flow) error {
+	encoded, err := flowCodec().Encode(flowCookieName, value)
+	if err != nil {
+		return err //nolint:wrapcheck // error logged by the caller
+	}
+	setFlowCookie(c, encoded, int(viper.GetDuration(config.EnvSSOFlowTTL).Seconds()))
+	return nil
+}
+
+// getFlow returns the flow stored in the cookie, nil if there is none or it expired.
+func getFlow(c *gin.Context) *flow {
+	encoded, err := c.Cookie(flowCookieName)
+	if err != nil {
+		return nil
+	}
+	var value flow
+	if err = flowCodec().Decode(flowCookieName, encoded, &value); err != nil {
+		return nil
+	}
+	return &value
+}
+
+// clearFlow removes the flow cookie.
+func clearFlow(c *gin.Context) {
+	setFlowCookie(c, "", -1)
+}
+
+func setFlowCookie(c *gin.Context, value string, maxAge int) {
+	// If we're running on localhost, we need to set domain to empty string.
+	domain := viper.GetString(config.EnvHTTPServerCookieDomain)
+	if domain == "localhost" {
+		domain = ""
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(flowCookieName, value, maxAge, flowCookiePath, domain, viper.GetBool(config.EnvHTTPServerCookieSecure), true)
+}
@@ -0,0 +1,15 @@
+package sso
+
+// RegisterSSOUser is a struct that contains data required to register user signed in with the identity provider.
+type RegisterSSOUser struct {
+	PIN             string `json:"pin"`
+	PINConfirmation string `json:"pinConfirmation"`
+	Alias           string `json:"alias"`
+	Domain          string `json:"domain"`
+}
+
+// RegisterResponse represents response that is sent after user registration.
+type RegisterResponse struct {
+	Mnemonic string `json:"mnemonic"`
+	Paymail  string `json:"paymail"`
+}
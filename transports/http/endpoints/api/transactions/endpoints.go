@@ -1,6 +1,7 @@
 package transactions
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 
@@ -9,6 +10,8 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/passkeys"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/paymail"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/transactions"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/notification"
@@ -22,6 +25,9 @@ import (
 type handler struct {
 	uService users.UserService
 	tService transactions.TransactionService
+	pService *passkeys.Service
+	tracker  *transactions.Tracker
+	resolver *paymail.Resolver
 	log      *zerolog.Logger
 	ws       websocket.Server
 }
@@ -34,6 +40,9 @@ func NewHandler(s *domain.Services, log *zerolog.Logger, ws websocket.Server) ro
 	return &handler{
 		uService: *s.UsersService,
 		tService: *s.TransactionsService,
+		pService: s.PasskeysService,
+		tracker:  s.TransactionTracker,
+		resolver: s.PaymailResolver,
 		log:      log,
 		ws:       ws,
 	}
@@ -116,23 +125,70 @@ func (h *handler) createTransaction(c *gin.Context) {
 		return
 	}
 
+	// Validate recipient, so invalid paymail is reported before transaction is drafted.
+	recipient, err := h.resolver.ValidateRecipient(c.Request.Context(), reqTransaction.Recipient)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
 	// Validate user.
-	xpriv, err := h.uService.GetUserXpriv(c.GetInt(auth.SessionUserID), reqTransaction.Password)
+	xpriv, err := h.unlockXpriv(c, &reqTransaction)
 	if err != nil {
 		spverrors.ErrorResponse(c, err, h.log)
 		return
 	}
 
+	userID := c.GetInt(auth.SessionUserID)
+	refund := h.apiTokenRefund(c, reqTransaction.Satoshis)
 	events := make(chan notification.TransactionEvent)
-	err = h.tService.CreateTransaction(c.GetString(auth.SessionUserPaymail), xpriv, reqTransaction.Recipient, reqTransaction.Satoshis, events)
+	err = h.tService.CreateTransaction(c.Request.Context(), c.GetString(auth.SessionUserPaymail), xpriv, recipient, reqTransaction.Satoshis, events)
 	if err != nil {
+		refund()
 		spverrors.ErrorResponse(c, err, h.log)
 		return
 	}
 	go func() {
 		transaction := <-events
-		h.ws.GetSocket(strconv.Itoa(c.GetInt(auth.SessionUserID))).Notify(transaction)
+		if transaction.Transaction != nil {
+			_ = h.tracker.Track(context.Background(), userID, transaction.Transaction.ID)
+		} else {
+			refund()
+		}
+		h.ws.GetSocket(websocket.UserTransactionsChannel(strconv.Itoa(userID))).Notify(transaction)
 	}()
 
 	c.Status(http.StatusOK)
 }
+
+// unlockXpriv returns xpriv of the user decrypted with the password, spending PIN or the passkey confirming the transaction.
+// For requests authorized by API token, the amount is reserved from the send limit of the token and xpriv decrypted
+// with the token is used instead.
+func (h *handler) unlockXpriv(c *gin.Context, reqTransaction *CreateTransaction) (string, error) {
+	if auth.IsAPITokenRequest(c) {
+		if err := h.uService.SpendAPIToken(c.Request.Context(), c.GetInt(auth.APITokenID), reqTransaction.Satoshis); err != nil {
+			return "", err //nolint:wrapcheck // error is already an SPVError
+		}
+		return c.GetString(auth.SessionXPriv), nil
+	}
+	if len(reqTransaction.Passkey) > 0 {
+		ceremony := auth.TakePasskeyCeremony(c, auth.PasskeyConfirmation)
+		return h.pService.UnlockXpriv(c.Request.Context(), c.GetInt(auth.SessionUserID), ceremony, reqTransaction.Passkey) //nolint:wrapcheck // error is already an SPVError
+	}
+	if reqTransaction.PIN != "" {
+		return h.uService.UnlockWithSpendingPIN(c.Request.Context(), c.GetInt(auth.SessionUserID), reqTransaction.PIN) //nolint:wrapcheck // error is already an SPVError
+	}
+	return h.uService.GetUserXpriv(c.Request.Context(), c.GetInt(auth.SessionUserID), reqTransaction.Password) //nolint:wrapcheck // error is already an SPVError
+}
+
+// apiTokenRefund returns function refunding the amount reserved from the send limit of API token authorizing the request,
+// which is called when the transaction is not sent. The function does nothing for requests authorized by the session.
+func (h *handler) apiTokenRefund(c *gin.Context, satoshis uint64) func() {
+	if !auth.IsAPITokenRequest(c) {
+		return func() {}
+	}
+	tokenID := c.GetInt(auth.APITokenID)
+	return func() {
+		h.uService.RefundAPIToken(context.Background(), tokenID, satoshis)
+	}
+}
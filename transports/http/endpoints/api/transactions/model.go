@@ -1,15 +1,21 @@
 package transactions
 
 import (
+	"encoding/json"
+
 	"github.com/bsv-blockchain/spv-wallet/models"
 	"github.com/bsv-blockchain/spv-wallet/models/filter"
 )
 
 // CreateTransaction represents request for creating new transaction.
+// Xpriv is unlocked with the passkey assertion of the begun confirmation if sent, otherwise with spending PIN if sent,
+// otherwise with the password.
 type CreateTransaction struct {
-	Password  string `json:"password"`
-	Recipient string `json:"recipient"`
-	Satoshis  uint64 `json:"satoshis"`
+	Password  string          `json:"password"`
+	PIN       string          `json:"pin,omitempty"`
+	Passkey   json.RawMessage `json:"passkey,omitempty" swaggertype:"object"`
+	Recipient string          `json:"recipient"`
+	Satoshis  uint64          `json:"satoshis"`
 }
 
 // SearchTransaction represents request for searching transactions.
@@ -1,28 +1,44 @@
 package users
 
 import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
 
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/audit"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/paymail"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/auth"
 	router "github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/routes"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/websocket"
 )
 
 type handler struct {
-	service *users.UserService
-	log     *zerolog.Logger
+	service      *users.UserService
+	config       *config.Service
+	resolver     *paymail.Resolver
+	auditService *audit.Service
+	log          *zerolog.Logger
+	ws           websocket.Server
 }
 
 // NewHandler creates new endpoint handler.
-func NewHandler(s *domain.Services, log *zerolog.Logger) (router.RootEndpoints, router.APIEndpoints) {
+func NewHandler(s *domain.Services, log *zerolog.Logger, ws websocket.Server) (router.RootEndpoints, router.APIEndpoints) {
 	h := &handler{
-		service: s.UsersService,
-		log:     log,
+		service:      s.UsersService,
+		config:       s.ConfigService,
+		resolver:     s.PaymailResolver,
+		auditService: s.AuditService,
+		log:          log,
+		ws:           ws,
 	}
 
 	prefix := "/api/v1"
@@ -30,18 +46,35 @@ func NewHandler(s *domain.Services, log *zerolog.Logger) (router.RootEndpoints,
 	// Register root endpoints.
 	rootEndpoints := router.RootEndpointsFunc(func(router *gin.RouterGroup) {
 		router.POST(prefix+"/user", h.register)
+		router.GET(prefix+"/user/paymail-availability", h.checkAliasAvailability)
+		router.POST(prefix+"/viewer-invitations/accept", h.acceptViewerInvitation)
 	})
 
 	// Register api endpoints which are athorized by session token.
 	apiEndpoints := router.APIEndpointsFunc(func(router *gin.RouterGroup) {
 		router.GET("/user", h.getUser)
+		router.DELETE("/user", h.deleteUser)
+		router.GET("/user/export", h.exportData)
+		router.GET("/user/security-events", h.getSecurityEvents)
+		router.GET("/user/paymails", h.getPaymails)
+		router.POST("/user/paymails", h.addPaymail)
+		router.PUT("/user/paymails/primary", h.setPrimaryPaymail)
+		router.GET("/user/viewers", h.getViewers)
+		router.POST("/user/viewers", h.inviteViewer)
+		router.DELETE("/user/viewers/:id", h.removeViewer)
+		router.GET("/user/tokens", h.getAPITokens)
+		router.POST("/user/tokens", h.createAPIToken)
+		router.DELETE("/user/tokens/:id", h.revokeAPIToken)
+		router.PUT("/user/spending-pin", h.setSpendingPIN)
+		router.DELETE("/user/spending-pin", h.removeSpendingPIN)
+		router.POST("/user/spending-pin/unlock", h.unlockSpendingPIN)
 	})
 
 	return rootEndpoints, apiEndpoints
 }
 
 // register registers new user.
-// @Description Register new user with given data, paymail is created based on username from sended email.
+// @Description Register new user with given data, paymail is created in chosen (or default) domain with given alias or based on username from sended email.
 //
 //	@Summary Register new user
 //	@Tags user
@@ -64,7 +97,13 @@ func (h *handler) register(c *gin.Context) {
 		return
 	}
 
-	newUser, err := h.service.CreateNewUser(reqUser.Email, reqUser.Password)
+	domain, err := h.config.RegistrationDomain(reqUser.Domain)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	newUser, err := h.service.CreateNewUser(reqUser.Email, reqUser.Password, reqUser.Alias, domain)
 	if err != nil {
 		spverrors.ErrorResponse(c, err, h.log)
 		return
@@ -102,12 +141,499 @@ func (h *handler) getUser(c *gin.Context) {
 		return
 	}
 
+	spendingPIN, err := h.service.HasSpendingPIN(c.Request.Context(), user)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
 	response := UserResponse{
-		UserID:  user.ID,
-		Paymail: user.Paymail,
-		Email:   user.Email,
-		Balance: *currentBalance,
+		UserID:      user.ID,
+		Paymail:     user.Paymail,
+		Email:       user.Email,
+		Balance:     *currentBalance,
+		SpendingPIN: spendingPIN,
 	}
 
 	c.JSON(http.StatusOK, response)
 }
+
+// checkAliasAvailability checks if paymail alias can be registered.
+//
+//	@Summary Check paymail alias availability
+//	@Tags user
+//	@Produce json
+//	@Success 200 {object} users.AliasAvailability
+//	@Router /api/v1/user/paymail-availability [get]
+//	@Param alias query string true "Paymail alias"
+//	@Param domain query string false "Paymail domain, default domain is used when empty"
+func (h *handler) checkAliasAvailability(c *gin.Context) {
+	domain, err := h.config.RegistrationDomain(c.Query("domain"))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	availability, err := h.service.CheckAliasAvailability(c.Query("alias"), domain)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, availability)
+}
+
+// getPaymails returns paymails of the user, the primary one first.
+//
+//	@Summary Get user paymails
+//	@Tags user
+//	@Produce json
+//	@Success 200 {array} users.UserPaymail
+//	@Router /user/paymails [get]
+func (h *handler) getPaymails(c *gin.Context) {
+	paymails, err := h.service.GetUserPaymails(c.GetInt(auth.SessionUserID))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, paymails)
+}
+
+// addPaymail registers an additional paymail for the user.
+//
+//	@Summary Add user paymail
+//	@Tags user
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} users.UserPaymail
+//	@Router /user/paymails [post]
+//	@Param data body AddPaymail true "Paymail alias, optional domain and user password"
+func (h *handler) addPaymail(c *gin.Context) {
+	var reqPaymail AddPaymail
+	if err := c.Bind(&reqPaymail); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	domain, err := h.config.RegistrationDomain(reqPaymail.Domain)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	paymail, err := h.service.AddPaymail(c.Request.Context(), c.GetInt(auth.SessionUserID), reqPaymail.Password, reqPaymail.Alias, domain)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, paymail)
+}
+
+// setPrimaryPaymail changes paymail used as sender of user transactions.
+//
+//	@Summary Set primary paymail
+//	@Tags user
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} users.UserPaymail
+//	@Router /user/paymails/primary [put]
+//	@Param data body SetPrimaryPaymail true "Paymail owned by the user"
+func (h *handler) setPrimaryPaymail(c *gin.Context) {
+	var reqPaymail SetPrimaryPaymail
+	if err := c.Bind(&reqPaymail); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	paymail, err := h.service.SetPrimaryPaymail(c.GetInt(auth.SessionUserID), reqPaymail.Paymail)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	if err = auth.UpdateSessionPaymail(c, paymail.Paymail); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrSessionUpdate, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, paymail)
+}
+
+// deleteUser deletes account of the user.
+// @Description Remaining funds are sent to given address, paymails are deleted, access keys revoked and all personal data erased.
+//
+//	@Summary Delete user account
+//	@Tags user
+//	@Accept json
+//	@Produce json
+//	@Success 200
+//	@Router /user [delete]
+//	@Param data body DeleteUser true "User password and address for remaining funds"
+func (h *handler) deleteUser(c *gin.Context) {
+	var reqDelete DeleteUser
+	if err := c.Bind(&reqDelete); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	sweepTo := reqDelete.SweepAddress
+	if sweepTo != "" {
+		// Validate address, so funds are not sent to paymail which cannot receive them.
+		recipient, err := h.resolver.ValidateRecipient(c.Request.Context(), sweepTo)
+		if err != nil {
+			spverrors.ErrorResponse(c, err, h.log)
+			return
+		}
+		sweepTo = recipient
+	}
+
+	userID := c.GetInt(auth.SessionUserID)
+	if err := h.service.DeleteAccount(c.Request.Context(), userID, reqDelete.Password, sweepTo); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	sessionID := auth.SessionID(c)
+	if err := auth.TerminateSession(c); err != nil {
+		h.log.Error().Msgf("Session of deleted user wasn't terminated: %s", err)
+	}
+	h.ws.DisconnectSession(strconv.Itoa(userID), sessionID)
+
+	c.Status(http.StatusOK)
+}
+
+// exportData returns zip archive with personal data of the user.
+//
+//	@Summary Export user data
+//	@Tags user
+//	@Produce application/zip
+//	@Success 200 {file} file
+//	@Router /user/export [get]
+func (h *handler) exportData(c *gin.Context) {
+	data, err := h.service.ExportData(c.GetInt(auth.SessionUserID), c.GetString(auth.SessionAccessKey))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	files := []struct {
+		name    string
+		content any
+	}{
+		{"user.json", data.User},
+		{"profile.json", data.Profile},
+		{"paymails.json", data.Paymails},
+		{"contacts.json", data.Contacts},
+		{"transactions.json", data.Transactions},
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"spv-wallet-export-%s.zip\"", data.ExportedAt.Format("20060102150405")))
+	c.Header("Content-Type", "application/zip")
+	c.Status(http.StatusOK)
+
+	archive := zip.NewWriter(c.Writer)
+	for _, file := range files {
+		if err = writeArchiveFile(archive, file.name, file.content); err != nil {
+			h.log.Error().Msgf("Error while writing %s to data export: %s", file.name, err)
+			return
+		}
+	}
+	if err = archive.Close(); err != nil {
+		h.log.Error().Msgf("Error while closing data export: %s", err)
+	}
+}
+
+// getSecurityEvents returns security history of the user.
+//
+//	@Summary Get security events
+//	@Description Returns sign-ins, key unlocks, contact confirmations and payments of the user, the newest first
+//	@Tags user
+//	@Produce json
+//	@Success 200 {object} audit.EventsPage
+//	@Router /user/security-events [get]
+//	@Param page query int false "Page number, starting from 1"
+//	@Param pageSize query int false "Page size, max 100"
+func (h *handler) getSecurityEvents(c *gin.Context) {
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("pageSize"))
+
+	events, err := h.auditService.GetUserEvents(c.GetInt(auth.SessionUserID), page, pageSize)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// getViewers returns viewers invited to the wallet of the user.
+//
+//	@Summary Get viewers
+//	@Tags user
+//	@Produce json
+//	@Success 200 {array} users.User
+//	@Router /user/viewers [get]
+func (h *handler) getViewers(c *gin.Context) {
+	viewers, err := h.service.GetViewers(c.GetInt(auth.SessionUserID))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, viewers)
+}
+
+// inviteViewer invites a viewer to the wallet of the user.
+// @Description Returned token has to be passed to the viewer, who accepts the invitation with it. Viewer can see balance and history of the wallet, but cannot spend funds.
+//
+//	@Summary Invite viewer
+//	@Tags user
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} users.CreatedInvitation
+//	@Router /user/viewers [post]
+//	@Param data body InviteViewer true "Email of the viewer and user password"
+func (h *handler) inviteViewer(c *gin.Context) {
+	var reqInvite InviteViewer
+	if err := c.Bind(&reqInvite); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	invitation, err := h.service.InviteViewer(c.Request.Context(), c.GetInt(auth.SessionUserID), reqInvite.Password, reqInvite.Email)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+// removeViewer revokes access of the viewer and deletes the viewer account.
+//
+//	@Summary Remove viewer
+//	@Tags user
+//	@Accept json
+//	@Success 200
+//	@Router /user/viewers/{id} [delete]
+//	@Param id path int true "Viewer id"
+//	@Param data body RemoveViewer true "User password"
+func (h *handler) removeViewer(c *gin.Context) {
+	viewerID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrViewerNotFound, h.log)
+		return
+	}
+
+	var reqRemove RemoveViewer
+	if err = c.Bind(&reqRemove); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	if err = h.service.RemoveViewer(c.Request.Context(), c.GetInt(auth.SessionUserID), viewerID, reqRemove.Password); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+	h.ws.DisconnectUser(strconv.Itoa(viewerID))
+
+	c.Status(http.StatusOK)
+}
+
+// getAPITokens returns API tokens of the user.
+//
+//	@Summary Get API tokens
+//	@Tags user
+//	@Produce json
+//	@Success 200 {array} users.APIToken
+//	@Router /user/tokens [get]
+func (h *handler) getAPITokens(c *gin.Context) {
+	tokens, err := h.service.GetAPITokens(c.GetInt(auth.SessionUserID))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// createAPIToken creates personal API token of the user.
+// @Description Returned token is shown only once, it is passed in the header "Authorization: Bearer <token>". Token can call only endpoints of its scopes: balance:read, transactions:read, transactions:send (up to the send limit in satoshis) and contacts:manage.
+//
+//	@Summary Create API token
+//	@Tags user
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} users.CreatedAPIToken
+//	@Router /user/tokens [post]
+//	@Param data body CreateAPIToken true "Name, scopes, send limit and expiry of the token and user password"
+func (h *handler) createAPIToken(c *gin.Context) {
+	var reqToken CreateAPIToken
+	if err := c.Bind(&reqToken); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	token, err := h.service.CreateAPIToken(c.Request.Context(), c.GetInt(auth.SessionUserID), reqToken.Password,
+		reqToken.Name, reqToken.Scopes, reqToken.SendLimit, reqToken.ExpiresAt)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, token)
+}
+
+// revokeAPIToken revokes API token of the user.
+//
+//	@Summary Revoke API token
+//	@Tags user
+//	@Accept json
+//	@Success 200
+//	@Router /user/tokens/{id} [delete]
+//	@Param id path int true "API token id"
+//	@Param data body RevokeAPIToken true "User password"
+func (h *handler) revokeAPIToken(c *gin.Context) {
+	tokenID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrAPITokenNotFound, h.log)
+		return
+	}
+
+	var reqRevoke RevokeAPIToken
+	if err = c.Bind(&reqRevoke); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	if err = h.service.RevokeAPIToken(c.Request.Context(), c.GetInt(auth.SessionUserID), tokenID, reqRevoke.Password); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// setSpendingPIN sets spending PIN of the user.
+// @Description Spending PIN can be used instead of password to send transactions, it is locked after too many invalid attempts.
+//
+//	@Summary Set spending PIN
+//	@Tags user
+//	@Accept json
+//	@Success 200
+//	@Router /user/spending-pin [put]
+//	@Param data body SetSpendingPIN true "User password and new spending PIN"
+func (h *handler) setSpendingPIN(c *gin.Context) {
+	var reqPIN SetSpendingPIN
+	if err := c.Bind(&reqPIN); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	if reqPIN.PIN != reqPIN.PINConfirmation {
+		spverrors.ErrorResponse(c, spverrors.ErrSpendingPINMismatch, h.log)
+		return
+	}
+
+	if err := h.service.SetSpendingPIN(c.Request.Context(), c.GetInt(auth.SessionUserID), reqPIN.Password, reqPIN.PIN); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// removeSpendingPIN removes spending PIN of the user.
+//
+//	@Summary Remove spending PIN
+//	@Tags user
+//	@Accept json
+//	@Success 200
+//	@Router /user/spending-pin [delete]
+//	@Param data body RemoveSpendingPIN true "User password"
+func (h *handler) removeSpendingPIN(c *gin.Context) {
+	var reqRemove RemoveSpendingPIN
+	if err := c.Bind(&reqRemove); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	if err := h.service.RemoveSpendingPIN(c.Request.Context(), c.GetInt(auth.SessionUserID), reqRemove.Password); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// unlockSpendingPIN unlocks xpriv of the user with spending PIN for the rest of the session.
+// @Description Sessions opened without password, e.g. with identity provider, have to be unlocked to manage contacts.
+//
+//	@Summary Unlock session with spending PIN
+//	@Tags user
+//	@Accept json
+//	@Success 200
+//	@Router /user/spending-pin/unlock [post]
+//	@Param data body UnlockSpendingPIN true "Spending PIN"
+func (h *handler) unlockSpendingPIN(c *gin.Context) {
+	var reqUnlock UnlockSpendingPIN
+	if err := c.Bind(&reqUnlock); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	xpriv, err := h.service.UnlockWithSpendingPIN(c.Request.Context(), c.GetInt(auth.SessionUserID), reqUnlock.PIN)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	if err = auth.UpdateSessionXPriv(c, xpriv); err != nil {
+		h.log.Error().Msgf("Spending PIN error. Session wasn't saved: %s", err)
+		spverrors.ErrorResponse(c, spverrors.ErrSessionUpdate, h.log)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// acceptViewerInvitation creates viewer account from the invitation.
+//
+//	@Summary Accept viewer invitation
+//	@Tags user
+//	@Accept json
+//	@Success 200
+//	@Router /api/v1/viewer-invitations/accept [post]
+//	@Param data body AcceptViewerInvitation true "Invitation token and password of the viewer"
+func (h *handler) acceptViewerInvitation(c *gin.Context) {
+	var reqAccept AcceptViewerInvitation
+	if err := c.Bind(&reqAccept); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	if reqAccept.Password != reqAccept.PasswordConfirmation {
+		spverrors.ErrorResponse(c, spverrors.ErrPasswordMismatch, h.log)
+		return
+	}
+
+	if _, err := h.service.AcceptViewerInvitation(c.Request.Context(), reqAccept.Token, reqAccept.Password); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func writeArchiveFile(archive *zip.Writer, name string, content any) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return err //nolint:wrapcheck // error logged by the caller
+	}
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(content) //nolint:wrapcheck // error logged by the caller
+}
@@ -1,12 +1,85 @@
 package users
 
-import "github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+import (
+	"time"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+)
 
 // RegisterUser is a struct that contains user register data.
 type RegisterUser struct {
 	Email                string `json:"email"`
 	Password             string `json:"password"`
 	PasswordConfirmation string `json:"passwordConfirmation"`
+	Alias                string `json:"alias"`
+	Domain               string `json:"domain"`
+}
+
+// AddPaymail is a struct that contains data required to register an additional paymail.
+type AddPaymail struct {
+	Alias    string `json:"alias"`
+	Domain   string `json:"domain"`
+	Password string `json:"password"`
+}
+
+// SetPrimaryPaymail is a struct that contains paymail which should be used as sender.
+type SetPrimaryPaymail struct {
+	Paymail string `json:"paymail"`
+}
+
+// DeleteUser is a struct that contains data required to delete user account.
+type DeleteUser struct {
+	Password     string `json:"password"`
+	SweepAddress string `json:"sweepAddress"`
+}
+
+// InviteViewer is a struct that contains data required to invite a viewer of the user wallet.
+type InviteViewer struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// RemoveViewer is a struct that contains data required to remove a viewer of the user wallet.
+type RemoveViewer struct {
+	Password string `json:"password"`
+}
+
+// AcceptViewerInvitation is a struct that contains data required to create viewer account from the invitation.
+type AcceptViewerInvitation struct {
+	Token                string `json:"token"`
+	Password             string `json:"password"`
+	PasswordConfirmation string `json:"passwordConfirmation"`
+}
+
+// CreateAPIToken is a struct that contains data required to create API token of the user.
+type CreateAPIToken struct {
+	Name      string    `json:"name"`
+	Scopes    []string  `json:"scopes"`
+	SendLimit uint64    `json:"sendLimit"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Password  string    `json:"password"`
+}
+
+// RevokeAPIToken is a struct that contains data required to revoke API token of the user.
+type RevokeAPIToken struct {
+	Password string `json:"password"`
+}
+
+// SetSpendingPIN is a struct that contains data required to set spending PIN of the user.
+type SetSpendingPIN struct {
+	Password        string `json:"password"`
+	PIN             string `json:"pin"`
+	PINConfirmation string `json:"pinConfirmation"`
+}
+
+// RemoveSpendingPIN is a struct that contains data required to remove spending PIN of the user.
+type RemoveSpendingPIN struct {
+	Password string `json:"password"`
+}
+
+// UnlockSpendingPIN is a struct that contains spending PIN unlocking the xpriv for the session.
+type UnlockSpendingPIN struct {
+	PIN string `json:"pin"`
 }
 
 // RegisterResponse represents response that is sent after user creation.
@@ -17,8 +90,9 @@ type RegisterResponse struct {
 
 // UserResponse is a struct that represents user information.
 type UserResponse struct {
-	UserID  int           `json:"userId"`
-	Paymail string        `json:"paymail"`
-	Email   string        `json:"email"`
-	Balance users.Balance `json:"balance"`
+	UserID      int           `json:"userId"`
+	Paymail     string        `json:"paymail"`
+	Email       string        `json:"email"`
+	Balance     users.Balance `json:"balance"`
+	SpendingPIN bool          `json:"spendingPin"`
 }
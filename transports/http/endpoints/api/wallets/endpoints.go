@@ -0,0 +1,404 @@
+package wallets
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/paymail"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/teams"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/transactions"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/notification"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/auth"
+	router "github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/routes"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/websocket"
+)
+
+type handler struct {
+	service  *teams.Service
+	config   *config.Service
+	resolver *paymail.Resolver
+	tracker  *transactions.Tracker
+	log      *zerolog.Logger
+	ws       websocket.Server
+}
+
+// NewHandler creates new endpoint handler.
+func NewHandler(s *domain.Services, log *zerolog.Logger, ws websocket.Server) router.APIEndpoints {
+	return &handler{
+		service:  s.TeamsService,
+		config:   s.ConfigService,
+		resolver: s.PaymailResolver,
+		tracker:  s.TransactionTracker,
+		log:      log,
+		ws:       ws,
+	}
+}
+
+// RegisterAPIEndpoints registers routes that are part of service API.
+func (h *handler) RegisterAPIEndpoints(router *gin.RouterGroup) {
+	wallets := router.Group("/wallets")
+	{
+		wallets.GET("", h.getWallets)
+		wallets.POST("", h.createWallet)
+		wallets.GET("/:id", h.getWallet)
+		wallets.POST("/:id/invitations", h.inviteMember)
+		wallets.DELETE("/:id/members/:userId", h.removeMember)
+		wallets.POST("/:id/transactions", h.createTransaction)
+		wallets.GET("/:id/transactions", h.getTransactions)
+		wallets.GET("/:id/payments", h.getPendingPayments)
+		wallets.POST("/:id/payments/:paymentId/approvals", h.approvePayment)
+	}
+	router.POST("/wallet-invitations/accept", h.acceptInvitation)
+}
+
+// getWallets returns team wallets of the user.
+//
+//	@Summary Get team wallets
+//	@Tags wallets
+//	@Produce json
+//	@Success 200 {array} teams.MemberWallet
+//	@Router /api/v1/wallets [get]
+func (h *handler) getWallets(c *gin.Context) {
+	wallets, err := h.service.GetUserWallets(c.GetInt(auth.SessionUserID))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, wallets)
+}
+
+// createWallet creates team wallet.
+// @Description Team wallet has its own key and paymail, the user becomes its first owner. Returned mnemonic is the only backup of the wallet key.
+//
+//	@Summary Create team wallet
+//	@Tags wallets
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} CreateWalletResponse
+//	@Router /api/v1/wallets [post]
+//	@Param data body CreateWallet true "Team wallet data and user password"
+func (h *handler) createWallet(c *gin.Context) {
+	var reqWallet CreateWallet
+	if err := c.Bind(&reqWallet); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	domain, err := h.config.RegistrationDomain(reqWallet.Domain)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	created, err := h.service.CreateWallet(c.Request.Context(), c.GetInt(auth.SessionUserID), reqWallet.Password, reqWallet.Name, reqWallet.Alias, domain)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, CreateWalletResponse{
+		ID:       created.Wallet.ID,
+		Name:     created.Wallet.Name,
+		Paymail:  created.Wallet.Paymail,
+		Mnemonic: created.Mnemonic,
+	})
+}
+
+// getWallet returns team wallet with its balance and members.
+//
+//	@Summary Get team wallet
+//	@Tags wallets
+//	@Produce json
+//	@Success 200 {object} teams.WalletDetails
+//	@Router /api/v1/wallets/{id} [get]
+//	@Param id path int true "Team wallet id"
+func (h *handler) getWallet(c *gin.Context) {
+	walletID, ok := h.walletID(c)
+	if !ok {
+		return
+	}
+
+	wallet, err := h.service.GetWallet(walletID, c.GetInt(auth.SessionUserID))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, wallet)
+}
+
+// inviteMember invites a user to the team wallet.
+// @Description Returned token has to be passed to the invited user, who accepts the invitation with it. Only owners of the team wallet can invite members.
+//
+//	@Summary Invite team wallet member
+//	@Tags wallets
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} teams.CreatedInvitation
+//	@Router /api/v1/wallets/{id}/invitations [post]
+//	@Param id path int true "Team wallet id"
+//	@Param data body InviteMember true "Email and role of the member and user password"
+func (h *handler) inviteMember(c *gin.Context) {
+	walletID, ok := h.walletID(c)
+	if !ok {
+		return
+	}
+
+	var reqInvite InviteMember
+	if err := c.Bind(&reqInvite); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	invitation, err := h.service.InviteMember(c.Request.Context(), walletID, c.GetInt(auth.SessionUserID), reqInvite.Password, reqInvite.Email, reqInvite.Role)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+// acceptInvitation makes the user a member of the team wallet.
+//
+//	@Summary Accept team wallet invitation
+//	@Tags wallets
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} teams.MemberWallet
+//	@Router /api/v1/wallet-invitations/accept [post]
+//	@Param data body AcceptInvitation true "Invitation token and user password"
+func (h *handler) acceptInvitation(c *gin.Context) {
+	var reqAccept AcceptInvitation
+	if err := c.Bind(&reqAccept); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	wallet, err := h.service.AcceptInvitation(c.Request.Context(), c.GetInt(auth.SessionUserID), reqAccept.Token, reqAccept.Password)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, wallet)
+}
+
+// removeMember removes the member from the team wallet.
+// @Description Owners can remove any member, other members can only leave the team wallet.
+//
+//	@Summary Remove team wallet member
+//	@Tags wallets
+//	@Success 200
+//	@Router /api/v1/wallets/{id}/members/{userId} [delete]
+//	@Param id path int true "Team wallet id"
+//	@Param userId path int true "User id of the member"
+func (h *handler) removeMember(c *gin.Context) {
+	walletID, ok := h.walletID(c)
+	if !ok {
+		return
+	}
+
+	memberID, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrMemberNotFound, h.log)
+		return
+	}
+
+	if err = h.service.RemoveMember(c.Request.Context(), walletID, c.GetInt(auth.SessionUserID), memberID); err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// createTransaction creates transaction from the team wallet.
+// @Description Transaction is sent with the wallet key decrypted by the password of the member, the member is recorded as its initiator.
+// @Description Payment above the approval threshold is held until enough members approve it, it is returned with status 202 and the members who can approve it are notified.
+//
+//	@Summary Create team wallet transaction
+//	@Tags wallets
+//	@Accept json
+//	@Produce json
+//	@Success 200
+//	@Success 202 {object} teams.PendingPayment
+//	@Router /api/v1/wallets/{id}/transactions [post]
+//	@Param id path int true "Team wallet id"
+//	@Param data body CreateTransaction true "Create transaction data"
+func (h *handler) createTransaction(c *gin.Context) {
+	walletID, ok := h.walletID(c)
+	if !ok {
+		return
+	}
+
+	var reqTransaction CreateTransaction
+	if err := c.Bind(&reqTransaction); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	// Validate recipient, so invalid paymail is reported before transaction is drafted.
+	recipient, err := h.resolver.ValidateRecipient(c.Request.Context(), reqTransaction.Recipient)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	userID := c.GetInt(auth.SessionUserID)
+	events := make(chan notification.TransactionEvent)
+	payment, err := h.service.CreateTransaction(c.Request.Context(), walletID, userID, reqTransaction.Password, recipient, reqTransaction.Satoshis, events)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	if payment != nil {
+		h.notifyApprovers(payment)
+		c.JSON(http.StatusAccepted, payment)
+		return
+	}
+	h.notifyTransaction(userID, events)
+
+	c.Status(http.StatusOK)
+}
+
+// getPendingPayments returns payments of the team wallet waiting for approvals.
+//
+//	@Summary Get team wallet payments waiting for approvals
+//	@Tags wallets
+//	@Produce json
+//	@Success 200 {array} teams.PendingPayment
+//	@Router /api/v1/wallets/{id}/payments [get]
+//	@Param id path int true "Team wallet id"
+func (h *handler) getPendingPayments(c *gin.Context) {
+	walletID, ok := h.walletID(c)
+	if !ok {
+		return
+	}
+
+	payments, err := h.service.GetPendingPayments(walletID, c.GetInt(auth.SessionUserID))
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, payments)
+}
+
+// approvePayment approves payment of the team wallet waiting for approvals.
+// @Description Payment is sent when it has the required number of approvals, the initiator is notified about the transaction.
+//
+//	@Summary Approve team wallet payment
+//	@Tags wallets
+//	@Accept json
+//	@Produce json
+//	@Success 200 {object} teams.PendingPayment
+//	@Router /api/v1/wallets/{id}/payments/{paymentId}/approvals [post]
+//	@Param id path int true "Team wallet id"
+//	@Param paymentId path int true "Payment id"
+//	@Param data body ApprovePayment true "User password"
+func (h *handler) approvePayment(c *gin.Context) {
+	walletID, ok := h.walletID(c)
+	if !ok {
+		return
+	}
+
+	paymentID, err := strconv.Atoi(c.Param("paymentId"))
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrPaymentNotFound, h.log)
+		return
+	}
+
+	var reqApprove ApprovePayment
+	if err = c.Bind(&reqApprove); err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrCannotBindRequest, h.log)
+		return
+	}
+
+	events := make(chan notification.TransactionEvent)
+	payment, err := h.service.ApprovePayment(c.Request.Context(), walletID, paymentID, c.GetInt(auth.SessionUserID), reqApprove.Password, events)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+	if payment.Status == teams.PaymentStatusApproved {
+		h.notifyTransaction(payment.InitiatorID, events)
+	}
+
+	c.JSON(http.StatusOK, payment)
+}
+
+// getTransactions returns transactions sent from the team wallet with members who initiated them.
+//
+//	@Summary Get team wallet transactions
+//	@Tags wallets
+//	@Produce json
+//	@Success 200 {object} teams.TransactionsPage
+//	@Router /api/v1/wallets/{id}/transactions [get]
+//	@Param id path int true "Team wallet id"
+//	@Param page query int false "Page number, starting from 1"
+//	@Param pageSize query int false "Number of transactions in the page"
+func (h *handler) getTransactions(c *gin.Context) {
+	walletID, ok := h.walletID(c)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("pageSize"))
+
+	txs, err := h.service.GetTransactions(walletID, c.GetInt(auth.SessionUserID), page, pageSize)
+	if err != nil {
+		spverrors.ErrorResponse(c, err, h.log)
+		return
+	}
+
+	c.JSON(http.StatusOK, txs)
+}
+
+// notifyTransaction tracks the transaction for the user and notifies the user about it once it is recorded.
+func (h *handler) notifyTransaction(userID int, events chan notification.TransactionEvent) {
+	go func() {
+		transaction := <-events
+		if transaction.Transaction != nil {
+			_ = h.tracker.Track(context.Background(), userID, transaction.Transaction.ID)
+		}
+		h.ws.GetSocket(websocket.UserTransactionsChannel(strconv.Itoa(userID))).Notify(transaction)
+	}()
+}
+
+// notifyApprovers notifies members who can approve the payment that it is waiting for them.
+func (h *handler) notifyApprovers(payment *teams.PendingPayment) {
+	event := notification.PreparePaymentApprovalEvent(&notification.PendingPayment{
+		ID:                payment.ID,
+		WalletID:          payment.WalletID,
+		Initiator:         payment.InitiatorEmail,
+		Recipient:         payment.Recipient,
+		Satoshis:          payment.Satoshis,
+		Approvals:         len(payment.ApprovedBy),
+		RequiredApprovals: payment.RequiredApprovals,
+		ExpiresAt:         payment.ExpiresAt,
+	})
+	for _, userID := range payment.Approvers {
+		h.ws.GetSocket(websocket.UserTransactionsChannel(strconv.Itoa(userID))).Notify(event)
+	}
+}
+
+// walletID returns id of the team wallet from the path, invalid id is responded as not found wallet.
+func (h *handler) walletID(c *gin.Context) (int, bool) {
+	walletID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		spverrors.ErrorResponse(c, spverrors.ErrTeamWalletNotFound, h.log)
+		return 0, false
+	}
+	return walletID, true
+}
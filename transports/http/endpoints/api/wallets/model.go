@@ -0,0 +1,42 @@
+package wallets
+
+// CreateWallet is a struct that contains data required to create a team wallet.
+type CreateWallet struct {
+	Name     string `json:"name"`
+	Alias    string `json:"alias"`
+	Domain   string `json:"domain"`
+	Password string `json:"password"`
+}
+
+// CreateWalletResponse represents response that is sent after team wallet creation.
+type CreateWalletResponse struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Paymail  string `json:"paymail"`
+	Mnemonic string `json:"mnemonic"`
+}
+
+// InviteMember is a struct that contains data required to invite a member to the team wallet.
+type InviteMember struct {
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+	Password string `json:"password"`
+}
+
+// AcceptInvitation is a struct that contains data required to become a member of the team wallet.
+type AcceptInvitation struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// CreateTransaction represents request for creating new transaction from the team wallet.
+type CreateTransaction struct {
+	Password  string `json:"password"`
+	Recipient string `json:"recipient"`
+	Satoshis  uint64 `json:"satoshis"`
+}
+
+// ApprovePayment is a struct that contains data required to approve payment of the team wallet.
+type ApprovePayment struct {
+	Password string `json:"password"`
+}
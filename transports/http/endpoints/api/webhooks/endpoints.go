@@ -0,0 +1,93 @@
+package webhooks
+
+import (
+	"crypto/subtle"
+
+	"github.com/bsv-blockchain/spv-wallet-go-client/notifications"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/spverrors"
+	router "github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/routes"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/spvwallet"
+)
+
+type handler struct {
+	webhook     *notifications.Webhook
+	tokenHeader string
+	tokenValue  string
+	log         *zerolog.Logger
+}
+
+// NewWebhook creates the SPV Wallet webhook consumer passing events to the events service.
+// When webhook url or token is not configured, it returns nil, as the webhook is disabled.
+// The webhook is subscribed in SPV Wallet by spvwallet.WebhookSubscription.
+func NewWebhook(s *domain.Services, log *zerolog.Logger) *notifications.Webhook {
+	url := viper.GetString(config.EnvWebhookURL)
+	if url == "" {
+		log.Info().Msg("SPV Wallet webhook is disabled, webhook url is not configured")
+		return nil
+	}
+
+	tokenHeader := viper.GetString(config.EnvWebhookTokenHeader)
+	tokenValue := viper.GetString(config.EnvWebhookTokenValue)
+	if tokenHeader == "" || tokenValue == "" {
+		log.Warn().Msg("SPV Wallet webhook is disabled, webhook token is not configured")
+		return nil
+	}
+
+	webhook, err := spvwallet.NewWebhook(log, url, tokenHeader, tokenValue)
+	if err != nil {
+		log.Error().Msgf("Cannot create SPV Wallet webhook: %v", err.Error())
+		return nil
+	}
+
+	_ = notifications.RegisterHandler(webhook, s.EventsService.HandleTransactionEvent)
+	_ = notifications.RegisterHandler(webhook, s.EventsService.HandleStringEvent)
+
+	return webhook
+}
+
+// NewHandler creates new endpoint handler of the webhook. When the webhook is disabled, no endpoints are registered.
+func NewHandler(webhook *notifications.Webhook, log *zerolog.Logger) router.RootEndpoints {
+	if webhook == nil {
+		return router.RootEndpointsFunc(func(_ *gin.RouterGroup) {})
+	}
+
+	return &handler{
+		webhook:     webhook,
+		tokenHeader: viper.GetString(config.EnvWebhookTokenHeader),
+		tokenValue:  viper.GetString(config.EnvWebhookTokenValue),
+		log:         log,
+	}
+}
+
+// RegisterEndpoints registers endpoints in root context of application.
+func (h *handler) RegisterEndpoints(router *gin.RouterGroup) {
+	prefix := "/api/v1"
+	router.POST(prefix+"/webhook", h.verifyToken, h.handleEvents)
+}
+
+// verifyToken aborts requests without the token shared with SPV Wallet.
+func (h *handler) verifyToken(c *gin.Context) {
+	token := c.GetHeader(h.tokenHeader)
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.tokenValue)) != 1 {
+		spverrors.AbortWithErrorResponse(c, spverrors.ErrUnauthorized, h.log)
+		return
+	}
+	c.Next()
+}
+
+// handleEvents receives events from SPV Wallet.
+//
+//	@Summary Receive SPV Wallet events
+//	@Tags webhook
+//	@Accept json
+//	@Success 200
+//	@Router /api/v1/webhook [post]
+func (h *handler) handleEvents(c *gin.Context) {
+	h.webhook.HTTPHandler().ServeHTTP(c.Writer, c.Request)
+}
@@ -3,17 +3,27 @@ package endpoints
 import (
 	"database/sql"
 	"errors"
+	"net/http"
 
+	"github.com/bsv-blockchain/spv-wallet-go-client/notifications"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
 
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain"
+	domainusers "github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/auth"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/api/access"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/api/admin"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/api/config"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/api/contacts"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/api/passkeys"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/api/paymails"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/api/profile"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/api/sso"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/api/transactions"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/api/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/api/wallets"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/api/webhooks"
 	router "github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/routes"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/status"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/swagger"
@@ -21,12 +31,44 @@ import (
 	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/websocket"
 )
 
+// viewerEndpoints are API endpoints which viewers can call, all API endpoints are available to owners.
+var viewerEndpoints = []auth.Endpoint{
+	{Method: http.MethodGet, Path: "/api/v1/user"},
+	{Method: http.MethodGet, Path: "/api/v1/user/security-events"},
+	{Method: http.MethodPost, Path: "/api/v1/sign-out"},
+	{Method: http.MethodPost, Path: "/api/v1/transaction/search"},
+	{Method: http.MethodGet, Path: "/api/v1/transaction/:id"},
+	{Method: http.MethodPost, Path: "/api/v1/contact/search"},
+}
+
+// apiTokenScopeEndpoints are API endpoints which can be called with API token of the scope, session is required for others.
+var apiTokenScopeEndpoints = map[string][]auth.Endpoint{
+	domainusers.APITokenScopeBalanceRead: {
+		{Method: http.MethodGet, Path: "/api/v1/user"},
+	},
+	domainusers.APITokenScopeTransactionsRead: {
+		{Method: http.MethodPost, Path: "/api/v1/transaction/search"},
+		{Method: http.MethodGet, Path: "/api/v1/transaction/:id"},
+	},
+	domainusers.APITokenScopeTransactionsSend: {
+		{Method: http.MethodPost, Path: "/api/v1/transaction"},
+	},
+	domainusers.APITokenScopeContactsManage: {
+		{Method: http.MethodPost, Path: "/api/v1/contact/search"},
+		{Method: http.MethodPut, Path: "/api/v1/contact/:paymail"},
+		{Method: http.MethodPatch, Path: "/api/v1/contact/accepted/:paymail"},
+		{Method: http.MethodPatch, Path: "/api/v1/contact/rejected/:paymail"},
+	},
+}
+
 // SetupWalletRoutes main point where we're registering endpoints registrars (handlers that will register endpoints in gin engine)
 //
 //	and middlewares. It's returning function that can be used to setup engine of httpserver.HTTPServer
-func SetupWalletRoutes(s *domain.Services, db *sql.DB, log *zerolog.Logger, ws websocket.Server) httpserver.GinEngineOpt {
-	accessRootEndpoints, accessAPIEndpoints := access.NewHandler(s, log)
-	usersRootEndpoints, usersAPIEndpoints := users.NewHandler(s, log)
+func SetupWalletRoutes(s *domain.Services, db *sql.DB, log *zerolog.Logger, ws websocket.Server, webhook *notifications.Webhook) httpserver.GinEngineOpt {
+	accessRootEndpoints, accessAPIEndpoints := access.NewHandler(s, log, ws)
+	usersRootEndpoints, usersAPIEndpoints := users.NewHandler(s, log, ws)
+	profileRootEndpoints, profileAPIEndpoints := profile.NewHandler(s, log)
+	passkeysRootEndpoints, passkeysAPIEndpoints := passkeys.NewHandler(s, log)
 
 	routes := []interface{}{
 		swagger.NewHandler(),
@@ -34,16 +76,28 @@ func SetupWalletRoutes(s *domain.Services, db *sql.DB, log *zerolog.Logger, ws w
 		config.NewHandler(s, log),
 		usersRootEndpoints,
 		usersAPIEndpoints,
+		profileRootEndpoints,
+		profileAPIEndpoints,
 		accessRootEndpoints,
 		accessAPIEndpoints,
+		passkeysRootEndpoints,
+		passkeysAPIEndpoints,
 		transactions.NewHandler(s, log, ws),
 		contacts.NewHandler(s, log),
+		wallets.NewHandler(s, log, ws),
+		paymails.NewHandler(s, log),
+		webhooks.NewHandler(webhook, log),
+		admin.NewHandler(s, log, ws),
+		sso.NewHandler(s, log),
 	}
 
 	return func(engine *gin.Engine) {
+		engine.Use(auth.AuditOriginMiddleware())
 		apiMiddlewares := router.ToHandlers(
 			auth.NewSessionMiddleware(db, engine),
 			auth.NewAuthMiddleware(s, log),
+			auth.NewRoleMiddleware(viewerEndpoints, log),
+			auth.NewScopeMiddleware(apiTokenScopeEndpoints, log),
 		)
 
 		rootRouter := engine.Group("")
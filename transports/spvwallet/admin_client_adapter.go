@@ -2,18 +2,25 @@ package spvwallet
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"strings"
 
 	walletclient "github.com/bsv-blockchain/spv-wallet-go-client"
 	"github.com/bsv-blockchain/spv-wallet-go-client/commands"
 	walletclientCfg "github.com/bsv-blockchain/spv-wallet-go-client/config"
+	"github.com/bsv-blockchain/spv-wallet-go-client/queries"
 	"github.com/bsv-blockchain/spv-wallet/models"
+	"github.com/bsv-blockchain/spv-wallet/models/filter"
+	"github.com/bsv-blockchain/spv-wallet/models/response"
 	"github.com/libsv/go-bk/bip32"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
 
 	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
 )
 
 type adminClientAdapter struct {
@@ -31,6 +38,10 @@ func (a *adminClientAdapter) RegisterXpub(xpriv *bip32.ExtendedKey) (string, err
 
 	_, err = a.api.CreateXPub(context.Background(), &commands.CreateUserXpub{XPub: xpub.String()})
 	if err != nil {
+		// Registration is retried with the same xpub, so the xpub may already be registered by a previous attempt.
+		if a.xpubExists(xpubID(xpub.String())) {
+			return xpub.String(), nil
+		}
 		a.log.Error().Str("xpub", xpub.String()).Msgf("Error while creating new xPub: %v", err.Error())
 		return "", errors.Wrap(err, "error while creating new xPub")
 	}
@@ -38,23 +49,23 @@ func (a *adminClientAdapter) RegisterXpub(xpriv *bip32.ExtendedKey) (string, err
 	return xpub.String(), nil
 }
 
-func (a *adminClientAdapter) RegisterPaymail(alias, xpub string) (string, error) {
-	// Get paymail domain from env.
-	domain := viper.GetString(config.EnvPaymailDomain)
-
+func (a *adminClientAdapter) RegisterPaymail(alias, domain, xpub string, profile *users.PaymailProfile) (string, error) {
 	// Create paymail address.
 	address := fmt.Sprintf("%s@%s", alias, domain)
 
-	// Get avatar url from env.
-	avatar := viper.GetString(config.EnvPaymailAvatar)
+	publicName, avatar := a.paymailProfile(alias, domain, profile)
 
 	_, err := a.api.CreatePaymail(context.Background(), &commands.CreatePaymail{
 		Key:        xpub,
 		Address:    address,
-		PublicName: alias,
+		PublicName: publicName,
 		Avatar:     avatar,
 	})
 	if err != nil {
+		// Registration is retried with the same xpub, so the paymail may already be registered by a previous attempt.
+		if existing, _ := a.findPaymail(alias, domain); existing != nil && existing.XpubID == xpubID(xpub) {
+			return address, nil
+		}
 		a.log.Error().Msgf("Error while creating new paymail: %v", err.Error())
 		return "", errors.Wrap(err, "error while creating new paymail")
 	}
@@ -62,6 +73,155 @@ func (a *adminClientAdapter) RegisterPaymail(alias, xpub string) (string, error)
 	return address, nil
 }
 
+// UpdatePaymailProfile sets public name and avatar of the paymail. SPV Wallet does not provide
+// a paymail update endpoint, so the paymail record is deleted and created again with the same address,
+// or with the original profile when it cannot be created with the new one.
+func (a *adminClientAdapter) UpdatePaymailProfile(address, xpub string, profile *users.PaymailProfile) error {
+	alias, domain, found := strings.Cut(address, "@")
+	if !found {
+		return errors.Errorf("invalid paymail address %s", address)
+	}
+
+	current, err := a.findPaymail(alias, domain)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return errors.Errorf("paymail %s not found", address)
+	}
+
+	publicName, avatar := a.paymailProfile(alias, domain, profile)
+	if current.PublicName == publicName && current.Avatar == avatar {
+		return nil
+	}
+
+	if err = a.api.DeletePaymail(context.Background(), current.ID); err != nil {
+		a.log.Error().Str("paymail", address).Msgf("Error while deleting paymail: %v", err.Error())
+		return errors.Wrap(err, "error while deleting paymail")
+	}
+
+	_, err = a.api.CreatePaymail(context.Background(), &commands.CreatePaymail{
+		Key:        xpub,
+		Address:    address,
+		PublicName: publicName,
+		Avatar:     avatar,
+	})
+	if err != nil {
+		a.log.Error().Str("paymail", address).Msgf("Error while recreating paymail: %v", err.Error())
+		a.restorePaymail(address, xpub, current)
+		return errors.Wrap(err, "error while recreating paymail")
+	}
+
+	return nil
+}
+
+// restorePaymail creates the deleted paymail again with its original public name and avatar,
+// so the user does not stay without the paymail when it cannot be recreated with the new profile.
+func (a *adminClientAdapter) restorePaymail(address, xpub string, paymail *response.PaymailAddress) {
+	_, err := a.api.CreatePaymail(context.Background(), &commands.CreatePaymail{
+		Key:        xpub,
+		Address:    address,
+		PublicName: paymail.PublicName,
+		Avatar:     paymail.Avatar,
+	})
+	if err != nil {
+		a.log.Error().Str("paymail", address).Msgf("Error while restoring paymail: %v", err.Error())
+	}
+}
+
+func (a *adminClientAdapter) IsPaymailAvailable(alias, domain string) (bool, error) {
+	paymail, err := a.findPaymail(alias, domain)
+	if err != nil {
+		return false, err
+	}
+
+	return paymail == nil, nil
+}
+
+// DeletePaymail deletes the paymail, paymail which does not exist is not an error.
+func (a *adminClientAdapter) DeletePaymail(address string) error {
+	alias, domain, found := strings.Cut(address, "@")
+	if !found {
+		return errors.Errorf("invalid paymail address %s", address)
+	}
+
+	paymail, err := a.findPaymail(alias, domain)
+	if err != nil || paymail == nil {
+		return err
+	}
+
+	if err = a.api.DeletePaymail(context.Background(), paymail.ID); err != nil {
+		a.log.Error().Str("paymail", address).Msgf("Error while deleting paymail: %v", err.Error())
+		return errors.Wrap(err, "error while deleting paymail")
+	}
+
+	return nil
+}
+
+// GetXpub returns xpub with given id.
+func (a *adminClientAdapter) GetXpub(xpubID string) (users.PubKey, error) {
+	page, err := a.api.XPubs(context.Background(), queries.QueryWithFilter(filter.XpubFilter{ID: &xpubID}))
+	if err != nil {
+		a.log.Error().Str("xpubId", xpubID).Msgf("Error while searching xPubs: %v", err.Error())
+		return nil, errors.Wrap(err, "error while searching xPubs")
+	}
+	if len(page.Content) == 0 {
+		return nil, errors.Errorf("xPub %s not found", xpubID)
+	}
+
+	return &XPub{ID: page.Content[0].ID, CurrentBalance: page.Content[0].CurrentBalance}, nil
+}
+
+// GetXpubPaymails returns paymails registered for xpub with given id.
+func (a *adminClientAdapter) GetXpubPaymails(xpubID string) ([]*users.WalletPaymail, error) {
+	page, err := a.api.Paymails(context.Background(), queries.QueryWithFilter(filter.AdminPaymailFilter{XpubID: &xpubID}))
+	if err != nil {
+		a.log.Error().Str("xpubId", xpubID).Msgf("Error while searching paymails: %v", err.Error())
+		return nil, errors.Wrap(err, "error while searching paymails")
+	}
+
+	paymails := make([]*users.WalletPaymail, 0, len(page.Content))
+	for _, paymail := range page.Content {
+		paymails = append(paymails, &users.WalletPaymail{
+			Address:    paymail.Address,
+			PublicName: paymail.PublicName,
+			Avatar:     paymail.Avatar,
+		})
+	}
+
+	return paymails, nil
+}
+
+// findPaymail returns paymail with given alias and domain, nil is returned when it does not exist.
+func (a *adminClientAdapter) findPaymail(alias, domain string) (*response.PaymailAddress, error) {
+	page, err := a.api.Paymails(context.Background(), queries.QueryWithFilter(filter.AdminPaymailFilter{
+		PaymailFilter: filter.PaymailFilter{
+			Alias:  &alias,
+			Domain: &domain,
+		},
+	}))
+	if err != nil {
+		a.log.Error().Str("alias", alias).Str("domain", domain).Msgf("Error while searching paymails: %v", err.Error())
+		return nil, errors.Wrap(err, "error while searching paymails")
+	}
+	if len(page.Content) == 0 {
+		return nil, nil
+	}
+
+	return page.Content[0], nil
+}
+
+// xpubExists checks if xpub with given id is registered.
+func (a *adminClientAdapter) xpubExists(id string) bool {
+	page, err := a.api.XPubs(context.Background(), queries.QueryWithFilter(filter.XpubFilter{ID: &id}))
+	if err != nil {
+		a.log.Error().Str("xpubId", id).Msgf("Error while searching xPubs: %v", err.Error())
+		return false
+	}
+
+	return len(page.Content) > 0
+}
+
 func (a *adminClientAdapter) GetSharedConfig() (*models.SharedConfig, error) {
 	sharedConfig, err := a.api.SharedConfig(context.Background())
 	if err != nil {
@@ -75,6 +235,55 @@ func (a *adminClientAdapter) GetSharedConfig() (*models.SharedConfig, error) {
 	}, nil
 }
 
+func (a *adminClientAdapter) GetTransaction(transactionID string) (users.FullTransaction, error) {
+	transaction, err := a.api.Transaction(context.Background(), transactionID)
+	if err != nil {
+		a.log.Error().Str("transactionId", transactionID).Msgf("Error while getting transaction: %v", err.Error())
+		return nil, errors.Wrap(err, "error while getting transaction")
+	}
+
+	sender, receiver := GetPaymailsFromMetadata(transaction, "unknown")
+	return &FullTransaction{
+		ID:              transaction.ID,
+		BlockHash:       transaction.BlockHash,
+		BlockHeight:     transaction.BlockHeight,
+		TotalValue:      getAbsoluteValue(transaction.OutputValue),
+		Direction:       fmt.Sprint(transaction.TransactionDirection),
+		Status:          transaction.Status,
+		Fee:             transaction.Fee,
+		NumberOfInputs:  transaction.NumberOfInputs,
+		NumberOfOutputs: transaction.NumberOfOutputs,
+		CreatedAt:       transaction.CreatedAt,
+		Sender:          sender,
+		Receiver:        receiver,
+	}, nil
+}
+
+// xpubID returns id of the xpub - SPV Wallet identifies xpubs by sha256 hash of their string representation.
+func xpubID(xpub string) string {
+	hash := sha256.Sum256([]byte(xpub))
+	return hex.EncodeToString(hash[:])
+}
+
+// paymailProfile returns public name and avatar of the paymail, falling back to the alias and avatar of the domain.
+func (a *adminClientAdapter) paymailProfile(alias, domain string, profile *users.PaymailProfile) (string, string) {
+	settings, err := config.GetPaymailDomainSettings(domain)
+	if err != nil {
+		a.log.Warn().Err(err).Str("domain", domain).Msg("Using default paymail domain settings")
+	}
+
+	publicName, avatar := alias, settings.Avatar
+	if profile != nil {
+		if profile.PublicName != "" {
+			publicName = profile.PublicName
+		}
+		if profile.Avatar != "" {
+			avatar = profile.Avatar
+		}
+	}
+	return publicName, avatar
+}
+
 func newAdminClientAdapter(log *zerolog.Logger) (*adminClientAdapter, error) {
 	adminKey := viper.GetString(config.EnvAdminXpriv)
 	serverURL := viper.GetString(config.EnvServerURL)
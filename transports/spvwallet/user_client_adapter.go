@@ -11,6 +11,7 @@ import (
 	"github.com/bsv-blockchain/spv-wallet/models"
 	"github.com/bsv-blockchain/spv-wallet/models/common"
 	"github.com/bsv-blockchain/spv-wallet/models/filter"
+	"github.com/bsv-blockchain/spv-wallet/models/response"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
@@ -19,6 +20,9 @@ import (
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
 )
 
+// accessKeysPageSize is the number of access keys fetched per request when revoking all of them.
+const accessKeysPageSize = 50
+
 type userClientAdapter struct {
 	api *walletclient.UserAPI
 	log *zerolog.Logger
@@ -60,6 +64,34 @@ func (u *userClientAdapter) RevokeAccessKey(accessKeyID string) (users.AccKey, e
 	return &AccessKey{ID: accessKey.ID, Key: accessKey.Key}, nil
 }
 
+// RevokeAllAccessKeys revokes every access key of the user which is not revoked yet.
+func (u *userClientAdapter) RevokeAllAccessKeys() error {
+	for page := 1; ; page++ {
+		accessKeys, err := u.api.AccessKeys(context.Background(), queries.QueryWithPageFilter[filter.AccessKeyFilter](filter.Page{
+			Number: page,
+			Size:   accessKeysPageSize,
+		}))
+		if err != nil {
+			u.log.Error().Msgf("Error while fetching accessKeys: %v", err.Error())
+			return errors.Wrap(err, "error while fetching accessKeys")
+		}
+
+		for _, accessKey := range accessKeys.Content {
+			if accessKey.RevokedAt != nil {
+				continue
+			}
+			if err = u.api.RevokeAccessKey(context.Background(), accessKey.ID); err != nil {
+				u.log.Error().Str("accessKeyID", accessKey.ID).Msgf("Error while revoking accessKey: %v", err.Error())
+				return errors.Wrap(err, "error while revoking accessKey")
+			}
+		}
+
+		if len(accessKeys.Content) == 0 || page >= accessKeys.Page.TotalPages {
+			return nil
+		}
+	}
+}
+
 // XPub Key methods
 func (u *userClientAdapter) GetXPub() (users.PubKey, error) {
 	xpub, err := u.api.XPub(context.Background())
@@ -94,6 +126,47 @@ func (u *userClientAdapter) SendToRecipients(recipients []*commands.Recipients,
 	}, nil
 }
 
+// SendAll sends all funds of the user to the recipient, the fee is paid from the sent amount.
+func (u *userClientAdapter) SendAll(to, senderPaymail string) (users.Transaction, error) {
+	metadata := map[string]any{
+		"receiver": to,
+		"sender":   senderPaymail,
+	}
+
+	draftTx, err := u.api.DraftTransaction(context.Background(), &commands.DraftTransaction{
+		Config:   response.TransactionConfig{SendAllTo: &response.TransactionOutput{To: to}},
+		Metadata: metadata,
+	})
+	if err != nil {
+		u.log.Error().Msgf("Error while creating draft transaction sending all funds: %v", err.Error())
+		return nil, errors.Wrap(err, "error while creating draft transaction")
+	}
+
+	hex, err := u.api.FinalizeTransaction(draftTx)
+	if err != nil {
+		u.log.Error().Str("draftTxID", draftTx.ID).Msgf("Error while finalizing transaction: %v", err.Error())
+		return nil, errors.Wrap(err, "error while finalizing transaction")
+	}
+
+	transaction, err := u.api.RecordTransaction(context.Background(), &commands.RecordTransaction{
+		Metadata:    metadata,
+		Hex:         hex,
+		ReferenceID: draftTx.ID,
+	})
+	if err != nil {
+		u.log.Error().Str("draftTxID", draftTx.ID).Msgf("Error while recording tx: %v", err.Error())
+		return nil, errors.Wrap(err, "error while recording tx")
+	}
+
+	return &Transaction{
+		ID:         transaction.ID,
+		Direction:  fmt.Sprint(transaction.TransactionDirection),
+		TotalValue: transaction.TotalValue,
+		Status:     transaction.Status,
+		CreatedAt:  transaction.CreatedAt,
+	}, nil
+}
+
 func (u *userClientAdapter) GetTransactions(queryParam *filter.QueryParams, userPaymail string) ([]users.Transaction, error) {
 	if queryParam.OrderByField == "" {
 		queryParam.OrderByField = "created_at"
@@ -166,18 +239,33 @@ func (u *userClientAdapter) GetTransactionsCount() (int64, error) {
 }
 
 func (u *userClientAdapter) CreateAndFinalizeTransaction(recipients []*commands.Recipients, metadata map[string]any) (users.DraftTransaction, error) {
-	draftTx, err := u.api.SendToRecipients(context.Background(), &commands.SendToRecipients{
-		Recipients: recipients,
-		Metadata:   metadata,
+	outputs := make([]*response.TransactionOutput, 0, len(recipients))
+	for _, recipient := range recipients {
+		outputs = append(outputs, &response.TransactionOutput{
+			To:       recipient.To,
+			Satoshis: recipient.Satoshis,
+			OpReturn: recipient.OpReturn,
+		})
+	}
+
+	draftTx, err := u.api.DraftTransaction(context.Background(), &commands.DraftTransaction{
+		Config:   response.TransactionConfig{Outputs: outputs},
+		Metadata: metadata,
 	})
 	if err != nil {
-		u.log.Error().Msgf("Error while sending to recipients: %v", err.Error())
-		return nil, errors.Wrap(err, "error while sending to recipients")
+		u.log.Error().Msgf("Error while creating draft transaction: %v", err.Error())
+		return nil, errors.Wrap(err, "error while creating draft transaction")
+	}
+
+	hex, err := u.api.FinalizeTransaction(draftTx)
+	if err != nil {
+		u.log.Error().Str("draftTxID", draftTx.ID).Msgf("Error while finalizing transaction: %v", err.Error())
+		return nil, errors.Wrap(err, "error while finalizing transaction")
 	}
 
 	return &DraftTransaction{
-		TxDraftID: draftTx.DraftID,
-		TxHex:     draftTx.Hex,
+		TxDraftID: draftTx.ID,
+		TxHex:     hex,
 	}, nil
 }
 
@@ -0,0 +1,82 @@
+package spvwallet
+
+import (
+	"context"
+	"time"
+
+	walletclient "github.com/bsv-blockchain/spv-wallet-go-client"
+	"github.com/bsv-blockchain/spv-wallet-go-client/commands"
+	"github.com/bsv-blockchain/spv-wallet-go-client/notifications"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+)
+
+// webhookSubscriber adapts admin API to the subscriber used to register webhook in SPV Wallet.
+type webhookSubscriber struct {
+	api *walletclient.AdminAPI
+}
+
+func (w *webhookSubscriber) AdminSubscribeWebhook(ctx context.Context, cmd *commands.CreateWebhookSubscription) error {
+	return w.api.SubscribeWebhook(ctx, cmd) //nolint:wrapcheck // error wrapped by notifications package
+}
+
+func (w *webhookSubscriber) AdminUnsubscribeWebhook(ctx context.Context, cmd *commands.CancelWebhookSubscription) error {
+	return w.api.UnsubscribeWebhook(ctx, cmd) //nolint:wrapcheck // error wrapped by notifications package
+}
+
+func (w *webhookSubscriber) AdminGetAllWebhooks(ctx context.Context) ([]*notifications.Webhook, error) {
+	return w.api.GetAllWebhooks(ctx) //nolint:wrapcheck // error wrapped by notifications package
+}
+
+// NewWebhook creates SPV Wallet webhook consumer which can be subscribed with admin key.
+func NewWebhook(log *zerolog.Logger, url, tokenHeader, tokenValue string) (*notifications.Webhook, error) {
+	adminClient, err := newAdminClientAdapter(log)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create webhook")
+	}
+
+	return notifications.NewWebhook(&webhookSubscriber{api: adminClient.api}, url, notifications.WithToken(tokenHeader, tokenValue)), nil
+}
+
+// WebhookSubscription subscribes the webhook in SPV Wallet, retrying until it succeeds,
+// so notifications are not off until restart when SPV Wallet is unavailable at startup.
+type WebhookSubscription struct {
+	webhook *notifications.Webhook
+	log     *zerolog.Logger
+}
+
+// NewWebhookSubscription creates subscription of the webhook in SPV Wallet.
+func NewWebhookSubscription(webhook *notifications.Webhook, logger *zerolog.Logger) *WebhookSubscription {
+	log := logger.With().Str("service", "webhook-subscription").Logger()
+	return &WebhookSubscription{
+		webhook: webhook,
+		log:     &log,
+	}
+}
+
+// Run subscribes the webhook, retrying failed attempts until it succeeds or the context is canceled.
+func (s *WebhookSubscription) Run(ctx context.Context) {
+	for {
+		err := s.subscribe(ctx)
+		if err == nil {
+			s.log.Info().Msg("SPV Wallet webhook subscribed")
+			return
+		}
+		s.log.Warn().Msgf("Cannot subscribe SPV Wallet webhook, retrying: %v", err.Error())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(viper.GetDuration(config.EnvWebhookSubscribeRetryInterval)):
+		}
+	}
+}
+
+func (s *WebhookSubscription) subscribe(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, viper.GetDuration(config.EnvWebhookSubscribeTimeout))
+	defer cancel()
+	return s.webhook.Subscribe(ctx) //nolint:wrapcheck // error wrapped by notifications package
+}
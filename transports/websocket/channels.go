@@ -0,0 +1,41 @@
+package websocket
+
+import "fmt"
+
+// Public channels to which every client is subscribed.
+const (
+	// BroadcastChannel is a channel with messages sent to all users.
+	BroadcastChannel = "broadcast"
+	// RatesChannel is a channel with BSV exchange rate updates.
+	RatesChannel = "rates"
+)
+
+// UserTransactionsChannel returns name of the channel with user's transaction events.
+func UserTransactionsChannel(userID string) string {
+	return fmt.Sprintf("user:%s:transactions", userID)
+}
+
+// UserContactsChannel returns name of the channel with user's contact events.
+func UserContactsChannel(userID string) string {
+	return fmt.Sprintf("user:%s:contacts", userID)
+}
+
+// userChannels returns all channels which the user is allowed to join.
+func userChannels(userID string) []string {
+	return []string{
+		UserTransactionsChannel(userID),
+		UserContactsChannel(userID),
+		RatesChannel,
+		BroadcastChannel,
+	}
+}
+
+// CanSubscribe checks if the user is allowed to join the channel. Users can join only their own and public channels.
+func CanSubscribe(userID, channel string) bool {
+	for _, allowed := range userChannels(userID) {
+		if channel == allowed {
+			return true
+		}
+	}
+	return false
+}
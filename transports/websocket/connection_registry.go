@@ -0,0 +1,189 @@
+package websocket
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ConnectionInfo describes a single live websocket connection.
+type ConnectionInfo struct {
+	ClientID     string    `json:"clientId"`
+	UserID       string    `json:"userId"`
+	UserAgent    string    `json:"userAgent"`
+	SessionID    string    `json:"-"`
+	AccessKey    string    `json:"-"`
+	Paymail      string    `json:"-"`
+	ConnectedAt  time.Time `json:"connectedAt"`
+	LastActivity time.Time `json:"lastActivity"`
+}
+
+// WatchersStarter starts background work for the user and returns function stopping it.
+type WatchersStarter func() context.CancelFunc
+
+// userConnections holds connections of a single user to this instance.
+type userConnections struct {
+	clients      map[string]*ConnectionInfo
+	stopWatching context.CancelFunc
+}
+
+// ConnectionRegistry is a concurrent-safe registry of live websocket connections of this instance.
+type ConnectionRegistry struct {
+	mutex sync.RWMutex
+	users map[string]*userConnections
+}
+
+// NewConnectionRegistry creates empty connection registry.
+func NewConnectionRegistry() *ConnectionRegistry {
+	return &ConnectionRegistry{
+		users: make(map[string]*userConnections),
+	}
+}
+
+// Add registers a connection. Watchers are started with the first connection of the user.
+func (r *ConnectionRegistry) Add(info ConnectionInfo, startWatchers WatchersStarter) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+	info.ConnectedAt = now
+	info.LastActivity = now
+
+	conns, ok := r.users[info.UserID]
+	if ok {
+		conns.clients[info.ClientID] = &info
+		return
+	}
+
+	// Connection is registered before watchers are started, so they can use it.
+	conns = &userConnections{
+		clients: map[string]*ConnectionInfo{info.ClientID: &info},
+	}
+	r.users[info.UserID] = conns
+	if startWatchers != nil {
+		conns.stopWatching = startWatchers()
+	}
+}
+
+// Remove unregisters a connection. Watchers are stopped when the last connection of the user is removed.
+func (r *ConnectionRegistry) Remove(clientID, userID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	conns, ok := r.users[userID]
+	if !ok {
+		return
+	}
+	delete(conns.clients, clientID)
+	if len(conns.clients) == 0 {
+		if conns.stopWatching != nil {
+			conns.stopWatching()
+		}
+		delete(r.users, userID)
+	}
+}
+
+// Touch updates last activity time of a connection.
+func (r *ConnectionRegistry) Touch(clientID, userID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if conns, ok := r.users[userID]; ok {
+		if info, ok := conns.clients[clientID]; ok {
+			info.LastActivity = time.Now()
+		}
+	}
+}
+
+// IsConnected checks if user has any live connection.
+func (r *ConnectionRegistry) IsConnected(userID string) bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	_, ok := r.users[userID]
+	return ok
+}
+
+// UserIDs returns identifiers of connected users.
+func (r *ConnectionRegistry) UserIDs() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	ids := make([]string, 0, len(r.users))
+	for userID := range r.users {
+		ids = append(ids, userID)
+	}
+	return ids
+}
+
+// UserConnections returns copies of live connections of the user.
+func (r *ConnectionRegistry) UserConnections(userID string) []ConnectionInfo {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	connections := make([]ConnectionInfo, 0)
+	if conns, ok := r.users[userID]; ok {
+		for _, info := range conns.clients {
+			connections = append(connections, *info)
+		}
+	}
+	return connections
+}
+
+// UserConnection returns copy of the live connection of the user.
+func (r *ConnectionRegistry) UserConnection(userID, clientID string) (ConnectionInfo, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if conns, ok := r.users[userID]; ok {
+		if info, ok := conns.clients[clientID]; ok {
+			return *info, true
+		}
+	}
+	return ConnectionInfo{}, false
+}
+
+// LatestConnection returns copy of the most recently opened live connection of the user.
+func (r *ConnectionRegistry) LatestConnection(userID string) (ConnectionInfo, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var latest *ConnectionInfo
+	if conns, ok := r.users[userID]; ok {
+		for _, info := range conns.clients {
+			if latest == nil || info.ConnectedAt.After(latest.ConnectedAt) {
+				latest = info
+			}
+		}
+	}
+	if latest == nil {
+		return ConnectionInfo{}, false
+	}
+	return *latest, true
+}
+
+// Connections returns copies of all live connections.
+func (r *ConnectionRegistry) Connections() []ConnectionInfo {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	connections := make([]ConnectionInfo, 0)
+	for _, conns := range r.users {
+		for _, info := range conns.clients {
+			connections = append(connections, *info)
+		}
+	}
+	return connections
+}
+
+// Count returns number of live connections.
+func (r *ConnectionRegistry) Count() int {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	count := 0
+	for _, conns := range r.users {
+		count += len(conns.clients)
+	}
+	return count
+}
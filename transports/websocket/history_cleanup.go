@@ -0,0 +1,85 @@
+package websocket
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+)
+
+const (
+	deleteExpiredPublications = `
+	DELETE FROM websocket_publications
+	WHERE expires_at < $1
+	`
+
+	// Channel without publications for the history TTL has no history left, so it's recreated with a new epoch
+	// when used again, and clients recovering from the old one are told the history is gone.
+	deleteInactiveChannels = `
+	DELETE FROM websocket_channels c
+	WHERE c.updated_at < $1
+	AND NOT EXISTS (SELECT 1 FROM websocket_publications p WHERE p.channel = c.channel)
+	`
+
+	deleteExpiredWatcherLeases = `
+	DELETE FROM websocket_watchers
+	WHERE expires_at < $1
+	`
+)
+
+// HistoryCleaner deletes expired history of the postgres broker, which is trimmed only when a channel is published to,
+// and leases of watchers left by instances which stopped without releasing them.
+type HistoryCleaner struct {
+	db  *sql.DB
+	log *zerolog.Logger
+}
+
+// NewHistoryCleaner creates cleaner of websocket history kept in database.
+func NewHistoryCleaner(db *sql.DB, logger *zerolog.Logger) *HistoryCleaner {
+	log := logger.With().Str("service", "websocket-history-cleaner").Logger()
+	return &HistoryCleaner{
+		db:  db,
+		log: &log,
+	}
+}
+
+// Run periodically deletes expired history until the context is canceled.
+func (c *HistoryCleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(viper.GetDuration(config.EnvWebsocketHistoryCleanupInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Cleanup(ctx)
+		}
+	}
+}
+
+// Cleanup deletes expired publications, channels inactive for longer than the history TTL and expired watcher leases.
+func (c *HistoryCleaner) Cleanup(ctx context.Context) {
+	now := time.Now().UTC()
+	inactiveSince := now.Add(-time.Duration(viper.GetInt(config.EnvWebsocketHistoryTTL)) * time.Minute)
+
+	c.delete(ctx, "expired publications", deleteExpiredPublications, now)
+	c.delete(ctx, "inactive channels", deleteInactiveChannels, inactiveSince)
+	c.delete(ctx, "expired watcher leases", deleteExpiredWatcherLeases, now)
+}
+
+func (c *HistoryCleaner) delete(ctx context.Context, what, query string, before time.Time) {
+	result, err := c.db.ExecContext(ctx, query, before)
+	if err != nil {
+		c.log.Error().Msgf("Error while deleting %s: %v", what, err.Error())
+		return
+	}
+
+	if deleted, err := result.RowsAffected(); err == nil && deleted > 0 {
+		c.log.Debug().Msgf("Deleted %d %s", deleted, what)
+	}
+}
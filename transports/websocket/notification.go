@@ -2,32 +2,50 @@ package websocket
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/centrifugal/centrifuge"
 	"github.com/rs/zerolog"
 )
 
 // Socket represents websocket server entrypoint used to publish messages via websocket communication.
+// Events are published to a channel, so they are delivered to all subscribed connections, and kept in its history,
+// so they can be recovered by the client after reconnection.
 type Socket struct {
-	Client *centrifuge.Client
-	Log    *zerolog.Logger
+	Log *zerolog.Logger
+
+	node        *centrifuge.Node
+	channel     string
+	historySize int
+	historyTTL  time.Duration
+}
+
+// typedEvent is an event which can tell its type without exposing its data in logs.
+type typedEvent interface {
+	GetEventType() string
 }
 
 // Notify send event notification.
 func (s *Socket) Notify(event any) {
+	eventType := "unknown"
+	if e, ok := event.(typedEvent); ok {
+		eventType = e.GetEventType()
+	}
+
 	bytes, err := json.Marshal(event)
 	if err != nil {
-		s.Log.Error().Msgf("Error when marshaling event %v: %v", event, err.Error())
+		s.Log.Error().Msgf("Error when marshaling event %s: %v", eventType, err.Error())
 		return
 	}
 
-	if s.Client == nil {
-		s.Log.Debug().Msgf("Skipping notification, no client connection to handle the event %s", bytes)
+	if s.node == nil {
+		s.Log.Debug().Msgf("Skipping notification, no channel to handle the event %s", eventType)
 		return
 	}
 
-	if err = s.Client.Send(bytes); err != nil {
-		s.Log.Error().Msgf("Error when sending event %v to websocket: %v", event, err.Error())
+	if _, err = s.node.Publish(s.channel, bytes, centrifuge.WithHistory(s.historySize, s.historyTTL)); err != nil {
+		s.Log.Error().Msgf("Error when publishing event %s to websocket channel %s: %v", eventType, s.channel, err.Error())
+		return
 	}
-	s.Log.Info().Msgf("Event %v sent to websocket", event)
+	s.Log.Debug().Msgf("Event %s published to websocket channel %s", eventType, s.channel)
 }
@@ -0,0 +1,371 @@
+package websocket
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/centrifugal/centrifuge"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// brokerNotifyChannel is a postgres channel used to pass websocket messages between application instances.
+const brokerNotifyChannel = "websocket_broker"
+
+// maxNotifyPayload is a limit of postgres NOTIFY payload size.
+const maxNotifyPayload = 7900
+
+const (
+	postgresUpsertChannel = `
+	INSERT INTO websocket_channels(channel, epoch, top_offset, updated_at)
+	VALUES($1, $2, 1, $3)
+	ON CONFLICT (channel) DO UPDATE SET top_offset = websocket_channels.top_offset + 1, updated_at = $3
+	RETURNING epoch, top_offset
+	`
+
+	postgresInsertChannelIfMissing = `
+	INSERT INTO websocket_channels(channel, epoch, top_offset, updated_at)
+	VALUES($1, $2, 0, $3)
+	ON CONFLICT (channel) DO NOTHING
+	`
+
+	postgresGetChannel = `
+	SELECT epoch, top_offset
+	FROM websocket_channels
+	WHERE channel = $1
+	`
+
+	postgresInsertPublication = `
+	INSERT INTO websocket_publications(channel, publication_offset, data, created_at, expires_at)
+	VALUES($1, $2, $3, $4, $5)
+	`
+
+	postgresTrimPublications = `
+	DELETE FROM websocket_publications
+	WHERE channel = $1 AND (publication_offset <= $2 OR expires_at < $3)
+	`
+
+	// Publication is read together with epoch of its channel, so a publication of the channel recreated
+	// after cleanup is not taken for the notified one.
+	postgresGetPublication = `
+	SELECT p.data, p.created_at
+	FROM websocket_publications p
+	JOIN websocket_channels c ON c.channel = p.channel
+	WHERE p.channel = $1 AND p.publication_offset = $2 AND c.epoch = $3
+	`
+
+	postgresGetPublications = `
+	SELECT publication_offset, data, created_at
+	FROM websocket_publications
+	WHERE channel = $1 AND expires_at > $2 AND publication_offset > $3
+	ORDER BY publication_offset
+	`
+
+	postgresGetPublicationsReverse = `
+	SELECT publication_offset, data, created_at
+	FROM websocket_publications
+	WHERE channel = $1 AND expires_at > $2 AND publication_offset < $3
+	ORDER BY publication_offset DESC
+	`
+
+	postgresDeletePublications = `
+	DELETE FROM websocket_publications
+	WHERE channel = $1
+	`
+
+	postgresNotify = `SELECT pg_notify($1, $2)`
+)
+
+// Broker message types.
+const (
+	brokerPublication = "publication"
+	brokerJoin        = "join"
+	brokerLeave       = "leave"
+)
+
+// brokerMessage is a message passed with postgres NOTIFY.
+// Data of publications stored in history is not passed, but read from database by receivers.
+type brokerMessage struct {
+	Type    string                 `json:"type"`
+	Channel string                 `json:"channel"`
+	Offset  uint64                 `json:"offset,omitempty"`
+	Epoch   string                 `json:"epoch,omitempty"`
+	Data    []byte                 `json:"data,omitempty"`
+	Time    int64                  `json:"time,omitempty"`
+	Info    *centrifuge.ClientInfo `json:"info,omitempty"`
+}
+
+// postgresBroker is a centrifuge broker which uses postgres LISTEN/NOTIFY to deliver messages
+// to all application instances and keeps channels history in database.
+type postgresBroker struct {
+	db       *sql.DB
+	listener *pq.Listener
+	log      *zerolog.Logger
+
+	mutex      sync.RWMutex
+	channels   map[string]struct{}
+	handler    centrifuge.BrokerEventHandler
+	stopListen context.CancelFunc
+}
+
+func newPostgresBroker(db *sql.DB, connectionString string, log *zerolog.Logger) *postgresBroker {
+	brokerLogger := log.With().Str("subservice", "postgres-broker").Logger()
+	listener := pq.NewListener(connectionString, 10*time.Second, time.Minute, func(_ pq.ListenerEventType, err error) {
+		if err != nil {
+			brokerLogger.Error().Msgf("Websocket broker listener error: %v", err.Error())
+		}
+	})
+	return &postgresBroker{
+		db:       db,
+		listener: listener,
+		log:      &brokerLogger,
+		channels: make(map[string]struct{}),
+	}
+}
+
+// RegisterBrokerEventHandler starts listening for messages from all application instances.
+func (b *postgresBroker) RegisterBrokerEventHandler(handler centrifuge.BrokerEventHandler) error {
+	b.mutex.Lock()
+	b.handler = handler
+	b.mutex.Unlock()
+
+	if err := b.listener.Listen(brokerNotifyChannel); err != nil {
+		return errors.Wrap(err, "cannot listen for websocket broker messages")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b.stopListen = cancel
+	go b.listen(ctx)
+	return nil
+}
+
+// Subscribe marks channel as subscribed by this instance.
+func (b *postgresBroker) Subscribe(ch string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.channels[ch] = struct{}{}
+	return nil
+}
+
+// Unsubscribe marks channel as no longer subscribed by this instance.
+func (b *postgresBroker) Unsubscribe(ch string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	delete(b.channels, ch)
+	return nil
+}
+
+// Publish stores publication in channel history (if requested) and notifies all application instances.
+func (b *postgresBroker) Publish(ch string, data []byte, opts centrifuge.PublishOptions) (centrifuge.StreamPosition, bool, error) {
+	now := time.Now().UTC()
+	if opts.HistorySize <= 0 || opts.HistoryTTL <= 0 {
+		err := b.notify(context.Background(), b.db, &brokerMessage{Type: brokerPublication, Channel: ch, Data: data, Time: now.UnixMilli(), Info: opts.ClientInfo})
+		return centrifuge.StreamPosition{}, false, err
+	}
+
+	ctx := context.Background()
+	tx, err := b.db.BeginTx(ctx, nil)
+	if err != nil {
+		return centrifuge.StreamPosition{}, false, errors.Wrap(err, "internal error")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var sp centrifuge.StreamPosition
+	if err = tx.QueryRowContext(ctx, postgresUpsertChannel, ch, newEpoch(), now).Scan(&sp.Epoch, &sp.Offset); err != nil {
+		return centrifuge.StreamPosition{}, false, errors.Wrap(err, "internal error")
+	}
+	if _, err = tx.ExecContext(ctx, postgresInsertPublication, ch, sp.Offset, data, now, now.Add(opts.HistoryTTL)); err != nil {
+		return centrifuge.StreamPosition{}, false, errors.Wrap(err, "internal error")
+	}
+	if _, err = tx.ExecContext(ctx, postgresTrimPublications, ch, int64(sp.Offset)-int64(opts.HistorySize), now); err != nil {
+		return centrifuge.StreamPosition{}, false, errors.Wrap(err, "internal error")
+	}
+	// Notification is delivered on commit, when the publication is already visible for receivers.
+	if err = b.notify(ctx, tx, &brokerMessage{Type: brokerPublication, Channel: ch, Offset: sp.Offset, Epoch: sp.Epoch}); err != nil {
+		return centrifuge.StreamPosition{}, false, err
+	}
+	if err = tx.Commit(); err != nil {
+		return centrifuge.StreamPosition{}, false, errors.Wrap(err, "internal error")
+	}
+	return sp, false, nil
+}
+
+// PublishJoin notifies all application instances about client joining the channel.
+func (b *postgresBroker) PublishJoin(ch string, info *centrifuge.ClientInfo) error {
+	return b.notify(context.Background(), b.db, &brokerMessage{Type: brokerJoin, Channel: ch, Info: info})
+}
+
+// PublishLeave notifies all application instances about client leaving the channel.
+func (b *postgresBroker) PublishLeave(ch string, info *centrifuge.ClientInfo) error {
+	return b.notify(context.Background(), b.db, &brokerMessage{Type: brokerLeave, Channel: ch, Info: info})
+}
+
+// History returns not expired publications from channel history.
+func (b *postgresBroker) History(ch string, opts centrifuge.HistoryOptions) ([]*centrifuge.Publication, centrifuge.StreamPosition, error) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	// Position and publications are read from the same snapshot, so publications trimmed or channel cleaned up
+	// in the meantime are not missing from the returned history.
+	tx, err := b.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
+	if err != nil {
+		return nil, centrifuge.StreamPosition{}, errors.Wrap(err, "internal error")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err = tx.ExecContext(ctx, postgresInsertChannelIfMissing, ch, newEpoch(), now); err != nil {
+		return nil, centrifuge.StreamPosition{}, errors.Wrap(err, "internal error")
+	}
+	var sp centrifuge.StreamPosition
+	if err = tx.QueryRowContext(ctx, postgresGetChannel, ch).Scan(&sp.Epoch, &sp.Offset); err != nil {
+		return nil, centrifuge.StreamPosition{}, errors.Wrap(err, "internal error")
+	}
+
+	filter := opts.Filter
+	if filter.Limit == 0 {
+		return nil, sp, errors.Wrap(tx.Commit(), "internal error")
+	}
+
+	query := postgresGetPublications
+	offset := uint64(0)
+	if filter.Reverse {
+		query = postgresGetPublicationsReverse
+		offset = sp.Offset + 1
+	}
+	if filter.Since != nil {
+		offset = filter.Since.Offset
+	}
+
+	rows, err := tx.QueryContext(ctx, query, ch, now, offset)
+	if err != nil {
+		return nil, centrifuge.StreamPosition{}, errors.Wrap(err, "internal error")
+	}
+	defer rows.Close() //nolint:errcheck // best effort cleanup
+
+	publications := make([]*centrifuge.Publication, 0)
+	for rows.Next() && (filter.Limit < 0 || len(publications) < filter.Limit) {
+		pub := &centrifuge.Publication{Channel: ch}
+		var createdAt time.Time
+		if err = rows.Scan(&pub.Offset, &pub.Data, &createdAt); err != nil {
+			return nil, centrifuge.StreamPosition{}, errors.Wrap(err, "internal error")
+		}
+		pub.Time = createdAt.UnixMilli()
+		publications = append(publications, pub)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, centrifuge.StreamPosition{}, errors.Wrap(err, "internal error")
+	}
+	if err = rows.Close(); err != nil {
+		return nil, centrifuge.StreamPosition{}, errors.Wrap(err, "internal error")
+	}
+	return publications, sp, errors.Wrap(tx.Commit(), "internal error")
+}
+
+// RemoveHistory removes all publications from channel history.
+func (b *postgresBroker) RemoveHistory(ch string) error {
+	_, err := b.db.ExecContext(context.Background(), postgresDeletePublications, ch)
+	return errors.Wrap(err, "internal error")
+}
+
+// Close stops listening for messages.
+func (b *postgresBroker) Close(_ context.Context) error {
+	if b.stopListen != nil {
+		b.stopListen()
+	}
+	return errors.Wrap(b.listener.Close(), "internal error")
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func (b *postgresBroker) notify(ctx context.Context, db execer, msg *brokerMessage) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(err, "internal error")
+	}
+	if len(payload) > maxNotifyPayload {
+		return fmt.Errorf("websocket message for channel %s is too large to be published without history", msg.Channel)
+	}
+	_, err = db.ExecContext(ctx, postgresNotify, brokerNotifyChannel, string(payload))
+	return errors.Wrap(err, "internal error")
+}
+
+func (b *postgresBroker) listen(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case n, ok := <-b.listener.Notify:
+			if !ok {
+				return
+			}
+			if n == nil {
+				// Connection was re-established, messages sent in the meantime are recovered from history by clients.
+				continue
+			}
+			if err := b.handleMessage(ctx, n.Extra); err != nil {
+				b.log.Error().Msgf("Cannot handle websocket broker message: %v", err.Error())
+			}
+		}
+	}
+}
+
+func (b *postgresBroker) handleMessage(ctx context.Context, payload string) error {
+	var msg brokerMessage
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+		return errors.Wrap(err, "cannot decode message")
+	}
+
+	b.mutex.RLock()
+	_, subscribed := b.channels[msg.Channel]
+	handler := b.handler
+	b.mutex.RUnlock()
+	if !subscribed || handler == nil {
+		return nil
+	}
+
+	switch msg.Type {
+	case brokerJoin:
+		return handler.HandleJoin(msg.Channel, msg.Info) //nolint:wrapcheck // error wrapped higher in call stack
+	case brokerLeave:
+		return handler.HandleLeave(msg.Channel, msg.Info) //nolint:wrapcheck // error wrapped higher in call stack
+	case brokerPublication:
+		pub := &centrifuge.Publication{Channel: msg.Channel, Offset: msg.Offset, Data: msg.Data, Time: msg.Time, Info: msg.Info}
+		if msg.Offset > 0 {
+			var createdAt time.Time
+			row := b.db.QueryRowContext(ctx, postgresGetPublication, msg.Channel, msg.Offset, msg.Epoch)
+			if err := row.Scan(&pub.Data, &createdAt); err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					// Publication was trimmed from history before it was read, clients notice the gap in offsets
+					// with the next publication and recover what is left in history.
+					b.log.Debug().Msgf("Publication %d of channel %s was trimmed before delivery", msg.Offset, msg.Channel)
+					return nil
+				}
+				return errors.Wrap(err, "cannot read publication")
+			}
+			pub.Time = createdAt.UnixMilli()
+		}
+		sp := centrifuge.StreamPosition{Offset: msg.Offset, Epoch: msg.Epoch}
+		return handler.HandlePublication(msg.Channel, pub, sp, false, nil) //nolint:wrapcheck // error wrapped higher in call stack
+	default:
+		return fmt.Errorf("unknown message type %s", msg.Type)
+	}
+}
+
+func newEpoch() string {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,85 @@
+package websocket
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// watcherLeaseTTL is how long an instance watches wallet of the user without renewing its lease.
+	watcherLeaseTTL = 30 * time.Second
+	// watcherLeaseRenewInterval is how often the lease is renewed, or acquired by instances waiting for it.
+	watcherLeaseRenewInterval = 10 * time.Second
+)
+
+const (
+	// Lease is taken over only when it expired, e.g. when the instance holding it stopped without releasing it.
+	postgresAcquireWatcherLease = `
+	INSERT INTO websocket_watchers(user_id, instance_id, expires_at)
+	VALUES($1, $2, $3)
+	ON CONFLICT (user_id) DO UPDATE SET instance_id = EXCLUDED.instance_id, expires_at = EXCLUDED.expires_at
+	WHERE websocket_watchers.instance_id = EXCLUDED.instance_id OR websocket_watchers.expires_at < $4
+	RETURNING instance_id
+	`
+
+	postgresReleaseWatcherLease = `
+	DELETE FROM websocket_watchers
+	WHERE user_id = $1 AND instance_id = $2
+	`
+)
+
+// watcherElection elects the instance which watches wallet of the user, so events found by watchers
+// are not published once by every instance the user is connected to.
+type watcherElection interface {
+	// Acquire acquires or renews the lease of watching wallet of the user and reports if this instance holds it.
+	Acquire(ctx context.Context, userID string) (bool, error)
+	// Release gives up the lease of watching wallet of the user, if this instance holds it.
+	Release(ctx context.Context, userID string)
+}
+
+// localWatcherElection is used when events are not shared between instances, so every instance watches its users.
+type localWatcherElection struct{}
+
+func (localWatcherElection) Acquire(context.Context, string) (bool, error) {
+	return true, nil
+}
+
+func (localWatcherElection) Release(context.Context, string) {}
+
+// postgresWatcherElection elects the instance with a lease row in database, which the instance holding it renews.
+type postgresWatcherElection struct {
+	db         *sql.DB
+	instanceID string
+	log        *zerolog.Logger
+}
+
+func newPostgresWatcherElection(db *sql.DB, log *zerolog.Logger) *postgresWatcherElection {
+	return &postgresWatcherElection{
+		db:         db,
+		instanceID: newEpoch() + newEpoch(),
+		log:        log,
+	}
+}
+
+func (e *postgresWatcherElection) Acquire(ctx context.Context, userID string) (bool, error) {
+	now := time.Now().UTC()
+	var instanceID string
+	row := e.db.QueryRowContext(ctx, postgresAcquireWatcherLease, userID, e.instanceID, now.Add(watcherLeaseTTL), now)
+	if err := row.Scan(&instanceID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "internal error")
+	}
+	return true, nil
+}
+
+func (e *postgresWatcherElection) Release(ctx context.Context, userID string) {
+	if _, err := e.db.ExecContext(ctx, postgresReleaseWatcherLease, userID, e.instanceID); err != nil {
+		e.log.Warn().Msgf("Cannot release lease of watching user %s: %v", userID, err.Error())
+	}
+}
@@ -6,15 +6,25 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/centrifugal/centrifuge"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
 
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/config/databases"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/domain"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/events"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/domain/users"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/notification"
 	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/auth"
+	"github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/api/cors"
 	router "github.com/bsv-blockchain/spv-wallet-web-backend/transports/http/endpoints/routes"
 )
 
@@ -24,16 +34,29 @@ type Server interface {
 	Shutdown() error
 	SetupEntrypoint(engine *gin.Engine)
 	GetNode() *centrifuge.Node
-	GetSocket(userID string) *Socket
-	GetSockets() map[string]*Socket
+	GetSocket(channel string) *Socket
+	GetConnections() []ConnectionInfo
+	DisconnectSession(userID, sessionID string)
+	DisconnectUser(userID string)
 }
 
+// Terminal disconnects, after which client should not reconnect without signing in again.
+var (
+	// DisconnectSignedOut is sent to connections of the session in which user signed out.
+	DisconnectSignedOut = centrifuge.Disconnect{Code: 4500, Reason: "signed out"}
+	// DisconnectSessionRevoked is sent to connections which session expired or access key was revoked.
+	DisconnectSessionRevoked = centrifuge.Disconnect{Code: 4501, Reason: "session expired or revoked"}
+)
+
 type server struct {
 	node     *centrifuge.Node
 	log      *zerolog.Logger
-	sockets  map[string]*Socket
 	services *domain.Services
 	db       *sql.DB
+
+	connections *ConnectionRegistry
+	sessions    *auth.SessionValidator
+	election    watcherElection
 }
 
 // NewServer creates new websocket server.
@@ -44,12 +67,19 @@ func NewServer(log *zerolog.Logger, services *domain.Services, db *sql.DB) (Serv
 	if err != nil {
 		return nil, err
 	}
+	var election watcherElection = localWatcherElection{}
+	if viper.GetString(config.EnvWebsocketBroker) == "postgres" {
+		// Events published on any instance are delivered to clients connected to all instances.
+		node.SetBroker(newPostgresBroker(db, databases.ConnectionString(), &websocketLogger))
+		election = newPostgresWatcherElection(db, &websocketLogger)
+	}
 	s := &server{
-		node:     node,
-		log:      &websocketLogger,
-		sockets:  make(map[string]*Socket),
-		services: services,
-		db:       db,
+		node:        node,
+		log:         &websocketLogger,
+		connections: NewConnectionRegistry(),
+		services:    services,
+		db:          db,
+		election:    election,
 	}
 	return s, nil
 }
@@ -57,6 +87,19 @@ func NewServer(log *zerolog.Logger, services *domain.Services, db *sql.DB) (Serv
 // Start starts a server.
 func (s *server) Start() error {
 	s.setupNode()
+	s.services.TransactionTracker.OnStatusChanged(func(userID int, event notification.TransactionStatusChangedEvent) {
+		s.GetSocket(UserTransactionsChannel(strconv.Itoa(userID))).Notify(event)
+	})
+	s.services.EventsService.Subscribe(func(userID int, event any) {
+		if userID == events.AllUsers {
+			s.GetSocket(BroadcastChannel).Notify(event)
+			return
+		}
+		s.GetSocket(UserTransactionsChannel(strconv.Itoa(userID))).Notify(event)
+	})
+	s.services.RatesService.OnRateChanged(func(rate float64) {
+		s.GetSocket(RatesChannel).Notify(notification.PrepareExchangeRateChangedEvent(rate))
+	})
 	if err := s.node.Run(); err != nil {
 		return fmt.Errorf("cannot start websocket server: %w", err)
 	}
@@ -86,12 +129,14 @@ func (s *server) SetupEntrypoint(engine *gin.Engine) {
 	)
 	r := engine.Group("/api/websocket", apiMiddlewares...)
 
-	config := centrifuge.WebsocketConfig{
-		CheckOrigin: func(_ *http.Request) bool { return true },
+	s.sessions = auth.NewSessionValidator(auth.NewSessionStore(s.db), s.services, s.log)
+
+	wsConfig := centrifuge.WebsocketConfig{
+		CheckOrigin: checkOrigin,
 	}
 
 	r.Use(auth.GinContextToContextMiddleware())
-	r.GET("", gin.WrapH(auth.WsAuthMiddleware(centrifuge.NewWebsocketHandler(s.GetNode(), config))))
+	r.GET("", gin.WrapH(auth.WsAuthMiddleware(centrifuge.NewWebsocketHandler(s.GetNode(), wsConfig))))
 }
 
 func newNode(l *zerolog.Logger) (*centrifuge.Node, error) {
@@ -117,49 +162,229 @@ func (s *server) setupNode() {
 		data, _ := json.Marshal(connectData{
 			Email: cred.UserID,
 		})
+		// Connection expires after the session check interval, so it's validated again on refresh.
+		credentials := &centrifuge.Credentials{
+			UserID:   cred.UserID,
+			ExpireAt: sessionCheckExpireAt(),
+		}
+		subscriptions := make(map[string]centrifuge.SubscribeOptions)
+		for _, channel := range userChannels(cred.UserID) {
+			subscriptions[channel] = centrifuge.SubscribeOptions{EnableRecovery: true}
+		}
 		return centrifuge.ConnectReply{
-			Data: data,
+			Credentials:   credentials,
+			Data:          data,
+			Subscriptions: subscriptions,
 		}, nil
 	})
 
 	s.node.OnConnect(func(client *centrifuge.Client) {
-		s.sockets[client.UserID()] = &Socket{
-			Client: client,
-			Log:    s.log,
-		}
+		s.addConnection(client)
 
 		client.OnRefresh(func(_ centrifuge.RefreshEvent, cb centrifuge.RefreshCallback) {
+			s.connections.Touch(client.ID(), client.UserID())
+			if err := s.validateSession(client); err != nil {
+				s.log.Info().Msgf("Disconnecting client %s of user %s: %v", client.ID(), client.UserID(), err.Error())
+				cb(centrifuge.RefreshReply{}, DisconnectSessionRevoked)
+				return
+			}
 			cb(centrifuge.RefreshReply{
-				ExpireAt: time.Now().Unix() + 10,
+				ExpireAt: sessionCheckExpireAt(),
 			}, nil)
 		})
 
-		client.OnSubscribe(func(_ centrifuge.SubscribeEvent, cb centrifuge.SubscribeCallback) {
+		client.OnSubscribe(func(e centrifuge.SubscribeEvent, cb centrifuge.SubscribeCallback) {
+			s.connections.Touch(client.ID(), client.UserID())
+			// Client can subscribe only to its own and public channels, e.g. to recover events since the last seen offset.
+			if !CanSubscribe(client.UserID(), e.Channel) {
+				s.log.Warn().Msgf("Client %s of user %s is not allowed to subscribe channel %s", client.ID(), client.UserID(), e.Channel)
+				cb(centrifuge.SubscribeReply{}, centrifuge.ErrorPermissionDenied)
+				return
+			}
 			cb(centrifuge.SubscribeReply{
 				Options: centrifuge.SubscribeOptions{
-					EmitPresence: true,
+					EnableRecovery: true,
 				},
 			}, nil)
 		})
 
 		client.OnDisconnect(func(_ centrifuge.DisconnectEvent) {
-			delete(s.sockets, client.ID())
+			s.removeConnection(client)
 		})
 	})
 }
 
+// addConnection registers client connection. Watchers are started with the first connection of the user.
+func (s *server) addConnection(client *centrifuge.Client) {
+	info := ConnectionInfo{
+		ClientID: client.ID(),
+		UserID:   client.UserID(),
+	}
+	if gc, err := auth.GinContextFromContext(client.Context()); err == nil {
+		info.UserAgent = gc.Request.UserAgent()
+		info.SessionID = auth.SessionID(gc)
+		info.AccessKey = gc.GetString(auth.SessionAccessKey)
+		info.Paymail = gc.GetString(auth.SessionUserPaymail)
+	}
+
+	s.connections.Add(info, func() context.CancelFunc {
+		watchCtx, stopWatching := context.WithCancel(context.Background())
+		go s.watchUser(watchCtx, info.UserID)
+		return stopWatching
+	})
+}
+
+// removeConnection unregisters client connection. Watchers are stopped when the last connection of the user is closed.
+func (s *server) removeConnection(client *centrifuge.Client) {
+	s.connections.Remove(client.ID(), client.UserID())
+}
+
+// validateSession checks if session in which client connected is still valid.
+func (s *server) validateSession(client *centrifuge.Client) error {
+	gc, err := auth.GinContextFromContext(client.Context())
+	if err != nil {
+		return err
+	}
+	return s.sessions.Validate(gc.Request, client.UserID()) //nolint:wrapcheck // error wrapped by validator
+}
+
+// DisconnectSession closes connections of the user opened within the session, e.g. when user signs out.
+// Connections to other instances are closed on their next session check.
+func (s *server) DisconnectSession(userID, sessionID string) {
+	for _, conn := range s.connections.UserConnections(userID) {
+		if conn.SessionID != sessionID {
+			continue
+		}
+		err := s.node.Disconnect(userID, centrifuge.WithDisconnectClient(conn.ClientID), centrifuge.WithCustomDisconnect(DisconnectSignedOut))
+		if err != nil {
+			s.log.Error().Msgf("Cannot disconnect client %s of user %s: %v", conn.ClientID, userID, err.Error())
+		}
+	}
+}
+
+// DisconnectUser disconnects all connections of the user on every instance, e.g. when an admin signs the user out.
+func (s *server) DisconnectUser(userID string) {
+	if err := s.node.Disconnect(userID, centrifuge.WithCustomDisconnect(DisconnectSessionRevoked)); err != nil {
+		s.log.Error().Msgf("Cannot disconnect clients of user %s: %v", userID, err.Error())
+	}
+}
+
+// watchUser runs watchers of the user while this instance is elected to watch the user's wallet, until the last
+// connection of the user to this instance is closed. Watchers use credentials of a live connection of the user
+// and are restarted with another one, when that connection is closed.
+func (s *server) watchUser(ctx context.Context, userID string) {
+	var watcherClientID string
+	stopWatchers := func() {}
+	stop := func() {
+		stopWatchers()
+		stopWatchers = func() {}
+		watcherClientID = ""
+	}
+	defer func() {
+		stop()
+		s.election.Release(context.Background(), userID)
+	}()
+
+	check := func() {
+		elected, err := s.election.Acquire(ctx, userID)
+		if err != nil {
+			// Lease can't be renewed, so another instance may take it over soon.
+			s.log.Error().Msgf("Cannot acquire lease of watching user %s: %v", userID, err.Error())
+		}
+		if !elected {
+			stop()
+			return
+		}
+
+		if _, ok := s.connections.UserConnection(userID, watcherClientID); ok {
+			return
+		}
+		stop()
+		conn, ok := s.connections.LatestConnection(userID)
+		if !ok {
+			return
+		}
+		watchCtx, cancel := context.WithCancel(ctx)
+		stopWatchers = cancel
+		watcherClientID = conn.ClientID
+		s.startWatchers(watchCtx, conn)
+	}
+
+	check()
+
+	ticker := time.NewTicker(watcherLeaseRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			check()
+		}
+	}
+}
+
+// startWatchers starts background checks of user's wallet state which are notified to all user's connections.
+func (s *server) startWatchers(ctx context.Context, conn ConnectionInfo) {
+	userID, err := strconv.Atoi(conn.UserID)
+	if err != nil {
+		s.log.Error().Msgf("Cannot start watchers for client %s: %v", conn.ClientID, err.Error())
+		return
+	}
+	accessKey := conn.AccessKey
+
+	contactsSocket := s.GetSocket(UserContactsChannel(conn.UserID))
+	transactionsSocket := s.GetSocket(UserTransactionsChannel(conn.UserID))
+
+	go s.services.ContactsService.WatchContacts(ctx, accessKey, func(event notification.ContactEvent) {
+		contactsSocket.Notify(event)
+	})
+
+	go s.services.TransactionsService.WatchIncomingTransactions(ctx, accessKey, conn.Paymail, func(tx users.Transaction) {
+		_ = s.services.TransactionTracker.Track(ctx, userID, tx.GetTransactionID())
+
+		balance, err := s.services.UsersService.GetUserBalance(accessKey)
+		if err != nil {
+			s.log.Warn().Msgf("Cannot get balance for incoming transaction notification: %v", err.Error())
+		}
+		transactionsSocket.Notify(notification.PrepareIncomingTransactionEvent(tx, balance))
+	})
+}
+
 func (s *server) GetNode() *centrifuge.Node {
 	return s.node
 }
 
-func (s *server) GetSocket(userID string) *Socket {
-	userSocket := s.sockets[userID]
-	if userSocket == nil {
-		userSocket = &Socket{Log: s.log}
+// GetSocket returns socket publishing to the channel, so to all subscribed connections, also those on other instances.
+// Events for disconnected users are still stored in channel history.
+func (s *server) GetSocket(channel string) *Socket {
+	return &Socket{
+		Log:         s.log,
+		node:        s.node,
+		channel:     channel,
+		historySize: viper.GetInt(config.EnvWebsocketHistoryMax),
+		historyTTL:  time.Duration(viper.GetInt(config.EnvWebsocketHistoryTTL)) * time.Minute,
+	}
+}
+
+// GetConnections returns live connections to this instance.
+func (s *server) GetConnections() []ConnectionInfo {
+	return s.connections.Connections()
+}
+
+// checkOrigin allows connections from configured allowed domains and from the same origin.
+// Requests without origin are not sent by browsers, so they are not exposed to cross-site websocket hijacking.
+func checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || cors.IsOriginAllowed(origin) {
+		return true
 	}
-	return userSocket
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
 }
 
-func (s *server) GetSockets() map[string]*Socket {
-	return s.sockets
+// sessionCheckExpireAt returns time when connection should be validated again.
+func sessionCheckExpireAt() int64 {
+	return time.Now().Add(viper.GetDuration(config.EnvWebsocketSessionCheckInterval)).Unix()
 }